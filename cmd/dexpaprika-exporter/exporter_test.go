@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestRegistry_WriteTo(t *testing.T) {
+	r := newRegistry()
+	r.Gauge(metricPoolPrice, 2.5, map[string]string{"pool": "0xa", "pair": `W"ETH/USDC`})
+	r.Count(dexpaprika.MetricRequests, 1, map[string]string{"status": "200"})
+	r.Count(dexpaprika.MetricRequests, 1, map[string]string{"status": "200"})
+	r.Observe(dexpaprika.MetricRequestDuration, 0.25, nil)
+	r.Observe(dexpaprika.MetricRequestDuration, 0.5, nil)
+
+	var buf bytes.Buffer
+	if _, err := r.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo returned error: %v", err)
+	}
+	want := `# HELP dexpaprika_pool_price_usd Last USD price of the pool's base token.
+# TYPE dexpaprika_pool_price_usd gauge
+dexpaprika_pool_price_usd{pair="W\"ETH/USDC",pool="0xa"} 2.5
+# TYPE dexpaprika_request_duration_seconds summary
+dexpaprika_request_duration_seconds_sum 0.75
+dexpaprika_request_duration_seconds_count 2
+# TYPE dexpaprika_requests_total counter
+dexpaprika_requests_total{status="200"} 2
+`
+	if buf.String() != want {
+		t.Errorf("WriteTo wrote\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestScrape(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/networks/ethereum/pools/0xpool":
+			fmt.Fprint(w, `{"id":"0xpool","dex_id":"uniswap_v3","last_price_usd":3000,"liquidity_usd":1e6,
+				"tokens":[{"symbol":"WETH"},{"symbol":"USDC"}],"24h":{"volume_usd":5e5,"txns":42}}`)
+		case "/networks/ethereum/tokens/0xtoken":
+			fmt.Fprint(w, `{"id":"0xtoken","symbol":"WETH","summary":{"price_usd":3001,"liquidity_usd":2e6,"24h":{"volume_usd":7e5,"txns":99}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+	r := newRegistry()
+	ctx := context.Background()
+
+	var targets []target
+	pools, tokens := targetList{kind: "pool", targets: &targets}, targetList{kind: "token", targets: &targets}
+	for _, set := range []func() error{
+		func() error { return pools.Set("ethereum:0xPOOL") },
+		func() error { return tokens.Set("ethereum:0xtoken") },
+		func() error { return pools.Set("ethereum:0xmissing") },
+	} {
+		if err := set(); err != nil {
+			t.Fatalf("Set returned error: %v", err)
+		}
+	}
+	if err := tokens.Set("no-address"); err == nil {
+		t.Error("Expected an error for a target without an address")
+	}
+
+	for i, target := range targets {
+		err := scrape(ctx, client, r, target)
+		if (err != nil) != (i == 2) {
+			t.Errorf("scrape(%s) returned %v", target, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	r.WriteTo(&buf)
+	for _, line := range []string{
+		`dexpaprika_pool_price_usd{dex="uniswap_v3",network="ethereum",pair="WETH/USDC",pool="0xpool"} 3000`,
+		`dexpaprika_pool_liquidity_usd{dex="uniswap_v3",network="ethereum",pair="WETH/USDC",pool="0xpool"} 1e+06`,
+		`dexpaprika_pool_transactions_24h{dex="uniswap_v3",network="ethereum",pair="WETH/USDC",pool="0xpool"} 42`,
+		`dexpaprika_token_volume_usd_24h{network="ethereum",symbol="WETH",token="0xtoken"} 700000`,
+		`dexpaprika_scrape_success{target="pool:ethereum:0xmissing"} 0`,
+		`dexpaprika_scrape_success{target="token:ethereum:0xtoken"} 1`,
+	} {
+		if !strings.Contains(buf.String(), line+"\n") {
+			t.Errorf("Metrics missing %s in\n%s", line, buf.String())
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Command dexpaprika-exporter serves DexPaprika market data as Prometheus
+// metrics, so pools and tokens can be charted and alerted on in Grafana.
+//
+// It scrapes the configured pools and tokens on an interval and exposes
+// their price, 24h volume, liquidity and 24h transaction count as gauges on
+// /metrics, together with the SDK's request metrics. /healthz and /readyz
+// serve liveness and readiness probes:
+//
+//	dexpaprika-exporter \
+//		-pool ethereum:0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640 \
+//		-token ethereum:0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2 \
+//		-interval 1m -listen :9101
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/health"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/schedule"
+)
+
+func main() {
+	var targets []target
+	listen := flag.String("listen", ":9101", "address to serve /metrics and health probes on")
+	interval := flag.Duration("interval", time.Minute, "how often each target is scraped")
+	rate := flag.Float64("rate", 5, "maximum API requests per second")
+	flag.Var(targetList{kind: "pool", targets: &targets}, "pool", "pool to scrape as network:address (repeatable)")
+	flag.Var(targetList{kind: "token", targets: &targets}, "token", "token to scrape as network:address (repeatable)")
+	flag.Parse()
+
+	if len(targets) == 0 {
+		fmt.Fprintln(os.Stderr, "dexpaprika-exporter: no -pool or -token given")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx, *listen, *interval, *rate, targets); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run serves metrics and scrapes targets until ctx is done.
+func run(ctx context.Context, listen string, interval time.Duration, rate float64, targets []target) error {
+	metrics := newRegistry()
+	client := dexpaprika.NewClient(dexpaprika.WithRateLimit(rate), dexpaprika.WithMetrics(metrics))
+	defer client.Close()
+
+	scheduler, err := newScheduler(client, metrics, interval, targets)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics)
+	health.New(client).Register(mux)
+	server := &http.Server{Addr: listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	group := dexpaprika.NewGroup(ctx)
+	group.Go("scrape", func(ctx context.Context) error {
+		// Scrape once on start, so /metrics is populated before the first
+		// interval has passed
+		for _, t := range targets {
+			if err := scrape(ctx, client, metrics, t); err != nil && ctx.Err() == nil {
+				log.Print(err)
+			}
+		}
+		return scheduler.Run(ctx)
+	})
+	group.Go("http", func(ctx context.Context) error {
+		errc := make(chan error, 1)
+		go func() { errc <- server.ListenAndServe() }()
+		log.Printf("serving metrics of %d targets on %s/metrics", len(targets), listen)
+		select {
+		case err := <-errc:
+			cancel() // Stop scraping when metrics cannot be served
+			return err
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+			defer cancel()
+			return server.Shutdown(shutdownCtx)
+		}
+	})
+
+	<-group.Context().Done()
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelShutdown()
+	return group.Shutdown(shutdownCtx)
+}
+
+// newScheduler creates a scheduler with a job scraping each target every
+// interval.
+func newScheduler(client *dexpaprika.Client, metrics *registry, interval time.Duration, targets []target) (*schedule.Scheduler, error) {
+	scheduler := schedule.NewScheduler(client)
+	scheduler.MaxConcurrent = 4
+	scheduler.Metrics = metrics
+	scheduler.OnError = func(job string, err error) { log.Print(err) }
+	for _, t := range targets {
+		err := scheduler.AddSchedule(t.String(), schedule.Every(interval), func(ctx context.Context, client *dexpaprika.Client) error {
+			return scrape(ctx, client, metrics, t)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return scheduler, nil
+}
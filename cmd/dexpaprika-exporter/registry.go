@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// registry keeps metric series and serves them in the Prometheus text
+// exposition format. It implements dexpaprika.Metrics, so the SDK's client
+// and scheduler metrics are exported alongside the market data.
+type registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+type family struct {
+	kind   string // "gauge", "counter" or "summary"
+	series map[string]*series
+}
+
+type series struct {
+	labels map[string]string
+	value  float64 // Gauge or counter value, or the sum of a summary
+	count  uint64  // Samples of a summary
+}
+
+// help describes the metrics set by the exporter.
+var help = map[string]string{
+	metricPoolPrice:        "Last USD price of the pool's base token.",
+	metricPoolVolume:       "USD volume of the pool over the last 24 hours.",
+	metricPoolLiquidity:    "USD liquidity of the pool.",
+	metricPoolTransactions: "Transactions of the pool over the last 24 hours.",
+	metricTokenPrice:       "USD price of the token.",
+	metricTokenVolume:      "USD volume of the token over the last 24 hours.",
+	metricTokenLiquidity:   "USD liquidity of the token across its pools.",
+	metricTokenTxns:        "Transactions of the token over the last 24 hours.",
+	metricScrapeSuccess:    "Whether the last scrape of a target succeeded.",
+	metricScrapeTime:       "Unix time of the last successful scrape of a target.",
+}
+
+func newRegistry() *registry {
+	return &registry{families: make(map[string]*family)}
+}
+
+// Count implements dexpaprika.Metrics.
+func (r *registry) Count(name string, delta float64, labels map[string]string) {
+	r.update(name, "counter", labels, func(s *series) { s.value += delta })
+}
+
+// Gauge implements dexpaprika.Metrics.
+func (r *registry) Gauge(name string, value float64, labels map[string]string) {
+	r.update(name, "gauge", labels, func(s *series) { s.value = value })
+}
+
+// Observe implements dexpaprika.Metrics, exporting samples as a summary
+// without quantiles.
+func (r *registry) Observe(name string, value float64, labels map[string]string) {
+	r.update(name, "summary", labels, func(s *series) {
+		s.value += value
+		s.count++
+	})
+}
+
+func (r *registry) update(name, kind string, labels map[string]string, fn func(*series)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, ok := r.families[name]
+	if !ok {
+		f = &family{kind: kind, series: make(map[string]*series)}
+		r.families[name] = f
+	}
+	key := formatLabels(labels)
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labels: labels}
+		f.series[key] = s
+	}
+	fn(s)
+}
+
+// ServeHTTP serves the metrics in the text exposition format. Write errors,
+// typically from a scraper that disconnected, are logged.
+func (r *registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if _, err := r.WriteTo(w); err != nil {
+		log.Printf("writing metrics: %v", err)
+	}
+}
+
+// WriteTo writes every series, sorted by metric name and labels.
+func (r *registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		f := r.families[name]
+		if h, ok := help[name]; ok {
+			fmt.Fprintf(&b, "# HELP %s %s\n", name, h)
+		}
+		fmt.Fprintf(&b, "# TYPE %s %s\n", name, f.kind)
+
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			s := f.series[key]
+			if f.kind == "summary" {
+				fmt.Fprintf(&b, "%s_sum%s %s\n", name, key, formatValue(s.value))
+				fmt.Fprintf(&b, "%s_count%s %d\n", name, key, s.count)
+				continue
+			}
+			fmt.Fprintf(&b, "%s%s %s\n", name, key, formatValue(s.value))
+		}
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// formatLabels formats labels as `{a="1",b="2"}`, sorted by name and with
+// values escaped.
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `%s="%s"`, name, labelEscaper.Replace(labels[name]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// labelEscaper escapes label values as the exposition format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// Metrics set by the exporter.
+const (
+	metricPoolPrice        = "dexpaprika_pool_price_usd"
+	metricPoolVolume       = "dexpaprika_pool_volume_usd_24h"
+	metricPoolLiquidity    = "dexpaprika_pool_liquidity_usd"
+	metricPoolTransactions = "dexpaprika_pool_transactions_24h"
+	metricTokenPrice       = "dexpaprika_token_price_usd"
+	metricTokenVolume      = "dexpaprika_token_volume_usd_24h"
+	metricTokenLiquidity   = "dexpaprika_token_liquidity_usd"
+	metricTokenTxns        = "dexpaprika_token_transactions_24h"
+	metricScrapeSuccess    = "dexpaprika_scrape_success"
+	metricScrapeTime       = "dexpaprika_scrape_timestamp_seconds"
+)
+
+// target is a pool or token to scrape.
+type target struct {
+	kind string // "pool" or "token"
+	key  dexpaprika.EntityKey
+}
+
+func (t target) String() string {
+	return t.kind + ":" + t.key.String()
+}
+
+// targetList is a repeatable flag of "network:address" targets.
+type targetList struct {
+	kind    string
+	targets *[]target
+}
+
+func (l targetList) String() string {
+	if l.targets == nil {
+		return ""
+	}
+	var s []string
+	for _, t := range *l.targets {
+		if t.kind == l.kind {
+			s = append(s, t.key.String())
+		}
+	}
+	return strings.Join(s, ",")
+}
+
+func (l targetList) Set(value string) error {
+	key, err := dexpaprika.ParseEntityKey(value)
+	if err != nil {
+		return err
+	}
+	*l.targets = append(*l.targets, target{kind: l.kind, key: key})
+	return nil
+}
+
+// scrape fetches a target and updates its metrics in r.
+func scrape(ctx context.Context, client *dexpaprika.Client, r *registry, t target) error {
+	var err error
+	if t.kind == "pool" {
+		err = scrapePool(ctx, client, r, t.key)
+	} else {
+		err = scrapeToken(ctx, client, r, t.key)
+	}
+
+	labels := map[string]string{"target": t.String()}
+	if err != nil {
+		r.Gauge(metricScrapeSuccess, 0, labels)
+		return fmt.Errorf("scrape %s: %w", t, err)
+	}
+	r.Gauge(metricScrapeSuccess, 1, labels)
+	r.Gauge(metricScrapeTime, float64(time.Now().Unix()), labels)
+	return nil
+}
+
+func scrapePool(ctx context.Context, client *dexpaprika.Client, r *registry, key dexpaprika.EntityKey) error {
+	pool, err := client.Pools.GetDetails(ctx, key.Chain, key.Address, nil)
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{"network": key.Chain, "pool": key.Address, "dex": pool.DexID}
+	if base, quote, ok := pool.Pair(); ok {
+		labels["pair"] = base.Symbol + "/" + quote.Symbol
+	}
+
+	r.Gauge(metricPoolPrice, pool.LastPriceUSD, labels)
+	r.Gauge(metricPoolVolume, pool.Day.VolumeUSD, labels)
+	r.Gauge(metricPoolTransactions, float64(pool.Day.Txns), labels)
+	if liquidity, ok := pool.GetLiquidityUSD(); ok {
+		r.Gauge(metricPoolLiquidity, liquidity, labels)
+	}
+	return nil
+}
+
+func scrapeToken(ctx context.Context, client *dexpaprika.Client, r *registry, key dexpaprika.EntityKey) error {
+	token, err := client.Tokens.GetDetails(ctx, key.Chain, key.Address)
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{"network": key.Chain, "token": key.Address, "symbol": token.Symbol}
+
+	summary, ok := token.GetSummary()
+	if !ok {
+		return nil
+	}
+	if price, ok := summary.GetPriceUSD(); ok {
+		r.Gauge(metricTokenPrice, price, labels)
+	}
+	if liquidity, ok := summary.GetLiquidityUSD(); ok {
+		r.Gauge(metricTokenLiquidity, liquidity, labels)
+	}
+	if day, ok := summary.Metrics(dexpaprika.Interval24h); ok {
+		r.Gauge(metricTokenVolume, day.VolumeUSD, labels)
+		r.Gauge(metricTokenTxns, float64(day.Txns), labels)
+	}
+	return nil
+}
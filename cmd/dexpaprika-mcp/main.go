@@ -0,0 +1,64 @@
+// Command dexpaprika-mcp is a Model Context Protocol server that lets LLM
+// agents query DEX data through this SDK. It exposes search, token details,
+// pool details, OHLCV and top pools as tools over stdio, with the client's
+// rate limiting and retries and a short-lived cache of tool results.
+//
+// Register it with an MCP client, e.g. in a claude_desktop_config.json or
+// .mcp.json:
+//
+//	{"mcpServers": {"dexpaprika": {"command": "dexpaprika-mcp", "args": ["-rate", "2"]}}}
+//
+// When run as a long-lived sidecar, -health serves /healthz and /readyz on
+// the given address for liveness and readiness probes.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/health"
+)
+
+func main() {
+	rate := flag.Float64("rate", 2, "maximum API requests per second")
+	ttl := flag.Duration("cache-ttl", 30*time.Second, "how long tool results are reused for identical calls")
+	healthAddr := flag.String("health", "", "address to serve /healthz and /readyz on (disabled if empty)")
+	flag.Parse()
+
+	// Stdout carries the protocol; logs go to stderr
+	log.SetOutput(os.Stderr)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := dexpaprika.NewClient(dexpaprika.WithRateLimit(*rate))
+	defer client.Close()
+	cache := dexpaprika.NewInMemoryCache()
+	defer cache.Close()
+
+	if *healthAddr != "" {
+		checker := health.New(client)
+		checker.Cache = cache
+		mux := http.NewServeMux()
+		checker.Register(mux)
+		server := &http.Server{Addr: *healthAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("health endpoints: %v", err)
+			}
+		}()
+		defer server.Close()
+	}
+
+	if err := newServer(client, cache, *ttl).serve(ctx, os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
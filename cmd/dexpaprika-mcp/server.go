@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// protocolVersions are the MCP versions the server speaks, newest first.
+var protocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"` // Absent for notifications
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// content is a text item of a tool result.
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type toolResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// server answers MCP requests with the SDK's tools. Tool results are cached
+// for ttl, so agents repeating a call do not spend the rate limit again.
+type server struct {
+	client *dexpaprika.Client
+	cache  dexpaprika.Cache
+	ttl    time.Duration
+
+	mu sync.Mutex // Serializes writes of responses
+}
+
+func newServer(client *dexpaprika.Client, cache dexpaprika.Cache, ttl time.Duration) *server {
+	return &server{client: client, cache: cache, ttl: ttl}
+}
+
+// serve reads newline-delimited JSON-RPC messages from r and writes the
+// responses to w until r ends or ctx is done. Requests are handled
+// concurrently; the client's rate limit bounds the API calls they make.
+func (s *server) serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), 4<<20)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return nil
+		}
+		line := append([]byte(nil), scanner.Bytes()...)
+		if len(line) == 0 {
+			continue
+		}
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			s.write(w, response{ID: json.RawMessage("null"), Error: &rpcError{codeParseError, err.Error()}})
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if resp, ok := s.handle(ctx, req); ok {
+				s.write(w, resp)
+			}
+		}()
+	}
+	return scanner.Err()
+}
+
+// handle answers a request. Notifications get no response.
+func (s *server) handle(ctx context.Context, req request) (response, bool) {
+	if len(req.ID) == 0 {
+		return response{}, false
+	}
+	resp := response{ID: req.ID}
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			resp.Error = &rpcError{codeInvalidParams, "invalid initialize params: " + err.Error()}
+			break
+		}
+		version := protocolVersions[0]
+		if slices.Contains(protocolVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		resp.Result = map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "dexpaprika", "version": dexpaprika.Version},
+			"instructions":    "Tools query DEX data from DexPaprika: search to find tokens and pools, then details, OHLCV and top pools by network ID and address.",
+		}
+	case "ping":
+		resp.Result = map[string]any{}
+	case "tools/list":
+		resp.Result = map[string]any{"tools": tools}
+	case "tools/call":
+		result, err := s.callTool(ctx, req.Params)
+		if err != nil {
+			resp.Error = &rpcError{codeInvalidParams, err.Error()}
+		} else {
+			resp.Result = result
+		}
+	default:
+		resp.Error = &rpcError{codeMethodNotFound, "method not found: " + req.Method}
+	}
+	return resp, true
+}
+
+// callTool runs a tool. Failures of the tool, such as API errors, are
+// returned as error results the agent can read; only unknown tools are
+// protocol errors.
+func (s *server) callTool(ctx context.Context, params json.RawMessage) (*toolResult, error) {
+	var call struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &call); err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(tools, func(t tool) bool { return t.Name == call.Name })
+	if i < 0 {
+		return nil, fmt.Errorf("unknown tool %q", call.Name)
+	}
+
+	key := "mcp:" + call.Name + ":" + string(call.Arguments)
+	if cached, ok := s.cache.Get(key); ok {
+		return cached.(*toolResult), nil
+	}
+
+	value, err := tools[i].call(ctx, s.client, call.Arguments)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	text, err := json.Marshal(value)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	result := &toolResult{Content: []content{{Type: "text", Text: string(text)}}}
+	s.cache.Set(key, result, s.ttl)
+	return result, nil
+}
+
+func errorResult(err error) *toolResult {
+	msg := err.Error()
+	var apiErr *dexpaprika.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+		msg = "not found: check the network ID and address; use search to find them"
+	}
+	return &toolResult{Content: []content{{Type: "text", Text: msg}}, IsError: true}
+}
+
+func (s *server) write(w io.Writer, resp response) {
+	resp.JSONRPC = "2.0"
+	data, _ := json.Marshal(resp)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = w.Write(append(data, '\n'))
+}
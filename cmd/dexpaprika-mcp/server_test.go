@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// session runs the server over the given request lines and returns the
+// responses by ID.
+func session(t *testing.T, handler http.HandlerFunc, lines ...string) map[string]response {
+	t.Helper()
+	api := httptest.NewServer(handler)
+	defer api.Close()
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(api.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+	cache := dexpaprika.NewInMemoryCache()
+	defer cache.Close()
+
+	var out strings.Builder
+	if err := newServer(client, cache, time.Minute).serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")), &out); err != nil {
+		t.Fatalf("serve returned error: %v", err)
+	}
+
+	responses := make(map[string]response)
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		var resp response
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatalf("Invalid response %s: %v", scanner.Text(), err)
+		}
+		if resp.JSONRPC != "2.0" {
+			t.Errorf("Response without jsonrpc 2.0: %s", scanner.Text())
+		}
+		responses[string(resp.ID)] = resp
+	}
+	return responses
+}
+
+// result decodes a response's result into v.
+func result(t *testing.T, resp response, v any) {
+	t.Helper()
+	if resp.Error != nil {
+		t.Fatalf("Unexpected error %+v", resp.Error)
+	}
+	data, _ := json.Marshal(resp.Result)
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_Protocol(t *testing.T) {
+	responses := session(t, nil,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/list"}`,
+		`not json`,
+		`{"jsonrpc":"2.0","id":4,"method":"initialize","params":["2024-11-05"]}`,
+	)
+	if len(responses) != 5 {
+		t.Fatalf("Got %d responses, want 5 (none for the notification)", len(responses))
+	}
+
+	var init struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ServerInfo      struct {
+			Version string `json:"version"`
+		} `json:"serverInfo"`
+	}
+	result(t, responses["1"], &init)
+	if init.ProtocolVersion != "2024-11-05" {
+		t.Errorf("Negotiated version %s, want the client's", init.ProtocolVersion)
+	}
+	if init.ServerInfo.Version != dexpaprika.Version {
+		t.Errorf("Server version %s, want the SDK's %s", init.ServerInfo.Version, dexpaprika.Version)
+	}
+
+	var list struct {
+		Tools []struct {
+			Name        string         `json:"name"`
+			InputSchema map[string]any `json:"inputSchema"`
+		} `json:"tools"`
+	}
+	result(t, responses["2"], &list)
+	var names []string
+	for _, tool := range list.Tools {
+		names = append(names, tool.Name)
+		if tool.InputSchema["type"] != "object" {
+			t.Errorf("Tool %s has schema %v", tool.Name, tool.InputSchema)
+		}
+	}
+	if got := strings.Join(names, ","); got != "search,get_token_details,get_pool_details,get_pool_ohlcv,get_top_pools" {
+		t.Errorf("Listed tools %s", got)
+	}
+
+	if resp := responses["3"]; resp.Error == nil || resp.Error.Code != codeMethodNotFound {
+		t.Errorf("Expected method not found, got %+v", resp)
+	}
+	if resp := responses["null"]; resp.Error == nil || resp.Error.Code != codeParseError {
+		t.Errorf("Expected a parse error, got %+v", resp)
+	}
+	if resp := responses["4"]; resp.Error == nil || resp.Error.Code != codeInvalidParams {
+		t.Errorf("Expected invalid params for malformed initialize params, got %+v", resp)
+	}
+}
+
+func TestServer_ToolsCall(t *testing.T) {
+	var requests atomic.Int32
+	responses := session(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/networks/ethereum/pools/0xpool":
+			fmt.Fprint(w, `{"id":"0xpool","last_price_usd":3000}`)
+		case "/networks/ethereum/dexes/uniswap_v3/pools":
+			if got := r.URL.Query().Get("limit"); got != "50" {
+				t.Errorf("limit = %s, want the cap of 50", got)
+			}
+			fmt.Fprint(w, `{"pools":[{"id":"0xa"}],"page_info":{"page":0,"total_pages":1}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"not found"}`)
+		}
+	},
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"get_pool_details","arguments":{"network":"ethereum","address":"0xpool"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"get_top_pools","arguments":{"network":"ethereum","dex":"uniswap_v3","limit":500}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"get_token_details","arguments":{"network":"ethereum","address":"0xmissing"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"get_pool_ohlcv","arguments":{"network":"ethereum","address":"0xpool"}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"drop_tables","arguments":{}}}`,
+	)
+
+	var res toolResult
+	result(t, responses["1"], &res)
+	if res.IsError || !strings.Contains(res.Content[0].Text, `"last_price_usd":3000`) {
+		t.Errorf("Unexpected pool details result %+v", res)
+	}
+
+	result(t, responses["2"], &res)
+	if res.IsError || !strings.HasPrefix(res.Content[0].Text, `[{"id":"0xa"`) {
+		t.Errorf("Unexpected top pools result %+v", res)
+	}
+
+	// API and argument errors are tool results the agent can read
+	result(t, responses["3"], &res)
+	if !res.IsError || !strings.Contains(res.Content[0].Text, "not found") {
+		t.Errorf("Expected a not found tool error, got %+v", res)
+	}
+	result(t, responses["4"], &res)
+	if !res.IsError || !strings.Contains(res.Content[0].Text, "start is required") {
+		t.Errorf("Expected a missing argument tool error, got %+v", res)
+	}
+
+	if resp := responses["5"]; resp.Error == nil || resp.Error.Code != codeInvalidParams {
+		t.Errorf("Expected invalid params for an unknown tool, got %+v", resp)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("Made %d API requests, want 3", requests.Load())
+	}
+}
+
+func TestServer_Cache(t *testing.T) {
+	var requests atomic.Int32
+	call := `{"jsonrpc":"2.0","id":%d,"method":"tools/call","params":{"name":"get_pool_details","arguments":{"network":"ethereum","address":"0xpool"}}}`
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, `{"id":"0xpool"}`)
+	}))
+	defer api.Close()
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(api.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+	cache := dexpaprika.NewInMemoryCache()
+	defer cache.Close()
+	s := newServer(client, cache, time.Minute)
+
+	// Sequential sessions, so the second call finds the first result cached
+	for id := 1; id <= 2; id++ {
+		var out strings.Builder
+		if err := s.serve(context.Background(), strings.NewReader(fmt.Sprintf(call, id)), &out); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out.String(), `\"id\":\"0xpool\"`) {
+			t.Errorf("Unexpected response %s", out.String())
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Made %d API requests, want 1", requests.Load())
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// maxResults caps the results a tool returns, keeping responses within an
+// agent's context window.
+const maxResults = 50
+
+// tool is an MCP tool backed by the SDK.
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+
+	call func(ctx context.Context, client *dexpaprika.Client, args json.RawMessage) (any, error)
+}
+
+// errInvalidArguments marks tool errors caused by the caller's arguments.
+var errInvalidArguments = errors.New("invalid arguments")
+
+// schema builds an object schema from property descriptions, with the
+// properties in required listed as required.
+func schema(properties map[string]map[string]any, required ...string) map[string]any {
+	s := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func str(description string) map[string]any {
+	return map[string]any{"type": "string", "description": description}
+}
+
+func integer(description string) map[string]any {
+	return map[string]any{"type": "integer", "description": description}
+}
+
+// decode unmarshals tool arguments and checks required strings are set.
+func decode(args json.RawMessage, v any, required map[string]*string) error {
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, v); err != nil {
+			return fmt.Errorf("%w: %v", errInvalidArguments, err)
+		}
+	}
+	for name, value := range required {
+		if *value == "" {
+			return fmt.Errorf("%w: %s is required", errInvalidArguments, name)
+		}
+	}
+	return nil
+}
+
+var tools = []tool{
+	{
+		Name:        "search",
+		Description: "Search tokens, pools and DEXes across all networks by name, symbol or address.",
+		InputSchema: schema(map[string]map[string]any{
+			"query":   str("Name, symbol or address to search for"),
+			"network": str("Only return results on this network, e.g. ethereum"),
+		}, "query"),
+		call: func(ctx context.Context, client *dexpaprika.Client, args json.RawMessage) (any, error) {
+			var in struct{ Query, Network string }
+			if err := decode(args, &in, map[string]*string{"query": &in.Query}); err != nil {
+				return nil, err
+			}
+			return client.Search.SearchWithOptions(ctx, in.Query, &dexpaprika.SearchOptions{Limit: maxResults, Chain: in.Network})
+		},
+	},
+	{
+		Name:        "get_token_details",
+		Description: "Get a token's details and market summary: USD price, liquidity, FDV and volume, buys, sells and transactions per interval.",
+		InputSchema: schema(map[string]map[string]any{
+			"network": str("Network ID, e.g. ethereum or solana"),
+			"address": str("Token address"),
+		}, "network", "address"),
+		call: func(ctx context.Context, client *dexpaprika.Client, args json.RawMessage) (any, error) {
+			var in struct{ Network, Address string }
+			if err := decode(args, &in, map[string]*string{"network": &in.Network, "address": &in.Address}); err != nil {
+				return nil, err
+			}
+			return client.Tokens.GetDetails(ctx, in.Network, in.Address)
+		},
+	},
+	{
+		Name:        "get_pool_details",
+		Description: "Get a liquidity pool's details: tokens, last price, liquidity, fee and volume and transactions per interval.",
+		InputSchema: schema(map[string]map[string]any{
+			"network": str("Network ID, e.g. ethereum or solana"),
+			"address": str("Pool address"),
+		}, "network", "address"),
+		call: func(ctx context.Context, client *dexpaprika.Client, args json.RawMessage) (any, error) {
+			var in struct{ Network, Address string }
+			if err := decode(args, &in, map[string]*string{"network": &in.Network, "address": &in.Address}); err != nil {
+				return nil, err
+			}
+			return client.Pools.GetDetails(ctx, in.Network, in.Address, nil)
+		},
+	},
+	{
+		Name:        "get_pool_ohlcv",
+		Description: "Get OHLCV candles of a pool from a start date. Prices are in USD of the pool's first token.",
+		InputSchema: schema(map[string]map[string]any{
+			"network":  str("Network ID, e.g. ethereum"),
+			"address":  str("Pool address"),
+			"start":    str("Start as RFC 3339 time or YYYY-MM-DD"),
+			"end":      str("Optional end as RFC 3339 time or YYYY-MM-DD"),
+			"interval": str("Candle interval: 1m, 5m, 10m, 15m, 30m, 1h, 6h, 12h or 24h (default)"),
+			"limit":    integer("Number of candles, at most 366 (default 50)"),
+		}, "network", "address", "start"),
+		call: func(ctx context.Context, client *dexpaprika.Client, args json.RawMessage) (any, error) {
+			var in struct {
+				Network, Address, Start, End, Interval string
+				Limit                                  int
+			}
+			if err := decode(args, &in, map[string]*string{"network": &in.Network, "address": &in.Address, "start": &in.Start}); err != nil {
+				return nil, err
+			}
+			if in.Interval != "" {
+				if _, ok := dexpaprika.OHLCVIntervalDuration(in.Interval); !ok {
+					return nil, fmt.Errorf("%w: unknown interval %q", errInvalidArguments, in.Interval)
+				}
+			}
+			if in.Limit <= 0 {
+				in.Limit = maxResults
+			}
+			return client.Pools.GetOHLCV(ctx, in.Network, in.Address, &dexpaprika.OHLCVOptions{
+				Start:    in.Start,
+				End:      in.End,
+				Interval: in.Interval,
+				Limit:    min(in.Limit, 366),
+			})
+		},
+	},
+	{
+		Name:        "get_top_pools",
+		Description: "List the top liquidity pools of a network, optionally of one DEX, ranked by volume or another metric.",
+		InputSchema: schema(map[string]map[string]any{
+			"network":  str("Network ID, e.g. ethereum"),
+			"dex":      str("Optional DEX ID, e.g. uniswap_v3"),
+			"order_by": str("volume_usd (default), price_usd, transactions, last_price_change_usd_24h or created_at"),
+			"limit":    integer(fmt.Sprintf("Number of pools, at most %d (default 10)", maxResults)),
+		}, "network"),
+		call: func(ctx context.Context, client *dexpaprika.Client, args json.RawMessage) (any, error) {
+			var in struct {
+				Network, Dex string
+				OrderBy      string `json:"order_by"`
+				Limit        int
+			}
+			if err := decode(args, &in, map[string]*string{"network": &in.Network}); err != nil {
+				return nil, err
+			}
+			if in.Limit <= 0 {
+				in.Limit = 10
+			}
+			if in.OrderBy == "" {
+				in.OrderBy = "volume_usd"
+			}
+			opts := &dexpaprika.ListOptions{Limit: min(in.Limit, maxResults), OrderBy: in.OrderBy, Sort: "desc"}
+			var resp *dexpaprika.PoolsResponse
+			var err error
+			if in.Dex != "" {
+				resp, err = client.Pools.ListByDex(ctx, in.Network, in.Dex, opts)
+			} else {
+				resp, err = client.Pools.ListByNetwork(ctx, in.Network, opts)
+			}
+			if err != nil {
+				return nil, err
+			}
+			return resp.Pools, nil
+		},
+	},
+}
@@ -0,0 +1,71 @@
+// Command dexpaprika-mock serves a fake DexPaprika REST API with canned
+// data, so services in any language and local development environments can
+// run without the live API:
+//
+//	dexpaprika-mock -listen :8080
+//	curl localhost:8080/networks/ethereum/pools?limit=10
+//
+// Go clients point at it with dexpaprika.WithBaseURL("http://localhost:8080").
+//
+// The data is the SDK's checked-in fixtures by default. -fixtures loads
+// <name>.json files from a directory instead, such as one written by the
+// fixtures refresh tool, falling back to the checked-in fixture for any
+// file missing; -snapshot adds the data of a snapshot file saved with
+// Snapshot.Save. Lists are filtered, sorted and paginated like the API's.
+//
+// -latency, -jitter and -error-rate make responses slow or flaky, to
+// exercise timeouts and retries. /healthz answers liveness probes.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.listen, "listen", ":8080", "address to serve on")
+	flag.StringVar(&cfg.fixtures, "fixtures", "", "directory of <name>.json fixture files (defaults to the checked-in fixtures)")
+	flag.StringVar(&cfg.snapshot, "snapshot", "", "snapshot file to add to the data")
+	flag.DurationVar(&cfg.latency, "latency", 0, "delay of every response")
+	flag.DurationVar(&cfg.jitter, "jitter", 0, "maximum random delay added to the latency")
+	flag.Float64Var(&cfg.errorRate, "error-rate", 0, "fraction of requests, from 0 to 1, answered with -error-status")
+	flag.IntVar(&cfg.errorStatus, "error-status", http.StatusInternalServerError, "status of injected errors")
+	flag.Int64Var(&cfg.seed, "seed", 1, "seed of the random errors and jitter")
+	flag.StringVar(&cfg.cors, "cors", "*", "Access-Control-Allow-Origin of responses, empty to send none")
+	flag.BoolVar(&cfg.logRequests, "log", false, "log every request")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx, cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run serves the mock until ctx is done.
+func run(ctx context.Context, cfg config) error {
+	handler, err := newHandler(cfg)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Addr: cfg.listen, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	errc := make(chan error, 1)
+	go func() { errc <- server.ListenAndServe() }()
+	log.Printf("serving the mock DexPaprika API on %s", cfg.listen)
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/dexpaprikatest"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/fixtures"
+)
+
+// config holds the command-line flags.
+type config struct {
+	listen      string
+	fixtures    string
+	snapshot    string
+	latency     time.Duration
+	jitter      time.Duration
+	errorRate   float64
+	errorStatus int
+	seed        int64
+	cors        string
+	logRequests bool
+}
+
+// maxRequests bounds the requests the fake keeps, which the mock never
+// reads back.
+const maxRequests = 100
+
+// newHandler seeds a fake with the configured data and wraps it with the
+// health check, CORS header and request log.
+func newHandler(cfg config) (http.Handler, error) {
+	fake := dexpaprikatest.NewFake()
+	fake.Latency = cfg.latency
+	fake.Jitter = cfg.jitter
+	fake.ErrorRate = cfg.errorRate
+	fake.ErrorStatus = cfg.errorStatus
+	fake.MaxRequests = maxRequests
+	fake.Seed(cfg.seed)
+
+	if cfg.fixtures == "" {
+		fixtures.Seed(fake)
+	} else if err := fixtures.SeedFS(fake, os.DirFS(cfg.fixtures)); err != nil {
+		return nil, err
+	}
+	if cfg.snapshot != "" {
+		if err := loadSnapshot(fake, cfg.snapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok\n"))
+	})
+	mux.Handle("/", fake)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cfg.cors != "" {
+			w.Header().Set("Access-Control-Allow-Origin", cfg.cors)
+		}
+		if cfg.logRequests {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			defer func() { log.Printf("%s %s %d %v", r.Method, r.URL.RequestURI(), rec.status, time.Since(start)) }()
+			w = rec
+		}
+		mux.ServeHTTP(w, r)
+	}), nil
+}
+
+func loadSnapshot(fake *dexpaprikatest.Fake, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	snapshot := dexpaprika.NewSnapshot()
+	if err := snapshot.Load(f); err != nil {
+		return fmt.Errorf("snapshot %s: %w", path, err)
+	}
+	fake.LoadSnapshot(snapshot)
+	return nil
+}
+
+// statusRecorder records the status of a response for the request log.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
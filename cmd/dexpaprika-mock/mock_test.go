@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/fixtures"
+)
+
+// newTestMock serves a mock with cfg and returns a client of it.
+func newTestMock(t *testing.T, cfg config) (*dexpaprika.Client, string) {
+	t.Helper()
+	handler, err := newHandler(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(srv.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+	t.Cleanup(func() { client.Close() })
+	return client, srv.URL
+}
+
+func TestMock_Fixtures(t *testing.T) {
+	client, url := newTestMock(t, config{cors: "*"})
+	ctx := context.Background()
+
+	details, err := client.Pools.GetDetails(ctx, fixtures.Network, fixtures.PoolAddress, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.DexName != "Uniswap V3" {
+		t.Errorf("Expected the fixture pool, got %s", details)
+	}
+	pools, err := client.Pools.ListByNetwork(ctx, fixtures.Network, &dexpaprika.ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools.Pools) != 2 {
+		t.Errorf("Expected a page of 2 pools, got %d", len(pools.Pools))
+	}
+
+	resp, err := http.Get(url + "/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "ok\n" {
+		t.Errorf("/healthz answered %d %q", resp.StatusCode, body)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want *", got)
+	}
+}
+
+func TestMock_FixturesDirAndSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "stats.json"), []byte(`{"chains": 1, "factories": 2, "pools": 3, "tokens": 4}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	snapshot := dexpaprika.NewSnapshot()
+	snapshot.Networks = []dexpaprika.Network{{ID: "mocknet", DisplayName: "Mock Net"}}
+	var buf bytes.Buffer
+	if err := snapshot.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	snapshotPath := filepath.Join(dir, "snapshot.json")
+	if err := os.WriteFile(snapshotPath, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client, _ := newTestMock(t, config{fixtures: dir, snapshot: snapshotPath})
+	ctx := context.Background()
+	stats, err := client.Utils.GetStats(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Chains != 1 || stats.Tokens != 4 {
+		t.Errorf("Expected the stats from the directory, got %+v", stats)
+	}
+	networks, err := client.Networks.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, n := range networks {
+		found = found || n.ID == "mocknet"
+	}
+	if !found || len(networks) < 2 {
+		t.Errorf("Expected the fixture networks and the snapshot's, got %v", networks)
+	}
+}
+
+func TestMock_Errors(t *testing.T) {
+	client, _ := newTestMock(t, config{errorRate: 1, errorStatus: http.StatusServiceUnavailable})
+	if _, err := client.Networks.List(context.Background()); err == nil {
+		t.Error("Expected an injected error")
+	}
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "networks.json"), []byte(`{`), 0o644)
+	if _, err := newHandler(config{fixtures: dir}); err == nil {
+		t.Error("Expected an error for a broken fixture file")
+	}
+	if _, err := newHandler(config{snapshot: filepath.Join(dir, "missing.json")}); err == nil {
+		t.Error("Expected an error for a missing snapshot")
+	}
+}
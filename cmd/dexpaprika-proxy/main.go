@@ -0,0 +1,77 @@
+// Command dexpaprika-proxy is an HTTP proxy for the DexPaprika REST API that
+// lets many internal clients share one API quota.
+//
+// It serves the same paths as the API, so clients only change their base
+// URL. Responses are cached for a TTL, concurrent requests for the same
+// resource are collapsed into one upstream call, and upstream calls go
+// through one SDK client with its rate limiting and retries:
+//
+//	dexpaprika-proxy -listen :8080 -rate 10 -ttl 30s
+//	curl localhost:8080/networks/ethereum/pools?limit=10
+//
+// Responses carry an X-Cache header of HIT or MISS and an Age header with
+// the seconds since they were fetched. API errors are passed through
+// uncached; unreachable upstreams are reported as 502 Bad Gateway.
+//
+// /healthz and /readyz serve liveness and readiness probes, the latter
+// reporting upstream reachability and the number of cached responses.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/health"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "address to serve on")
+	rate := flag.Float64("rate", 5, "maximum upstream API requests per second")
+	ttl := flag.Duration("ttl", 30*time.Second, "how long responses are cached")
+	baseURL := flag.String("upstream", "", "API base URL (defaults to the SDK's)")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx, *listen, *rate, *ttl, *baseURL); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run serves the proxy until ctx is done.
+func run(ctx context.Context, listen string, rate float64, ttl time.Duration, baseURL string) error {
+	opts := []dexpaprika.ClientOption{dexpaprika.WithRateLimit(rate)}
+	if baseURL != "" {
+		opts = append(opts, dexpaprika.WithBaseURL(baseURL))
+	}
+	client := dexpaprika.NewClient(opts...)
+	defer client.Close()
+	cache := dexpaprika.NewInMemoryCache()
+	defer cache.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle("/", newProxy(client, cache, ttl))
+	checker := health.New(client)
+	checker.Cache = cache
+	checker.Register(mux)
+	server := &http.Server{Addr: listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	errc := make(chan error, 1)
+	go func() { errc <- server.ListenAndServe() }()
+	log.Printf("proxying the DexPaprika API on %s", listen)
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// fetchTimeout bounds an upstream fetch, which is shared by every request
+// waiting on it and so does not end when the first requester goes away.
+const fetchTimeout = time.Minute
+
+// entry is a cached upstream response.
+type entry struct {
+	status      int
+	contentType string
+	body        []byte
+	fetched     time.Time
+}
+
+// call is an upstream fetch in progress that concurrent requests for the
+// same resource wait on.
+type call struct {
+	done  chan struct{}
+	entry *entry
+	err   error
+}
+
+// proxy serves DexPaprika REST paths from a cache, fetching misses through
+// the SDK client so that all callers share its rate limit and retries.
+type proxy struct {
+	client *dexpaprika.Client
+	cache  dexpaprika.Cache
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+func newProxy(client *dexpaprika.Client, cache dexpaprika.Cache, ttl time.Duration) *proxy {
+	return &proxy{client: client, cache: cache, ttl: ttl, calls: make(map[string]*call)}
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	// Query parameters are sorted so equivalent URLs share an entry
+	key := r.URL.Path
+	if q := r.URL.Query(); len(q) > 0 {
+		key += "?" + q.Encode()
+	}
+
+	if v, ok := p.cache.Get(key); ok {
+		writeEntry(w, v.(*entry), "HIT")
+		return
+	}
+
+	e, err := p.fetch(r.Context(), key)
+	if err != nil {
+		if r.Context().Err() == nil {
+			log.Printf("GET %s: %v", key, err)
+			writeError(w, http.StatusBadGateway, err.Error())
+		}
+		return
+	}
+	writeEntry(w, e, "MISS")
+}
+
+// fetch returns the upstream response for key, joining a fetch already in
+// progress for it. Successful responses are cached for the proxy's TTL;
+// API errors are passed through uncached.
+func (p *proxy) fetch(ctx context.Context, key string) (*entry, error) {
+	p.mu.Lock()
+	c, ok := p.calls[key]
+	if !ok {
+		c = &call{done: make(chan struct{})}
+		p.calls[key] = c
+		go p.do(ctx, key, c)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.entry, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *proxy) do(ctx context.Context, key string, c *call) {
+	defer func() {
+		p.mu.Lock()
+		delete(p.calls, key)
+		p.mu.Unlock()
+		close(c.done)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+	defer cancel()
+
+	req, err := p.client.NewRequest(http.MethodGet, key, nil)
+	if err != nil {
+		c.err = err
+		return
+	}
+	resp, err := p.client.Do(ctx, req, nil)
+	if err != nil {
+		var apiErr *dexpaprika.APIError
+		if errors.As(err, &apiErr) && resp != nil {
+			c.entry = &entry{
+				status:      apiErr.StatusCode,
+				contentType: resp.Header.Get("Content-Type"),
+				body:        apiErr.RawResponse,
+				fetched:     time.Now(),
+			}
+			return
+		}
+		c.err = err
+		return
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.err = err
+		return
+	}
+	c.entry = &entry{
+		status:      resp.StatusCode,
+		contentType: resp.Header.Get("Content-Type"),
+		body:        body,
+		fetched:     time.Now(),
+	}
+	p.cache.Set(key, c.entry, p.ttl)
+}
+
+// writeEntry writes a cached or fetched response, marking in X-Cache
+// whether it came from the cache.
+func writeEntry(w http.ResponseWriter, e *entry, cache string) {
+	if e.contentType != "" {
+		w.Header().Set("Content-Type", e.contentType)
+	}
+	w.Header().Set("X-Cache", cache)
+	w.Header().Set("Age", strconv.Itoa(int(time.Since(e.fetched).Seconds())))
+	w.WriteHeader(e.status)
+	_, _ = w.Write(e.body)
+}
+
+// writeError writes an error in the API's {"error": "..."} body format.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
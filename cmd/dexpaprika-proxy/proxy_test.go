@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// newTestProxy serves a proxy in front of handler and returns its URL.
+func newTestProxy(t *testing.T, handler http.HandlerFunc) string {
+	t.Helper()
+	api := httptest.NewServer(handler)
+	t.Cleanup(api.Close)
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(api.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+	cache := dexpaprika.NewInMemoryCache()
+	t.Cleanup(func() { cache.Close() })
+
+	srv := httptest.NewServer(newProxy(client, cache, time.Minute))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func get(t *testing.T, url string) (*http.Response, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp, string(body)
+}
+
+func TestProxy_Caches(t *testing.T) {
+	var calls atomic.Int32
+	url := newTestProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != "/networks/ethereum/pools" || r.URL.Query().Get("limit") != "10" {
+			t.Errorf("Unexpected upstream request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"pools":[]}`))
+	})
+
+	resp, body := get(t, url+"/networks/ethereum/pools?limit=10&sort=desc")
+	if resp.StatusCode != http.StatusOK || body != `{"pools":[]}` || resp.Header.Get("X-Cache") != "MISS" {
+		t.Errorf("First response %d %q X-Cache=%q", resp.StatusCode, body, resp.Header.Get("X-Cache"))
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	// Same query in a different order is served from the cache
+	resp, body = get(t, url+"/networks/ethereum/pools?sort=desc&limit=10")
+	if body != `{"pools":[]}` || resp.Header.Get("X-Cache") != "HIT" {
+		t.Errorf("Second response %q X-Cache=%q", body, resp.Header.Get("X-Cache"))
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Upstream called %d times, want 1", n)
+	}
+}
+
+func TestProxy_CollapsesConcurrentMisses(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	url := newTestProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Write([]byte(`[]`))
+	})
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, body := get(t, url+"/networks"); body != `[]` {
+				t.Errorf("Body = %q", body)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Upstream called %d times, want 1", n)
+	}
+}
+
+func TestProxy_PassesErrorsThroughUncached(t *testing.T) {
+	var calls atomic.Int32
+	url := newTestProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"pool not found"}`))
+	})
+
+	for range 2 {
+		resp, body := get(t, url+"/networks/ethereum/pools/0xmissing")
+		if resp.StatusCode != http.StatusNotFound || body != `{"error":"pool not found"}` {
+			t.Errorf("Response %d %q", resp.StatusCode, body)
+		}
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("Upstream called %d times, want 2", n)
+	}
+}
+
+func TestProxy_RejectsWrites(t *testing.T) {
+	url := newTestProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Upstream called for a POST")
+	})
+
+	resp, err := http.Post(url+"/networks", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed || resp.Header.Get("Allow") != "GET, HEAD" {
+		t.Errorf("Response %d Allow=%q", resp.StatusCode, resp.Header.Get("Allow"))
+	}
+}
+
+func TestRun_ServesHealthEndpoints(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer api.Close()
+
+	// Reserve a free port for run to listen on
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- run(ctx, addr, 100, time.Minute, api.URL) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("run returned error: %v", err)
+		}
+	}()
+
+	var resp *http.Response
+	for range 50 {
+		if resp, err = http.Get("http://" + addr + "/readyz"); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"upstream"`) {
+		t.Errorf("Readiness %d %s", resp.StatusCode, body)
+	}
+}
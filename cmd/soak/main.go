@@ -0,0 +1,51 @@
+// Command soak runs a mixed workload against the DexPaprika API through one
+// SDK client for hours at a target rate, to validate the SDK's stability
+// before it ships inside long-running services:
+//
+//	soak -duration 6h -rate 2 -workload list=4,details=3,ohlcv=2,search=1
+//
+// Every -report interval it prints, per operation, the requests made, the
+// error rate and the latency percentiles of the interval, together with the
+// live heap and goroutine count and their growth since the first interval.
+// The final report has the percentiles of the whole run.
+//
+// Requests are started at -rate per second whether or not earlier ones have
+// completed, up to -concurrency at a time; requests that would exceed it are
+// counted as skipped. soak exits with status 1 if the error rate exceeds
+// -max-error-rate or the heap grew more than -max-heap-growth MiB.
+//
+// The operations are list, details, token, ohlcv, transactions and search.
+// They request the top pool of -network, one of its tokens, and -query.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.baseURL, "base-url", "", "API base URL (defaults to the SDK's)")
+	flag.DurationVar(&cfg.duration, "duration", time.Hour, "how long to run")
+	flag.Float64Var(&cfg.rate, "rate", 2, "requests started per second")
+	flag.IntVar(&cfg.concurrency, "concurrency", 16, "maximum requests in flight")
+	flag.StringVar(&cfg.workload, "workload", "list=4,details=3,token=2,ohlcv=2,transactions=1,search=1", "operations and their relative weights")
+	flag.StringVar(&cfg.network, "network", "ethereum", "network of the requested pools and tokens")
+	flag.StringVar(&cfg.query, "query", "usdc", "search query")
+	flag.DurationVar(&cfg.report, "report", time.Minute, "interval between reports")
+	flag.Float64Var(&cfg.maxErrorRate, "max-error-rate", 0.01, "maximum fraction of failed requests")
+	flag.Float64Var(&cfg.maxHeapGrowth, "max-heap-growth", 64, "maximum live heap growth in MiB after the first report, 0 for no limit")
+	flag.Int64Var(&cfg.seed, "seed", 1, "seed of the operation mix")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx, cfg, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
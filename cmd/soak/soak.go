@@ -0,0 +1,346 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// config holds the command-line flags.
+type config struct {
+	baseURL       string
+	duration      time.Duration
+	rate          float64
+	concurrency   int
+	workload      string
+	network       string
+	query         string
+	report        time.Duration
+	maxErrorRate  float64
+	maxHeapGrowth float64 // MiB
+	seed          int64
+}
+
+// targets are the resources the requests are made for.
+type targets struct {
+	network, pool, token, query string
+}
+
+// ops are the requests of a workload, by name.
+var ops = map[string]func(ctx context.Context, c *dexpaprika.Client, t targets) error{
+	"list": func(ctx context.Context, c *dexpaprika.Client, t targets) error {
+		_, err := c.Pools.ListByNetwork(ctx, t.network, &dexpaprika.ListOptions{Limit: 50, OrderBy: "volume_usd", Sort: "desc"})
+		return err
+	},
+	"details": func(ctx context.Context, c *dexpaprika.Client, t targets) error {
+		_, err := c.Pools.GetDetails(ctx, t.network, t.pool, nil)
+		return err
+	},
+	"token": func(ctx context.Context, c *dexpaprika.Client, t targets) error {
+		_, err := c.Tokens.GetDetails(ctx, t.network, t.token)
+		return err
+	},
+	"ohlcv": func(ctx context.Context, c *dexpaprika.Client, t targets) error {
+		start := time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+		_, err := c.Pools.GetOHLCV(ctx, t.network, t.pool, &dexpaprika.OHLCVOptions{Start: start, Interval: "1h", Limit: 24})
+		return err
+	},
+	"transactions": func(ctx context.Context, c *dexpaprika.Client, t targets) error {
+		_, err := c.Pools.GetTransactions(ctx, t.network, t.pool, 0, 50, "")
+		return err
+	},
+	"search": func(ctx context.Context, c *dexpaprika.Client, t targets) error {
+		_, err := c.Search.Search(ctx, t.query)
+		return err
+	},
+}
+
+// weightedOp is an op with its share of the workload.
+type weightedOp struct {
+	name   string
+	weight int
+}
+
+// parseWorkload parses a workload such as "list=4,details=3,ohlcv=2".
+func parseWorkload(s string) ([]weightedOp, error) {
+	var workload []weightedOp
+	for _, part := range strings.Split(s, ",") {
+		name, weight, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if _, known := ops[name]; !known {
+			return nil, fmt.Errorf("unknown operation %q in workload %q", name, s)
+		}
+		w := 1
+		if ok {
+			var err error
+			if w, err = strconv.Atoi(weight); err != nil || w < 0 {
+				return nil, fmt.Errorf("invalid weight %q of %s", weight, name)
+			}
+		}
+		if w > 0 {
+			workload = append(workload, weightedOp{name, w})
+		}
+	}
+	if len(workload) == 0 {
+		return nil, errors.New("the workload has no operations")
+	}
+	return workload, nil
+}
+
+// pick returns an op of the workload at random, in proportion to weights.
+func pick(r *rand.Rand, workload []weightedOp) string {
+	total := 0
+	for _, w := range workload {
+		total += w.weight
+	}
+	n := r.Intn(total)
+	for _, w := range workload {
+		if n < w.weight {
+			return w.name
+		}
+		n -= w.weight
+	}
+	return workload[len(workload)-1].name
+}
+
+// reservoirSize bounds the latencies kept per op for the final percentiles.
+const reservoirSize = 10000
+
+// opStats are the results of an op.
+type opStats struct {
+	requests, errors int
+	window           []time.Duration // Latencies since the last report
+	reservoir        []time.Duration // Uniform sample of all latencies
+	lastErr          error
+}
+
+// stats collects the results of a soak test. It is safe for concurrent use.
+type stats struct {
+	mu      sync.Mutex
+	ops     map[string]*opStats
+	skipped int // Requests not made because all workers were busy
+	rand    *rand.Rand
+}
+
+func newStats(seed int64) *stats {
+	return &stats{ops: make(map[string]*opStats), rand: rand.New(rand.NewSource(seed))}
+}
+
+func (s *stats) record(name string, d time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.ops[name]
+	if o == nil {
+		o = &opStats{}
+		s.ops[name] = o
+	}
+	o.requests++
+	if err != nil {
+		o.errors++
+		o.lastErr = err
+	}
+	o.window = append(o.window, d)
+	if len(o.reservoir) < reservoirSize {
+		o.reservoir = append(o.reservoir, d)
+	} else if i := s.rand.Intn(o.requests); i < reservoirSize {
+		o.reservoir[i] = d
+	}
+}
+
+func (s *stats) skip() {
+	s.mu.Lock()
+	s.skipped++
+	s.mu.Unlock()
+}
+
+// errorRate returns the fraction of requests that failed.
+func (s *stats) errorRate() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests, errs := 0, 0
+	for _, o := range s.ops {
+		requests += o.requests
+		errs += o.errors
+	}
+	if requests == 0 {
+		return 0
+	}
+	return float64(errs) / float64(requests)
+}
+
+// memory is a reading of the process's memory use.
+type memory struct {
+	heap       uint64 // Live heap bytes after a GC
+	goroutines int
+}
+
+func readMemory() memory {
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return memory{heap: m.HeapAlloc, goroutines: runtime.NumGoroutine()}
+}
+
+const mib = 1 << 20
+
+// write writes a report of the requests so far: latency percentiles of the
+// requests since the last report or, in the final report, of all requests.
+func (s *stats) write(w io.Writer, elapsed time.Duration, mem, baseline memory, final bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	growth := (float64(mem.heap) - float64(baseline.heap)) / mib
+	fmt.Fprintf(w, "elapsed %v  heap %.1f MiB (%+.1f MiB)  goroutines %d (%+d)  skipped %d\n",
+		elapsed.Round(time.Second), float64(mem.heap)/mib, growth, mem.goroutines, mem.goroutines-baseline.goroutines, s.skipped)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "op\trequests\terrors\terror%\tp50\tp90\tp99\tmax\tlast error")
+	names := make([]string, 0, len(s.ops))
+	for name := range s.ops {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		o := s.ops[name]
+		latencies := o.window
+		if final {
+			latencies = o.reservoir
+		}
+		p := percentiles(latencies, 0.5, 0.9, 0.99, 1)
+		lastErr := ""
+		if o.lastErr != nil {
+			lastErr = o.lastErr.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f\t%v\t%v\t%v\t%v\t%s\n", name, o.requests, o.errors,
+			100*float64(o.errors)/float64(max(o.requests, 1)), p[0], p[1], p[2], p[3], lastErr)
+		o.window = o.window[:0]
+	}
+	tw.Flush()
+}
+
+// percentiles returns the latencies at the quantiles qs, rounded to the
+// millisecond.
+func percentiles(latencies []time.Duration, qs ...float64) []time.Duration {
+	out := make([]time.Duration, len(qs))
+	if len(latencies) == 0 {
+		return out
+	}
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+	for i, q := range qs {
+		idx := int(q*float64(len(sorted))+0.5) - 1
+		out[i] = sorted[min(max(idx, 0), len(sorted)-1)].Round(time.Millisecond)
+	}
+	return out
+}
+
+// discover finds a pool and one of its tokens on the network to request.
+func discover(ctx context.Context, client *dexpaprika.Client, network, query string) (targets, error) {
+	pools, err := client.Pools.ListByNetwork(ctx, network, &dexpaprika.ListOptions{Limit: 1, OrderBy: "volume_usd", Sort: "desc"})
+	if err != nil {
+		return targets{}, fmt.Errorf("discovering a pool on %s: %w", network, err)
+	}
+	if len(pools.Pools) == 0 || len(pools.Pools[0].Tokens) == 0 {
+		return targets{}, fmt.Errorf("no pools with tokens on %s", network)
+	}
+	pool := pools.Pools[0]
+	return targets{network: network, pool: pool.ID, token: pool.Tokens[0].ID, query: query}, nil
+}
+
+// run soaks the API for cfg.duration, or until ctx is done, writing a
+// report to w every cfg.report and at the end. It fails if the error rate or
+// heap growth exceed their limits.
+func run(ctx context.Context, cfg config, w io.Writer) error {
+	workload, err := parseWorkload(cfg.workload)
+	if err != nil {
+		return err
+	}
+	if cfg.rate <= 0 || cfg.concurrency <= 0 {
+		return errors.New("-rate and -concurrency must be positive")
+	}
+	opts := []dexpaprika.ClientOption{}
+	if cfg.baseURL != "" {
+		opts = append(opts, dexpaprika.WithBaseURL(cfg.baseURL))
+	}
+	client := dexpaprika.NewClient(opts...)
+	defer client.Close()
+
+	t, err := discover(ctx, client, cfg.network, cfg.query)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "soaking %s for %v at %g requests/s: pool %s, token %s\n", cfg.network, cfg.duration, cfg.rate, t.pool, t.token)
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.duration)
+	defer cancel()
+	s := newStats(cfg.seed)
+	r := rand.New(rand.NewSource(cfg.seed))
+	sem := make(chan struct{}, cfg.concurrency)
+	var wg sync.WaitGroup
+
+	tick := time.NewTicker(time.Duration(float64(time.Second) / cfg.rate))
+	defer tick.Stop()
+	report := time.NewTicker(cfg.report)
+	defer report.Stop()
+	start := time.Now()
+	baseline := readMemory()
+	warm := false
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-report.C:
+			mem := readMemory()
+			if !warm {
+				// Measure growth from the end of the first interval, once
+				// connections and caches are warm
+				baseline, warm = mem, true
+			}
+			s.write(w, time.Since(start), mem, baseline, false)
+		case <-tick.C:
+			name := pick(r, workload)
+			select {
+			case sem <- struct{}{}:
+			default:
+				s.skip()
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				begin := time.Now()
+				err := ops[name](ctx, client, t)
+				if ctx.Err() != nil {
+					return // Cut short by the end of the test
+				}
+				s.record(name, time.Since(begin), err)
+			}()
+		}
+	}
+	wg.Wait()
+
+	mem := readMemory()
+	fmt.Fprintln(w, "final:")
+	s.write(w, time.Since(start), mem, baseline, true)
+
+	var problems []error
+	if rate := s.errorRate(); rate > cfg.maxErrorRate {
+		problems = append(problems, fmt.Errorf("error rate %.2f%% exceeds %.2f%%", 100*rate, 100*cfg.maxErrorRate))
+	}
+	if growth := (float64(mem.heap) - float64(baseline.heap)) / mib; cfg.maxHeapGrowth > 0 && growth > cfg.maxHeapGrowth {
+		problems = append(problems, fmt.Errorf("heap grew %.1f MiB, more than %.1f MiB", growth, cfg.maxHeapGrowth))
+	}
+	return errors.Join(problems...)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/dexpaprikatest"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/fixtures"
+)
+
+// newTestConfig returns a short soak test of a fake seeded with the
+// fixtures.
+func newTestConfig(t *testing.T, fake *dexpaprikatest.Fake) config {
+	t.Helper()
+	fixtures.Seed(fake)
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	return config{
+		baseURL:      srv.URL,
+		duration:     500 * time.Millisecond,
+		rate:         200,
+		concurrency:  8,
+		workload:     "list=2,details=2,token,ohlcv,transactions,search",
+		network:      fixtures.Network,
+		query:        "usdc",
+		report:       200 * time.Millisecond,
+		maxErrorRate: 0.01,
+		seed:         1,
+	}
+}
+
+func TestRun(t *testing.T) {
+	fake := dexpaprikatest.NewFake()
+	fake.MaxRequests = 100
+	cfg := newTestConfig(t, fake)
+
+	var out bytes.Buffer
+	if err := run(context.Background(), cfg, &out); err != nil {
+		t.Fatalf("run() = %v\n%s", err, &out)
+	}
+	report := out.String()
+	final := report[strings.LastIndex(report, "final:"):]
+	for _, want := range []string{"elapsed", "heap", "goroutines", "p99", "list", "details", "token", "ohlcv", "transactions", "search"} {
+		if !strings.Contains(final, want) {
+			t.Errorf("Final report lacks %q:\n%s", want, report)
+		}
+	}
+	if strings.Count(report, "op  ") < 2 {
+		t.Errorf("Expected periodic reports before the final one:\n%s", report)
+	}
+}
+
+func TestRun_ErrorRate(t *testing.T) {
+	fake := dexpaprikatest.NewFake()
+	cfg := newTestConfig(t, fake)
+	cfg.duration = 200 * time.Millisecond
+	cfg.workload = "details"
+	// Let discovery succeed, then fail every request
+	handler := http.Handler(fake)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/pools") {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		http.Error(w, `{"message": "not found"}`, http.StatusNotFound)
+	}))
+	defer srv.Close()
+	cfg.baseURL = srv.URL
+
+	var out bytes.Buffer
+	err := run(context.Background(), cfg, &out)
+	if err == nil || !strings.Contains(err.Error(), "error rate 100.00% exceeds 1.00%") {
+		t.Errorf("run() = %v, want the error rate exceeded\n%s", err, &out)
+	}
+}
+
+func TestParseWorkload(t *testing.T) {
+	workload, err := parseWorkload("list=4, details ,search=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(workload) != 2 || workload[0] != (weightedOp{"list", 4}) || workload[1] != (weightedOp{"details", 1}) {
+		t.Errorf("parseWorkload() = %v", workload)
+	}
+	for _, bad := range []string{"", "list=x", "lists=1", "search=0", "list=-1"} {
+		if _, err := parseWorkload(bad); err == nil {
+			t.Errorf("parseWorkload(%q) succeeded, want an error", bad)
+		}
+	}
+
+	counts := map[string]int{}
+	r := rand.New(rand.NewSource(1))
+	for range 10000 {
+		counts[pick(r, workload)]++
+	}
+	if share := float64(counts["list"]) / 10000; share < 0.75 || share > 0.85 {
+		t.Errorf("list picked %.2f of the time, want about 0.8", share)
+	}
+}
+
+func TestPercentiles(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	got := percentiles(latencies, 0.5, 0.99, 1)
+	want := []time.Duration{50 * time.Millisecond, 99 * time.Millisecond, 100 * time.Millisecond}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("percentiles() = %v, want %v", got, want)
+			break
+		}
+	}
+	if got := percentiles(nil, 0.5); got[0] != 0 {
+		t.Errorf("percentiles(nil) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,169 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for Transactions.AddressActivity.
+const (
+	defaultActivityConcurrency = 4
+	defaultActivityPages       = 10
+	activityRateLimitRetries   = 3
+)
+
+// AddressActivityOptions configures Transactions.AddressActivity.
+type AddressActivityOptions struct {
+	Since time.Time // Oldest transaction to include; zero scans MaxPages per pool
+	Until time.Time // Newest transaction to include; zero means no upper bound
+
+	// Concurrency is the number of pools scanned in parallel. It defaults
+	// to 4. Requests still go through the client's rate limiter.
+	Concurrency int
+
+	// MaxPages caps the transaction pages, of 100 transactions each,
+	// scanned per pool. It defaults to 10.
+	MaxPages int
+}
+
+// AddressActivity scans the recent transactions of a set of pools for trades
+// sent or received by address, and returns them newest first.
+//
+// Pools are scanned concurrently, bounded by opts.Concurrency, and paging
+// stops at the first transaction older than opts.Since. Requests that are
+// rate limited after the client's own retries are paused and retried up to
+// three more times before the scan fails. The first error cancels the
+// remaining pools.
+func (s *TransactionsService) AddressActivity(ctx context.Context, networkID, address string, poolAddresses []string, opts *AddressActivityOptions) ([]*Trade, error) {
+	var o AddressActivityOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = defaultActivityConcurrency
+	}
+	if o.MaxPages <= 0 {
+		o.MaxPages = defaultActivityPages
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		trades   []*Trade
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	sem := make(chan struct{}, o.Concurrency)
+	for _, pool := range poolAddresses {
+		wg.Add(1)
+		go func(pool string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			found, err := s.poolActivity(ctx, networkID, pool, address, &o)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			trades = append(trades, found...)
+		}(pool)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	sort.SliceStable(trades, func(i, j int) bool {
+		if !trades[i].Time.Equal(trades[j].Time) {
+			return trades[i].Time.After(trades[j].Time)
+		}
+		if trades[i].BlockNumber != trades[j].BlockNumber {
+			return trades[i].BlockNumber > trades[j].BlockNumber
+		}
+		return trades[i].LogIndex > trades[j].LogIndex
+	})
+	return trades, nil
+}
+
+// poolActivity returns the trades of address in a single pool.
+func (s *TransactionsService) poolActivity(ctx context.Context, networkID, poolAddress, address string, o *AddressActivityOptions) ([]*Trade, error) {
+	var pool *PoolDetails
+	err := s.retryRateLimited(ctx, func() (err error) {
+		pool, err = s.client.Pools.GetDetails(ctx, networkID, poolAddress, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	const limit = 100
+	var trades []*Trade
+	for page := 0; page < o.MaxPages; page++ {
+		var resp *TransactionsResponse
+		err := s.retryRateLimited(ctx, func() (err error) {
+			resp, err = s.client.Pools.GetTransactions(ctx, networkID, poolAddress, page, limit, "")
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		done := len(resp.Transactions) < limit || page+1 >= resp.PageInfo.TotalPages
+		for _, tx := range resp.Transactions {
+			if created, err := time.Parse(time.RFC3339, tx.CreatedAt); err == nil {
+				if !o.Since.IsZero() && created.Before(o.Since) {
+					done = true
+					break
+				}
+				if !o.Until.IsZero() && created.After(o.Until) {
+					continue
+				}
+			}
+			if !strings.EqualFold(tx.Sender, address) && !strings.EqualFold(tx.Recipient, address) {
+				continue
+			}
+			trade, err := EnrichTransaction(tx, pool)
+			if err != nil {
+				continue
+			}
+			trades = append(trades, trade)
+		}
+		if done {
+			break
+		}
+	}
+	return trades, nil
+}
+
+// retryRateLimited calls fn, pausing for the client's maximum retry wait and
+// trying again while it fails with ErrRateLimit.
+func (s *TransactionsService) retryRateLimited(ctx context.Context, fn func() error) error {
+	err := fn()
+	for i := 0; i < activityRateLimitRetries && errors.Is(err, ErrRateLimit); i++ {
+		timer := time.NewTimer(s.client.retryWaitMax)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		err = fn()
+	}
+	return err
+}
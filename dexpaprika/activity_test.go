@@ -0,0 +1,74 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransactions_AddressActivity(t *testing.T) {
+	var throttled atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/networks/ethereum/pools/0xa", "/networks/ethereum/pools/0xb":
+			w.Write([]byte(`{"id": "pool", "last_price_usd": 2000, "tokens": [{"id": "0xweth", "symbol": "WETH"}, {"id": "0xusdc", "symbol": "USDC"}]}`))
+		case "/networks/ethereum/pools/0xa/transactions":
+			w.Write([]byte(`{"transactions": [
+				{"id": "a1", "sender": "0xWallet", "token_0": "0xweth", "token_1": "0xusdc", "amount_0": -1, "amount_1": 2000, "created_at": "2024-06-01T12:00:00Z"},
+				{"id": "a2", "sender": "0xother", "token_0": "0xweth", "token_1": "0xusdc", "amount_0": -1, "amount_1": 2000, "created_at": "2024-06-01T11:00:00Z"},
+				{"id": "a3", "sender": "0xwallet", "token_0": "0xweth", "token_1": "0xusdc", "amount_0": 1, "amount_1": -2000, "created_at": "2024-05-01T00:00:00Z"}
+			], "page_info": {"limit": 100, "page": 0, "total_items": 3, "total_pages": 1}}`))
+		case "/networks/ethereum/pools/0xb/transactions":
+			if throttled.CompareAndSwap(false, true) {
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"error": "rate limited"}`))
+				return
+			}
+			w.Write([]byte(`{"transactions": [
+				{"id": "b1", "recipient": "0xwallet", "token_0": "0xweth", "token_1": "0xusdc", "amount_0": 2, "amount_1": -4000, "created_at": "2024-06-01T13:00:00Z"}
+			], "page_info": {"limit": 100, "page": 0, "total_items": 1, "total_pages": 1}}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	trades, err := client.Transactions.AddressActivity(context.Background(), "ethereum", "0xwallet", []string{"0xa", "0xb"},
+		&AddressActivityOptions{Since: since, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("AddressActivity returned error: %v", err)
+	}
+	if len(trades) != 2 || trades[0].ID != "b1" || trades[1].ID != "a1" {
+		t.Fatalf("Expected trades b1 and a1 newest first, got %+v", trades)
+	}
+	if trades[0].Side != TradeSideSell || trades[0].ValueUSD != 4000 {
+		t.Errorf("Unexpected enrichment of b1: %+v", trades[0])
+	}
+	if !throttled.Load() {
+		t.Error("Expected the rate-limited request to be retried")
+	}
+}
+
+func TestTransactions_AddressActivityError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "pool not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+
+	_, err := client.Transactions.AddressActivity(context.Background(), "ethereum", "0xwallet", []string{"0xa", "0xb", "0xc"}, nil)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package dexpaprika
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidAddress is returned when an address is malformed for its network.
+var ErrInvalidAddress = errors.New("invalid address")
+
+// evmNetworks lists the networks whose addresses are 20-byte hex strings.
+var evmNetworks = map[string]bool{
+	NetworkArbitrum:  true,
+	NetworkAvalanche: true,
+	NetworkBase:      true,
+	NetworkBerachain: true,
+	NetworkBlast:     true,
+	NetworkBsc:       true,
+	NetworkCelo:      true,
+	NetworkCronos:    true,
+	NetworkEthereum:  true,
+	NetworkFantom:    true,
+	NetworkLinea:     true,
+	NetworkMantle:    true,
+	NetworkOptimism:  true,
+	NetworkPolygon:   true,
+	NetworkScroll:    true,
+	NetworkSonic:     true,
+	NetworkUnichain:  true,
+	NetworkZksync:    true,
+}
+
+// IsEVMNetwork reports whether networkID uses EVM-style hex addresses.
+func IsEVMNetwork(networkID string) bool {
+	return evmNetworks[networkID]
+}
+
+// NormalizeAddress returns address in the canonical form expected by the API.
+// EVM addresses are lowercased; addresses on other networks are only trimmed,
+// since formats such as base58 are case-sensitive.
+func NormalizeAddress(networkID, address string) string {
+	address = strings.TrimSpace(address)
+	if IsEVMNetwork(networkID) && hasHexPrefix(address) {
+		return strings.ToLower(address)
+	}
+	return address
+}
+
+// ValidateAddress checks that address is well-formed for networkID. Mixed-case
+// EVM addresses must carry a valid EIP-55 checksum and Solana addresses must
+// decode from base58 to 32 bytes. Other networks are not validated.
+func ValidateAddress(networkID, address string) error {
+	switch {
+	case IsEVMNetwork(networkID):
+		return validateEVMAddress(address)
+	case networkID == NetworkSolana:
+		return validateSolanaAddress(address)
+	default:
+		return nil
+	}
+}
+
+// ChecksumAddress returns the EIP-55 mixed-case checksum form of an EVM address.
+func ChecksumAddress(address string) (string, error) {
+	if !isHexAddress(address) {
+		return "", fmt.Errorf("%w: %q is not a 20-byte hex address", ErrInvalidAddress, address)
+	}
+
+	lower := strings.ToLower(address[2:])
+	hash := keccak256([]byte(lower))
+
+	var b strings.Builder
+	b.Grow(42)
+	b.WriteString("0x")
+	for i, c := range lower {
+		// Each hex character is uppercased when the matching nibble of the hash is >= 8
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if c >= 'a' && nibble&0x0f >= 8 {
+			c -= 'a' - 'A'
+		}
+		b.WriteRune(c)
+	}
+	return b.String(), nil
+}
+
+func validateEVMAddress(address string) error {
+	if !isHexAddress(address) {
+		return fmt.Errorf("%w: %q is not a 20-byte hex address", ErrInvalidAddress, address)
+	}
+
+	body := address[2:]
+	if body == strings.ToLower(body) || body == strings.ToUpper(body) {
+		return nil
+	}
+
+	checksummed, err := ChecksumAddress(address)
+	if err != nil {
+		return err
+	}
+	if checksummed[2:] != body {
+		return fmt.Errorf("%w: %q has an invalid EIP-55 checksum", ErrInvalidAddress, address)
+	}
+	return nil
+}
+
+func validateSolanaAddress(address string) error {
+	decoded, err := decodeBase58(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+	if len(decoded) != 32 {
+		return fmt.Errorf("%w: %q decodes to %d bytes, want 32", ErrInvalidAddress, address, len(decoded))
+	}
+	return nil
+}
+
+func hasHexPrefix(s string) bool {
+	return len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X')
+}
+
+func isHexAddress(s string) bool {
+	if !hasHexPrefix(s) || len(s) != 42 {
+		return false
+	}
+	_, err := hex.DecodeString(s[2:])
+	return err == nil
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// decodeBase58 decodes a Bitcoin-alphabet base58 string.
+func decodeBase58(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("empty base58 string")
+	}
+
+	var out []byte
+	for i := 0; i < len(s); i++ {
+		carry := strings.IndexByte(base58Alphabet, s[i])
+		if carry < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", s[i])
+		}
+		for j := len(out) - 1; j >= 0; j-- {
+			carry += int(out[j]) * 58
+			out[j] = byte(carry)
+			carry >>= 8
+		}
+		for carry > 0 {
+			out = append([]byte{byte(carry)}, out...)
+			carry >>= 8
+		}
+	}
+
+	// Leading '1' characters encode leading zero bytes
+	zeros := 0
+	for zeros < len(s) && s[zeros] == '1' {
+		zeros++
+	}
+	return append(make([]byte, zeros), out...), nil
+}
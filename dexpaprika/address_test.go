@@ -0,0 +1,125 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChecksumAddress(t *testing.T) {
+	// Test vectors from EIP-55
+	tests := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+
+	for _, want := range tests {
+		got, err := ChecksumAddress(NormalizeAddress(NetworkEthereum, want))
+		if err != nil {
+			t.Errorf("ChecksumAddress(%s) returned error: %v", want, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ChecksumAddress() = %s, want %s", got, want)
+		}
+	}
+
+	if _, err := ChecksumAddress("0x1234"); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("Expected ErrInvalidAddress for short address, got %v", err)
+	}
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	tests := []struct {
+		network string
+		address string
+		want    string
+	}{
+		{NetworkEthereum, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"},
+		{NetworkBase, " 0xABC ", "0xabc"},
+		{NetworkSolana, "So11111111111111111111111111111111111111112", "So11111111111111111111111111111111111111112"},
+		{"unknown", "MixedCase", "MixedCase"},
+	}
+
+	for _, tc := range tests {
+		if got := NormalizeAddress(tc.network, tc.address); got != tc.want {
+			t.Errorf("NormalizeAddress(%s, %q) = %q, want %q", tc.network, tc.address, got, tc.want)
+		}
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	tests := []struct {
+		name        string
+		network     string
+		address     string
+		expectError bool
+	}{
+		{"lowercase evm", NetworkEthereum, "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", false},
+		{"valid checksum", NetworkEthereum, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", false},
+		{"invalid checksum", NetworkEthereum, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", true},
+		{"short evm", NetworkEthereum, "0x1234", true},
+		{"non-hex evm", NetworkEthereum, "0xzz2aaa39b223fe8d0a0e5c4f27ead9083c756cc2", true},
+		{"valid solana", NetworkSolana, "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", false},
+		{"invalid solana character", NetworkSolana, "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt10", true},
+		{"short solana", NetworkSolana, "EPjFWdd5", true},
+		{"unvalidated network", "unknown", "anything", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateAddress(tc.network, tc.address)
+			if tc.expectError && !errors.Is(err, ErrInvalidAddress) {
+				t.Errorf("Expected ErrInvalidAddress, got %v", err)
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAddressNormalizationInPaths(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, 1*time.Millisecond, 1*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := client.Tokens.GetDetails(ctx, NetworkEthereum, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"); err != nil {
+		t.Fatalf("Tokens.GetDetails returned error: %v", err)
+	}
+	if want := "/networks/ethereum/tokens/0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"; gotPath != want {
+		t.Errorf("Expected path '%s', got '%s'", want, gotPath)
+	}
+
+	if _, err := client.Pools.GetDetails(ctx, NetworkEthereum, "0x88E6A0c2dDD26FEEb64F039a2c41296FcB3f5640", nil); err != nil {
+		t.Fatalf("Pools.GetDetails returned error: %v", err)
+	}
+	if want := "/networks/ethereum/pools/0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640"; gotPath != want {
+		t.Errorf("Expected path '%s', got '%s'", want, gotPath)
+	}
+
+	// Solana addresses are case-sensitive and must be left untouched
+	if _, err := client.Tokens.GetDetails(ctx, NetworkSolana, "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"); err != nil {
+		t.Fatalf("Tokens.GetDetails returned error: %v", err)
+	}
+	if want := "/networks/solana/tokens/EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"; gotPath != want {
+		t.Errorf("Expected path '%s', got '%s'", want, gotPath)
+	}
+}
@@ -0,0 +1,116 @@
+package dexpaprika
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// Allocation budgets of the request path. A test failing here means a change
+// added allocations to every request: if that is intended, raise the budget
+// in the same change and say why.
+
+// cannedTransport answers every request with body, without a network
+// round trip, so only the client's own allocations are measured.
+type cannedTransport struct {
+	body []byte
+}
+
+func (t cannedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+// checkAllocs fails t if fn allocates more than budget times per run.
+func checkAllocs(t *testing.T, name string, budget float64, fn func()) {
+	t.Helper()
+	if got := testing.AllocsPerRun(100, fn); got > budget {
+		t.Errorf("%s allocates %v times per run, budget %v", name, got, budget)
+	}
+}
+
+func skipAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector adds allocations")
+	}
+}
+
+func TestAllocs_NewRequest(t *testing.T) {
+	skipAllocs(t)
+	client := NewClient()
+	checkAllocs(t, "NewRequest", 10, func() {
+		if _, err := client.NewRequest(http.MethodGet, "/networks/ethereum/pools?limit=50", nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestAllocs_Query(t *testing.T) {
+	skipAllocs(t)
+	opts := &ListOptions{Page: 2, Limit: 50, Sort: "desc", OrderBy: "volume_usd"}
+	checkAllocs(t, "addOptions", 1, func() {
+		addOptions("/networks/ethereum/pools", opts)
+	})
+	checkAllocs(t, "addOptions without options", 0, func() {
+		addOptions("/networks/ethereum/pools", (*ListOptions)(nil))
+	})
+}
+
+func TestAllocs_DecodeSmall(t *testing.T) {
+	skipAllocs(t)
+	tests := []struct {
+		name   string
+		budget float64
+		data   string
+		target func() any
+	}{
+		{"Stats", 1, `{"chains": 1, "factories": 2, "pools": 3, "tokens": 4}`, func() any { return new(Stats) }},
+		{"PageInfo", 1, `{"limit": 10, "page": 0, "total_items": 3, "total_pages": 1}`, func() any { return new(PageInfo) }},
+		{"Token", 4, `{"id": "0xa0b8", "name": "USD Coin", "symbol": "USDC", "chain": "ethereum", "decimals": 6, "added_at": "2024-10-02T22:04:08Z", "fdv": 42164081934.3}`, func() any { return new(Token) }},
+		{"OHLCVRecord", 1, `{"time_open": "2024-06-01T00:00:00Z", "time_close": "2024-06-01T01:00:00Z", "open": 0.1, "high": 0.2, "low": 0.05, "close": 0.15, "volume": 5911284}`, func() any { return new(OHLCVRecord) }},
+	}
+	for _, tt := range tests {
+		data := []byte(tt.data)
+		checkAllocs(t, "decoding "+tt.name, tt.budget, func() {
+			if err := json.Unmarshal(data, tt.target()); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestAllocs_Request(t *testing.T) {
+	skipAllocs(t)
+	ctx := context.Background()
+	newClient := func(body string) *Client {
+		return NewClient(WithHTTPClient(&http.Client{Transport: cannedTransport{[]byte(body)}}))
+	}
+
+	// These include the allocations of net/http, which vary a little between
+	// Go releases, so the budgets have some headroom.
+	stats := newClient(`{"chains": 1, "factories": 2, "pools": 3, "tokens": 4}`)
+	checkAllocs(t, "Utils.GetStats", 33, func() {
+		if _, err := stats.Utils.GetStats(ctx); err != nil {
+			t.Fatal(err)
+		}
+	})
+	pools := newClient(`{"pools": [], "page_info": {"page": 0}}`)
+	checkAllocs(t, "Pools.ListByNetwork", 36, func() {
+		if _, err := pools.Pools.ListByNetwork(ctx, "ethereum", &ListOptions{Limit: 10, OrderBy: "volume_usd"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	ohlcv := newClient(`[{"time_open": "2024-06-01T00:00:00Z", "time_close": "2024-06-01T01:00:00Z", "open": 0.1, "high": 0.2, "low": 0.05, "close": 0.15, "volume": 5911284}]`)
+	checkAllocs(t, "Pools.GetOHLCV", 41, func() {
+		if _, err := ohlcv.Pools.GetOHLCV(ctx, "ethereum", "0xpool", &OHLCVOptions{Start: "2024-06-01", Limit: 10, Interval: "1h"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package dexpaprika
+
+import "context"
+
+// NetworksAPI is the interface of the network endpoints, implemented by
+// NetworksService. Application code can depend on it instead of the
+// concrete service, so it can be mocked in tests.
+type NetworksAPI interface {
+	List(ctx context.Context, reqOpts ...RequestOption) ([]Network, error)
+	Get(ctx context.Context, networkID string) (*Network, error)
+	ListDexes(ctx context.Context, networkID string, page, limit int, reqOpts ...RequestOption) (*DexesResponse, error)
+}
+
+// DexesAPI is the interface of the DEX endpoints, implemented by
+// DexesService.
+type DexesAPI interface {
+	List(ctx context.Context, networkID string, page, limit int, reqOpts ...RequestOption) (*DexesResponse, error)
+	Get(ctx context.Context, networkID, dexID string) (*Dex, error)
+}
+
+// PoolsAPI is the interface of the pool endpoints, implemented by
+// PoolsService. Helpers built on them, such as GetOHLCVRange and TWAP,
+// remain methods of PoolsService only.
+type PoolsAPI interface {
+	List(ctx context.Context, opts *ListOptions, reqOpts ...RequestOption) (*PoolsResponse, error)
+	ListByNetwork(ctx context.Context, networkID string, opts *ListOptions, reqOpts ...RequestOption) (*PoolsResponse, error)
+	ListByDex(ctx context.Context, networkID, dexID string, opts *ListOptions, reqOpts ...RequestOption) (*PoolsResponse, error)
+	GetByTokenPair(ctx context.Context, networkID, tokenA, tokenB string, opts *ListOptions, reqOpts ...RequestOption) (*PoolsResponse, error)
+	GetDetails(ctx context.Context, networkID, poolAddress string, opts *PoolDetailsOptions, reqOpts ...RequestOption) (*PoolDetails, error)
+	GetOHLCV(ctx context.Context, networkID, poolAddress string, opts *OHLCVOptions, reqOpts ...RequestOption) ([]OHLCVRecord, error)
+	GetTransactions(ctx context.Context, networkID, poolAddress string, page, limit int, cursor string, reqOpts ...RequestOption) (*TransactionsResponse, error)
+}
+
+// TokensAPI is the interface of the token endpoints, implemented by
+// TokensService.
+type TokensAPI interface {
+	GetDetails(ctx context.Context, networkID, tokenAddress string, reqOpts ...RequestOption) (*TokenDetails, error)
+	GetPools(ctx context.Context, networkID, tokenAddress string, opts *ListOptions, additionalTokenAddress string, reqOpts ...RequestOption) (*PoolsResponse, error)
+	GetTransactions(ctx context.Context, networkID, tokenAddress string, opts *TransactionsOptions, reqOpts ...RequestOption) (*TransactionsResponse, error)
+}
+
+// SearchAPI is the interface of the search endpoint, implemented by
+// SearchService.
+type SearchAPI interface {
+	Search(ctx context.Context, query string, reqOpts ...RequestOption) (*SearchResult, error)
+	SearchWithOptions(ctx context.Context, query string, opts *SearchOptions, reqOpts ...RequestOption) (*SearchResult, error)
+}
+
+// UtilsAPI is the interface of the statistics endpoints, implemented by
+// UtilsService.
+type UtilsAPI interface {
+	GetStats(ctx context.Context, reqOpts ...RequestOption) (*Stats, error)
+	GetNetworkStats(ctx context.Context, networkID string) (*NetworkStats, error)
+}
+
+var (
+	_ NetworksAPI = (*NetworksService)(nil)
+	_ DexesAPI    = (*DexesService)(nil)
+	_ PoolsAPI    = (*PoolsService)(nil)
+	_ TokensAPI   = (*TokensService)(nil)
+	_ SearchAPI   = (*SearchService)(nil)
+	_ UtilsAPI    = (*UtilsService)(nil)
+)
+
+// Services bundles the service interfaces, for application code taking the
+// SDK as a single dependency. Tests can fill in mocks of just the services
+// they exercise.
+type Services struct {
+	Networks NetworksAPI
+	Dexes    DexesAPI
+	Pools    PoolsAPI
+	Tokens   TokensAPI
+	Search   SearchAPI
+	Utils    UtilsAPI
+}
+
+// Services returns the client's services as interfaces.
+func (c *Client) Services() Services {
+	return Services{
+		Networks: c.Networks,
+		Dexes:    c.Dexes,
+		Pools:    c.Pools,
+		Tokens:   c.Tokens,
+		Search:   c.Search,
+		Utils:    c.Utils,
+	}
+}
@@ -0,0 +1,50 @@
+package dexpaprika
+
+import (
+	"context"
+	"testing"
+)
+
+// fakePools serves a fixed page of pools, standing in for a generated mock.
+type fakePools struct {
+	PoolsAPI // Unimplemented methods panic
+	pools    []Pool
+	networks []string
+}
+
+func (f *fakePools) ListByNetwork(ctx context.Context, networkID string, opts *ListOptions, reqOpts ...RequestOption) (*PoolsResponse, error) {
+	f.networks = append(f.networks, networkID)
+	return &PoolsResponse{Pools: f.pools}, nil
+}
+
+// topPoolID is application code depending on the interface.
+func topPoolID(ctx context.Context, api Services, networkID string) (string, error) {
+	resp, err := api.Pools.ListByNetwork(ctx, networkID, &ListOptions{Limit: 1, OrderBy: "volume_usd", Sort: "desc"})
+	if err != nil || len(resp.Pools) == 0 {
+		return "", err
+	}
+	return resp.Pools[0].ID, nil
+}
+
+func TestServices_Mock(t *testing.T) {
+	fake := &fakePools{pools: []Pool{{ID: "0xtop"}}}
+
+	id, err := topPoolID(context.Background(), Services{Pools: fake}, "ethereum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "0xtop" || len(fake.networks) != 1 || fake.networks[0] != "ethereum" {
+		t.Errorf("Expected the fake to serve the call, got %q and calls %v", id, fake.networks)
+	}
+}
+
+func TestClient_Services(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+
+	s := client.Services()
+	if s.Pools != client.Pools || s.Tokens != client.Tokens || s.Networks != client.Networks ||
+		s.Dexes != client.Dexes || s.Search != client.Search || s.Utils != client.Utils {
+		t.Error("Expected Services to return the client's services")
+	}
+}
@@ -0,0 +1,177 @@
+// Package backfill loads large amounts of DexPaprika data, such as long
+// OHLCV histories or every pool of a network, into sinks, splitting the work
+// into requests the API accepts and resuming interrupted runs from
+// checkpoints.
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/watch"
+)
+
+// maxChunkCandles is the largest number of candles the OHLCV endpoint
+// returns per request.
+const maxChunkCandles = 366
+
+// Progress reports a completed chunk of a backfill.
+type Progress struct {
+	ChunkStart time.Time
+	ChunkEnd   time.Time
+	Candles    int // Candles written in the chunk
+	Total      int // Candles written so far
+}
+
+// Option configures a backfill.
+type Option func(*config)
+
+type config struct {
+	store      watch.Store
+	chunk      int
+	onProgress func(Progress)
+}
+
+// WithCheckpoints saves the progress of a backfill in store after every
+// chunk, under the key "backfill:ohlcv:<network>:<pool>:<interval>:<from>"
+// with from in RFC 3339, so running it again from the same time continues
+// where it stopped, even with a later end such as time.Now(). A backfill
+// from another time starts from its beginning. watch.NewFileStore keeps
+// checkpoints across restarts.
+func WithCheckpoints(store watch.Store) Option {
+	return func(c *config) { c.store = store }
+}
+
+// WithChunkSize sets the number of candles requested at once. It defaults
+// to, and is capped at, the API's limit of 366.
+func WithChunkSize(candles int) Option {
+	return func(c *config) { c.chunk = candles }
+}
+
+// WithProgress calls fn after every chunk written.
+func WithProgress(fn func(Progress)) Option {
+	return func(c *config) { c.onProgress = fn }
+}
+
+// OHLCV writes the candles of a pool opening in [from, to) to sink, oldest
+// first, and returns how many were written. The range is fetched in chunks
+// of at most 366 candles; candles repeated across chunk boundaries are
+// written once. The sink is flushed after every chunk, and only then is the
+// checkpoint advanced, so a resumed backfill never skips candles. The sink
+// is not closed.
+func OHLCV(ctx context.Context, client *dexpaprika.Client, networkID, poolAddress string, from, to time.Time, interval string, sink dexpaprika.Sink[dexpaprika.OHLCVRecord], opts ...Option) (int, error) {
+	cfg := config{chunk: maxChunkCandles}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.chunk <= 0 || cfg.chunk > maxChunkCandles {
+		cfg.chunk = maxChunkCandles
+	}
+	step, ok := dexpaprika.OHLCVIntervalDuration(interval)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", dexpaprika.ErrInvalidInterval, interval)
+	}
+
+	key := fmt.Sprintf("backfill:ohlcv:%s:%s:%s:%s", networkID, dexpaprika.NormalizeAddress(networkID, poolAddress), interval, from.UTC().Format(time.RFC3339))
+	start, err := resume(ctx, cfg.store, key, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	var last time.Time // Open time of the last candle written
+	for chunkStart := start; chunkStart.Before(to); {
+		chunkEnd := chunkStart.Add(time.Duration(cfg.chunk) * step)
+		if chunkEnd.After(to) {
+			chunkEnd = to
+		}
+
+		candles, err := client.Pools.GetOHLCV(ctx, networkID, poolAddress, &dexpaprika.OHLCVOptions{
+			Start:    chunkStart.UTC().Format(time.RFC3339),
+			End:      chunkEnd.UTC().Format(time.RFC3339),
+			Limit:    cfg.chunk,
+			Interval: interval,
+		})
+		if err != nil {
+			return total, fmt.Errorf("backfill %s: %w", key, err)
+		}
+		rows := inRange(candles, chunkStart, chunkEnd, last)
+		if len(rows) > 0 {
+			if err := sink.Write(ctx, rows); err != nil {
+				return total, err
+			}
+			last, _ = rows[len(rows)-1].OpenTime()
+		}
+		if err := sink.Flush(ctx); err != nil {
+			return total, err
+		}
+		total += len(rows)
+
+		if cfg.store != nil {
+			if err := cfg.store.Set(ctx, key, chunkEnd.UTC().Format(time.RFC3339)); err != nil {
+				return total, fmt.Errorf("backfill %s: save checkpoint: %w", key, err)
+			}
+		}
+		if cfg.onProgress != nil {
+			cfg.onProgress(Progress{ChunkStart: chunkStart, ChunkEnd: chunkEnd, Candles: len(rows), Total: total})
+		}
+		chunkStart = chunkEnd
+	}
+	return total, nil
+}
+
+// resume returns where a backfill of [from, to) starts: at its checkpoint,
+// up to which a run from the same time wrote every candle, if one is saved,
+// otherwise at from.
+func resume(ctx context.Context, store watch.Store, key string, from, to time.Time) (time.Time, error) {
+	if store == nil {
+		return from, nil
+	}
+	value, ok, err := store.Get(ctx, key)
+	if err != nil {
+		return from, fmt.Errorf("backfill %s: load checkpoint: %w", key, err)
+	}
+	if !ok {
+		return from, nil
+	}
+	checkpoint, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return from, fmt.Errorf("backfill %s: invalid checkpoint %q: %w", key, value, err)
+	}
+	switch {
+	case checkpoint.After(to):
+		return to, nil
+	case checkpoint.After(from):
+		return checkpoint, nil
+	}
+	return from, nil
+}
+
+// inRange returns the candles opening in [start, end) and after last, oldest
+// first and without duplicates.
+func inRange(candles []dexpaprika.OHLCVRecord, start, end, last time.Time) []dexpaprika.OHLCVRecord {
+	type candle struct {
+		open time.Time
+		rec  dexpaprika.OHLCVRecord
+	}
+	var kept []candle
+	seen := make(map[time.Time]bool, len(candles))
+	for _, c := range candles {
+		open, err := c.OpenTime()
+		if err != nil || open.Before(start) || !open.Before(end) || !open.After(last) || seen[open] {
+			continue
+		}
+		seen[open] = true
+		kept = append(kept, candle{open, c})
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].open.Before(kept[j].open) })
+
+	rows := make([]dexpaprika.OHLCVRecord, len(kept))
+	for i, c := range kept {
+		rows[i] = c.rec
+	}
+	return rows
+}
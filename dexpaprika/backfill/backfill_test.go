@@ -0,0 +1,164 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/watch"
+)
+
+// sliceSink is a dexpaprika.Sink collecting rows in memory.
+type sliceSink struct {
+	rows    []dexpaprika.OHLCVRecord
+	flushes int
+}
+
+func (s *sliceSink) Write(ctx context.Context, rows []dexpaprika.OHLCVRecord) error {
+	s.rows = append(s.rows, rows...)
+	return nil
+}
+
+func (s *sliceSink) Flush(ctx context.Context) error {
+	s.flushes++
+	return nil
+}
+
+func (s *sliceSink) Close() error { return nil }
+
+var base = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ohlcvServer serves hourly candles for the requested range, newest first
+// and including the candle before the range, as an overlapping API would.
+// Requests after failAfter fail.
+func ohlcvServer(t *testing.T, requests *atomic.Int32, failAfter int32) *dexpaprika.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n := requests.Add(1); failAfter > 0 && n > failAfter {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		start, _ := time.Parse(time.RFC3339, r.URL.Query().Get("start"))
+		end, _ := time.Parse(time.RFC3339, r.URL.Query().Get("end"))
+		var candles []string
+		for open := end.Add(-time.Hour); !open.Before(start.Add(-time.Hour)); open = open.Add(-time.Hour) {
+			candles = append(candles, fmt.Sprintf(`{"time_open":%q,"close":%d}`, open.Format(time.RFC3339), open.Hour()))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(candles, ","))
+	}))
+	t.Cleanup(server.Close)
+	return dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+}
+
+func TestOHLCV(t *testing.T) {
+	var requests atomic.Int32
+	client := ohlcvServer(t, &requests, 0)
+	sink := &sliceSink{}
+	var progress []Progress
+
+	n, err := OHLCV(context.Background(), client, "ethereum", "0xpool", base, base.Add(5*time.Hour), "1h", sink,
+		WithChunkSize(2), WithProgress(func(p Progress) { progress = append(progress, p) }))
+	if err != nil {
+		t.Fatalf("OHLCV returned error: %v", err)
+	}
+	if n != 5 || len(sink.rows) != 5 {
+		t.Fatalf("OHLCV wrote %d candles (%d in sink), want 5", n, len(sink.rows))
+	}
+	for i, c := range sink.rows {
+		if want := base.Add(time.Duration(i) * time.Hour).Format(time.RFC3339); c.TimeOpen != want {
+			t.Errorf("Candle %d opens at %s, want %s", i, c.TimeOpen, want)
+		}
+	}
+	if requests.Load() != 3 || sink.flushes != 3 || len(progress) != 3 {
+		t.Errorf("Got %d requests, %d flushes and %d progress reports, want 3 chunks", requests.Load(), sink.flushes, len(progress))
+	}
+	if last := progress[len(progress)-1]; last.Total != 5 || !last.ChunkEnd.Equal(base.Add(5*time.Hour)) {
+		t.Errorf("Unexpected final progress %+v", last)
+	}
+}
+
+func TestOHLCV_Resume(t *testing.T) {
+	store := watch.NewMemoryStore()
+	ctx := context.Background()
+	to := base.Add(6 * time.Hour)
+
+	// The second chunk fails after the first is checkpointed
+	var failing atomic.Int32
+	sink := &sliceSink{}
+	_, err := OHLCV(ctx, ohlcvServer(t, &failing, 1), "ethereum", "0xPOOL", base, to, "1h", sink,
+		WithChunkSize(3), WithCheckpoints(store))
+	var apiErr *dexpaprika.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected the API error, got %v", err)
+	}
+	key := "backfill:ohlcv:ethereum:0xpool:1h:2024-01-01T00:00:00Z"
+	if checkpoint, _, _ := store.Get(ctx, key); checkpoint != base.Add(3*time.Hour).Format(time.RFC3339) {
+		t.Fatalf("Checkpoint %q, want the end of the first chunk", checkpoint)
+	}
+
+	var requests atomic.Int32
+	n, err := OHLCV(ctx, ohlcvServer(t, &requests, 0), "ethereum", "0xPOOL", base, to, "1h", sink,
+		WithChunkSize(3), WithCheckpoints(store))
+	if err != nil {
+		t.Fatalf("OHLCV returned error: %v", err)
+	}
+	if n != 3 || requests.Load() != 1 || len(sink.rows) != 6 {
+		t.Errorf("Resumed backfill wrote %d candles in %d requests (%d total), want 3 in 1 (6 total)", n, requests.Load(), len(sink.rows))
+	}
+	if sink.rows[3].TimeOpen != base.Add(3*time.Hour).Format(time.RFC3339) {
+		t.Errorf("Resumed at %s", sink.rows[3].TimeOpen)
+	}
+}
+
+func TestOHLCV_ResumeWiderRange(t *testing.T) {
+	store := watch.NewMemoryStore()
+	ctx := context.Background()
+
+	// A completed backfill of hours 3 to 6 must not make a later one of
+	// hours 0 to 9 skip hours 0 to 3
+	var requests atomic.Int32
+	client := ohlcvServer(t, &requests, 0)
+	if _, err := OHLCV(ctx, client, "ethereum", "0xpool", base.Add(3*time.Hour), base.Add(6*time.Hour), "1h", &sliceSink{},
+		WithChunkSize(3), WithCheckpoints(store)); err != nil {
+		t.Fatalf("OHLCV returned error: %v", err)
+	}
+
+	sink := &sliceSink{}
+	n, err := OHLCV(ctx, client, "ethereum", "0xpool", base, base.Add(9*time.Hour), "1h", sink,
+		WithChunkSize(3), WithCheckpoints(store))
+	if err != nil {
+		t.Fatalf("OHLCV returned error: %v", err)
+	}
+	if n != 9 || len(sink.rows) != 9 || sink.rows[0].TimeOpen != base.Format(time.RFC3339) {
+		t.Errorf("Wider backfill wrote %d candles starting at %v, want 9 from the start of its range", n, sink.rows)
+	}
+
+	// Extending the end, as with time.Now(), resumes the backfill
+	n, err = OHLCV(ctx, client, "ethereum", "0xpool", base, base.Add(12*time.Hour), "1h", sink,
+		WithChunkSize(3), WithCheckpoints(store))
+	if err != nil {
+		t.Fatalf("OHLCV returned error: %v", err)
+	}
+	if n != 3 || sink.rows[9].TimeOpen != base.Add(9*time.Hour).Format(time.RFC3339) {
+		t.Errorf("Extended backfill wrote %d candles from %s, want 3 from its checkpoint", n, sink.rows[9].TimeOpen)
+	}
+
+	// A shorter backfill from the same time is already complete
+	if n, err := OHLCV(ctx, client, "ethereum", "0xpool", base, base.Add(6*time.Hour), "1h", sink,
+		WithChunkSize(3), WithCheckpoints(store)); err != nil || n != 0 {
+		t.Errorf("Completed backfill wrote %d candles (%v), want none", n, err)
+	}
+}
+
+func TestOHLCV_InvalidInterval(t *testing.T) {
+	_, err := OHLCV(context.Background(), dexpaprika.NewClient(), "ethereum", "0xpool", base, base.Add(time.Hour), "2h", &sliceSink{})
+	if !errors.Is(err, dexpaprika.ErrInvalidInterval) {
+		t.Errorf("Expected ErrInvalidInterval, got %v", err)
+	}
+}
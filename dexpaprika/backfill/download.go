@@ -0,0 +1,237 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/watch"
+)
+
+// Downloader enumerates the pools of a network or dex and fetches their
+// details and transactions into sinks, a bounded number of pools at a time.
+// With Checkpoints and Resume set, a restarted run skips the pools a
+// previous run completed, so downloads can span hours and survive restarts.
+type Downloader struct {
+	client    *dexpaprika.Client
+	networkID string
+	dexID     string
+
+	// Concurrency is the number of pools fetched at once. It defaults to 4.
+	Concurrency int
+
+	// Pools, if set, receives every pool enumerated, except those skipped
+	// by Resume.
+	Pools dexpaprika.Sink[dexpaprika.Pool]
+
+	// Details, if set, receives the details of every pool.
+	Details dexpaprika.Sink[*dexpaprika.PoolDetails]
+
+	// Transactions, if set, receives the latest transactions of every pool,
+	// up to TransactionPages pages of 100.
+	Transactions     dexpaprika.Sink[dexpaprika.Transaction]
+	TransactionPages int
+
+	// Checkpoints, if set, records each pool once its data is flushed to the
+	// sinks, under one key per pool. watch.OpenLogStore keeps them in a file
+	// at a constant cost per pool, however many pools the network has.
+	Checkpoints watch.Store
+
+	// Resume skips the pools recorded in Checkpoints by an earlier run.
+	// Without it, every pool is downloaded again.
+	Resume bool
+
+	// OnError, if set, is called for every pool that failed, possibly from
+	// several goroutines at once. Failed pools are not checkpointed, so a
+	// resumed run retries them.
+	OnError func(poolAddress string, err error)
+}
+
+// DownloadStats summarizes a Downloader run.
+type DownloadStats struct {
+	Pools   int // Pools downloaded
+	Skipped int // Pools skipped as completed by an earlier run
+	Failed  int // Pools that failed
+}
+
+// NewDownloader creates a downloader of the pools of a network, or of one
+// of its dexes if dexID is not empty.
+func NewDownloader(client *dexpaprika.Client, networkID, dexID string) *Downloader {
+	return &Downloader{client: client, networkID: networkID, dexID: dexID, Concurrency: 4, TransactionPages: 1}
+}
+
+// Run downloads every pool and returns once all are done, or ctx is done.
+// Failing pools are reported to OnError and counted in the stats; Run only
+// returns an error if enumerating the pools, writing to a sink or saving a
+// checkpoint fails, or ctx is done.
+func (d *Downloader) Run(ctx context.Context) (DownloadStats, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		stats DownloadStats
+		mu    sync.Mutex // Guards stats and the sinks
+		wg    sync.WaitGroup
+	)
+	pools := make(chan dexpaprika.Pool)
+	concurrency := max(d.Concurrency, 1)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pool := range pools {
+				if err := d.download(ctx, pool, &mu, &stats); err != nil {
+					cancel(err)
+				}
+			}
+		}()
+	}
+
+	err := d.enumerate(ctx, pools, &mu, &stats)
+	close(pools)
+	wg.Wait()
+	if cause := context.Cause(ctx); cause != nil {
+		err = cause
+	}
+	return stats, err
+}
+
+// enumerate sends the pools to download on pools, writing them to the Pools
+// sink a page at a time.
+func (d *Downloader) enumerate(ctx context.Context, pools chan<- dexpaprika.Pool, mu *sync.Mutex, stats *DownloadStats) error {
+	paginator := dexpaprika.NewPoolsPaginator(d.client, &dexpaprika.ListOptions{Limit: 100, OrderBy: "volume_usd", Sort: "desc"})
+	if d.dexID != "" {
+		paginator.ForDex(d.networkID, d.dexID)
+	} else {
+		paginator.ForNetwork(d.networkID)
+	}
+
+	for paginator.HasNextPage() {
+		if err := paginator.GetNextPage(ctx); err != nil {
+			return fmt.Errorf("list pools: %w", err)
+		}
+		var todo []dexpaprika.Pool
+		for _, pool := range paginator.GetCurrentPage() {
+			if d.Resume {
+				done, err := d.completed(ctx, pool.ID)
+				if err != nil {
+					return err
+				}
+				if done {
+					mu.Lock()
+					stats.Skipped++
+					mu.Unlock()
+					continue
+				}
+			}
+			todo = append(todo, pool)
+		}
+		if d.Pools != nil {
+			mu.Lock()
+			err := writeFlush(ctx, d.Pools, todo)
+			mu.Unlock()
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, pool := range todo {
+			select {
+			case pools <- pool:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// download fetches and writes the data of a pool. It returns an error only
+// for failures that end the run.
+func (d *Downloader) download(ctx context.Context, pool dexpaprika.Pool, mu *sync.Mutex, stats *DownloadStats) error {
+	details, txs, err := d.fetch(ctx, pool.ID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		mu.Lock()
+		stats.Failed++
+		mu.Unlock()
+		if d.OnError != nil {
+			d.OnError(pool.ID, err)
+		}
+		return nil
+	}
+
+	mu.Lock()
+	err = errors.Join(
+		writeFlush(ctx, d.Details, []*dexpaprika.PoolDetails{details}),
+		writeFlush(ctx, d.Transactions, txs),
+	)
+	if err == nil {
+		stats.Pools++
+	}
+	mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if d.Checkpoints != nil {
+		if err := d.Checkpoints.Set(ctx, d.checkpointKey(pool.ID), "done"); err != nil {
+			return fmt.Errorf("save checkpoint: %w", err)
+		}
+	}
+	return nil
+}
+
+// fetch returns the details and transactions of a pool, as far as they
+// are wanted.
+func (d *Downloader) fetch(ctx context.Context, poolAddress string) (*dexpaprika.PoolDetails, []dexpaprika.Transaction, error) {
+	var details *dexpaprika.PoolDetails
+	if d.Details != nil {
+		var err error
+		if details, err = d.client.Pools.GetDetails(ctx, d.networkID, poolAddress, nil); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var txs []dexpaprika.Transaction
+	if d.Transactions != nil {
+		paginator := dexpaprika.NewTransactionsPaginator(d.client, d.networkID, poolAddress, 100)
+		for page := 0; page < max(d.TransactionPages, 1) && paginator.HasNextPage(); page++ {
+			if err := paginator.GetNextPage(ctx); err != nil {
+				return nil, nil, err
+			}
+			txs = append(txs, paginator.GetCurrentPage()...)
+		}
+	}
+	return details, txs, nil
+}
+
+func (d *Downloader) completed(ctx context.Context, poolAddress string) (bool, error) {
+	if d.Checkpoints == nil {
+		return false, nil
+	}
+	_, ok, err := d.Checkpoints.Get(ctx, d.checkpointKey(poolAddress))
+	if err != nil {
+		return false, fmt.Errorf("load checkpoint: %w", err)
+	}
+	return ok, nil
+}
+
+func (d *Downloader) checkpointKey(poolAddress string) string {
+	return fmt.Sprintf("backfill:pool:%s:%s", d.networkID, dexpaprika.NormalizeAddress(d.networkID, poolAddress))
+}
+
+// writeFlush writes rows to sink, if set, and flushes it.
+func writeFlush[T any](ctx context.Context, sink dexpaprika.Sink[T], rows []T) error {
+	if sink == nil || len(rows) == 0 {
+		return nil
+	}
+	if err := sink.Write(ctx, rows); err != nil {
+		return err
+	}
+	return sink.Flush(ctx)
+}
@@ -0,0 +1,113 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/watch"
+)
+
+// memSink is a dexpaprika.Sink collecting rows in memory.
+type memSink[T any] struct {
+	mu   sync.Mutex
+	rows []T
+}
+
+func (s *memSink[T]) Write(ctx context.Context, rows []T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rows = append(s.rows, rows...)
+	return nil
+}
+
+func (s *memSink[T]) Flush(ctx context.Context) error { return nil }
+func (s *memSink[T]) Close() error                    { return nil }
+
+func TestDownloader(t *testing.T) {
+	var failB atomic.Bool
+	failB.Store(true)
+	var detailRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/networks/ethereum/dexes/uniswap_v3/pools":
+			fmt.Fprint(w, `{"pools":[{"id":"0xa"},{"id":"0xb"},{"id":"0xc"}],"page_info":{"page":0,"total_pages":1}}`)
+		case "/networks/ethereum/pools/0xa", "/networks/ethereum/pools/0xb", "/networks/ethereum/pools/0xc":
+			detailRequests.Add(1)
+			if r.URL.Path == "/networks/ethereum/pools/0xb" && failB.Load() {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintf(w, `{"id":%q}`, r.URL.Path[len("/networks/ethereum/pools/"):])
+		default:
+			fmt.Fprint(w, `{"transactions":[{"id":"0xt"}],"page_info":{"page":0,"total_pages":1}}`)
+		}
+	}))
+	defer server.Close()
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+	store := watch.NewMemoryStore()
+	ctx := context.Background()
+
+	var pools *memSink[dexpaprika.Pool]
+	newDownloader := func() (*Downloader, *memSink[*dexpaprika.PoolDetails], *memSink[dexpaprika.Transaction]) {
+		details, txs := &memSink[*dexpaprika.PoolDetails]{}, &memSink[dexpaprika.Transaction]{}
+		pools = &memSink[dexpaprika.Pool]{}
+		d := NewDownloader(client, "ethereum", "uniswap_v3")
+		d.Pools, d.Details, d.Transactions = pools, details, txs
+		d.Checkpoints, d.Resume = store, true
+		return d, details, txs
+	}
+
+	d, details, txs := newDownloader()
+	var failed []string
+	d.OnError = func(pool string, err error) { failed = append(failed, pool) }
+	stats, err := d.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if stats != (DownloadStats{Pools: 2, Failed: 1}) || len(failed) != 1 || failed[0] != "0xb" {
+		t.Fatalf("Unexpected stats %+v, failed pools %v", stats, failed)
+	}
+	if len(pools.rows) != 3 || len(details.rows) != 2 || len(txs.rows) != 2 {
+		t.Errorf("Got %d pools, %d details and %d transactions, want 3, 2 and 2", len(pools.rows), len(details.rows), len(txs.rows))
+	}
+
+	// The resumed run only fetches the failed pool
+	failB.Store(false)
+	detailRequests.Store(0)
+	d, details, _ = newDownloader()
+	stats, err = d.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if stats != (DownloadStats{Pools: 1, Skipped: 2}) || detailRequests.Load() != 1 || details.rows[0].ID != "0xb" {
+		t.Errorf("Resumed run got stats %+v with %d detail requests", stats, detailRequests.Load())
+	}
+	if len(pools.rows) != 1 || pools.rows[0].ID != "0xb" {
+		t.Errorf("Resumed run wrote pools %v, want only the retried 0xb", pools.rows)
+	}
+
+	// Without Resume every pool is fetched again
+	d, details, _ = newDownloader()
+	d.Resume = false
+	if stats, err = d.Run(ctx); err != nil || stats.Pools != 3 || len(details.rows) != 3 {
+		t.Errorf("Run without Resume got stats %+v, error %v", stats, err)
+	}
+}
+
+func TestDownloader_ListError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	if _, err := NewDownloader(client, "ethereum", "").Run(context.Background()); err == nil {
+		t.Fatal("Expected the listing error")
+	}
+}
@@ -3,6 +3,7 @@ package dexpaprika
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
@@ -19,6 +20,10 @@ type Cache interface {
 type InMemoryCache struct {
 	items map[string]*cacheItem
 	mu    sync.RWMutex
+	clock Clock
+
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 type cacheItem struct {
@@ -30,6 +35,8 @@ type cacheItem struct {
 func NewInMemoryCache() *InMemoryCache {
 	cache := &InMemoryCache{
 		items: make(map[string]*cacheItem),
+		clock: SystemClock,
+		done:  make(chan struct{}),
 	}
 
 	// Start a cleanup routine
@@ -49,7 +56,7 @@ func (c *InMemoryCache) Get(key string) (interface{}, bool) {
 	}
 
 	// Check if the item has expired
-	if time.Now().After(item.expiresAt) {
+	if c.clock.Now().After(item.expiresAt) {
 		return nil, false
 	}
 
@@ -63,7 +70,7 @@ func (c *InMemoryCache) Set(key string, value interface{}, ttl time.Duration) {
 
 	c.items[key] = &cacheItem{
 		value:     value,
-		expiresAt: time.Now().Add(ttl),
+		expiresAt: c.clock.Now().Add(ttl),
 	}
 }
 
@@ -83,16 +90,38 @@ func (c *InMemoryCache) Clear() {
 	c.items = make(map[string]*cacheItem)
 }
 
+// Len returns the number of unexpired items in the cache
+func (c *InMemoryCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := c.clock.Now()
+	n := 0
+	for _, item := range c.items {
+		if !now.After(item.expiresAt) {
+			n++
+		}
+	}
+	return n
+}
+
 // cleanup periodically removes expired items from the cache
 func (c *InMemoryCache) cleanup() {
 	ticker := time.NewTicker(5 * time.Minute)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.done:
+			return
+		}
+
 		c.mu.Lock()
 
+		now := c.clock.Now()
 		for key, item := range c.items {
-			if time.Now().After(item.expiresAt) {
+			if now.After(item.expiresAt) {
 				delete(c.items, key)
 			}
 		}
@@ -101,7 +130,24 @@ func (c *InMemoryCache) cleanup() {
 	}
 }
 
-// CachedClient wraps a Client with caching functionality
+// SetClock sets the clock deciding when items expire, such as a fake clock
+// in tests.
+func (c *InMemoryCache) SetClock(clock Clock) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clock = clock
+}
+
+// Close stops the cache's cleanup routine. The cache remains usable, but
+// expired items are no longer removed in the background.
+func (c *InMemoryCache) Close() error {
+	c.closeOnce.Do(func() { close(c.done) })
+	return nil
+}
+
+// CachedClient wraps a Client with caching functionality. It is safe for
+// concurrent use if its Cache is, as InMemoryCache is.
 type CachedClient struct {
 	client *Client
 	cache  Cache
@@ -160,7 +206,7 @@ func (c *CachedClient) GetDexes(ctx context.Context, networkID string, page, lim
 	}
 
 	// If not in cache or wrong type, fetch from API
-	dexes, err := c.client.Networks.ListDexes(ctx, networkID, page, limit)
+	dexes, err := c.client.Dexes.List(ctx, networkID, page, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -174,16 +220,17 @@ func (c *CachedClient) GetDexes(ctx context.Context, networkID string, page, lim
 // GetPools retrieves pools with caching
 func (c *CachedClient) GetPools(ctx context.Context, opts *ListOptions) (*PoolsResponse, error) {
 	var optsPage, optsLimit int
-	var optsSort, optsOrderBy string
+	var optsSort, optsOrderBy, optsFields string
 
 	if opts != nil {
 		optsPage = opts.Page
 		optsLimit = opts.Limit
 		optsSort = opts.Sort
 		optsOrderBy = opts.OrderBy
+		optsFields = strings.Join(opts.Fields, ",")
 	}
 
-	cacheKey := fmt.Sprintf("pools:%d:%d:%s:%s", optsPage, optsLimit, optsSort, optsOrderBy)
+	cacheKey := fmt.Sprintf("pools:%d:%d:%s:%s:%s", optsPage, optsLimit, optsSort, optsOrderBy, optsFields)
 
 	// Try to get from cache first
 	if cachedValue, found := c.cache.Get(cacheKey); found {
@@ -207,16 +254,17 @@ func (c *CachedClient) GetPools(ctx context.Context, opts *ListOptions) (*PoolsR
 // GetNetworkPools retrieves network pools with caching
 func (c *CachedClient) GetNetworkPools(ctx context.Context, networkID string, opts *ListOptions) (*PoolsResponse, error) {
 	var optsPage, optsLimit int
-	var optsSort, optsOrderBy string
+	var optsSort, optsOrderBy, optsFields string
 
 	if opts != nil {
 		optsPage = opts.Page
 		optsLimit = opts.Limit
 		optsSort = opts.Sort
 		optsOrderBy = opts.OrderBy
+		optsFields = strings.Join(opts.Fields, ",")
 	}
 
-	cacheKey := fmt.Sprintf("network_pools:%s:%d:%d:%s:%s", networkID, optsPage, optsLimit, optsSort, optsOrderBy)
+	cacheKey := fmt.Sprintf("network_pools:%s:%d:%d:%s:%s:%s", networkID, optsPage, optsLimit, optsSort, optsOrderBy, optsFields)
 
 	// Try to get from cache first
 	if cachedValue, found := c.cache.Get(cacheKey); found {
@@ -238,8 +286,12 @@ func (c *CachedClient) GetNetworkPools(ctx context.Context, networkID string, op
 }
 
 // GetPoolDetails retrieves pool details with caching
-func (c *CachedClient) GetPoolDetails(ctx context.Context, networkID, poolAddress string, inversed bool) (*PoolDetails, error) {
-	cacheKey := fmt.Sprintf("pool_details:%s:%s:%t", networkID, poolAddress, inversed)
+func (c *CachedClient) GetPoolDetails(ctx context.Context, networkID, poolAddress string, opts *PoolDetailsOptions) (*PoolDetails, error) {
+	var o PoolDetailsOptions
+	if opts != nil {
+		o = *opts
+	}
+	cacheKey := fmt.Sprintf("pool_details:%s:%s:%t:%s", networkID, poolAddress, o.Inversed, o.QuoteToken)
 
 	// Try to get from cache first
 	if cachedValue, found := c.cache.Get(cacheKey); found {
@@ -249,7 +301,7 @@ func (c *CachedClient) GetPoolDetails(ctx context.Context, networkID, poolAddres
 	}
 
 	// If not in cache or wrong type, fetch from API
-	details, err := c.client.Pools.GetDetails(ctx, networkID, poolAddress, inversed)
+	details, err := c.client.Pools.GetDetails(ctx, networkID, poolAddress, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -286,16 +338,17 @@ func (c *CachedClient) GetTokenDetails(ctx context.Context, networkID, tokenAddr
 // GetTokenPools retrieves token pools with caching
 func (c *CachedClient) GetTokenPools(ctx context.Context, networkID, tokenAddress string, opts *ListOptions, additionalTokenAddress string) (*PoolsResponse, error) {
 	var optsPage, optsLimit int
-	var optsSort, optsOrderBy string
+	var optsSort, optsOrderBy, optsFields string
 
 	if opts != nil {
 		optsPage = opts.Page
 		optsLimit = opts.Limit
 		optsSort = opts.Sort
 		optsOrderBy = opts.OrderBy
+		optsFields = strings.Join(opts.Fields, ",")
 	}
 
-	cacheKey := fmt.Sprintf("token_pools:%s:%s:%d:%d:%s:%s:%s", networkID, tokenAddress, optsPage, optsLimit, optsSort, optsOrderBy, additionalTokenAddress)
+	cacheKey := fmt.Sprintf("token_pools:%s:%s:%d:%d:%s:%s:%s:%s", networkID, tokenAddress, optsPage, optsLimit, optsSort, optsOrderBy, optsFields, additionalTokenAddress)
 
 	// Try to get from cache first
 	if cachedValue, found := c.cache.Get(cacheKey); found {
@@ -338,3 +391,26 @@ func (c *CachedClient) GetStats(ctx context.Context) (*Stats, error) {
 
 	return stats, nil
 }
+
+// GetDexMarketShare retrieves a network's DEX market share report with caching
+func (c *CachedClient) GetDexMarketShare(ctx context.Context, networkID string) (*MarketShareReport, error) {
+	cacheKey := fmt.Sprintf("dex_market_share:%s", networkID)
+
+	// Try to get from cache first
+	if cachedValue, found := c.cache.Get(cacheKey); found {
+		if report, ok := cachedValue.(*MarketShareReport); ok {
+			return report, nil
+		}
+	}
+
+	// If not in cache or wrong type, build the report
+	report, err := c.client.Dexes.MarketShare(ctx, networkID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store in cache
+	c.cache.Set(cacheKey, report, c.ttl)
+
+	return report, nil
+}
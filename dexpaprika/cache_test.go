@@ -53,6 +53,29 @@ func TestInMemoryCache(t *testing.T) {
 	}
 }
 
+func TestInMemoryCache_Close(t *testing.T) {
+	cache := NewInMemoryCache()
+	cache.Set("key", "value", time.Minute)
+	if err := cache.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	cache.Close()
+
+	if v, ok := cache.Get("key"); !ok || v != "value" {
+		t.Errorf("Expected the cache to remain usable after Close, got %v, %v", v, ok)
+	}
+}
+
+func TestInMemoryCache_Len(t *testing.T) {
+	cache := NewInMemoryCache()
+	defer cache.Close()
+	cache.Set("live", 1, time.Minute)
+	cache.Set("expired", 2, -time.Second)
+	if n := cache.Len(); n != 1 {
+		t.Errorf("Len() = %d, want 1", n)
+	}
+}
+
 func TestCachedClient(t *testing.T) {
 	// Create a standard client with test settings
 	client := NewClient(
@@ -193,14 +216,14 @@ func TestCachedClient_GetPoolDetails(t *testing.T) {
 	networkID := "ethereum"
 
 	// First call should hit the API
-	pool1, err := cachedClient.GetPoolDetails(ctx, networkID, poolID, false)
+	pool1, err := cachedClient.GetPoolDetails(ctx, networkID, poolID, nil)
 	if err != nil {
 		t.Fatalf("GetPoolDetails() first call error = %v", err)
 	}
 
 	// Second call should hit the cache
 	startTime := time.Now()
-	pool2, err := cachedClient.GetPoolDetails(ctx, networkID, poolID, false)
+	pool2, err := cachedClient.GetPoolDetails(ctx, networkID, poolID, nil)
 	duration := time.Since(startTime)
 	if err != nil {
 		t.Fatalf("GetPoolDetails() second call error = %v", err)
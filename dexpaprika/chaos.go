@@ -0,0 +1,110 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrChaosDrop is the error of requests dropped by a ChaosProfile. It is
+// returned wrapped in a *url.Error, as transport failures are, so it is
+// retried like one.
+var ErrChaosDrop = errors.New("request dropped by chaos profile")
+
+// ChaosProfile describes degradation injected into every HTTP attempt of a
+// client, to verify in staging that timeouts and retries cope with a slow,
+// lossy network. See WithChaos.
+type ChaosProfile struct {
+	// Latency delays every attempt.
+	Latency time.Duration
+
+	// Jitter adds a random delay of up to Jitter to the latency.
+	Jitter time.Duration
+
+	// DropRate is the probability, from 0 to 1, that an attempt fails with
+	// ErrChaosDrop instead of being sent.
+	DropRate float64
+
+	// Seed seeds the random jitter and drops; zero seeds them from the time.
+	Seed int64
+}
+
+// Chaos profiles for common staging setups.
+var (
+	// ChaosMild is a healthy but distant API.
+	ChaosMild = ChaosProfile{Latency: 50 * time.Millisecond, Jitter: 100 * time.Millisecond, DropRate: 0.001}
+
+	// ChaosDegraded is an API under load, with slow responses and
+	// occasional dropped connections.
+	ChaosDegraded = ChaosProfile{Latency: 200 * time.Millisecond, Jitter: time.Second, DropRate: 0.02}
+)
+
+// WithChaos injects the latency and drops of profile into every HTTP
+// attempt of the client. It is meant for staging builds, never production:
+//
+//	if os.Getenv("ENV") == "staging" {
+//		opts = append(opts, dexpaprika.WithChaos(dexpaprika.ChaosDegraded))
+//	}
+//
+// Delays wait on the client's Clock and end early, with the context's
+// error, when the request's context is done. Dropped attempts are retried
+// like network errors and recorded in the client's metrics.
+func WithChaos(profile ChaosProfile) ClientOption {
+	return func(c *Client) {
+		seed := profile.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		c.chaos = &chaos{profile: profile, rand: rand.New(rand.NewSource(seed))}
+	}
+}
+
+// chaos injects a ChaosProfile. It is safe for concurrent use.
+type chaos struct {
+	profile ChaosProfile
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// draw returns the delay of an attempt and whether to drop it.
+func (ch *chaos) draw() (time.Duration, bool) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	delay := ch.profile.Latency
+	if ch.profile.Jitter > 0 {
+		delay += time.Duration(ch.rand.Int63n(int64(ch.profile.Jitter)))
+	}
+	return delay, ch.rand.Float64() < ch.profile.DropRate
+}
+
+// chaosDo sends an HTTP attempt, through the chaos profile if set.
+func (c *Client) chaosDo(req *http.Request) (*http.Response, error) {
+	if c.chaos == nil {
+		return c.client.Do(req)
+	}
+	delay, drop := c.chaos.draw()
+	if delay > 0 {
+		if err := sleepCtx(req.Context(), c.clock, delay); err != nil {
+			return nil, &url.Error{Op: req.Method, URL: req.URL.String(), Err: err}
+		}
+	}
+	if drop {
+		return nil, &url.Error{Op: req.Method, URL: req.URL.String(), Err: ErrChaosDrop}
+	}
+	return c.client.Do(req)
+}
+
+// sleepCtx waits for d on clock, or until ctx is done.
+func sleepCtx(ctx context.Context, clock Clock, d time.Duration) error {
+	select {
+	case <-clock.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,99 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newChaosServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte(`{"chains": 1}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func TestWithChaos_Latency(t *testing.T) {
+	server, _ := newChaosServer(t)
+	client := NewClient(WithBaseURL(server.URL), WithChaos(ChaosProfile{Latency: 30 * time.Millisecond, Jitter: 20 * time.Millisecond, Seed: 1}))
+
+	start := time.Now()
+	if _, err := client.Utils.GetStats(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("GetStats() took %v, want at least the 30ms latency", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if _, err := client.Utils.GetStats(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetStats() with a shorter timeout = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestWithChaos_Drops(t *testing.T) {
+	server, hits := newChaosServer(t)
+	metrics := NewInMemoryMetrics()
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(2, time.Millisecond, time.Millisecond),
+		WithMetrics(metrics),
+		WithChaos(ChaosProfile{DropRate: 1}),
+	)
+
+	_, err := client.Utils.GetStats(context.Background())
+	if !errors.Is(err, ErrChaosDrop) {
+		t.Fatalf("GetStats() = %v, want ErrChaosDrop", err)
+	}
+	if hits.Load() != 0 {
+		t.Errorf("Dropped attempts reached the server %d times", hits.Load())
+	}
+	if got := metrics.Value(MetricRequests, map[string]string{"status": "error"}); got != 3 {
+		t.Errorf("Recorded %v failed attempts, want 3", got)
+	}
+}
+
+func TestWithChaos_Retried(t *testing.T) {
+	server, hits := newChaosServer(t)
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(10, time.Millisecond, time.Millisecond),
+		WithChaos(ChaosProfile{DropRate: 0.5, Seed: 1}),
+	)
+
+	for range 20 {
+		if _, err := client.Utils.GetStats(context.Background()); err != nil {
+			t.Fatalf("GetStats() = %v, want drops to be retried", err)
+		}
+	}
+	if hits.Load() != 20 {
+		t.Errorf("The server got %d requests, want 20", hits.Load())
+	}
+}
+
+func TestChaos_Draw(t *testing.T) {
+	client := NewClient(WithChaos(ChaosProfile{Latency: time.Second, Jitter: time.Second, DropRate: 0.25}))
+
+	drops := 0
+	for range 10000 {
+		delay, drop := client.chaos.draw()
+		if delay < time.Second || delay >= 2*time.Second {
+			t.Fatalf("draw() delay = %v, want within the latency and jitter", delay)
+		}
+		if drop {
+			drops++
+		}
+	}
+	if drops < 2200 || drops > 2800 {
+		t.Errorf("Dropped %d of 10000 attempts, want about 2500", drops)
+	}
+}
@@ -9,8 +9,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -25,33 +29,70 @@ const (
 	DefaultRetryWaitMin = 1 * time.Second
 	// DefaultRetryWaitMax is the maximum amount of time to wait between retries
 	DefaultRetryWaitMax = 5 * time.Second
+	// APIVersionHeader is the header used to request a specific API version
+	APIVersionHeader = "X-API-Version"
 )
 
-// Client represents a DexPaprika API client
+// Client represents a DexPaprika API client. A Client is safe for
+// concurrent use by multiple goroutines, including SetBaseURL and
+// SetUserAgent; share one rather than creating one per request.
 type Client struct {
 	// HTTP client used to communicate with the API
 	client *http.Client
 
+	// Guards baseURL and userAgent, which SetBaseURL and SetUserAgent may
+	// change while requests are made. The other fields are set by the
+	// options and not changed afterwards.
+	mu sync.RWMutex
+
 	// Base URL for API requests
 	baseURL *url.URL
 
 	// User agent for client
 	userAgent string
 
+	// API version requested via WithAPIVersion, empty for the current unversioned API
+	apiVersion string
+
 	// Retry configuration
 	maxRetries   int
 	retryWaitMin time.Duration
 	retryWaitMax time.Duration
+	backoff      Backoff // Overrides the exponential backoff from retryWaitMin to retryWaitMax, if set
 
 	// Rate limiting
-	rateLimiter *time.Ticker
+	rateLimiter RateLimiter
+	queue       *Queue
+
+	clock Clock
+
+	metrics Metrics
+
+	// Logs requests, if set by WithDebug
+	debug *log.Logger
+
+	// Injects latency and drops into HTTP attempts, if set by WithChaos
+	chaos *chaos
+
+	// Records HTTP attempts, if set by WithHAR
+	har *HARRecorder
+
+	// Fills in token metadata the API lacks, if set
+	metadata *metadataResolver
+
+	// Closed by Close
+	closed    chan struct{}
+	closeOnce sync.Once
 
 	// Services used for communicating with the API
-	Networks *NetworksService
-	Pools    *PoolsService
-	Tokens   *TokensService
-	Search   *SearchService
-	Utils    *UtilsService
+	Networks     *NetworksService
+	Dexes        *DexesService
+	Pools        *PoolsService
+	Tokens       *TokensService
+	Search       *SearchService
+	Prices       *PricesService
+	Transactions *TransactionsService
+	Utils        *UtilsService
 }
 
 // ClientOption is a function that configures a Client
@@ -83,6 +124,15 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
+// WithAPIVersion selects an API version. Requests carry the version in the
+// X-API-Version header and their paths are prefixed with it (e.g. "/v2/pools"),
+// allowing migrations between the current and next-generation APIs.
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) {
+		c.apiVersion = strings.Trim(version, "/ ")
+	}
+}
+
 // WithRetryConfig sets the retry configuration for the API client
 func WithRetryConfig(maxRetries int, retryWaitMin, retryWaitMax time.Duration) ClientOption {
 	return func(c *Client) {
@@ -97,11 +147,21 @@ func WithRateLimit(requestsPerSecond float64) ClientOption {
 	return func(c *Client) {
 		if requestsPerSecond > 0 {
 			interval := time.Duration(1e9 / requestsPerSecond)
-			c.rateLimiter = time.NewTicker(interval)
+			c.stopRateLimiter()
+			c.rateLimiter = newTickerLimiter(interval)
 		}
 	}
 }
 
+// WithQueue makes every request wait for a slot in q, at the priority set
+// on its context with ContextWithPriority. Clients sharing a queue share its
+// rate.
+func WithQueue(q *Queue) ClientOption {
+	return func(c *Client) {
+		c.queue = q
+	}
+}
+
 // NewClient returns a new DexPaprika API client with the given options
 func NewClient(options ...ClientOption) *Client {
 	baseURL, _ := url.Parse(DefaultBaseURL)
@@ -111,10 +171,12 @@ func NewClient(options ...ClientOption) *Client {
 			Timeout: DefaultTimeout,
 		},
 		baseURL:      baseURL,
-		userAgent:    "DexPaprika-SDK-Go",
+		userAgent:    sdkName,
 		maxRetries:   DefaultMaxRetries,
 		retryWaitMin: DefaultRetryWaitMin,
 		retryWaitMax: DefaultRetryWaitMax,
+		clock:        SystemClock,
+		closed:       make(chan struct{}),
 	}
 
 	// Apply options
@@ -124,11 +186,18 @@ func NewClient(options ...ClientOption) *Client {
 
 	// Initialize services
 	c.Networks = &NetworksService{client: c}
+	c.Dexes = &DexesService{client: c}
 	c.Pools = &PoolsService{client: c}
 	c.Tokens = &TokensService{client: c}
 	c.Search = &SearchService{client: c}
+	c.Prices = &PricesService{client: c}
+	c.Transactions = &TransactionsService{client: c}
 	c.Utils = &UtilsService{client: c}
 
+	if c.debug != nil {
+		c.debug.Printf("dexpaprika: %s/%s, base URL %s", sdkName, Version, c.baseURL)
+	}
+
 	return c
 }
 
@@ -139,23 +208,49 @@ func (c *Client) SetBaseURL(urlStr string) error {
 		return err
 	}
 
+	c.mu.Lock()
 	c.baseURL = baseURL
+	c.mu.Unlock()
 	return nil
 }
 
 // SetUserAgent sets a custom user agent string for the client
 func (c *Client) SetUserAgent(userAgent string) {
+	c.mu.Lock()
 	c.userAgent = userAgent
+	c.mu.Unlock()
+}
+
+// APIVersion returns the API version selected with WithAPIVersion, or an
+// empty string for the current unversioned API.
+func (c *Client) APIVersion() string {
+	return c.apiVersion
+}
+
+// IsVersioned reports whether the client targets an explicitly versioned API,
+// letting services switch between current and next-generation behavior.
+func (c *Client) IsVersioned() bool {
+	return c.apiVersion != ""
 }
 
 // NewRequest creates an API request
 func (c *Client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
+	if c.apiVersion != "" && strings.HasPrefix(path, "/") {
+		prefix := "/" + c.apiVersion
+		if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			path = prefix + path
+		}
+	}
+
 	rel, err := url.Parse(path)
 	if err != nil {
 		return nil, err
 	}
 
+	c.mu.RLock()
 	u := c.baseURL.ResolveReference(rel)
+	userAgent := userAgentHeader(c.userAgent)
+	c.mu.RUnlock()
 
 	var buf io.ReadWriter
 	if body != nil {
@@ -175,7 +270,10 @@ func (c *Client) NewRequest(method, path string, body interface{}) (*http.Reques
 		req.Header.Set("Content-Type", "application/json")
 	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("User-Agent", userAgent)
+	if c.apiVersion != "" {
+		req.Header.Set(APIVersionHeader, c.apiVersion)
+	}
 
 	return req, nil
 }
@@ -191,27 +289,78 @@ var (
 	ErrServiceUnavailable  = errors.New("service unavailable")
 	ErrTimeout             = errors.New("request timeout")
 	ErrRetryableError      = errors.New("retryable error")
+	ErrClientClosed        = errors.New("client closed")
+)
+
+// Sentinel errors for specific API failures. They are matched against the
+// error code in the response body, or the message when no code is provided,
+// so programs can branch with errors.Is.
+var (
+	ErrInvalidInterval  = errors.New("invalid interval")
+	ErrInvalidParameter = errors.New("invalid parameter")
+	ErrNetworkNotFound  = errors.New("network not found")
+	ErrDexNotFound      = errors.New("dex not found")
+	ErrPoolNotFound     = errors.New("pool not found")
+	ErrTokenNotFound    = errors.New("token not found")
 )
 
+// errorCodeSentinels maps normalized API error codes to sentinel errors.
+var errorCodeSentinels = map[string]error{
+	"invalid_interval":  ErrInvalidInterval,
+	"invalid_parameter": ErrInvalidParameter,
+	"invalid_address":   ErrInvalidAddress,
+	"network_not_found": ErrNetworkNotFound,
+	"dex_not_found":     ErrDexNotFound,
+	"pool_not_found":    ErrPoolNotFound,
+	"token_not_found":   ErrTokenNotFound,
+}
+
 // APIError represents a structured API error
 type APIError struct {
 	StatusCode  int
 	Message     string
+	Code        string          // Error code from the response body, if any
+	Details     json.RawMessage // Additional error details from the response body, if any
 	RawResponse []byte
 	Err         error
 }
 
 func (e *APIError) Error() string {
+	status := fmt.Sprintf("status code: %d", e.StatusCode)
+	if e.Code != "" {
+		status += ", code: " + e.Code
+	}
 	if e.Message != "" {
-		return fmt.Sprintf("%s: %s (status code: %d)", e.Err, e.Message, e.StatusCode)
+		return fmt.Sprintf("%s: %s (%s)", e.Err, e.Message, status)
 	}
-	return fmt.Sprintf("%s (status code: %d)", e.Err, e.StatusCode)
+	return fmt.Sprintf("%s (%s)", e.Err, status)
 }
 
 func (e *APIError) Unwrap() error {
 	return e.Err
 }
 
+// Is reports whether the error code (or, lacking one, the message) matches
+// the target sentinel, e.g. errors.Is(err, ErrInvalidInterval).
+func (e *APIError) Is(target error) bool {
+	for _, s := range []string{e.Code, e.Message} {
+		if s == "" {
+			continue
+		}
+		if sentinel, ok := errorCodeSentinels[normalizeErrorCode(s)]; ok && sentinel == target {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeErrorCode converts codes and messages such as "Invalid Interval"
+// or "INVALID-INTERVAL" into the "invalid_interval" form.
+func normalizeErrorCode(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.NewReplacer(" ", "_", "-", "_", ".", "_").Replace(s)
+}
+
 // IsRetryable returns whether the error is potentially retryable
 func IsRetryable(err error) bool {
 	if err == nil {
@@ -238,45 +387,164 @@ func IsRetryable(err error) bool {
 	return false
 }
 
+// Close stops the client's rate limiter. Requests made or waiting for the
+// rate limiter afterwards fail with ErrClientClosed; requests in flight
+// complete. Close is safe to call more than once.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		c.stopRateLimiter()
+		if c.closed != nil {
+			close(c.closed)
+		}
+	})
+	return nil
+}
+
+// stopRateLimiter stops the rate limiter of WithRateLimit, if set.
+func (c *Client) stopRateLimiter() {
+	if l, ok := c.rateLimiter.(*tickerLimiter); ok {
+		l.Stop()
+	}
+}
+
+// retryDelay returns how long to wait before a retry.
+func (c *Client) retryDelay(attempt int) time.Duration {
+	if c.backoff != nil {
+		return c.backoff.Delay(attempt)
+	}
+	return ExponentialBackoff{Min: c.retryWaitMin, Max: c.retryWaitMax}.Delay(attempt)
+}
+
+// Ping checks that the API is reachable with a single request to /stats.
+// Unlike other requests it neither waits for the rate limiter nor retries,
+// so health checks answer promptly even when the client is busy.
+func (c *Client) Ping(ctx context.Context) error {
+	select {
+	case <-c.closed:
+		return ErrClientClosed
+	default:
+	}
+	req, err := c.NewRequest(http.MethodGet, "/stats", nil)
+	if err != nil {
+		return err
+	}
+	start := c.clock.Now()
+	resp, err := c.roundTrip(req.WithContext(ctx))
+	c.recordAttempt(0, start, resp, err)
+	if err != nil {
+		return &APIError{Err: fmt.Errorf("ping: %w", err)}
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return createAPIError(resp, body)
+	}
+	return nil
+}
+
+// pace waits for the client's rate limiter and queue, if configured.
+func (c *Client) pace(ctx context.Context) error {
+	select {
+	case <-c.closed:
+		return ErrClientClosed
+	default:
+	}
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if c.queue != nil {
+		return c.queue.Wait(ctx, PriorityFromContext(ctx))
+	}
+	return nil
+}
+
+// recordAttempt records an HTTP attempt in the client's metrics, if set.
+func (c *Client) recordAttempt(attempt int, start time.Time, resp *http.Response, err error) {
+	if c.metrics == nil {
+		return
+	}
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	c.metrics.Count(MetricRequests, 1, map[string]string{"status": status})
+	c.metrics.Observe(MetricRequestDuration, c.clock.Now().Sub(start).Seconds(), nil)
+	if attempt > 0 {
+		c.metrics.Count(MetricRetries, 1, nil)
+	}
+}
+
+// roundTrip sends an HTTP attempt, recording it if a HAR recorder is set.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	if c.har != nil {
+		return c.har.record(req, c.clock, c.chaosDo)
+	}
+	return c.chaosDo(req)
+}
+
+// logAttempt logs an HTTP attempt in debug mode.
+func (c *Client) logAttempt(req *http.Request, attempt int, start time.Time, resp *http.Response, err error) {
+	if c.debug == nil {
+		return
+	}
+	status := "error: " + fmt.Sprint(err)
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	c.debug.Printf("dexpaprika: %s %s (attempt %d): %s in %v", req.Method, req.URL, attempt+1, status, c.clock.Now().Sub(start))
+}
+
+// attemptRequest returns the request to send for attempt i of req. It is a
+// shallow copy of req with ctx, sharing its URL and headers, as transports
+// must not modify requests; retries get a fresh body from req.GetBody, as
+// the previous attempt consumed it.
+func attemptRequest(ctx context.Context, req *http.Request, i int) (*http.Request, error) {
+	if i == 0 && req.Context() == ctx {
+		return req, nil
+	}
+	attempt := req.WithContext(ctx)
+	if i > 0 && req.Body != nil && req.Body != http.NoBody && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		attempt.Body = body
+	}
+	return attempt, nil
+}
+
 // Do sends an API request and returns the API response
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
 	var resp *http.Response
-	var err error
 	var respBody []byte
 
 	// Apply rate limiting if configured
-	if c.rateLimiter != nil {
-		select {
-		case <-c.rateLimiter.C:
-			// Rate limit wait completed
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		}
+	if err := c.pace(ctx); err != nil {
+		return nil, err
 	}
 
 	// Retry logic
 	for i := 0; i <= c.maxRetries; i++ {
 		if i > 0 {
-			// Calculate backoff duration
-			backoff := c.retryWaitMin * time.Duration(1<<uint(i-1))
-			if backoff > c.retryWaitMax {
-				backoff = c.retryWaitMax
-			}
-
 			// Wait with backoff
-			timer := time.NewTimer(backoff)
 			select {
-			case <-timer.C:
+			case <-c.clock.After(c.retryDelay(i)):
 				// Backoff completed
 			case <-ctx.Done():
-				timer.Stop()
 				return nil, ctx.Err()
 			}
 		}
 
-		// Clone the request to ensure we can retry with a fresh request
-		reqClone := req.Clone(ctx)
-		resp, err = c.client.Do(reqClone)
+		attempt, err := attemptRequest(ctx, req, i)
+		if err != nil {
+			return nil, err
+		}
+		start := c.clock.Now()
+		resp, err = c.roundTrip(attempt)
+		c.recordAttempt(i, start, resp, err)
+		c.logAttempt(attempt, i, start, resp, err)
 
 		// Check for context cancellation
 		select {
@@ -346,18 +614,25 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*htt
 	return resp, nil
 }
 
+// Do sends an API request and decodes the response body into a new value of
+// type T. It is intended for endpoints that the SDK does not wrap yet:
+//
+//	req, _ := client.NewRequest(http.MethodGet, "/networks/ethereum/new-endpoint", nil)
+//	result, _, err := dexpaprika.Do[MyResult](ctx, client, req)
+func Do[T any](ctx context.Context, client *Client, req *http.Request) (T, *http.Response, error) {
+	var v T
+	resp, err := client.Do(ctx, req, &v)
+	if err != nil {
+		var zero T
+		return zero, resp, err
+	}
+	return v, resp, nil
+}
+
 // createAPIError creates an appropriate APIError based on the HTTP status code
 func createAPIError(resp *http.Response, body []byte) *APIError {
-	var errMsg string
 	var err error
-
-	// Try to extract an error message from body
-	var errorResp struct {
-		Error string `json:"error"`
-	}
-	if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Error != "" {
-		errMsg = errorResp.Error
-	}
+	errMsg, code, details := parseErrorBody(body)
 
 	// Map status codes to appropriate errors
 	switch resp.StatusCode {
@@ -386,7 +661,70 @@ func createAPIError(resp *http.Response, body []byte) *APIError {
 	return &APIError{
 		StatusCode:  resp.StatusCode,
 		Message:     errMsg,
+		Code:        code,
+		Details:     details,
 		RawResponse: body,
 		Err:         err,
 	}
 }
+
+// parseErrorBody extracts the message, code and details from an error body.
+// It accepts both {"error": "msg", "code": ..., "details": ...} and the
+// nested {"error": {"message": "msg", "code": ..., "details": ...}} forms.
+func parseErrorBody(body []byte) (message, code string, details json.RawMessage) {
+	var errorResp struct {
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+		Code    json.RawMessage `json:"code"`
+		Details json.RawMessage `json:"details"`
+	}
+	if err := json.Unmarshal(body, &errorResp); err != nil {
+		return "", "", nil
+	}
+
+	message = errorResp.Message
+	code = rawString(errorResp.Code)
+	details = errorResp.Details
+
+	if len(errorResp.Error) > 0 {
+		var msg string
+		var nested struct {
+			Message string          `json:"message"`
+			Code    json.RawMessage `json:"code"`
+			Details json.RawMessage `json:"details"`
+		}
+		switch {
+		case json.Unmarshal(errorResp.Error, &msg) == nil:
+			if msg != "" {
+				message = msg
+			}
+		case json.Unmarshal(errorResp.Error, &nested) == nil:
+			if nested.Message != "" {
+				message = nested.Message
+			}
+			if c := rawString(nested.Code); c != "" {
+				code = c
+			}
+			if len(nested.Details) > 0 {
+				details = nested.Details
+			}
+		}
+	}
+
+	if string(details) == "null" {
+		details = nil
+	}
+	return message, code, details
+}
+
+// rawString returns a JSON string or number as a Go string.
+func rawString(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
@@ -156,7 +156,7 @@ func TestClient_NewRequest(t *testing.T) {
 	}
 
 	// Check headers
-	expectedUserAgent := "DexPaprika-SDK-Go"
+	expectedUserAgent := "DexPaprika-SDK-Go/" + Version
 	if got, want := req.Header.Get("User-Agent"), expectedUserAgent; got != want {
 		t.Errorf("NewRequest() User-Agent = %v, want %v", got, want)
 	}
@@ -171,6 +171,48 @@ func TestClient_NewRequest(t *testing.T) {
 	}
 }
 
+func TestClient_WithAPIVersion(t *testing.T) {
+	client := NewClient()
+	if client.IsVersioned() || client.APIVersion() != "" {
+		t.Errorf("Default client should be unversioned, got %q", client.APIVersion())
+	}
+
+	req, err := client.NewRequest(http.MethodGet, "/pools", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if req.URL.Path != "/pools" || req.Header.Get(APIVersionHeader) != "" {
+		t.Errorf("Unversioned request got path %q and version header %q", req.URL.Path, req.Header.Get(APIVersionHeader))
+	}
+
+	client = NewClient(WithAPIVersion("/v2/"))
+	if !client.IsVersioned() || client.APIVersion() != "v2" {
+		t.Errorf("APIVersion() = %q, want v2", client.APIVersion())
+	}
+
+	tests := []struct {
+		path     string
+		wantPath string
+	}{
+		{"/pools", "/v2/pools"},
+		{"/v2/pools", "/v2/pools"},
+		{"/v2", "/v2"},
+		{"/v20/pools", "/v2/v20/pools"},
+	}
+	for _, tc := range tests {
+		req, err := client.NewRequest(http.MethodGet, tc.path, nil)
+		if err != nil {
+			t.Fatalf("NewRequest(%s) returned error: %v", tc.path, err)
+		}
+		if req.URL.Path != tc.wantPath {
+			t.Errorf("NewRequest(%s) path = %q, want %q", tc.path, req.URL.Path, tc.wantPath)
+		}
+		if got := req.Header.Get(APIVersionHeader); got != "v2" {
+			t.Errorf("NewRequest(%s) %s = %q, want v2", tc.path, APIVersionHeader, got)
+		}
+	}
+}
+
 func TestAPIError_Error(t *testing.T) {
 	apiErr := &APIError{
 		StatusCode:  404,
@@ -185,6 +227,86 @@ func TestAPIError_Error(t *testing.T) {
 	}
 }
 
+func TestAPIError_CodesAndSentinels(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		response    string
+		wantCode    string
+		wantMessage string
+		wantDetails string
+		wantErr     error
+		wantStatus  error
+	}{
+		{
+			name:        "flat code and details",
+			statusCode:  http.StatusBadRequest,
+			response:    `{"error": "interval must be one of 1m, 5m, 1h", "code": "INVALID_INTERVAL", "details": {"allowed": ["1m", "5m", "1h"]}}`,
+			wantCode:    "INVALID_INTERVAL",
+			wantMessage: "interval must be one of 1m, 5m, 1h",
+			wantDetails: `{"allowed": ["1m", "5m", "1h"]}`,
+			wantErr:     ErrInvalidInterval,
+			wantStatus:  ErrBadRequest,
+		},
+		{
+			name:        "nested error object with numeric code",
+			statusCode:  http.StatusNotFound,
+			response:    `{"error": {"message": "no such pool", "code": 4041, "details": "0xabc"}}`,
+			wantCode:    "4041",
+			wantMessage: "no such pool",
+			wantDetails: `"0xabc"`,
+			wantStatus:  ErrNotFound,
+		},
+		{
+			name:        "message only matches sentinel",
+			statusCode:  http.StatusNotFound,
+			response:    `{"error": "Pool not found"}`,
+			wantMessage: "Pool not found",
+			wantErr:     ErrPoolNotFound,
+			wantStatus:  ErrNotFound,
+		},
+		{
+			name:        "message field",
+			statusCode:  http.StatusNotFound,
+			response:    `{"message": "Network not found"}`,
+			wantMessage: "Network not found",
+			wantErr:     ErrNetworkNotFound,
+			wantStatus:  ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tc.statusCode}
+			apiErr := createAPIError(resp, []byte(tc.response))
+
+			if apiErr.Code != tc.wantCode {
+				t.Errorf("Code = %q, want %q", apiErr.Code, tc.wantCode)
+			}
+			if apiErr.Message != tc.wantMessage {
+				t.Errorf("Message = %q, want %q", apiErr.Message, tc.wantMessage)
+			}
+			if string(apiErr.Details) != tc.wantDetails {
+				t.Errorf("Details = %s, want %s", apiErr.Details, tc.wantDetails)
+			}
+			if tc.wantErr != nil && !errors.Is(apiErr, tc.wantErr) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", apiErr, tc.wantErr)
+			}
+			if !errors.Is(apiErr, tc.wantStatus) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", apiErr, tc.wantStatus)
+			}
+			if errors.Is(apiErr, ErrTokenNotFound) {
+				t.Errorf("errors.Is(%v, ErrTokenNotFound) = true, want false", apiErr)
+			}
+		})
+	}
+
+	withCode := &APIError{StatusCode: 400, Message: "bad interval", Code: "invalid_interval", Err: ErrBadRequest}
+	if got, want := withCode.Error(), "bad request: bad interval (status code: 400, code: invalid_interval)"; got != want {
+		t.Errorf("APIError.Error() = %q, want %q", got, want)
+	}
+}
+
 // TestClient_NewRequestWithBody tests creating a request with a body
 func TestClient_NewRequestWithBody(t *testing.T) {
 	client := NewClient()
@@ -363,6 +485,70 @@ func TestClient_Do_HTTPError(t *testing.T) {
 	}
 }
 
+func TestDo_Generic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/custom":
+			fmt.Fprintln(w, `{"name": "custom", "count": 3}`)
+		case "/list":
+			fmt.Fprintln(w, `[1, 2, 3]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, `{"error": "Not Found"}`)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, 1*time.Millisecond, 1*time.Millisecond),
+	)
+
+	type custom struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	req, err := client.NewRequest(http.MethodGet, "/custom", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	result, resp, err := Do[custom](context.Background(), client, req)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	defer resp.Body.Close()
+	if result.Name != "custom" || result.Count != 3 {
+		t.Errorf("Do returned %+v, want {custom 3}", result)
+	}
+
+	req, err = client.NewRequest(http.MethodGet, "/list", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	list, resp, err := Do[[]int](context.Background(), client, req)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	defer resp.Body.Close()
+	if len(list) != 3 {
+		t.Errorf("Do returned %v, want 3 items", list)
+	}
+
+	req, err = client.NewRequest(http.MethodGet, "/missing", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	_, resp, err = Do[custom](context.Background(), client, req)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Do returned error %v, want ErrNotFound", err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Error("Do should return the response alongside API errors")
+	}
+}
+
 // TestClient_Do_NetworkError tests the Do method with network errors
 func TestClient_Do_NetworkError(t *testing.T) {
 	// Create a client with a non-existent server URL
@@ -464,6 +650,67 @@ func TestClient_Do_RetryOnServerError(t *testing.T) {
 	}
 }
 
+// TestClient_Do_RetryResendsBody tests that retries send the request body again
+func TestClient_Do_RetryResendsBody(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, `{"success": true}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(1, time.Millisecond, time.Millisecond))
+	req, err := client.NewRequest(http.MethodPost, "/test", map[string]string{"query": "usdc"})
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do() returned error: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || !strings.Contains(bodies[1], "usdc") {
+		t.Errorf("Server received bodies %q, want the same body twice", bodies)
+	}
+}
+
+func TestAttemptRequest(t *testing.T) {
+	ctx := context.Background()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/pools", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	first, err := attemptRequest(ctx, req, 0)
+	if err != nil || first != req {
+		t.Errorf("attemptRequest() of the first attempt = %p, %v; want req itself", first, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for i := range 2 {
+		attempt, err := attemptRequest(ctx, req, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if attempt == req || attempt.Context() != ctx {
+			t.Errorf("attemptRequest(%d) with another context did not copy the request with it", i)
+		}
+		attempt.Header.Set("X-Attempt", "1")
+		if req.Header.Get("X-Attempt") == "" {
+			t.Errorf("attemptRequest(%d) cloned the headers, want them shared", i)
+		}
+		req.Header.Del("X-Attempt")
+	}
+	if req.Context() == ctx {
+		t.Error("attemptRequest() modified req")
+	}
+}
+
 // TestClient_Do_RateLimit tests that the client respects rate limiting
 func TestClient_Do_RateLimit(t *testing.T) {
 	// Create a test server
@@ -568,6 +815,64 @@ func TestClient_Do_ContextCancellation(t *testing.T) {
 	}
 }
 
+// TestClient_Close tests that requests fail once the client is closed,
+// including ones waiting for the rate limiter
+func TestClient_Close(t *testing.T) {
+	client := NewClient(WithBaseURL("http://127.0.0.1:0"), WithRateLimit(0.1))
+
+	done := make(chan error)
+	go func() {
+		_, err := client.Networks.List(context.Background())
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if err := <-done; !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Expected the waiting request to fail with ErrClientClosed, got %v", err)
+	}
+	if _, err := client.Networks.List(context.Background()); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Expected ErrClientClosed after Close, got %v", err)
+	}
+	client.Close()
+}
+
+func TestClient_Ping(t *testing.T) {
+	var calls int
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/stats" {
+			t.Errorf("Expected /stats, got %s", r.URL.Path)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// A slow rate limit must not delay the ping
+	client := NewClient(WithBaseURL(server.URL), WithRateLimit(0.01))
+	defer client.Close()
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping returned error: %v", err)
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := client.Ping(context.Background()); !errors.Is(err, ErrServiceUnavailable) {
+		t.Errorf("Expected ErrServiceUnavailable, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 requests without retries, got %d", calls)
+	}
+
+	client.Close()
+	if err := client.Ping(context.Background()); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Expected ErrClientClosed after Close, got %v", err)
+	}
+}
+
 // Test IsRetryable function
 func TestIsRetryable(t *testing.T) {
 	tests := []struct {
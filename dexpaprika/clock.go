@@ -0,0 +1,113 @@
+package dexpaprika
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the time and waits. The client uses it for retry backoff and
+// InMemoryCache for expiry, so tests can substitute a fake clock that is
+// advanced by hand instead of sleeping.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the Clock of the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// RateLimiter paces requests. Wait blocks until a request may be sent or
+// ctx is done.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// tickerLimiter is the RateLimiter of WithRateLimit, allowing one request
+// per tick.
+type tickerLimiter struct {
+	ticker *time.Ticker
+	done   chan struct{}
+}
+
+func newTickerLimiter(interval time.Duration) *tickerLimiter {
+	return &tickerLimiter{ticker: time.NewTicker(interval), done: make(chan struct{})}
+}
+
+func (l *tickerLimiter) Wait(ctx context.Context) error {
+	select {
+	case <-l.ticker.C:
+		return nil
+	case <-l.done:
+		return ErrClientClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stop releases the ticker; waiting and later requests fail with
+// ErrClientClosed.
+func (l *tickerLimiter) Stop() {
+	l.ticker.Stop()
+	close(l.done)
+}
+
+// Backoff decides how long to wait before a retry. Attempt is 1 for the
+// first retry.
+type Backoff interface {
+	Delay(attempt int) time.Duration
+}
+
+// ExponentialBackoff doubles the delay from Min on every retry, up to Max.
+// It is the default, configured with WithRetryConfig.
+type ExponentialBackoff struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// Delay returns Min * 2^(attempt-1), capped at Max.
+func (b ExponentialBackoff) Delay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := b.Min
+	for i := 1; i < attempt && d < b.Max; i++ {
+		d *= 2
+	}
+	if d > b.Max {
+		d = b.Max
+	}
+	return d
+}
+
+// WithClock sets the clock used to wait between retries and to time
+// requests for metrics.
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		if clock != nil {
+			c.clock = clock
+		}
+	}
+}
+
+// WithRateLimiter paces requests with a custom limiter, replacing the one
+// of WithRateLimit.
+func WithRateLimiter(limiter RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.stopRateLimiter()
+		c.rateLimiter = limiter
+	}
+}
+
+// WithBackoff sets how long to wait between retries, replacing the
+// exponential backoff of WithRetryConfig. The number of retries is still
+// set by WithRetryConfig.
+func WithBackoff(backoff Backoff) ClientOption {
+	return func(c *Client) {
+		c.backoff = backoff
+	}
+}
@@ -0,0 +1,168 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff{Min: time.Second, Max: 5 * time.Second}
+	want := []time.Duration{time.Second, time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for attempt, w := range want {
+		if got := b.Delay(attempt); got != w {
+			t.Errorf("Delay(%d) = %v, want %v", attempt, got, w)
+		}
+	}
+	if got := b.Delay(1000); got != 5*time.Second {
+		t.Errorf("Delay(1000) = %v, want the maximum", got)
+	}
+}
+
+// stepClock is a Clock whose waits complete at once, advancing its time.
+type stepClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *stepClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *stepClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+type countingLimiter struct {
+	waits atomic.Int32
+	err   error
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits.Add(1)
+	return l.err
+}
+
+func TestClient_RetriesWaitOnClock(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"chains":1,"factories":2,"pools":3,"tokens":4}`))
+	}))
+	defer server.Close()
+
+	clock := &stepClock{now: time.Unix(0, 0)}
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(3, time.Hour, 3*time.Hour), WithClock(clock))
+
+	start := time.Now()
+	if _, err := client.Utils.GetStats(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("retries took %v of real time, want no sleeping", elapsed)
+	}
+	want := []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour}
+	if len(clock.waits) != len(want) {
+		t.Fatalf("waits = %v, want %v", clock.waits, want)
+	}
+	for i := range want {
+		if clock.waits[i] != want[i] {
+			t.Errorf("wait %d = %v, want %v", i, clock.waits[i], want[i])
+		}
+	}
+}
+
+type delays []time.Duration
+
+func (d delays) Delay(attempt int) time.Duration { return d[attempt-1] }
+
+func TestWithBackoff(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	clock := &stepClock{now: time.Unix(0, 0)}
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(2, time.Hour, time.Hour),
+		WithBackoff(delays{time.Millisecond, time.Minute}),
+		WithClock(clock),
+	)
+	if _, err := client.Utils.GetStats(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(clock.waits) != 2 || clock.waits[0] != time.Millisecond || clock.waits[1] != time.Minute {
+		t.Errorf("waits = %v, want the custom backoff's", clock.waits)
+	}
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	limiter := &countingLimiter{}
+	client := NewClient(WithBaseURL(server.URL), WithRateLimit(0.001), WithRateLimiter(limiter))
+	for i := 0; i < 3; i++ {
+		if _, err := client.Utils.GetStats(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := limiter.waits.Load(); got != 3 {
+		t.Errorf("limiter waited %d times, want 3", got)
+	}
+
+	limiter.err = ErrRateLimit
+	if _, err := client.Utils.GetStats(context.Background()); !errors.Is(err, ErrRateLimit) {
+		t.Errorf("GetStats() error = %v, want the limiter's", err)
+	}
+
+	client.Close()
+	if _, err := client.Utils.GetStats(context.Background()); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("GetStats() after Close error = %v, want ErrClientClosed", err)
+	}
+}
+
+func TestInMemoryCache_SetClock(t *testing.T) {
+	cache := NewInMemoryCache()
+	defer cache.Close()
+	clock := &stepClock{now: time.Unix(0, 0)}
+	cache.SetClock(clock)
+
+	cache.Set("key", 1, time.Minute)
+	<-clock.After(59 * time.Second)
+	if _, ok := cache.Get("key"); !ok {
+		t.Error("item expired before its TTL")
+	}
+	<-clock.After(2 * time.Second)
+	if _, ok := cache.Get("key"); ok {
+		t.Error("item did not expire after its TTL")
+	}
+	if n := cache.Len(); n != 0 {
+		t.Errorf("Len() = %d, want 0", n)
+	}
+}
@@ -0,0 +1,217 @@
+// Package coinpaprika links DexPaprika tokens to CoinPaprika coin IDs, so
+// on-chain DEX data can be joined with CoinPaprika's market data and coin
+// metadata.
+//
+// A Mapper resolves a token by network and contract address. Well-known
+// tokens are answered from a bundled mapping; others are looked up with the
+// CoinPaprika contracts endpoints and remembered, including tokens
+// CoinPaprika does not list:
+//
+//	mapper := coinpaprika.NewMapper()
+//	id, err := mapper.CoinID(ctx, dexpaprika.NetworkEthereum, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+//	// id == "usdc-usd-coin"
+package coinpaprika
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// DefaultBaseURL is the CoinPaprika API the Mapper queries.
+const DefaultBaseURL = "https://api.coinpaprika.com/v1"
+
+var (
+	// ErrNotMapped is returned for tokens CoinPaprika lists no coin for.
+	ErrNotMapped = errors.New("coinpaprika: token not mapped")
+	// ErrUnsupportedNetwork is returned for networks without an entry in
+	// Platforms.
+	ErrUnsupportedNetwork = errors.New("coinpaprika: unsupported network")
+)
+
+// Platforms maps DexPaprika network IDs to the CoinPaprika platform IDs
+// under which their token contracts are listed. Add entries to map tokens on
+// other networks.
+var Platforms = map[string]string{
+	dexpaprika.NetworkEthereum:  "eth-ethereum",
+	dexpaprika.NetworkBsc:       "bnb-binance-coin",
+	dexpaprika.NetworkPolygon:   "matic-polygon",
+	dexpaprika.NetworkSolana:    "sol-solana",
+	dexpaprika.NetworkAvalanche: "avax-avalanche",
+	dexpaprika.NetworkTron:      "trx-tron",
+}
+
+// Bundled maps well-known tokens to their CoinPaprika coin IDs. It is
+// consulted before the API, so these tokens resolve without a request.
+var Bundled = map[dexpaprika.EntityKey]string{
+	dexpaprika.NewEntityKey(dexpaprika.NetworkEthereum, "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"): "weth-weth",
+	dexpaprika.NewEntityKey(dexpaprika.NetworkEthereum, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"): "usdc-usd-coin",
+	dexpaprika.NewEntityKey(dexpaprika.NetworkEthereum, "0xdac17f958d2ee523a2206206994597c13d831ec7"): "usdt-tether",
+	dexpaprika.NewEntityKey(dexpaprika.NetworkEthereum, "0x6b175474e89094c44da98b954eedeac495271d0f"): "dai-dai",
+	dexpaprika.NewEntityKey(dexpaprika.NetworkEthereum, "0x2260fac5e5542a773aa44fbcd4ed8eec3fd83f8c"): "wbtc-wrapped-bitcoin",
+	dexpaprika.NewEntityKey(dexpaprika.NetworkSolana, "So11111111111111111111111111111111111111112"):  "sol-solana",
+	dexpaprika.NewEntityKey(dexpaprika.NetworkSolana, "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"): "usdc-usd-coin",
+	dexpaprika.NewEntityKey(dexpaprika.NetworkSolana, "Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB"): "usdt-tether",
+}
+
+// Contract is a token contract listed on a CoinPaprika platform.
+type Contract struct {
+	Address string `json:"address"`
+	CoinID  string `json:"id"`
+	Type    string `json:"type"`
+	Active  bool   `json:"active"`
+}
+
+// Mapper resolves DexPaprika tokens to CoinPaprika coin IDs and remembers
+// the results. It is safe for concurrent use.
+type Mapper struct {
+	// BaseURL is the CoinPaprika API to query. Defaults to DefaultBaseURL.
+	BaseURL string
+
+	// HTTPClient sends the requests. It defaults to a client with a
+	// 10 second timeout.
+	HTTPClient *http.Client
+
+	mu     sync.RWMutex
+	ids    map[dexpaprika.EntityKey]string // Empty for tokens known to be unmapped
+	loaded map[string]bool                 // Networks whose contract list was loaded
+}
+
+// NewMapper creates a mapper seeded with the Bundled mapping.
+func NewMapper() *Mapper {
+	m := &Mapper{
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		ids:        make(map[dexpaprika.EntityKey]string, len(Bundled)),
+		loaded:     make(map[string]bool),
+	}
+	for k, id := range Bundled {
+		m.ids[k] = id
+	}
+	return m
+}
+
+// Add records a mapping, overriding bundled or resolved ones.
+func (m *Mapper) Add(networkID, address, coinID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ids[dexpaprika.NewEntityKey(networkID, address)] = coinID
+}
+
+// CoinID returns the CoinPaprika coin ID of a token. Tokens not seen before
+// are looked up with one request, unless the network's contracts were
+// loaded with Load. It returns ErrNotMapped for tokens CoinPaprika does not
+// list.
+func (m *Mapper) CoinID(ctx context.Context, networkID, address string) (string, error) {
+	key := dexpaprika.NewEntityKey(networkID, address)
+	m.mu.RLock()
+	id, known := m.ids[key]
+	loaded := m.loaded[key.Chain]
+	m.mu.RUnlock()
+	if known || loaded {
+		if id == "" {
+			return "", fmt.Errorf("%w: %s", ErrNotMapped, key)
+		}
+		return id, nil
+	}
+
+	platform, ok := Platforms[key.Chain]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedNetwork, networkID)
+	}
+	var ticker struct {
+		ID string `json:"id"`
+	}
+	// The endpoint redirects to the coin's ticker, which carries its ID
+	err := m.get(ctx, "/contracts/"+url.PathEscape(platform)+"/"+url.PathEscape(address), &ticker)
+	if err != nil && !errors.Is(err, ErrNotMapped) {
+		return "", err
+	}
+
+	m.mu.Lock()
+	m.ids[key] = ticker.ID
+	m.mu.Unlock()
+	if ticker.ID == "" {
+		return "", fmt.Errorf("%w: %s", ErrNotMapped, key)
+	}
+	return ticker.ID, nil
+}
+
+// Token returns the coin ID of a DexPaprika token.
+func (m *Mapper) Token(ctx context.Context, token dexpaprika.Token) (string, error) {
+	return m.CoinID(ctx, token.Chain, token.ID)
+}
+
+// Load fetches every contract CoinPaprika lists on a network with one
+// request, after which CoinID answers for the network without further
+// requests. It returns the number of contracts loaded.
+func (m *Mapper) Load(ctx context.Context, networkID string) (int, error) {
+	platform, ok := Platforms[networkID]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedNetwork, networkID)
+	}
+	var contracts []Contract
+	if err := m.get(ctx, "/contracts/"+url.PathEscape(platform), &contracts); err != nil {
+		return 0, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range contracts {
+		key := dexpaprika.NewEntityKey(networkID, c.Address)
+		// Bundled and added mappings take precedence
+		if m.ids[key] == "" {
+			m.ids[key] = c.CoinID
+		}
+	}
+	m.loaded[networkID] = true
+	return len(contracts), nil
+}
+
+// Tokens returns the tokens known to map to a coin, e.g. USDC's contracts on
+// every loaded network, ordered by network and address.
+func (m *Mapper) Tokens(coinID string) []dexpaprika.EntityKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var keys []dexpaprika.EntityKey
+	for k, id := range m.ids {
+		if id == coinID {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
+
+// get fetches path and decodes the JSON response into v. A 404 is reported
+// as ErrNotMapped.
+func (m *Mapper) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.BaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := m.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotMapped
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("coinpaprika: %s returned %d: %s", path, resp.StatusCode, body)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
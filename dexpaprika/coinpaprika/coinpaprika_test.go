@@ -0,0 +1,132 @@
+package coinpaprika
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func newTestMapper(t *testing.T, handler http.HandlerFunc) *Mapper {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	m := NewMapper()
+	m.BaseURL = server.URL
+	return m
+}
+
+func TestMapper_CoinID(t *testing.T) {
+	var calls atomic.Int32
+	m := newTestMapper(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch r.URL.Path {
+		case "/contracts/eth-ethereum/0x514910771af9ca656af840dff83e8264ecf986ca":
+			// The API redirects contract lookups to the coin's ticker
+			http.Redirect(w, r, "/tickers/link-chainlink", http.StatusFound)
+		case "/tickers/link-chainlink":
+			w.Write([]byte(`{"id":"link-chainlink","name":"Chainlink","symbol":"LINK"}`))
+		default:
+			http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	id, err := m.CoinID(ctx, dexpaprika.NetworkEthereum, "0x514910771af9ca656af840dff83e8264ecf986ca")
+	if err != nil || id != "link-chainlink" {
+		t.Fatalf("CoinID = %q, %v", id, err)
+	}
+	// Remembered across address casing
+	if id, err := m.CoinID(ctx, dexpaprika.NetworkEthereum, "0x514910771AF9Ca656af840dff83E8264EcF986CA"); err != nil || id != "link-chainlink" {
+		t.Errorf("Second CoinID = %q, %v", id, err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("API calls = %d, want 2 (lookup and redirect)", n)
+	}
+
+	// Bundled tokens need no request
+	if id, err := m.CoinID(ctx, dexpaprika.NetworkEthereum, "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"); err != nil || id != "usdc-usd-coin" {
+		t.Errorf("Bundled CoinID = %q, %v", id, err)
+	}
+
+	// Unlisted tokens are remembered as unmapped
+	for range 2 {
+		if _, err := m.CoinID(ctx, dexpaprika.NetworkEthereum, "0xdeadbeef"); !errors.Is(err, ErrNotMapped) {
+			t.Errorf("Expected ErrNotMapped, got %v", err)
+		}
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("API calls = %d, want 3", n)
+	}
+
+	if _, err := m.CoinID(ctx, dexpaprika.NetworkSui, "0x2::sui::SUI"); !errors.Is(err, ErrUnsupportedNetwork) {
+		t.Errorf("Expected ErrUnsupportedNetwork, got %v", err)
+	}
+}
+
+func TestMapper_Load(t *testing.T) {
+	var calls atomic.Int32
+	m := newTestMapper(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != "/contracts/sol-solana" {
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+		w.Write([]byte(`[
+			{"address":"JUPyiwrYJFskUPiHa7hkeR8VUtAeFoSYbKedZNsDvCN","id":"jup-jupiter","type":"SPL","active":true},
+			{"address":"EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v","id":"usdc-wrong","type":"SPL","active":true}
+		]`))
+	})
+	ctx := context.Background()
+
+	n, err := m.Load(ctx, dexpaprika.NetworkSolana)
+	if err != nil || n != 2 {
+		t.Fatalf("Load = %d, %v", n, err)
+	}
+	if id, _ := m.CoinID(ctx, dexpaprika.NetworkSolana, "JUPyiwrYJFskUPiHa7hkeR8VUtAeFoSYbKedZNsDvCN"); id != "jup-jupiter" {
+		t.Errorf("CoinID = %q, want jup-jupiter", id)
+	}
+	if id, _ := m.CoinID(ctx, dexpaprika.NetworkSolana, "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"); id != "usdc-usd-coin" {
+		t.Errorf("Bundled mapping overridden by %q", id)
+	}
+	// Tokens missing from a loaded network are unmapped without a request
+	if _, err := m.CoinID(ctx, dexpaprika.NetworkSolana, "Unknown1111111111111111111111111111111111111"); !errors.Is(err, ErrNotMapped) {
+		t.Errorf("Expected ErrNotMapped, got %v", err)
+	}
+	if c := calls.Load(); c != 1 {
+		t.Errorf("API calls = %d, want 1", c)
+	}
+
+	keys := m.Tokens("usdc-usd-coin")
+	var got []string
+	for _, k := range keys {
+		got = append(got, k.String())
+	}
+	if strings.Join(got, ",") != "ethereum:0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48,solana:EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v" {
+		t.Errorf("Tokens = %v", got)
+	}
+}
+
+func TestMapper_Errors(t *testing.T) {
+	m := newTestMapper(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	})
+	_, err := m.CoinID(context.Background(), dexpaprika.NetworkEthereum, "0x1111111111111111111111111111111111111111")
+	if err == nil || errors.Is(err, ErrNotMapped) || !strings.Contains(err.Error(), "429") {
+		t.Errorf("Expected a 429 error, got %v", err)
+	}
+	// Failures are not remembered as unmapped
+	_, err = m.CoinID(context.Background(), dexpaprika.NetworkEthereum, "0x1111111111111111111111111111111111111111")
+	if errors.Is(err, ErrNotMapped) {
+		t.Errorf("Failed lookup remembered as unmapped")
+	}
+
+	m.Add(dexpaprika.NetworkEthereum, "0x1111111111111111111111111111111111111111", "custom-coin")
+	if id, err := m.CoinID(context.Background(), dexpaprika.NetworkEthereum, "0x1111111111111111111111111111111111111111"); id != "custom-coin" || err != nil {
+		t.Errorf("CoinID after Add = %q, %v", id, err)
+	}
+}
@@ -370,6 +370,22 @@ func testGetPoolDetails(t *testing.T, ctx context.Context, client *Client) {
 	if !ok || id != poolAddress {
 		t.Errorf("Expected pool ID %s, got %v", poolAddress, id)
 	}
+
+	// Decode through the typed model to check liquidity and reserve fields
+	details, err := client.Pools.GetDetails(ctx, "ethereum", poolAddress, nil)
+	if err != nil {
+		t.Fatalf("Failed to get typed pool details: %v", err)
+	}
+	if liquidity, ok := details.GetLiquidityUSD(); !ok || liquidity != 45000000.5 {
+		t.Errorf("Expected liquidity 45000000.5, got %v (present: %t)", liquidity, ok)
+	}
+	if len(details.TokenReserves) != 2 {
+		t.Fatalf("Expected 2 token reserves, got %d", len(details.TokenReserves))
+	}
+	reserve, ok := details.ReserveOf("WETH")
+	if !ok || reserve.Amount != 7500 {
+		t.Errorf("Expected WETH reserve of 7500, got %+v (found: %t)", reserve, ok)
+	}
 }
 
 func testGetPoolOHLCV(t *testing.T, ctx context.Context, client *Client) {
@@ -570,12 +586,17 @@ func createMockPoolDetails(id string, chain string) map[string]interface{} {
 		"last_price_usd": 0.06,
 		"fee":            0.003,
 		"price_time":     "2023-06-15T10:00:00Z",
+		"liquidity_usd":  45000000.5,
 		"24h":            createMockTimeIntervalMetrics(1500000.0),
 		"6h":             createMockTimeIntervalMetrics(500000.0),
 		"1h":             createMockTimeIntervalMetrics(100000.0),
 		"30m":            createMockTimeIntervalMetrics(50000.0),
 		"15m":            createMockTimeIntervalMetrics(25000.0),
 		"5m":             createMockTimeIntervalMetrics(10000.0),
+		"token_reserves": []map[string]interface{}{
+			{"token_id": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "amount": 22500000.25, "amount_usd": 22500000.25},
+			{"token_id": "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", "amount": 7500.0, "amount_usd": 22500000.25},
+		},
 	}
 }
 
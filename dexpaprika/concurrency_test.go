@@ -0,0 +1,159 @@
+package dexpaprika
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Run with -race: these tests share one Client, and one CachedClient, among
+// hundreds of goroutines.
+
+const concurrentGoroutines = 200
+
+// newConcurrencyServer answers every endpoint with a small valid body.
+func newConcurrencyServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/networks":
+			w.Write([]byte(`[{"id": "ethereum", "display_name": "Ethereum"}]`))
+		case r.URL.Path == "/stats":
+			w.Write([]byte(`{"chains": 1, "factories": 2, "pools": 3, "tokens": 4}`))
+		case strings.HasSuffix(r.URL.Path, "/pools"):
+			w.Write([]byte(`{"pools": [{"id": "0xpool", "chain": "ethereum"}], "page_info": {"page": 0, "total_pages": 1}}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// runConcurrently calls fn from n goroutines and waits for them.
+func runConcurrently(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestClient_Concurrent(t *testing.T) {
+	server := newConcurrencyServer(t)
+	metrics := NewInMemoryMetrics()
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(1, time.Millisecond, time.Millisecond),
+		WithRateLimit(1e6),
+		WithMetrics(metrics),
+	)
+	defer client.Close()
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var errs []error
+	fail := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+
+	runConcurrently(concurrentGoroutines, func(i int) {
+		var err error
+		switch i % 8 {
+		case 0:
+			_, err = client.Networks.List(ctx)
+		case 1:
+			_, err = client.Networks.Refresh(ctx)
+			client.Networks.IsSupported("ethereum")
+		case 2:
+			_, err = client.Pools.ListByNetwork(ctx, "ethereum", &ListOptions{Limit: 10})
+		case 3:
+			_, err = client.Pools.GetDetails(ctx, "ethereum", "0xPool", nil)
+		case 4:
+			_, err = client.Tokens.GetDetails(ctx, "ethereum", "0xToken")
+		case 5:
+			_, err = client.Utils.GetStats(ctx)
+		case 6:
+			// Setters race with the requests of the other goroutines
+			err = client.SetBaseURL(server.URL)
+			client.SetUserAgent("concurrent-test")
+		case 7:
+			err = client.Ping(ctx)
+		}
+		if err != nil {
+			fail(err)
+		}
+	})
+
+	for _, err := range errs {
+		t.Error(err)
+	}
+	if got := metrics.Value(MetricRequests, map[string]string{"status": "200"}); got == 0 {
+		t.Error("Expected the requests to be recorded in the metrics")
+	}
+}
+
+func TestCachedClient_Concurrent(t *testing.T) {
+	server := newConcurrencyServer(t)
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+	defer client.Close()
+	cache := NewInMemoryCache()
+	defer cache.Close()
+	cached := NewCachedClient(client, cache, time.Minute)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var errs []error
+	runConcurrently(concurrentGoroutines, func(i int) {
+		var err error
+		switch i % 5 {
+		case 0:
+			_, err = cached.GetNetworks(ctx)
+		case 1:
+			_, err = cached.GetStats(ctx)
+		case 2:
+			_, err = cached.GetNetworkPools(ctx, "ethereum", &ListOptions{Limit: 10})
+		case 3:
+			_, err = cached.GetPoolDetails(ctx, "ethereum", "0xPool", nil)
+		case 4:
+			_, err = cached.GetTokenDetails(ctx, "ethereum", "0xToken")
+		}
+		if err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+	})
+
+	for _, err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestClient_ConcurrentClose(t *testing.T) {
+	server := newConcurrencyServer(t)
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0), WithRateLimit(1e6))
+	ctx := context.Background()
+
+	// Requests racing with Close either complete or fail with
+	// ErrClientClosed.
+	runConcurrently(concurrentGoroutines, func(i int) {
+		if i%50 == 0 {
+			client.Close()
+			return
+		}
+		if _, err := client.Utils.GetStats(ctx); err != nil && err != ErrClientClosed {
+			t.Errorf("GetStats() error = %v, want nil or ErrClientClosed", err)
+		}
+	})
+}
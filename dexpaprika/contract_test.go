@@ -0,0 +1,138 @@
+package dexpaprika
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/internal/openapi"
+)
+
+// TestContract checks the SDK against the embedded OpenAPI spec: every
+// request it sends must match an operation's path and parameters, every
+// operation must be called by some service method, and the spec's example
+// responses must decode into the models.
+func TestContract(t *testing.T) {
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu      sync.Mutex
+		covered = make(map[string]bool)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, err := spec.ValidateRequest(r)
+		if err != nil {
+			t.Errorf("request diverges from the spec: %v", err)
+			if route.Operation == nil {
+				http.NotFound(w, r)
+				return
+			}
+		}
+		mu.Lock()
+		covered[route.OperationID] = true
+		mu.Unlock()
+
+		example, err := spec.Example(route, http.StatusOK)
+		if err != nil {
+			t.Error(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(example)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+	ctx := context.Background()
+	const (
+		network = "ethereum"
+		pool    = "0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640"
+		token   = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
+		other   = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	)
+	listing := &ListOptions{Page: 1, Limit: 50, OrderBy: "volume_usd", Sort: "desc"}
+
+	calls := []struct {
+		name string
+		call func() (empty bool, err error)
+	}{
+		{"Networks.List", func() (bool, error) {
+			networks, err := client.Networks.List(ctx)
+			return len(networks) == 0, err
+		}},
+		{"Dexes.List", func() (bool, error) {
+			resp, err := client.Dexes.List(ctx, network, 1, 20)
+			return err == nil && len(resp.Dexes) == 0, err
+		}},
+		{"Pools.List", func() (bool, error) {
+			resp, err := client.Pools.List(ctx, listing)
+			return err == nil && len(resp.Pools) == 0, err
+		}},
+		{"Pools.ListByNetwork", func() (bool, error) {
+			resp, err := client.Pools.ListByNetwork(ctx, network, listing)
+			return err == nil && len(resp.Pools) == 0, err
+		}},
+		{"Pools.ListByDex", func() (bool, error) {
+			resp, err := client.Pools.ListByDex(ctx, network, "uniswap_v3", listing)
+			return err == nil && len(resp.Pools) == 0, err
+		}},
+		{"Pools.GetDetails", func() (bool, error) {
+			details, err := client.Pools.GetDetails(ctx, network, pool, &PoolDetailsOptions{Inversed: true})
+			return err == nil && details.ID == "", err
+		}},
+		{"Pools.GetOHLCV", func() (bool, error) {
+			records, err := client.Pools.GetOHLCV(ctx, network, pool, &OHLCVOptions{
+				Start: "2024-06-01", End: "2024-06-02", Limit: 24, Interval: OHLCVInterval1h, Inversed: true,
+			})
+			return len(records) == 0, err
+		}},
+		{"Pools.GetTransactions", func() (bool, error) {
+			resp, err := client.Pools.GetTransactions(ctx, network, pool, 0, 20, "0xcursor")
+			return err == nil && len(resp.Transactions) == 0, err
+		}},
+		{"Tokens.GetDetails", func() (bool, error) {
+			details, err := client.Tokens.GetDetails(ctx, network, token)
+			return err == nil && details.Summary == nil, err
+		}},
+		{"Tokens.GetPools", func() (bool, error) {
+			resp, err := client.Tokens.GetPools(ctx, network, token, listing, other)
+			return err == nil && len(resp.Pools) == 0, err
+		}},
+		{"Tokens.GetTransactions", func() (bool, error) {
+			resp, err := client.Tokens.GetTransactions(ctx, network, token, &TransactionsOptions{Page: 1, Limit: 20, Cursor: "0xcursor"})
+			return err == nil && len(resp.Transactions) == 0, err
+		}},
+		{"Search.Search", func() (bool, error) {
+			resp, err := client.Search.Search(ctx, "uniswap v3")
+			return err == nil && len(resp.Tokens)+len(resp.Pools)+len(resp.Dexes) == 0, err
+		}},
+		{"Utils.GetStats", func() (bool, error) {
+			stats, err := client.Utils.GetStats(ctx)
+			return err == nil && stats.Pools == 0, err
+		}},
+	}
+	for _, c := range calls {
+		empty, err := c.call()
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+		} else if empty {
+			t.Errorf("%s decoded the spec's example into an empty model", c.name)
+		}
+	}
+
+	var missing []string
+	for _, route := range spec.Routes() {
+		if !covered[route.OperationID] {
+			missing = append(missing, route.OperationID)
+		}
+	}
+	sort.Strings(missing)
+	if len(missing) > 0 {
+		t.Errorf("operations of the spec without an SDK method: %v", missing)
+	}
+}
@@ -0,0 +1,248 @@
+package dexpaprika
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Some API deployments encode numeric fields as strings ("volume_usd": "123.4").
+// The models below decode both forms: the strict decode is attempted first and
+// only when it fails on a string value are quoted numbers rewritten in place.
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings.
+func (t *Token) UnmarshalJSON(data []byte) error {
+	type alias Token
+	return unmarshalTolerant(data, (*alias)(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings.
+func (p *Pool) UnmarshalJSON(data []byte) error {
+	type alias Pool
+	return unmarshalTolerant(data, (*alias)(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings.
+func (p *PageInfo) UnmarshalJSON(data []byte) error {
+	type alias PageInfo
+	return unmarshalTolerant(data, (*alias)(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings.
+func (m *TimeIntervalMetrics) UnmarshalJSON(data []byte) error {
+	type alias TimeIntervalMetrics
+	return unmarshalTolerant(data, (*alias)(m))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings.
+func (p *PoolDetails) UnmarshalJSON(data []byte) error {
+	type alias PoolDetails
+	return unmarshalTolerant(data, (*alias)(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings.
+func (r *TokenReserve) UnmarshalJSON(data []byte) error {
+	type alias TokenReserve
+	return unmarshalTolerant(data, (*alias)(r))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings.
+func (r *OHLCVRecord) UnmarshalJSON(data []byte) error {
+	type alias OHLCVRecord
+	return unmarshalTolerant(data, (*alias)(r))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings.
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	type alias Transaction
+	return unmarshalTolerant(data, (*alias)(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings.
+func (s *TokenSummary) UnmarshalJSON(data []byte) error {
+	type alias TokenSummary
+	return unmarshalTolerant(data, (*alias)(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings.
+func (t *TokenDetails) UnmarshalJSON(data []byte) error {
+	type alias TokenDetails
+	return unmarshalTolerant(data, (*alias)(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings.
+func (d *DexInfo) UnmarshalJSON(data []byte) error {
+	type alias DexInfo
+	return unmarshalTolerant(data, (*alias)(d))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings
+// and collecting unmodeled fields into Extra.
+func (t *SearchToken) UnmarshalJSON(data []byte) error {
+	type alias SearchToken
+	if err := unmarshalTolerant(data, (*alias)(t)); err != nil {
+		return err
+	}
+	t.Extra = extraFields(data, reflect.TypeOf(*t))
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings
+// and collecting unmodeled fields into Extra.
+func (p *SearchPool) UnmarshalJSON(data []byte) error {
+	type alias SearchPool
+	if err := unmarshalTolerant(data, (*alias)(p)); err != nil {
+		return err
+	}
+	p.Extra = extraFields(data, reflect.TypeOf(*p))
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting numbers encoded as strings.
+func (s *Stats) UnmarshalJSON(data []byte) error {
+	type alias Stats
+	return unmarshalTolerant(data, (*alias)(s))
+}
+
+// unmarshalTolerant decodes data into v, a pointer to a struct. If the strict
+// decode fails because a numeric field holds a string, numeric strings are
+// unquoted (empty strings become null) and the decode is retried.
+func unmarshalTolerant(data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	if err == nil {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Value != "string" {
+		return err
+	}
+
+	fixed, ok := unquoteNumericFields(data, reflect.TypeOf(v).Elem())
+	if !ok {
+		return err
+	}
+
+	// Reset to a zero value so the retry does not merge into a partial decode
+	rv := reflect.ValueOf(v).Elem()
+	rv.Set(reflect.Zero(rv.Type()))
+
+	return json.Unmarshal(fixed, v)
+}
+
+// unquoteNumericFields rewrites string values of numeric struct fields in the
+// JSON object data. It reports false if nothing could be rewritten.
+func unquoteNumericFields(data []byte, t reflect.Type) ([]byte, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, false
+	}
+
+	known := jsonFields(t)
+	changed := false
+	for name, raw := range fields {
+		if !known[strings.ToLower(name)] {
+			continue
+		}
+		trimmed := bytes.TrimSpace(raw)
+		if len(trimmed) == 0 || trimmed[0] != '"' {
+			continue
+		}
+
+		var s string
+		if err := json.Unmarshal(trimmed, &s); err != nil {
+			return nil, false
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			fields[name] = json.RawMessage("null")
+			changed = true
+			continue
+		}
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, false
+		}
+		fields[name] = json.RawMessage(s)
+		changed = true
+	}
+
+	if !changed {
+		return nil, false
+	}
+
+	fixed, err := json.Marshal(fields)
+	if err != nil {
+		return nil, false
+	}
+	return fixed, true
+}
+
+// extraFields returns the members of the JSON object data that do not map to
+// a field of t, or nil if there are none.
+func extraFields(data []byte, t reflect.Type) map[string]json.RawMessage {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+
+	known := jsonFields(t)
+	for name := range fields {
+		if _, ok := known[strings.ToLower(name)]; ok {
+			delete(fields, name)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+var jsonFieldsCache sync.Map // map[reflect.Type]map[string]bool
+
+// jsonFields maps the lowercased JSON names of the fields of t to whether
+// the field is numeric.
+func jsonFields(t reflect.Type) map[string]bool {
+	if cached, ok := jsonFieldsCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+
+	fields := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := f.Name
+		if tag := f.Tag.Get("json"); tag != "" {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		switch ft.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			fields[strings.ToLower(name)] = true
+		default:
+			fields[strings.ToLower(name)] = false
+		}
+	}
+
+	jsonFieldsCache.Store(t, fields)
+	return fields
+}
@@ -0,0 +1,154 @@
+package dexpaprika
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// Benchmarks of decoding large responses. Sub-benchmarks are named
+// size=N/numbers=json|quoted, so runs compare with benchstat:
+//
+//	go test -run '^$' -bench Decode -benchmem -count 10 . > new.txt
+//	benchstat old.txt new.txt
+
+// benchSizes are the item counts of the decoded lists.
+var benchSizes = []int{100, 1000}
+
+// quotedNumbers matches the numeric values of indented JSON.
+var quotedNumbers = regexp.MustCompile(`: (-?[0-9][0-9.eE+-]*)(,?\n)`)
+
+// benchPayload builds a response of n items, repeating the items of a
+// fixture, with numbers as JSON numbers or as strings.
+func benchPayload(tb testing.TB, fixture, key string, n int, quoted bool) []byte {
+	tb.Helper()
+	data, err := os.ReadFile(filepath.Join("fixtures", "responses", fixture))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	var items []json.RawMessage
+	if key == "" {
+		err = json.Unmarshal(data, &items)
+	} else {
+		var resp map[string]json.RawMessage
+		if err = json.Unmarshal(data, &resp); err == nil {
+			err = json.Unmarshal(resp[key], &items)
+		}
+	}
+	if err != nil || len(items) == 0 {
+		tb.Fatalf("%s has no %q items: %v", fixture, key, err)
+	}
+
+	list := make([]json.RawMessage, n)
+	for i := range list {
+		list[i] = items[i%len(items)]
+	}
+	var payload any = list
+	if key != "" {
+		payload = map[string]any{
+			key:         list,
+			"page_info": PageInfo{Limit: n, TotalItems: n, TotalPages: 1},
+		}
+	}
+	out, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if quoted {
+		out = quotedNumbers.ReplaceAll(out, []byte(`: "$1"$2`))
+	}
+	return out
+}
+
+// benchDecode runs decode against payloads of every size and number form.
+func benchDecode(b *testing.B, fixture, key string, decode func([]byte) error) {
+	for _, n := range benchSizes {
+		for _, quoted := range []bool{false, true} {
+			numbers := "json"
+			if quoted {
+				numbers = "quoted"
+			}
+			b.Run(fmt.Sprintf("size=%d/numbers=%s", n, numbers), func(b *testing.B) {
+				data := benchPayload(b, fixture, key, n, quoted)
+				if err := decode(data); err != nil {
+					b.Fatal(err)
+				}
+				b.SetBytes(int64(len(data)))
+				b.ReportAllocs()
+				for b.Loop() {
+					if err := decode(data); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkDecodePools(b *testing.B) {
+	benchDecode(b, "network_pools.json", "pools", func(data []byte) error {
+		var resp PoolsResponse
+		return json.Unmarshal(data, &resp)
+	})
+}
+
+func BenchmarkDecodeOHLCV(b *testing.B) {
+	benchDecode(b, "pool_ohlcv.json", "", func(data []byte) error {
+		var records []OHLCVRecord
+		return json.Unmarshal(data, &records)
+	})
+}
+
+func BenchmarkDecodeTransactions(b *testing.B) {
+	benchDecode(b, "pool_transactions.json", "transactions", func(data []byte) error {
+		var resp TransactionsResponse
+		return json.Unmarshal(data, &resp)
+	})
+}
+
+// BenchmarkClientDo_Pools measures a page of pools through Client.Do, which
+// buffers the body before decoding it.
+func BenchmarkClientDo_Pools(b *testing.B) {
+	data := benchPayload(b, "network_pools.json", "pools", 1000, false)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+	ctx := context.Background()
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for b.Loop() {
+		resp, err := client.Pools.ListByNetwork(ctx, "ethereum", &ListOptions{Limit: 1000})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(resp.Pools) != 1000 {
+			b.Fatalf("decoded %d pools, want 1000", len(resp.Pools))
+		}
+	}
+}
+
+func TestBenchPayload_Quoted(t *testing.T) {
+	// The quoted payloads must exercise the string-number fallback rather
+	// than fail to decode.
+	data := benchPayload(t, "network_pools.json", "pools", 3, true)
+	if !bytes.Contains(data, []byte(`"volume_usd": "`)) {
+		t.Fatalf("numbers were not quoted:\n%.300s", data)
+	}
+	var resp PoolsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Pools) != 3 || resp.Pools[0].VolumeUSD == 0 {
+		t.Errorf("Decoded %+v", resp.Pools)
+	}
+}
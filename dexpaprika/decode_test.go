@@ -0,0 +1,97 @@
+package dexpaprika
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUnmarshalTolerant_StringNumbers(t *testing.T) {
+	body := `{
+		"id": "0xpool",
+		"volume_usd": "123.4",
+		"transactions": "42",
+		"price_usd": 1.5,
+		"fee": "",
+		"tokens": [{"id": "0xtoken", "decimals": "18", "fdv": "1000"}]
+	}`
+
+	var pool Pool
+	if err := json.Unmarshal([]byte(body), &pool); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if pool.ID != "0xpool" {
+		t.Errorf("Expected ID '0xpool', got '%s'", pool.ID)
+	}
+	if pool.VolumeUSD != 123.4 {
+		t.Errorf("Expected VolumeUSD 123.4, got %f", pool.VolumeUSD)
+	}
+	if pool.Transactions != 42 {
+		t.Errorf("Expected Transactions 42, got %d", pool.Transactions)
+	}
+	if pool.PriceUSD != 1.5 {
+		t.Errorf("Expected PriceUSD 1.5, got %f", pool.PriceUSD)
+	}
+	if pool.Fee != 0 {
+		t.Errorf("Expected empty Fee to decode as 0, got %f", pool.Fee)
+	}
+	if len(pool.Tokens) != 1 || pool.Tokens[0].Decimals != 18 {
+		t.Fatalf("Expected nested token with 18 decimals, got %+v", pool.Tokens)
+	}
+	if fdv, ok := pool.Tokens[0].GetFDV(); !ok || fdv != 1000 {
+		t.Errorf("Expected nested FDV 1000, got %f, %t", fdv, ok)
+	}
+}
+
+func TestUnmarshalTolerant_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"non-numeric string", `{"volume_usd": "lots"}`},
+		{"integer field with fraction", `{"transactions": "1.5"}`},
+		{"string field with number", `{"id": 123}`},
+		{"malformed json", `{"volume_usd": `},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var pool Pool
+			if err := json.Unmarshal([]byte(tc.body), &pool); err == nil {
+				t.Error("Expected an error but got nil")
+			}
+		})
+	}
+}
+
+func TestUnmarshalTolerant_Response(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"last_price_usd": "3000.5", "24h": {"volume_usd": "1000", "txns": "12"}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, 1*time.Millisecond, 1*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	details, err := client.Pools.GetDetails(ctx, "ethereum", "0xpool", nil)
+	if err != nil {
+		t.Fatalf("Pools.GetDetails returned error: %v", err)
+	}
+	if details.LastPriceUSD != 3000.5 {
+		t.Errorf("Expected LastPriceUSD 3000.5, got %f", details.LastPriceUSD)
+	}
+	if details.Day.VolumeUSD != 1000 || details.Day.Txns != 12 {
+		t.Errorf("Expected 24h volume 1000 and 12 txns, got %+v", details.Day)
+	}
+}
@@ -0,0 +1,102 @@
+package dexpaprika
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// DexesService handles communication with the DEX related
+// methods of the DexPaprika API.
+type DexesService struct {
+	client *Client
+}
+
+// List returns a page of the dexes available on a specific network.
+// Implements the getNetworkDexes operation from the OpenAPI spec.
+func (s *DexesService) List(ctx context.Context, networkID string, page, limit int, reqOpts ...RequestOption) (*DexesResponse, error) {
+	q := newQuery("/networks/" + networkID + "/dexes")
+	q.addInt("limit", limit)
+	q.addInt("page", page)
+
+	req, err := s.client.NewRequest(http.MethodGet, q.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DexesResponse
+	cfg := newRequestConfig(reqOpts)
+	r, err := s.client.Do(ctx, req, cfg.decodeInto(&response))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	if cfg.target != nil {
+		return nil, nil
+	}
+
+	return &response, nil
+}
+
+// Get returns a single dex on a network. The API has no single-dex endpoint,
+// so the network's dex list is paged through until the dex is found. Unknown
+// dexes return an error matching ErrDexNotFound.
+func (s *DexesService) Get(ctx context.Context, networkID, dexID string) (*Dex, error) {
+	var found *Dex
+	err := s.each(ctx, networkID, func(d Dex) bool {
+		if d.ID == dexID {
+			found = &d
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s on %s", ErrDexNotFound, dexID, networkID)
+	}
+	return found, nil
+}
+
+// ListAll returns the dexes on every supported network, following pagination
+// for each network in turn.
+func (s *DexesService) ListAll(ctx context.Context) ([]Dex, error) {
+	networks, err := s.client.Networks.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var dexes []Dex
+	for _, n := range networks {
+		err := s.each(ctx, n.ID, func(d Dex) bool {
+			dexes = append(dexes, d)
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list dexes on %s: %w", n.ID, err)
+		}
+	}
+	return dexes, nil
+}
+
+// each calls fn for every dex on a network until fn returns false. Dexes
+// without a chain in the response are attributed to networkID.
+func (s *DexesService) each(ctx context.Context, networkID string, fn func(Dex) bool) error {
+	paginator := NewDexesPaginator(s.client, networkID, 100)
+	for paginator.HasNextPage() {
+		if err := paginator.GetNextPage(ctx); err != nil {
+			return err
+		}
+		for _, d := range paginator.GetCurrentPage() {
+			if d.Chain == "" {
+				d.Chain = networkID
+			}
+			if !fn(d) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newDexesTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/networks":
+			fmt.Fprintln(w, `[{"id": "ethereum", "display_name": "Ethereum"}, {"id": "solana", "display_name": "Solana"}]`)
+		case "/networks/ethereum/dexes":
+			if r.URL.Query().Get("limit") != "100" {
+				t.Errorf("Expected limit=100, got %q", r.URL.Query().Get("limit"))
+			}
+			// Two pages on ethereum
+			if r.URL.Query().Get("page") == "1" {
+				fmt.Fprintln(w, `{"dexes": [{"dex_id": "sushiswap", "dex_name": "SushiSwap", "protocol": "uniswap_v2"}], "page_info": {"limit": 100, "page": 1, "total_items": 101, "total_pages": 2}}`)
+				return
+			}
+			dexes := `{"dex_id": "uniswap_v3", "dex_name": "Uniswap V3", "chain": "ethereum", "protocol": "uniswap_v3"}`
+			for i := 1; i < 100; i++ {
+				dexes += fmt.Sprintf(`, {"dex_id": "dex_%d", "dex_name": "Dex %d", "protocol": "other"}`, i, i)
+			}
+			fmt.Fprintf(w, `{"dexes": [%s], "page_info": {"limit": 100, "page": 0, "total_items": 101, "total_pages": 2}}`+"\n", dexes)
+		case "/networks/solana/dexes":
+			fmt.Fprintln(w, `{"dexes": [{"dex_id": "raydium", "dex_name": "Raydium", "protocol": "raydium"}], "page_info": {"limit": 100, "page": 0, "total_items": 1, "total_pages": 1}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, `{"error": "not found"}`)
+		}
+	}))
+}
+
+func TestDexes_Get(t *testing.T) {
+	server := newDexesTestServer(t)
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, 1*time.Millisecond, 1*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The dex is on the second page and has no chain in the response
+	dex, err := client.Dexes.Get(ctx, "ethereum", "sushiswap")
+	if err != nil {
+		t.Fatalf("Dexes.Get returned error: %v", err)
+	}
+	if dex.Name != "SushiSwap" || dex.Chain != "ethereum" {
+		t.Errorf("Unexpected dex: %+v", dex)
+	}
+
+	if _, err := client.Dexes.Get(ctx, "ethereum", "missing"); !errors.Is(err, ErrDexNotFound) {
+		t.Errorf("Expected ErrDexNotFound, got %v", err)
+	}
+}
+
+func TestDexes_ListAll(t *testing.T) {
+	server := newDexesTestServer(t)
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, 1*time.Millisecond, 1*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	dexes, err := client.Dexes.ListAll(ctx)
+	if err != nil {
+		t.Fatalf("Dexes.ListAll returned error: %v", err)
+	}
+	if len(dexes) != 102 {
+		t.Fatalf("Expected 102 dexes, got %d", len(dexes))
+	}
+	last := dexes[len(dexes)-1]
+	if last.ID != "raydium" || last.Chain != "solana" {
+		t.Errorf("Unexpected last dex: %+v", last)
+	}
+}
@@ -0,0 +1,56 @@
+package dexpaprikatest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/internal/httpassert"
+)
+
+// Query lists the query parameters a request is expected to have. An empty
+// value expects the parameter to be absent.
+//
+// The Assert functions check requests in httptest handlers, such as mocks
+// written around the fake:
+//
+//	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//		dexpaprikatest.AssertRequest(t, r, http.MethodGet, "/networks/ethereum/pools")
+//		dexpaprikatest.AssertQuery(t, r, dexpaprikatest.Query{"order_by": "volume_usd", "cursor": ""})
+//		fake.ServeHTTP(w, r)
+//	}))
+//
+// They report failures with Errorf, which is safe in handlers, naming the
+// request as in `GET /networks/ethereum/pools?limit=5: query order_by
+// missing, want "volume_usd"`, and return whether the request passed.
+type Query = httpassert.Query
+
+// AssertMethod checks the method of a request.
+func AssertMethod(tb testing.TB, r *http.Request, want string) bool {
+	tb.Helper()
+	return httpassert.Method(tb, r, want)
+}
+
+// AssertPath checks the path of a request.
+func AssertPath(tb testing.TB, r *http.Request, want string) bool {
+	tb.Helper()
+	return httpassert.Path(tb, r, want)
+}
+
+// AssertRequest checks the method and path of a request.
+func AssertRequest(tb testing.TB, r *http.Request, method, path string) bool {
+	tb.Helper()
+	return httpassert.Request(tb, r, method, path)
+}
+
+// AssertQuery checks the query parameters listed in want, ignoring others.
+func AssertQuery(tb testing.TB, r *http.Request, want Query) bool {
+	tb.Helper()
+	return httpassert.Params(tb, r, want)
+}
+
+// AssertExactQuery checks that a request has the query parameters in want
+// and no others.
+func AssertExactQuery(tb testing.TB, r *http.Request, want Query) bool {
+	tb.Helper()
+	return httpassert.ExactParams(tb, r, want)
+}
@@ -0,0 +1,46 @@
+package dexpaprikatest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestAssertions(t *testing.T) {
+	fake := NewFake()
+	fake.AddPool(NewPool().Build())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		AssertRequest(t, r, http.MethodGet, "/networks/ethereum/pools")
+		AssertQuery(t, r, Query{"order_by": "volume_usd", "sort": "desc", "cursor": ""})
+		AssertExactQuery(t, r, Query{"limit": "5", "order_by": "volume_usd", "sort": "desc"})
+		fake.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL))
+	defer client.Close()
+
+	opts := &dexpaprika.ListOptions{Limit: 5, OrderBy: "volume_usd", Sort: "desc"}
+	if _, err := client.Pools.ListByNetwork(context.Background(), "ethereum", opts); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/pools?limit=5", nil)
+	failed := &failingTB{TB: t}
+	if AssertMethod(failed, req, http.MethodGet) || AssertPath(failed, req, "/networks") || AssertQuery(failed, req, Query{"limit": "10"}) {
+		t.Error("a failing assertion returned true")
+	}
+	if failed.errors != 3 {
+		t.Errorf("assertions reported %d failures, want 3", failed.errors)
+	}
+}
+
+// failingTB counts failures instead of failing the test.
+type failingTB struct {
+	testing.TB
+	errors int
+}
+
+func (tb *failingTB) Errorf(string, ...interface{}) { tb.errors++ }
@@ -0,0 +1,251 @@
+package dexpaprikatest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// Clock is a dexpaprika.Clock that only moves when advanced, so retries
+// and cache expiry can be tested without sleeping:
+//
+//	clock := dexpaprikatest.NewClock(time.Time{})
+//	cache := dexpaprika.NewInMemoryCache()
+//	cache.SetClock(clock)
+//	cache.Set("key", value, time.Minute)
+//	clock.Advance(2 * time.Minute) // "key" has expired
+//
+// With AutoAdvance set, every wait completes at once by moving the clock
+// forward, which suits retries when only the total wait matters.
+type Clock struct {
+	// AutoAdvance makes After advance the clock by the waited duration and
+	// fire immediately.
+	AutoAdvance bool
+
+	mu      sync.Mutex
+	now     time.Time
+	waiters []waiter
+	changed chan struct{} // Closed and replaced when waiters change
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+var _ dexpaprika.Clock = (*Clock)(nil)
+
+// NewClock returns a clock set to start, or to 2024-01-01 UTC if start is
+// zero.
+func NewClock(start time.Time) *Clock {
+	if start.IsZero() {
+		start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return &Clock{now: start, changed: make(chan struct{})}
+}
+
+// Now returns the clock's time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel receiving the clock's time once it has been
+// advanced by d.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if c.AutoAdvance && d > 0 {
+		c.now = c.now.Add(d)
+	}
+	if d <= 0 || c.AutoAdvance {
+		ch <- c.now
+		c.fire()
+		return ch
+	}
+	c.waiters = append(c.waiters, waiter{at: c.now.Add(d), ch: ch})
+	c.notify()
+	return ch
+}
+
+// Advance moves the clock forward by d, firing the waits that are due.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.fire()
+}
+
+// Set moves the clock to t, firing the waits that are due.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+	c.fire()
+}
+
+// Waiters returns the number of pending waits.
+func (c *Clock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil waits until n waits are pending or ctx is done, so a test can
+// advance the clock once the code under test is waiting on it.
+func (c *Clock) BlockUntil(ctx context.Context, n int) error {
+	for {
+		c.mu.Lock()
+		pending, changed := len(c.waiters), c.changed
+		c.mu.Unlock()
+		if pending >= n {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// fire sends the time to the waits that are due, in order.
+func (c *Clock) fire() {
+	sort.SliceStable(c.waiters, func(i, j int) bool { return c.waiters[i].at.Before(c.waiters[j].at) })
+	n := 0
+	for n < len(c.waiters) && !c.waiters[n].at.After(c.now) {
+		c.waiters[n].ch <- c.now
+		n++
+	}
+	if n > 0 {
+		c.waiters = append(c.waiters[:0], c.waiters[n:]...)
+		c.notify()
+	}
+}
+
+func (c *Clock) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// RateLimiter is a dexpaprika.RateLimiter that counts waits and lets every
+// request through, unless it is blocked or denying:
+//
+//	limiter := dexpaprikatest.NewRateLimiter()
+//	client := fake.Start(t, dexpaprika.WithRateLimiter(limiter))
+//	limiter.Block()                       // Requests now wait until Unblock
+//	limiter.Deny(dexpaprika.ErrRateLimit) // Or fail at once
+type RateLimiter struct {
+	mu      sync.Mutex
+	waits   int
+	blocked bool
+	err     error
+	changed chan struct{}
+}
+
+var _ dexpaprika.RateLimiter = (*RateLimiter)(nil)
+
+// NewRateLimiter returns a rate limiter letting every request through.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{changed: make(chan struct{})}
+}
+
+// Wait returns the error set with Deny, waits while the limiter is blocked,
+// and otherwise returns at once.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	l.waits++
+	l.mu.Unlock()
+	for {
+		l.mu.Lock()
+		blocked, err, changed := l.blocked, l.err, l.changed
+		l.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		if !blocked {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Waits returns the number of calls to Wait so far.
+func (l *RateLimiter) Waits() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.waits
+}
+
+// Block makes Wait block until Unblock.
+func (l *RateLimiter) Block() {
+	l.set(func() { l.blocked = true })
+}
+
+// Unblock releases waiting requests and lets later ones through.
+func (l *RateLimiter) Unblock() {
+	l.set(func() { l.blocked = false })
+}
+
+// Deny makes Wait fail with err, or succeed again if err is nil.
+func (l *RateLimiter) Deny(err error) {
+	l.set(func() { l.err = err })
+}
+
+func (l *RateLimiter) set(change func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	change()
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// Backoff is a dexpaprika.Backoff returning fixed delays, zero by default,
+// and recording the retry attempts it was asked about. With zero delays a
+// client retries immediately, even on the system clock.
+type Backoff struct {
+	mu       sync.Mutex
+	delays   []time.Duration
+	attempts []int
+}
+
+var _ dexpaprika.Backoff = (*Backoff)(nil)
+
+// NewBackoff returns a backoff waiting delays[i] before retry i+1, and the
+// last delay for later retries.
+func NewBackoff(delays ...time.Duration) *Backoff {
+	return &Backoff{delays: delays}
+}
+
+// Delay returns the delay of a retry attempt, starting at 1.
+func (b *Backoff) Delay(attempt int) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.attempts = append(b.attempts, attempt)
+	if len(b.delays) == 0 {
+		return 0
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > len(b.delays) {
+		return b.delays[len(b.delays)-1]
+	}
+	return b.delays[attempt-1]
+}
+
+// Attempts returns the retry attempts asked about so far, in order.
+func (b *Backoff) Attempts() []int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]int(nil), b.attempts...)
+}
@@ -0,0 +1,176 @@
+package dexpaprikatest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewClock(start)
+	if !clock.Now().Equal(start) {
+		t.Errorf("Now() = %v, want %v", clock.Now(), start)
+	}
+	if NewClock(time.Time{}).Now().IsZero() {
+		t.Error("NewClock(zero) starts at the zero time")
+	}
+
+	later := clock.After(2 * time.Minute)
+	sooner := clock.After(time.Minute)
+	if clock.Waiters() != 2 {
+		t.Errorf("Waiters() = %d, want 2", clock.Waiters())
+	}
+	clock.Advance(time.Minute)
+	select {
+	case got := <-sooner:
+		if !got.Equal(start.Add(time.Minute)) {
+			t.Errorf("After(1m) fired at %v", got)
+		}
+	default:
+		t.Error("After(1m) did not fire after a minute")
+	}
+	select {
+	case <-later:
+		t.Error("After(2m) fired after a minute")
+	default:
+	}
+	clock.Set(start.Add(time.Hour))
+	<-later
+	if clock.Waiters() != 0 {
+		t.Errorf("Waiters() = %d, want 0", clock.Waiters())
+	}
+
+	select {
+	case <-clock.After(0):
+	default:
+		t.Error("After(0) did not fire at once")
+	}
+}
+
+func TestClock_AutoAdvance(t *testing.T) {
+	clock := NewClock(time.Time{})
+	clock.AutoAdvance = true
+	start := clock.Now()
+	<-clock.After(time.Hour)
+	if got := clock.Now().Sub(start); got != time.Hour {
+		t.Errorf("clock advanced by %v, want 1h", got)
+	}
+}
+
+func TestClock_Retries(t *testing.T) {
+	fake := NewFake()
+	fake.AddPool(NewPool().Build())
+	fake.FailNext(http.StatusInternalServerError, http.StatusServiceUnavailable)
+	clock := NewClock(time.Time{})
+	client := fake.Start(t, dexpaprika.WithRetryConfig(3, time.Minute, time.Hour), dexpaprika.WithClock(clock))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Utils.GetStats(ctx)
+		done <- err
+	}()
+
+	// Release each backoff once the client is waiting on it
+	for _, wait := range []time.Duration{time.Minute, 2 * time.Minute} {
+		if err := clock.BlockUntil(ctx, 1); err != nil {
+			t.Fatal(err)
+		}
+		clock.Advance(wait)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if got := len(fake.Requests()); got != 3 {
+		t.Errorf("fake received %d requests, want 3", got)
+	}
+}
+
+func TestClock_CacheExpiry(t *testing.T) {
+	clock := NewClock(time.Time{})
+	cache := dexpaprika.NewInMemoryCache()
+	defer cache.Close()
+	cache.SetClock(clock)
+
+	cache.Set("pools", 1, time.Minute)
+	clock.Advance(30 * time.Second)
+	if _, ok := cache.Get("pools"); !ok {
+		t.Error("item expired early")
+	}
+	clock.Advance(time.Minute)
+	if _, ok := cache.Get("pools"); ok {
+		t.Error("item did not expire")
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	fake := NewFake()
+	limiter := NewRateLimiter()
+	client := fake.Start(t, dexpaprika.WithRateLimiter(limiter), dexpaprika.WithRetryConfig(0, 0, 0))
+	ctx := context.Background()
+
+	if _, err := client.Networks.List(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if limiter.Waits() != 1 {
+		t.Errorf("Waits() = %d, want 1", limiter.Waits())
+	}
+
+	limiter.Deny(dexpaprika.ErrRateLimit)
+	if _, err := client.Networks.List(ctx); !errors.Is(err, dexpaprika.ErrRateLimit) {
+		t.Errorf("List() error = %v, want ErrRateLimit", err)
+	}
+	limiter.Deny(nil)
+
+	limiter.Block()
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Networks.List(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("List() while blocked error = %v, want a deadline error", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Networks.List(ctx)
+		done <- err
+	}()
+	for limiter.Waits() < 4 {
+		time.Sleep(time.Millisecond)
+	}
+	limiter.Unblock()
+	if err := <-done; err != nil {
+		t.Errorf("List() after Unblock: %v", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	fake := NewFake()
+	fake.FailNext(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+	backoff := NewBackoff()
+	client := fake.Start(t, dexpaprika.WithRetryConfig(3, time.Hour, time.Hour), dexpaprika.WithBackoff(backoff))
+
+	start := time.Now()
+	if _, err := client.Networks.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("retries took %v, want no sleeping", elapsed)
+	}
+	if got := backoff.Attempts(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Attempts() = %v, want [1 2 3]", got)
+	}
+
+	fixed := NewBackoff(time.Second, time.Minute)
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: time.Minute, 5: time.Minute} {
+		if got := fixed.Delay(attempt); got != want {
+			t.Errorf("Delay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
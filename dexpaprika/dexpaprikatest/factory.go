@@ -0,0 +1,490 @@
+package dexpaprikatest
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// addressSeq numbers generated addresses, keeping them unique within a
+// process.
+var addressSeq atomic.Int64
+
+// Address returns a new, valid-looking address for a network: 20 hex bytes
+// on EVM networks, 32 base58-encoded bytes on Solana and 32 hex bytes
+// elsewhere. Addresses are unique within a process.
+func Address(networkID string) string {
+	rng := rand.New(rand.NewSource(addressSeq.Add(1)))
+	switch {
+	case dexpaprika.IsEVMNetwork(networkID):
+		return "0x" + randomHex(rng, 20)
+	case networkID == dexpaprika.NetworkSolana:
+		b := make([]byte, 32)
+		rng.Read(b)
+		b[0] |= 1 // No leading zero bytes, which base58 encodes as '1'
+		return encodeBase58(b)
+	default:
+		return "0x" + randomHex(rng, 32)
+	}
+}
+
+func randomHex(rng *rand.Rand, n int) string {
+	b := make([]byte, n)
+	rng.Read(b)
+	return fmt.Sprintf("%x", b)
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func encodeBase58(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix, mod := big.NewInt(58), new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// TokenBuilder builds tokens for tests. The zero configuration is an
+// 18-decimal token on Ethereum with a generated address.
+type TokenBuilder struct {
+	token    dexpaprika.TokenDetails
+	priceUSD float64
+}
+
+// NewToken starts building a token.
+func NewToken() *TokenBuilder {
+	return &TokenBuilder{
+		token: dexpaprika.TokenDetails{
+			Name:        "Test Token",
+			Symbol:      "TEST",
+			Chain:       dexpaprika.NetworkEthereum,
+			Decimals:    18,
+			TotalSupply: 1e9,
+			AddedAt:     "2024-01-01T00:00:00Z",
+		},
+		priceUSD: 1,
+	}
+}
+
+// WithID sets the token's address.
+func (b *TokenBuilder) WithID(address string) *TokenBuilder { b.token.ID = address; return b }
+
+// WithChain sets the token's network.
+func (b *TokenBuilder) WithChain(networkID string) *TokenBuilder { b.token.Chain = networkID; return b }
+
+// WithSymbol sets the token's symbol, and its name unless set by WithName.
+func (b *TokenBuilder) WithSymbol(symbol string) *TokenBuilder {
+	if b.token.Name == "Test Token" {
+		b.token.Name = symbol
+	}
+	b.token.Symbol = symbol
+	return b
+}
+
+// WithName sets the token's name.
+func (b *TokenBuilder) WithName(name string) *TokenBuilder { b.token.Name = name; return b }
+
+// WithDecimals sets the token's decimals.
+func (b *TokenBuilder) WithDecimals(decimals int) *TokenBuilder {
+	b.token.Decimals = decimals
+	return b
+}
+
+// WithPrice sets the token's USD price, reported in its details summary
+// and used as its price in pools built with it.
+func (b *TokenBuilder) WithPrice(usd float64) *TokenBuilder { b.priceUSD = usd; return b }
+
+// Build returns the token as it appears in pool listings.
+func (b *TokenBuilder) Build() dexpaprika.Token {
+	t := b.BuildDetails()
+	fdv := b.priceUSD * t.TotalSupply
+	return dexpaprika.Token{ID: t.ID, Name: t.Name, Symbol: t.Symbol, Chain: t.Chain, Decimals: t.Decimals, AddedAt: t.AddedAt, FDV: &fdv}
+}
+
+// BuildDetails returns the token's details, with a summary carrying its
+// price and FDV.
+func (b *TokenBuilder) BuildDetails() dexpaprika.TokenDetails {
+	t := b.token
+	if t.ID == "" {
+		b.token.ID = Address(t.Chain) // Later builds keep the address
+		t.ID = b.token.ID
+	}
+	fdv := b.priceUSD * t.TotalSupply
+	t.Summary = &dexpaprika.TokenSummary{PriceUSD: b.priceUSD, FDV: &fdv}
+	t.LastUpdated = time.Now().UTC().Format(time.RFC3339)
+	return t
+}
+
+// WETH starts building Wrapped Ether on Ethereum, priced at 3000 USD.
+func WETH() *TokenBuilder {
+	return NewToken().WithID("0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2").WithSymbol("WETH").WithName("Wrapped Ether").WithPrice(3000)
+}
+
+// USDC starts building USD Coin on Ethereum, priced at 1 USD.
+func USDC() *TokenBuilder {
+	return NewToken().WithID("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48").WithSymbol("USDC").WithName("USD Coin").WithDecimals(6).WithPrice(1)
+}
+
+// PoolBuilder builds pools for tests. The zero configuration is a WETH/USDC
+// Uniswap V3 pool on Ethereum with $1M of 24h volume and a generated
+// address.
+type PoolBuilder struct {
+	pool        dexpaprika.Pool
+	tokenPrices []float64 // USD prices of the tokens
+	liquidity   float64
+}
+
+// NewPool starts building a pool.
+func NewPool() *PoolBuilder {
+	return &PoolBuilder{
+		pool: dexpaprika.Pool{
+			DexID:                "uniswap_v3",
+			DexName:              "Uniswap V3",
+			Chain:                dexpaprika.NetworkEthereum,
+			VolumeUSD:            1e6,
+			CreatedAt:            "2024-01-01T00:00:00Z",
+			CreatedAtBlockNumber: 18908895,
+			Transactions:         2500,
+			PriceUSD:             3000,
+			Fee:                  0.003,
+			Tokens:               []dexpaprika.Token{WETH().Build(), USDC().Build()},
+		},
+		tokenPrices: []float64{3000, 1},
+		liquidity:   5e6,
+	}
+}
+
+// WithID sets the pool's address.
+func (b *PoolBuilder) WithID(address string) *PoolBuilder { b.pool.ID = address; return b }
+
+// WithChain sets the pool's network, and that of its tokens. Tokens not
+// built for the network get new addresses on it.
+func (b *PoolBuilder) WithChain(networkID string) *PoolBuilder {
+	for i, t := range b.pool.Tokens {
+		if t.Chain != networkID {
+			t.Chain, t.ID = networkID, Address(networkID)
+			b.pool.Tokens[i] = t
+		}
+	}
+	b.pool.Chain = networkID
+	return b
+}
+
+// WithDex sets the pool's DEX.
+func (b *PoolBuilder) WithDex(dexID, dexName string) *PoolBuilder {
+	b.pool.DexID, b.pool.DexName = dexID, dexName
+	return b
+}
+
+// WithTokens sets the pool's tokens, base token first, and the pool's price
+// to that of the base token.
+func (b *PoolBuilder) WithTokens(tokens ...*TokenBuilder) *PoolBuilder {
+	b.pool.Tokens = make([]dexpaprika.Token, len(tokens))
+	b.tokenPrices = make([]float64, len(tokens))
+	for i, t := range tokens {
+		b.pool.Tokens[i] = t.Build()
+		b.tokenPrices[i] = t.priceUSD
+	}
+	if len(tokens) > 0 {
+		b.pool.PriceUSD = b.tokenPrices[0]
+	}
+	return b
+}
+
+// WithPrice sets the USD price of the pool's base token.
+func (b *PoolBuilder) WithPrice(usd float64) *PoolBuilder { b.pool.PriceUSD = usd; return b }
+
+// WithVolume sets the pool's 24h volume in USD.
+func (b *PoolBuilder) WithVolume(usd float64) *PoolBuilder { b.pool.VolumeUSD = usd; return b }
+
+// WithLiquidity sets the pool's liquidity in USD, reported in its details.
+func (b *PoolBuilder) WithLiquidity(usd float64) *PoolBuilder { b.liquidity = usd; return b }
+
+// WithTransactions sets the pool's 24h transaction count.
+func (b *PoolBuilder) WithTransactions(n int) *PoolBuilder { b.pool.Transactions = n; return b }
+
+// WithFee sets the pool's fee, as a fraction such as 0.003.
+func (b *PoolBuilder) WithFee(fee float64) *PoolBuilder { b.pool.Fee = fee; return b }
+
+// WithPriceChange sets the USD price changes over 5 minutes, 1 hour and 24
+// hours, in percent.
+func (b *PoolBuilder) WithPriceChange(change5m, change1h, change24h float64) *PoolBuilder {
+	b.pool.LastPriceChangeUSD5m = change5m
+	b.pool.LastPriceChangeUSD1h = change1h
+	b.pool.LastPriceChangeUSD24h = change24h
+	return b
+}
+
+// WithCreatedAt sets the pool's creation time.
+func (b *PoolBuilder) WithCreatedAt(t time.Time) *PoolBuilder {
+	b.pool.CreatedAt = t.UTC().Format(time.RFC3339)
+	return b
+}
+
+// Build returns the pool as it appears in pool listings.
+func (b *PoolBuilder) Build() dexpaprika.Pool {
+	if b.pool.ID == "" {
+		b.pool.ID = Address(b.pool.Chain) // Later builds keep the address
+	}
+	p := b.pool
+	p.Tokens = append([]dexpaprika.Token(nil), p.Tokens...)
+	return p
+}
+
+// BuildDetails returns the pool's details, with 24h metrics split evenly
+// into buys and sells, the liquidity split evenly across the tokens'
+// reserves, and the base token's price in the quote token.
+func (b *PoolBuilder) BuildDetails() dexpaprika.PoolDetails {
+	p := b.Build()
+	liquidity := b.liquidity
+	d := dexpaprika.PoolDetails{
+		ID:                   p.ID,
+		CreatedAtBlockNumber: p.CreatedAtBlockNumber,
+		Chain:                p.Chain,
+		CreatedAt:            p.CreatedAt,
+		FactoryID:            p.DexID,
+		DexID:                p.DexID,
+		DexName:              p.DexName,
+		Tokens:               p.Tokens,
+		LastPriceUSD:         p.PriceUSD,
+		Fee:                  p.Fee,
+		PriceTime:            time.Now().UTC().Truncate(time.Second).Format(time.RFC3339),
+		LiquidityUSD:         &liquidity,
+		Day:                  metrics(p.VolumeUSD, p.Transactions, p.LastPriceChangeUSD24h),
+		Hour6:                metrics(p.VolumeUSD/4, p.Transactions/4, 0),
+		Hour1:                metrics(p.VolumeUSD/24, p.Transactions/24, p.LastPriceChangeUSD1h),
+		Minute30:             metrics(p.VolumeUSD/48, p.Transactions/48, 0),
+		Minute15:             metrics(p.VolumeUSD/96, p.Transactions/96, 0),
+		Minute5:              metrics(p.VolumeUSD/288, p.Transactions/288, p.LastPriceChangeUSD5m),
+	}
+	prices := append([]float64(nil), b.tokenPrices...)
+	if len(prices) > 0 {
+		prices[0] = p.PriceUSD
+	}
+	if len(prices) > 1 && prices[1] > 0 {
+		d.LastPrice = prices[0] / prices[1]
+	}
+	for i, t := range p.Tokens {
+		share := liquidity / float64(len(p.Tokens))
+		reserve := dexpaprika.TokenReserve{TokenID: t.ID, AmountUSD: &share}
+		if i < len(prices) && prices[i] > 0 {
+			reserve.Amount = share / prices[i]
+		}
+		d.TokenReserves = append(d.TokenReserves, reserve)
+	}
+	return d
+}
+
+func metrics(volume float64, txns int, change float64) dexpaprika.TimeIntervalMetrics {
+	return dexpaprika.TimeIntervalMetrics{
+		LastPriceUSDChange: change,
+		VolumeUSD:          volume,
+		BuyUSD:             volume / 2,
+		SellUSD:            volume / 2,
+		Buys:               txns / 2,
+		Sells:              txns - txns/2,
+		Txns:               txns,
+	}
+}
+
+// OHLCVBuilder builds candle series for tests as a seeded random walk. The
+// zero configuration is 24 hourly candles starting at 3000 with 1% hourly
+// volatility, ending at the current hour.
+type OHLCVBuilder struct {
+	interval   string
+	count      int
+	start      time.Time
+	price      float64
+	volatility float64
+	drift      float64
+	volume     int64
+	seed       int64
+}
+
+// NewOHLCV starts building a candle series.
+func NewOHLCV() *OHLCVBuilder {
+	return &OHLCVBuilder{
+		interval:   dexpaprika.OHLCVInterval1h,
+		count:      24,
+		price:      3000,
+		volatility: 0.01,
+		volume:     1000,
+		seed:       1,
+	}
+}
+
+// WithInterval sets the candle interval, such as "15m". Unknown intervals
+// panic at Build.
+func (b *OHLCVBuilder) WithInterval(interval string) *OHLCVBuilder { b.interval = interval; return b }
+
+// WithCount sets the number of candles.
+func (b *OHLCVBuilder) WithCount(n int) *OHLCVBuilder { b.count = n; return b }
+
+// WithStart sets the open time of the first candle, truncated to the
+// interval.
+func (b *OHLCVBuilder) WithStart(t time.Time) *OHLCVBuilder { b.start = t; return b }
+
+// WithPrice sets the open of the first candle.
+func (b *OHLCVBuilder) WithPrice(open float64) *OHLCVBuilder { b.price = open; return b }
+
+// WithVolatility sets the standard deviation of each candle's return, as a
+// fraction such as 0.02.
+func (b *OHLCVBuilder) WithVolatility(v float64) *OHLCVBuilder { b.volatility = v; return b }
+
+// WithDrift sets the mean return of each candle, as a fraction; positive
+// values trend up.
+func (b *OHLCVBuilder) WithDrift(d float64) *OHLCVBuilder { b.drift = d; return b }
+
+// WithVolume sets the mean volume of a candle.
+func (b *OHLCVBuilder) WithVolume(v int64) *OHLCVBuilder { b.volume = v; return b }
+
+// WithSeed sets the seed of the random walk; equal seeds build equal series.
+func (b *OHLCVBuilder) WithSeed(seed int64) *OHLCVBuilder { b.seed = seed; return b }
+
+// Build returns the candles in time order.
+func (b *OHLCVBuilder) Build() []dexpaprika.OHLCVRecord {
+	step, ok := dexpaprika.OHLCVIntervalDuration(b.interval)
+	if !ok {
+		panic("dexpaprikatest: unknown OHLCV interval " + b.interval)
+	}
+	start := b.start
+	if start.IsZero() {
+		start = time.Now().Add(-time.Duration(b.count-1) * step)
+	}
+	start = start.UTC().Truncate(step)
+
+	rng := rand.New(rand.NewSource(b.seed))
+	records := make([]dexpaprika.OHLCVRecord, b.count)
+	open := b.price
+	for i := range records {
+		closePrice := open * math.Exp(b.drift+b.volatility*rng.NormFloat64())
+		high := math.Max(open, closePrice) * (1 + b.volatility*math.Abs(rng.NormFloat64())/2)
+		low := math.Min(open, closePrice) * (1 - b.volatility*math.Abs(rng.NormFloat64())/2)
+		t := start.Add(time.Duration(i) * step)
+		records[i] = dexpaprika.OHLCVRecord{
+			TimeOpen:  t.Format(time.RFC3339),
+			TimeClose: t.Add(step).Format(time.RFC3339),
+			Open:      open,
+			High:      high,
+			Low:       low,
+			Close:     closePrice,
+			Volume:    int64(float64(b.volume) * (0.5 + rng.Float64())),
+		}
+		open = closePrice
+	}
+	return records
+}
+
+// TransactionBuilder builds swaps of a pool for tests. The zero
+// configuration sells 1 WETH for 3000 USDC in a generated pool.
+type TransactionBuilder struct {
+	tx      dexpaprika.Transaction
+	network string
+	time    time.Time
+	amount0 float64
+	amount1 float64
+	spacing time.Duration
+}
+
+// NewTransaction starts building a transaction.
+func NewTransaction() *TransactionBuilder {
+	return &TransactionBuilder{
+		tx: dexpaprika.Transaction{
+			Token0:               WETH().Build().ID,
+			Token1:               USDC().Build().ID,
+			CreatedAtBlockNumber: 19000000,
+		},
+		network: dexpaprika.NetworkEthereum,
+		amount0: 1,
+		amount1: -3000,
+		spacing: 12 * time.Second,
+	}
+}
+
+// WithPool sets the pool and its tokens.
+func (b *TransactionBuilder) WithPool(p dexpaprika.Pool) *TransactionBuilder {
+	b.tx.PoolID = p.ID
+	b.network = p.Chain
+	if len(p.Tokens) == 2 {
+		b.tx.Token0, b.tx.Token1 = p.Tokens[0].ID, p.Tokens[1].ID
+	}
+	return b
+}
+
+// WithAmounts sets the token amounts from the pool's point of view: the
+// positive amount flows into the pool, the negative one out of it.
+func (b *TransactionBuilder) WithAmounts(amount0, amount1 float64) *TransactionBuilder {
+	b.amount0, b.amount1 = amount0, amount1
+	return b
+}
+
+// WithSender sets the sender, who is also the recipient.
+func (b *TransactionBuilder) WithSender(address string) *TransactionBuilder {
+	b.tx.Sender, b.tx.Recipient = address, address
+	return b
+}
+
+// WithTime sets the creation time of the (newest) transaction.
+func (b *TransactionBuilder) WithTime(t time.Time) *TransactionBuilder { b.time = t; return b }
+
+// WithBlock sets the block number of the (newest) transaction.
+func (b *TransactionBuilder) WithBlock(n int64) *TransactionBuilder {
+	b.tx.CreatedAtBlockNumber = n
+	return b
+}
+
+// Build returns the transaction.
+func (b *TransactionBuilder) Build() dexpaprika.Transaction {
+	return b.BuildN(1)[0]
+}
+
+// BuildN returns n transactions newest first, as the transaction endpoints
+// list them, one block apart with amounts varying around the configured
+// ones.
+func (b *TransactionBuilder) BuildN(n int) []dexpaprika.Transaction {
+	if b.tx.PoolID == "" {
+		b.tx.PoolID = Address(b.network)
+	}
+	newest := b.time
+	if newest.IsZero() {
+		newest = time.Now()
+	}
+	rng := rand.New(rand.NewSource(b.tx.CreatedAtBlockNumber))
+	txs := make([]dexpaprika.Transaction, n)
+	for i := range txs {
+		tx := b.tx
+		tx.ID = "0x" + randomHex(rng, 32)
+		tx.LogIndex = rng.Intn(200)
+		tx.TransactionIndex = rng.Intn(150)
+		tx.CreatedAtBlockNumber -= int64(i)
+		tx.CreatedAt = newest.Add(-time.Duration(i) * b.spacing).UTC().Format(time.RFC3339)
+		if tx.Sender == "" {
+			tx.Sender = Address(b.network)
+			tx.Recipient = tx.Sender
+		}
+		scale := 0.5 + rng.Float64()
+		tx.Amount0 = formatAmount(b.amount0 * scale)
+		tx.Amount1 = formatAmount(b.amount1 * scale)
+		txs[i] = tx
+	}
+	return txs
+}
+
+// formatAmount formats an amount as the API does, as a decimal string.
+func formatAmount(v float64) string {
+	s := fmt.Sprintf("%.18f", v)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
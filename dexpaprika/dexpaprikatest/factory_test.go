@@ -0,0 +1,109 @@
+package dexpaprikatest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestAddress(t *testing.T) {
+	for _, network := range []string{dexpaprika.NetworkEthereum, dexpaprika.NetworkSolana} {
+		a, b := Address(network), Address(network)
+		if a == b {
+			t.Errorf("Expected unique %s addresses, got %s twice", network, a)
+		}
+		if err := dexpaprika.ValidateAddress(network, a); err != nil {
+			t.Errorf("Expected a valid %s address, got %v", network, err)
+		}
+	}
+}
+
+func TestNewPool(t *testing.T) {
+	pool := NewPool().WithChain("ethereum").WithVolume(1e6).Build()
+	if pool.ID == "" || pool.Chain != "ethereum" || pool.VolumeUSD != 1e6 || len(pool.Tokens) != 2 || pool.Tokens[0].Symbol != "WETH" {
+		t.Errorf("Unexpected pool: %+v", pool)
+	}
+
+	b := NewPool().WithTokens(NewToken().WithSymbol("PEPE").WithPrice(0.5), USDC()).WithLiquidity(1000)
+	if first, second := b.Build(), b.Build(); first.ID != second.ID {
+		t.Errorf("Expected builds to keep the generated address, got %s and %s", first.ID, second.ID)
+	}
+	details := b.BuildDetails()
+	if details.LastPriceUSD != 0.5 || details.LastPrice != 0.5 || *details.LiquidityUSD != 1000 {
+		t.Errorf("Unexpected details: %+v", details)
+	}
+	if usd, ok := details.PriceUSDOf("USDC"); !ok || usd != 1 {
+		t.Errorf("Expected USDC at 1 USD, got %v", usd)
+	}
+	if r, ok := details.ReserveOf("PEPE"); !ok || r.Amount != 1000 {
+		t.Errorf("Expected a reserve of 1000 PEPE, got %+v", r)
+	}
+
+	solana := NewPool().WithChain(dexpaprika.NetworkSolana).Build()
+	if err := dexpaprika.ValidateAddress(dexpaprika.NetworkSolana, solana.Tokens[0].ID); err != nil || solana.Tokens[0].Chain != dexpaprika.NetworkSolana {
+		t.Errorf("Expected tokens moved to Solana, got %+v, %v", solana.Tokens[0], err)
+	}
+}
+
+func TestNewOHLCV(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)
+	candles := NewOHLCV().WithInterval("15m").WithCount(10).WithStart(start).WithPrice(100).WithSeed(7).Build()
+	if len(candles) != 10 || candles[0].TimeOpen != "2024-01-01T00:30:00Z" || candles[0].TimeClose != "2024-01-01T00:45:00Z" || candles[0].Open != 100 {
+		t.Fatalf("Unexpected candles: %+v", candles[:1])
+	}
+	for i, c := range candles {
+		if c.High < c.Open || c.High < c.Close || c.Low > c.Open || c.Low > c.Close || c.Low <= 0 {
+			t.Errorf("Candle %d is inconsistent: %+v", i, c)
+		}
+		if i > 0 && c.Open != candles[i-1].Close {
+			t.Errorf("Candle %d does not open at the previous close", i)
+		}
+	}
+
+	again := NewOHLCV().WithInterval("15m").WithCount(10).WithStart(start).WithPrice(100).WithSeed(7).Build()
+	if again[9] != candles[9] {
+		t.Error("Expected equal seeds to build equal series")
+	}
+
+	latest := NewOHLCV().Build()
+	if last, _ := latest[len(latest)-1].OpenTime(); time.Since(last) > time.Hour {
+		t.Errorf("Expected the default series to end at the current hour, got %v", last)
+	}
+}
+
+func TestNewTransaction(t *testing.T) {
+	pool := NewPool().Build()
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	txs := NewTransaction().WithPool(pool).WithTime(at).WithAmounts(-2, 6000).BuildN(3)
+
+	if len(txs) != 3 || txs[0].PoolID != pool.ID || txs[0].Token0 != pool.Tokens[0].ID {
+		t.Fatalf("Unexpected transactions: %+v", txs)
+	}
+	if txs[0].CreatedAt != "2024-01-01T00:00:00Z" || txs[1].CreatedAt >= txs[0].CreatedAt {
+		t.Errorf("Expected transactions newest first, got %s then %s", txs[0].CreatedAt, txs[1].CreatedAt)
+	}
+	amount0, ok0 := txs[0].GetAmount0()
+	amount1, ok1 := txs[0].GetAmount1()
+	if !ok0 || !ok1 || amount0 >= 0 || amount1 <= 0 || amount1/amount0 < -3001 || amount1/amount0 > -2999 {
+		t.Errorf("Expected amounts around -2 and 6000, got %v and %v", amount0, amount1)
+	}
+	if txs[0].ID == txs[1].ID {
+		t.Error("Expected unique transaction IDs")
+	}
+}
+
+func TestFactories_WithFake(t *testing.T) {
+	fake := NewFake()
+	pool := NewPool().WithVolume(5e6)
+	fake.AddPool(pool.Build())
+	fake.SetPoolDetails(pool.BuildDetails())
+	fake.AddOHLCV("ethereum", pool.Build().ID, "1h", NewOHLCV().Build()...)
+	client := fake.Start(t)
+
+	details, err := client.Pools.GetDetails(context.Background(), "ethereum", pool.Build().ID, nil)
+	if err != nil || details.Day.VolumeUSD != 5e6 {
+		t.Errorf("Expected the built details, got %+v, %v", details, err)
+	}
+}
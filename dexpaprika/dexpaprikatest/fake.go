@@ -0,0 +1,325 @@
+// Package dexpaprikatest provides an in-memory fake of the DexPaprika API,
+// so applications using the SDK can write fast integration tests without
+// network access or hand-written mock handlers.
+//
+// A Fake serves every endpoint used by the SDK from data seeded with its
+// Add methods or from a snapshot, and can inject latency and errors:
+//
+//	fake := dexpaprikatest.NewFake()
+//	fake.AddPool(dexpaprika.Pool{ID: "0xpool", Chain: "ethereum", DexID: "uniswap_v3", PriceUSD: 3000})
+//	fake.ErrorRate = 0.1 // One request in ten fails with a 500
+//	client := fake.Start(t)
+//	resp, err := client.Pools.ListByNetwork(ctx, "ethereum", nil)
+//
+// Builders such as NewPool, NewToken, NewOHLCV and NewTransaction generate
+// realistic models for seeding the fake or for unit tests.
+package dexpaprikatest
+
+import (
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// Request is a request received by a Fake.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+}
+
+// Fake is an in-memory DexPaprika API. It implements http.Handler; Start
+// serves it on a test server. Seed methods and requests may be called
+// concurrently.
+type Fake struct {
+	// Latency delays every response, plus a random duration of up to Jitter.
+	Latency time.Duration
+	Jitter  time.Duration
+
+	// ErrorRate is the fraction of requests, from 0 to 1, answered with
+	// ErrorStatus (default 500) instead of data.
+	ErrorRate   float64
+	ErrorStatus int
+
+	// MaxRequests, if positive, keeps only the most recent requests for
+	// Requests, so long-running servers do not grow without bound.
+	MaxRequests int
+
+	mu       sync.Mutex
+	rng      *rand.Rand
+	failNext []int // Statuses of the next requests to fail, in order
+
+	networks     []dexpaprika.Network
+	dexes        map[string][]dexpaprika.Dex // By network
+	pools        []dexpaprika.Pool
+	details      map[dexpaprika.EntityKey]dexpaprika.PoolDetails
+	tokens       map[dexpaprika.EntityKey]dexpaprika.TokenDetails
+	ohlcv        map[string][]dexpaprika.OHLCVRecord // By pool key and interval
+	transactions map[dexpaprika.EntityKey][]dexpaprika.Transaction
+	stats        *dexpaprika.Stats
+	requests     []Request
+}
+
+// NewFake creates an empty fake. Its random error and jitter decisions are
+// seeded deterministically; see Seed.
+func NewFake() *Fake {
+	return &Fake{
+		ErrorStatus:  http.StatusInternalServerError,
+		rng:          rand.New(rand.NewSource(1)),
+		dexes:        make(map[string][]dexpaprika.Dex),
+		details:      make(map[dexpaprika.EntityKey]dexpaprika.PoolDetails),
+		tokens:       make(map[dexpaprika.EntityKey]dexpaprika.TokenDetails),
+		ohlcv:        make(map[string][]dexpaprika.OHLCVRecord),
+		transactions: make(map[dexpaprika.EntityKey][]dexpaprika.Transaction),
+	}
+}
+
+// Start serves the fake on a test server closed when the test ends, and
+// returns a client of it. Options are applied after the base URL.
+func (f *Fake) Start(tb testing.TB, opts ...dexpaprika.ClientOption) *dexpaprika.Client {
+	tb.Helper()
+	server := httptest.NewServer(f)
+	tb.Cleanup(server.Close)
+	client := dexpaprika.NewClient(append([]dexpaprika.ClientOption{dexpaprika.WithBaseURL(server.URL)}, opts...)...)
+	tb.Cleanup(func() { client.Close() })
+	return client
+}
+
+// Seed reseeds the random source deciding injected errors and jitter.
+func (f *Fake) Seed(seed int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rng = rand.New(rand.NewSource(seed))
+}
+
+// FailNext makes the next requests fail with the given statuses, one per
+// request, before ErrorRate applies.
+func (f *Fake) FailNext(statuses ...int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failNext = append(f.failNext, statuses...)
+}
+
+// Requests returns the requests received so far.
+func (f *Fake) Requests() []Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Request(nil), f.requests...)
+}
+
+// AddNetwork adds a network, replacing one with the same ID.
+func (f *Fake) AddNetwork(n dexpaprika.Network) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.addNetwork(n)
+}
+
+func (f *Fake) addNetwork(n dexpaprika.Network) {
+	for i, existing := range f.networks {
+		if existing.ID == n.ID {
+			f.networks[i] = n
+			return
+		}
+	}
+	f.networks = append(f.networks, n)
+}
+
+// AddDex adds a DEX to the network of its Chain, adding the network if
+// needed.
+func (f *Fake) AddDex(d dexpaprika.Dex) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.addDex(d)
+}
+
+func (f *Fake) addDex(d dexpaprika.Dex) {
+	f.ensureNetwork(d.Chain)
+	dexes := f.dexes[d.Chain]
+	for i, existing := range dexes {
+		if existing.ID == d.ID {
+			dexes[i] = d
+			return
+		}
+	}
+	f.dexes[d.Chain] = append(dexes, d)
+}
+
+// AddPool adds a pool, replacing one with the same key. Its network, DEX
+// and tokens are added if not seeded already, so a list of pools is enough
+// to serve every endpoint.
+func (f *Fake) AddPool(p dexpaprika.Pool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.addPool(p)
+}
+
+func (f *Fake) addPool(p dexpaprika.Pool) {
+	f.ensureNetwork(p.Chain)
+	if p.DexID != "" && !f.hasDex(p.Chain, p.DexID) {
+		f.addDex(dexpaprika.Dex{ID: p.DexID, Name: p.DexName, Chain: p.Chain})
+	}
+	p.Tokens = append([]dexpaprika.Token(nil), p.Tokens...)
+	for i, t := range p.Tokens {
+		if t.Chain == "" {
+			t.Chain = p.Chain
+		}
+		key := t.Key()
+		if _, ok := f.tokens[key]; !ok {
+			f.tokens[key] = dexpaprika.TokenDetails{
+				ID: t.ID, Name: t.Name, Symbol: t.Symbol, Chain: t.Chain,
+				Decimals: t.Decimals, AddedAt: t.AddedAt,
+			}
+		}
+		p.Tokens[i] = t
+	}
+	for i, existing := range f.pools {
+		if existing.SameAs(p) {
+			f.pools[i] = p
+			return
+		}
+	}
+	f.pools = append(f.pools, p)
+}
+
+// SetPoolDetails sets the details served for a pool, adding the pool if
+// needed. Without them, details are derived from the pool listing.
+func (f *Fake) SetPoolDetails(d dexpaprika.PoolDetails) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.pool(d.Key()); !ok {
+		f.addPool(dexpaprika.Pool{
+			ID: d.ID, Chain: d.Chain, DexID: d.DexID, DexName: d.DexName,
+			CreatedAt: d.CreatedAt, PriceUSD: d.LastPriceUSD, Fee: d.Fee,
+			VolumeUSD: d.Day.VolumeUSD, Transactions: d.Day.Txns, Tokens: d.Tokens,
+		})
+	}
+	f.details[d.Key()] = d
+}
+
+// AddToken adds a token, replacing one with the same key.
+func (f *Fake) AddToken(t dexpaprika.TokenDetails) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ensureNetwork(t.Chain)
+	f.tokens[t.Key()] = t
+}
+
+// AddOHLCV adds candles of a pool for an interval, such as "1h". Candles
+// are kept sorted by open time, replacing those with the same open time.
+func (f *Fake) AddOHLCV(networkID, poolAddress, interval string, records ...dexpaprika.OHLCVRecord) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := ohlcvKey(dexpaprika.NewEntityKey(networkID, poolAddress), interval)
+	byOpen := make(map[string]dexpaprika.OHLCVRecord)
+	for _, r := range f.ohlcv[key] {
+		byOpen[r.TimeOpen] = r
+	}
+	for _, r := range records {
+		byOpen[r.TimeOpen] = r
+	}
+	merged := make([]dexpaprika.OHLCVRecord, 0, len(byOpen))
+	for _, r := range byOpen {
+		merged = append(merged, r)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].TimeOpen < merged[j].TimeOpen })
+	f.ohlcv[key] = merged
+}
+
+// AddTransactions adds transactions of a pool. They are served newest
+// first, ordered by CreatedAt.
+func (f *Fake) AddTransactions(networkID, poolAddress string, txs ...dexpaprika.Transaction) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := dexpaprika.NewEntityKey(networkID, poolAddress)
+	all := append(f.transactions[key], txs...)
+	sortTransactions(all)
+	f.transactions[key] = all
+}
+
+// SetStats sets the response of /stats. Without it, stats are counted from
+// the seeded data.
+func (f *Fake) SetStats(s dexpaprika.Stats) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stats = &s
+}
+
+// LoadSnapshot seeds the fake with the networks, dexes, pools, pool
+// details, tokens, transactions and candles of a snapshot.
+func (f *Fake) LoadSnapshot(s *dexpaprika.Snapshot) {
+	for _, n := range s.Networks {
+		f.AddNetwork(n)
+	}
+	for _, dexes := range s.Dexes {
+		for _, d := range dexes {
+			f.AddDex(d)
+		}
+	}
+	for _, p := range s.Pools {
+		f.AddPool(p)
+	}
+	for _, d := range s.PoolDetails {
+		f.SetPoolDetails(*d)
+	}
+	for _, t := range s.Tokens {
+		f.AddToken(*t)
+	}
+	for key, txs := range s.Transactions {
+		if pool, err := dexpaprika.ParseEntityKey(key); err == nil {
+			f.AddTransactions(pool.Chain, pool.Address, txs...)
+		}
+	}
+	for key, records := range s.OHLCV {
+		f.mu.Lock()
+		f.ohlcv[key] = append([]dexpaprika.OHLCVRecord(nil), records...)
+		f.mu.Unlock()
+	}
+}
+
+// ensureNetwork adds a network by ID if it is missing.
+func (f *Fake) ensureNetwork(id string) {
+	if id == "" {
+		return
+	}
+	for _, n := range f.networks {
+		if n.ID == id {
+			return
+		}
+	}
+	f.networks = append(f.networks, dexpaprika.Network{ID: id, DisplayName: id})
+}
+
+func (f *Fake) hasDex(networkID, dexID string) bool {
+	for _, d := range f.dexes[networkID] {
+		if d.ID == dexID {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Fake) pool(key dexpaprika.EntityKey) (dexpaprika.Pool, bool) {
+	for _, p := range f.pools {
+		if p.Key() == key {
+			return p, true
+		}
+	}
+	return dexpaprika.Pool{}, false
+}
+
+// ohlcvKey matches the keys of Snapshot.OHLCV.
+func ohlcvKey(pool dexpaprika.EntityKey, interval string) string {
+	return pool.String() + ":" + interval
+}
+
+// sortTransactions orders transactions newest first.
+func sortTransactions(txs []dexpaprika.Transaction) {
+	sort.SliceStable(txs, func(i, j int) bool { return txs[i].CreatedAt > txs[j].CreatedAt })
+}
@@ -0,0 +1,108 @@
+package dexpaprikatest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestFake_Errors(t *testing.T) {
+	fake := NewFake()
+	fake.AddPool(dexpaprika.Pool{ID: "0xpool", Chain: "ethereum"})
+	client := fake.Start(t, dexpaprika.WithRetryConfig(0, time.Millisecond, time.Millisecond))
+	ctx := context.Background()
+
+	fake.FailNext(http.StatusTooManyRequests, http.StatusInternalServerError)
+	if _, err := client.Networks.List(ctx); !errors.Is(err, dexpaprika.ErrRateLimit) {
+		t.Errorf("Expected a rate limit error, got %v", err)
+	}
+	if _, err := client.Networks.List(ctx); !errors.Is(err, dexpaprika.ErrInternalServerError) {
+		t.Errorf("Expected a server error, got %v", err)
+	}
+	if _, err := client.Networks.List(ctx); err != nil {
+		t.Errorf("Expected success after the injected errors, got %v", err)
+	}
+
+	fake.ErrorRate = 1
+	if _, err := client.Utils.GetStats(ctx); !errors.Is(err, dexpaprika.ErrInternalServerError) {
+		t.Errorf("Expected an injected error, got %v", err)
+	}
+	fake.ErrorRate = 0
+
+	if got := len(fake.Requests()); got != 4 {
+		t.Errorf("Expected 4 recorded requests, got %d", got)
+	}
+}
+
+func TestFake_ErrorRate(t *testing.T) {
+	fake := NewFake()
+	fake.ErrorRate = 0.5
+	client := fake.Start(t, dexpaprika.WithRetryConfig(0, time.Millisecond, time.Millisecond))
+
+	failures := 0
+	for i := 0; i < 100; i++ {
+		if _, err := client.Utils.GetStats(context.Background()); err != nil {
+			failures++
+		}
+	}
+	if failures < 30 || failures > 70 {
+		t.Errorf("Expected about half of the requests to fail, got %d", failures)
+	}
+}
+
+func TestFake_MaxRequests(t *testing.T) {
+	fake := NewFake()
+	fake.MaxRequests = 2
+	client := fake.Start(t)
+
+	for _, network := range []string{"ethereum", "solana", "base"} {
+		client.Pools.ListByNetwork(context.Background(), network, nil) // Unknown networks are recorded too
+	}
+	requests := fake.Requests()
+	if len(requests) != 2 || requests[0].Path != "/networks/solana/pools" || requests[1].Path != "/networks/base/pools" {
+		t.Errorf("Expected the last 2 requests, got %+v", requests)
+	}
+}
+
+func TestFake_Latency(t *testing.T) {
+	fake := NewFake()
+	fake.Latency = 20 * time.Millisecond
+	client := fake.Start(t)
+
+	start := time.Now()
+	if _, err := client.Utils.GetStats(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < fake.Latency {
+		t.Errorf("Expected a response after at least %v, got %v", fake.Latency, elapsed)
+	}
+}
+
+func TestFake_LoadSnapshot(t *testing.T) {
+	snap := dexpaprika.NewSnapshot()
+	snap.Pools = []dexpaprika.Pool{{ID: "0xpool", Chain: "ethereum", DexID: "uniswap_v3"}}
+	snap.SetTransactions("ethereum", "0xpool", []dexpaprika.Transaction{{ID: "tx1"}})
+	snap.SetOHLCV("ethereum", "0xpool", "1h", []dexpaprika.OHLCVRecord{{TimeOpen: "2024-01-01T00:00:00Z", Close: 5}})
+
+	fake := NewFake()
+	fake.LoadSnapshot(snap)
+	client := fake.Start(t)
+	ctx := context.Background()
+
+	txs, err := client.Pools.GetTransactions(ctx, "ethereum", "0xpool", 0, 10, "")
+	if err != nil || len(txs.Transactions) != 1 {
+		t.Errorf("Expected the snapshot's transaction, got %v, %v", txs, err)
+	}
+	candles, err := client.Pools.GetOHLCV(ctx, "ethereum", "0xpool", &dexpaprika.OHLCVOptions{Start: "2024-01-01", Interval: "1h"})
+	if err != nil || len(candles) != 1 || candles[0].Close != 5 {
+		t.Errorf("Expected the snapshot's candle, got %v, %v", candles, err)
+	}
+	dexes, err := client.Networks.ListDexes(ctx, "ethereum", 0, 10)
+	if err != nil || len(dexes.Dexes) != 1 || dexes.Dexes[0].ID != "uniswap_v3" {
+		t.Errorf("Expected the pool's DEX, got %v, %v", dexes, err)
+	}
+}
@@ -0,0 +1,334 @@
+package dexpaprikatest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+type faultKind int
+
+const (
+	faultTimeout faultKind = iota + 1
+	faultStatus
+	faultTruncated
+	faultSlow
+	faultReset
+)
+
+// Fault is a failure injected into a request by a FaultTransport.
+type Fault struct {
+	kind       faultKind
+	status     int
+	retryAfter time.Duration
+	delay      time.Duration
+	keep       int
+}
+
+// Timeout fails the request with a timeout error after waiting d, or when
+// the request's context is done if sooner, like a server that never
+// answers.
+func Timeout(d time.Duration) Fault {
+	return Fault{kind: faultTimeout, delay: d}
+}
+
+// Status answers the request with an error status and a JSON error body
+// without sending it.
+func Status(code int) Fault {
+	return Fault{kind: faultStatus, status: code}
+}
+
+// RateLimited answers the request with a 429 and, if retryAfter is positive,
+// a Retry-After header.
+func RateLimited(retryAfter time.Duration) Fault {
+	return Fault{kind: faultStatus, status: http.StatusTooManyRequests, retryAfter: retryAfter}
+}
+
+// Truncated sends the request but cuts the response body after at most keep
+// bytes, failing the read with io.ErrUnexpectedEOF even if the body was
+// shorter.
+func Truncated(keep int) Fault {
+	return Fault{kind: faultTruncated, keep: keep}
+}
+
+// Slow delays the request by d before sending it.
+func Slow(d time.Duration) Fault {
+	return Fault{kind: faultSlow, delay: d}
+}
+
+// ConnReset fails the request with a connection reset error.
+func ConnReset() Fault {
+	return Fault{kind: faultReset}
+}
+
+func (f Fault) String() string {
+	switch f.kind {
+	case faultTimeout:
+		return fmt.Sprintf("timeout after %v", f.delay)
+	case faultStatus:
+		if f.retryAfter > 0 {
+			return fmt.Sprintf("status %d, retry after %v", f.status, f.retryAfter)
+		}
+		return fmt.Sprintf("status %d", f.status)
+	case faultTruncated:
+		return fmt.Sprintf("body truncated after %d bytes", f.keep)
+	case faultSlow:
+		return fmt.Sprintf("delayed by %v", f.delay)
+	case faultReset:
+		return "connection reset"
+	}
+	return "no fault"
+}
+
+// Schedule decides the fault injected into the nth request sent through a
+// transport, counting from 1, or nil to let it through.
+type Schedule func(n int, req *http.Request) *Fault
+
+// Always injects f into every request.
+func Always(f Fault) Schedule {
+	return func(int, *http.Request) *Fault { return &f }
+}
+
+// Sequence injects faults[i] into request i+1; nil entries and requests
+// past the end go through.
+func Sequence(faults ...*Fault) Schedule {
+	return func(n int, _ *http.Request) *Fault {
+		if n > len(faults) {
+			return nil
+		}
+		return faults[n-1]
+	}
+}
+
+// Every injects f into every kth request.
+func Every(k int, f Fault) Schedule {
+	return func(n int, _ *http.Request) *Fault {
+		if k > 0 && n%k == 0 {
+			return &f
+		}
+		return nil
+	}
+}
+
+// Window injects f into requests from to to, inclusive, such as a storm of
+// 429s in the middle of a run.
+func Window(from, to int, f Fault) Schedule {
+	return func(n int, _ *http.Request) *Fault {
+		if n >= from && n <= to {
+			return &f
+		}
+		return nil
+	}
+}
+
+// Randomly injects f into a fraction p of requests, reproducibly for a
+// seed.
+func Randomly(p float64, seed int64, f Fault) Schedule {
+	var mu sync.Mutex
+	rng := rand.New(rand.NewSource(seed))
+	return func(int, *http.Request) *Fault {
+		mu.Lock()
+		defer mu.Unlock()
+		if rng.Float64() < p {
+			return &f
+		}
+		return nil
+	}
+}
+
+// OnPath applies s only to requests whose path contains substr, such as
+// "/ohlcv".
+func OnPath(substr string, s Schedule) Schedule {
+	return func(n int, req *http.Request) *Fault {
+		if !strings.Contains(req.URL.Path, substr) {
+			return nil
+		}
+		return s(n, req)
+	}
+}
+
+// Injection records a fault injected by a FaultTransport.
+type Injection struct {
+	N     int // Request number, from 1
+	Path  string
+	Fault Fault
+}
+
+// FaultTransport is an http.RoundTripper that injects failures into
+// requests on a schedule, so applications can verify how they handle the
+// SDK's error modes:
+//
+//	faults := dexpaprikatest.NewFaultTransport(nil,
+//		dexpaprikatest.Window(3, 6, dexpaprikatest.RateLimited(time.Second)),
+//		dexpaprikatest.Every(10, dexpaprikatest.Truncated(20)),
+//	)
+//	client := fake.Start(t, faults.Option())
+//
+// The first schedule returning a fault wins; requests without one are sent
+// through Base.
+type FaultTransport struct {
+	// Base sends requests that are not failed, http.DefaultTransport if nil.
+	Base http.RoundTripper
+	// Clock times the waits of Timeout and Slow faults, the system clock if
+	// nil.
+	Clock dexpaprika.Clock
+
+	schedules []Schedule
+
+	mu       sync.Mutex
+	n        int
+	injected []Injection
+}
+
+// NewFaultTransport returns a transport injecting faults on the schedules
+// into requests sent through base.
+func NewFaultTransport(base http.RoundTripper, schedules ...Schedule) *FaultTransport {
+	return &FaultTransport{Base: base, schedules: schedules}
+}
+
+// Option returns a client option sending requests through the transport.
+func (t *FaultTransport) Option() dexpaprika.ClientOption {
+	return dexpaprika.WithHTTPClient(&http.Client{Transport: t, Timeout: dexpaprika.DefaultTimeout})
+}
+
+// Injected returns the faults injected so far, in order.
+func (t *FaultTransport) Injected() []Injection {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Injection(nil), t.injected...)
+}
+
+// Requests returns the number of requests received so far.
+func (t *FaultTransport) Requests() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.n
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.n++
+	n := t.n
+	var fault *Fault
+	for _, s := range t.schedules {
+		if fault = s(n, req); fault != nil {
+			t.injected = append(t.injected, Injection{N: n, Path: req.URL.Path, Fault: *fault})
+			break
+		}
+	}
+	t.mu.Unlock()
+
+	if fault == nil {
+		return t.base().RoundTrip(req)
+	}
+	switch fault.kind {
+	case faultTimeout:
+		if err := t.wait(req.Context(), fault.delay); err != nil {
+			return nil, err
+		}
+		return nil, timeoutError{}
+	case faultStatus:
+		return statusResponse(req, fault.status, fault.retryAfter), nil
+	case faultTruncated:
+		resp, err := t.base().RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = &truncatedBody{body: resp.Body, left: fault.keep}
+		resp.ContentLength = -1
+		return resp, nil
+	case faultSlow:
+		if err := t.wait(req.Context(), fault.delay); err != nil {
+			return nil, err
+		}
+		return t.base().RoundTrip(req)
+	case faultReset:
+		return nil, fmt.Errorf("read tcp: %w", syscall.ECONNRESET)
+	}
+	return t.base().RoundTrip(req)
+}
+
+func (t *FaultTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// wait waits d on the transport's clock, or until ctx is done.
+func (t *FaultTransport) wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	clock := t.Clock
+	if clock == nil {
+		clock = dexpaprika.SystemClock
+	}
+	select {
+	case <-clock.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// statusResponse builds an error response as the API would send it.
+func statusResponse(req *http.Request, status int, retryAfter time.Duration) *http.Response {
+	body := fmt.Sprintf(`{"error":%q}`, strings.ToLower(http.StatusText(status)))
+	header := http.Header{"Content-Type": {"application/json"}}
+	if retryAfter > 0 {
+		header.Set("Retry-After", strconv.Itoa(int((retryAfter+time.Second-1)/time.Second)))
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// timeoutError is the error of a Timeout fault. It implements net.Error.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "injected timeout awaiting response headers" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// truncatedBody returns the first bytes of a body, then io.ErrUnexpectedEOF.
+type truncatedBody struct {
+	body io.ReadCloser
+	left int
+}
+
+func (b *truncatedBody) Read(p []byte) (int, error) {
+	if b.left <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if len(p) > b.left {
+		p = p[:b.left]
+	}
+	n, err := b.body.Read(p)
+	b.left -= n
+	if err == io.EOF {
+		// The body was shorter than the cut, but the connection still drops
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (b *truncatedBody) Close() error { return b.body.Close() }
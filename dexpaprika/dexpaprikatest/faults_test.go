@@ -0,0 +1,197 @@
+package dexpaprikatest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestFaultTransport_Statuses(t *testing.T) {
+	fake := NewFake()
+	fake.AddPool(NewPool().Build())
+	faults := NewFaultTransport(nil, Window(1, 2, RateLimited(3*time.Second)), Sequence(nil, nil, ptr(Status(http.StatusBadGateway))))
+	client := fake.Start(t, faults.Option(), dexpaprika.WithRetryConfig(0, 0, 0))
+	ctx := context.Background()
+
+	_, err := client.Networks.List(ctx)
+	var apiErr *dexpaprika.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || !errors.Is(err, dexpaprika.ErrRateLimit) {
+		t.Fatalf("first request error = %v, want a 429", err)
+	}
+	if apiErr.Message != "too many requests" {
+		t.Errorf("Message = %q, want the status text", apiErr.Message)
+	}
+	if _, err := client.Networks.List(ctx); !errors.Is(err, dexpaprika.ErrRateLimit) {
+		t.Errorf("second request error = %v, want a 429", err)
+	}
+	if _, err := client.Networks.List(ctx); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("third request error = %v, want a 502", err)
+	}
+	if _, err := client.Networks.List(ctx); err != nil {
+		t.Errorf("fourth request: %v", err)
+	}
+
+	if got := len(fake.Requests()); got != 1 {
+		t.Errorf("fake received %d requests, want only the one not failed", got)
+	}
+	injected := faults.Injected()
+	if len(injected) != 3 || injected[0].N != 1 || injected[2].Fault.String() != "status 502" {
+		t.Errorf("Injected() = %+v", injected)
+	}
+	if faults.Requests() != 4 {
+		t.Errorf("Requests() = %d, want 4", faults.Requests())
+	}
+}
+
+func TestFaultTransport_RetriedByClient(t *testing.T) {
+	fake := NewFake()
+	faults := NewFaultTransport(nil, Sequence(ptr(ConnReset()), ptr(Truncated(5)), ptr(Timeout(0))))
+	backoff := NewBackoff()
+	client := fake.Start(t, faults.Option(), dexpaprika.WithRetryConfig(3, 0, 0), dexpaprika.WithBackoff(backoff))
+
+	if _, err := client.Networks.List(context.Background()); err != nil {
+		t.Fatalf("List() = %v, want success after three retries", err)
+	}
+	if got := len(backoff.Attempts()); got != 3 {
+		t.Errorf("client retried %d times, want 3", got)
+	}
+}
+
+func TestFaultTransport_Errors(t *testing.T) {
+	fake := NewFake()
+
+	tests := []struct {
+		name  string
+		fault Fault
+		check func(t *testing.T, resp *http.Response, err error)
+	}{
+		{"timeout", Timeout(0), func(t *testing.T, resp *http.Response, err error) {
+			var netErr net.Error
+			if !errors.As(err, &netErr) || !netErr.Timeout() {
+				t.Errorf("error = %v, want a timeout", err)
+			}
+		}},
+		{"reset", ConnReset(), func(t *testing.T, resp *http.Response, err error) {
+			if !errors.Is(err, syscall.ECONNRESET) {
+				t.Errorf("error = %v, want ECONNRESET", err)
+			}
+		}},
+		{"truncated", Truncated(4), func(t *testing.T, resp *http.Response, err error) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if len(body) > 4 || !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("body = %q, %v; want at most 4 bytes and ErrUnexpectedEOF", body, err)
+			}
+		}},
+		{"retry after", RateLimited(1500 * time.Millisecond), func(t *testing.T, resp *http.Response, err error) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "2" {
+				t.Errorf("response = %d with Retry-After %q, want 429 and 2", resp.StatusCode, resp.Header.Get("Retry-After"))
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &http.Client{Transport: NewFaultTransport(handlerTransport{fake}, Always(tt.fault))}
+			resp, err := client.Get("http://api.test/networks")
+			tt.check(t, resp, err)
+		})
+	}
+}
+
+func TestFaultTransport_SlowAndTimeoutUseClock(t *testing.T) {
+	clock := NewClock(time.Time{})
+	faults := NewFaultTransport(handlerTransport{NewFake()}, Sequence(ptr(Slow(time.Minute)), ptr(Timeout(time.Hour))))
+	faults.Clock = clock
+	client := &http.Client{Transport: faults}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		resp, err := client.Get("http://api.test/networks")
+		if err == nil {
+			resp.Body.Close()
+		}
+		done <- err
+	}()
+	if err := clock.BlockUntil(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Minute)
+	if err := <-done; err != nil {
+		t.Errorf("slow request: %v", err)
+	}
+
+	// A timeout ends early when the request's context is done
+	short, cancelShort := context.WithCancel(ctx)
+	go func() {
+		req, _ := http.NewRequestWithContext(short, http.MethodGet, "http://api.test/networks", nil)
+		_, err := client.Do(req)
+		done <- err
+	}()
+	if err := clock.BlockUntil(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	cancelShort()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled timeout error = %v, want context.Canceled", err)
+	}
+}
+
+func TestSchedules(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://api.test/networks/ethereum/pools/0x1/ohlcv", nil)
+	other, _ := http.NewRequest(http.MethodGet, "http://api.test/networks", nil)
+	f := Status(http.StatusInternalServerError)
+
+	count := func(s Schedule, r *http.Request) int {
+		n := 0
+		for i := 1; i <= 100; i++ {
+			if s(i, r) != nil {
+				n++
+			}
+		}
+		return n
+	}
+	if got := count(Every(10, f), req); got != 10 {
+		t.Errorf("Every(10) injected %d of 100, want 10", got)
+	}
+	if got := count(Window(5, 9, f), req); got != 5 {
+		t.Errorf("Window(5, 9) injected %d, want 5", got)
+	}
+	if got := count(OnPath("/ohlcv", Always(f)), other); got != 0 {
+		t.Errorf("OnPath injected %d into other paths, want 0", got)
+	}
+	if got := count(OnPath("/ohlcv", Always(f)), req); got != 100 {
+		t.Errorf("OnPath injected %d into matching paths, want 100", got)
+	}
+	a, b := count(Randomly(0.3, 7, f), req), count(Randomly(0.3, 7, f), req)
+	if a != b || a < 15 || a > 45 {
+		t.Errorf("Randomly(0.3) injected %d and %d, want about 30 and reproducible", a, b)
+	}
+}
+
+func ptr(f Fault) *Fault { return &f }
+
+// handlerTransport serves requests with a handler, without a server.
+type handlerTransport struct{ h http.Handler }
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	t.h.ServeHTTP(rec, req)
+	return rec.Result(), nil
+}
@@ -0,0 +1,501 @@
+package dexpaprikatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// Pagination limits of the API.
+const (
+	defaultLimit  = 10
+	maxLimit      = 100
+	maxOHLCVLimit = 366
+)
+
+// versionPrefix matches the path prefix of clients created with
+// dexpaprika.WithAPIVersion.
+var versionPrefix = regexp.MustCompile(`^/v[0-9]+(/|$)`)
+
+// errBadRequest marks errors answered with 400 Bad Request.
+type errBadRequest string
+
+func (e errBadRequest) Error() string { return string(e) }
+
+// errNotFound marks errors answered with 404 Not Found.
+type errNotFound string
+
+func (e errNotFound) Error() string { return string(e) }
+
+// ServeHTTP serves the API from the seeded data.
+func (f *Fake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := versionPrefix.ReplaceAllString(r.URL.Path, "/")
+
+	f.mu.Lock()
+	f.requests = append(f.requests, Request{Method: r.Method, Path: path, Query: r.URL.Query()})
+	if f.MaxRequests > 0 && len(f.requests) > f.MaxRequests {
+		f.requests = append(f.requests[:0], f.requests[len(f.requests)-f.MaxRequests:]...)
+	}
+	delay := f.Latency
+	if f.Jitter > 0 {
+		delay += time.Duration(f.rng.Int63n(int64(f.Jitter)))
+	}
+	status := 0
+	if len(f.failNext) > 0 {
+		status, f.failNext = f.failNext[0], f.failNext[1:]
+	} else if f.ErrorRate > 0 && f.rng.Float64() < f.ErrorRate {
+		status = f.ErrorStatus
+	}
+	f.mu.Unlock()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		}
+	}
+	if status != 0 {
+		writeError(w, status, "injected error")
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	f.mu.Lock()
+	v, err := f.route(strings.Split(strings.Trim(path, "/"), "/"), r.URL.Query())
+	f.mu.Unlock()
+
+	switch err.(type) {
+	case nil:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	case errBadRequest:
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errNotFound:
+		writeError(w, http.StatusNotFound, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// route returns the response to a request for the path segments.
+func (f *Fake) route(seg []string, q url.Values) (interface{}, error) {
+	switch {
+	case len(seg) == 1 && seg[0] == "networks":
+		return f.listNetworks(), nil
+	case len(seg) == 1 && seg[0] == "pools":
+		return f.listPools(q, f.pools)
+	case len(seg) == 1 && seg[0] == "search":
+		return f.search(q), nil
+	case len(seg) == 1 && seg[0] == "stats":
+		return f.getStats(), nil
+	case len(seg) < 3 || seg[0] != "networks":
+		return nil, errNotFound("not found")
+	}
+
+	networkID := seg[1]
+	if !f.hasNetwork(networkID) {
+		return nil, errNotFound("network not found")
+	}
+	switch {
+	case len(seg) == 3 && seg[2] == "dexes":
+		return f.listDexes(networkID, q)
+	case len(seg) == 3 && seg[2] == "pools":
+		return f.listPools(q, f.filterPools(func(p dexpaprika.Pool) bool { return p.Chain == networkID }))
+	case len(seg) == 5 && seg[2] == "dexes" && seg[4] == "pools":
+		return f.listPools(q, f.filterPools(func(p dexpaprika.Pool) bool { return p.Chain == networkID && p.DexID == seg[3] }))
+	case len(seg) >= 4 && seg[2] == "pools":
+		key := dexpaprika.NewEntityKey(networkID, seg[3])
+		switch {
+		case len(seg) == 4:
+			pool, ok := f.pool(key)
+			if !ok {
+				return nil, errNotFound("pool not found")
+			}
+			return f.poolDetails(pool, q.Get("inversed") == "true"), nil
+		case len(seg) == 5 && seg[4] == "ohlcv":
+			return f.getOHLCV(key, q)
+		case len(seg) == 5 && seg[4] == "transactions":
+			return paginateTransactions(f.transactions[key], q)
+		}
+	case len(seg) >= 4 && seg[2] == "tokens":
+		key := dexpaprika.NewEntityKey(networkID, seg[3])
+		token, ok := f.tokens[key]
+		if !ok {
+			return nil, errNotFound("token not found")
+		}
+		switch {
+		case len(seg) == 4:
+			return f.tokenDetails(token), nil
+		case len(seg) == 5 && seg[4] == "pools":
+			pools := f.tokenPools(key)
+			if other := q.Get("address"); other != "" {
+				pools = f.filterPools(func(p dexpaprika.Pool) bool {
+					return containsToken(p, key) && containsToken(p, dexpaprika.NewEntityKey(networkID, other))
+				})
+			}
+			return f.listPools(q, pools)
+		case len(seg) == 5 && seg[4] == "transactions":
+			var txs []dexpaprika.Transaction
+			for _, p := range f.tokenPools(key) {
+				txs = append(txs, f.transactions[p.Key()]...)
+			}
+			sortTransactions(txs)
+			return paginateTransactions(txs, q)
+		}
+	}
+	return nil, errNotFound("not found")
+}
+
+func (f *Fake) hasNetwork(id string) bool {
+	for _, n := range f.networks {
+		if n.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Fake) listNetworks() []dexpaprika.Network {
+	return append([]dexpaprika.Network{}, f.networks...)
+}
+
+func (f *Fake) listDexes(networkID string, q url.Values) (*dexpaprika.DexesResponse, error) {
+	dexes := f.dexes[networkID]
+	start, end, info, err := paginate(q, len(dexes), maxLimit)
+	if err != nil {
+		return nil, err
+	}
+	return &dexpaprika.DexesResponse{Dexes: append([]dexpaprika.Dex{}, dexes[start:end]...), PageInfo: info}, nil
+}
+
+func (f *Fake) filterPools(keep func(dexpaprika.Pool) bool) []dexpaprika.Pool {
+	var pools []dexpaprika.Pool
+	for _, p := range f.pools {
+		if keep(p) {
+			pools = append(pools, p)
+		}
+	}
+	return pools
+}
+
+func (f *Fake) tokenPools(token dexpaprika.EntityKey) []dexpaprika.Pool {
+	return f.filterPools(func(p dexpaprika.Pool) bool { return containsToken(p, token) })
+}
+
+func containsToken(p dexpaprika.Pool, token dexpaprika.EntityKey) bool {
+	for _, t := range p.Tokens {
+		if t.Key() == token {
+			return true
+		}
+	}
+	return false
+}
+
+// poolOrderings are the values of order_by accepted for pool listings.
+var poolOrderings = map[string]func(dexpaprika.Pool) float64{
+	"volume_usd":                func(p dexpaprika.Pool) float64 { return p.VolumeUSD },
+	"price_usd":                 func(p dexpaprika.Pool) float64 { return p.PriceUSD },
+	"transactions":              func(p dexpaprika.Pool) float64 { return float64(p.Transactions) },
+	"last_price_change_usd_24h": func(p dexpaprika.Pool) float64 { return p.LastPriceChangeUSD24h },
+	"created_at": func(p dexpaprika.Pool) float64 {
+		t, _ := time.Parse(time.RFC3339, p.CreatedAt)
+		return float64(t.Unix())
+	},
+}
+
+// listPools sorts and paginates pools as the pool listings do, by 24h
+// volume descending unless order_by and sort say otherwise.
+func (f *Fake) listPools(q url.Values, pools []dexpaprika.Pool) (*dexpaprika.PoolsResponse, error) {
+	orderBy := q.Get("order_by")
+	if orderBy == "" {
+		orderBy = "volume_usd"
+	}
+	value, ok := poolOrderings[orderBy]
+	if !ok {
+		return nil, errBadRequest("invalid order_by: " + orderBy)
+	}
+	desc := true
+	switch q.Get("sort") {
+	case "", "desc":
+	case "asc":
+		desc = false
+	default:
+		return nil, errBadRequest("invalid sort: " + q.Get("sort"))
+	}
+
+	sorted := append([]dexpaprika.Pool(nil), pools...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if desc {
+			return value(sorted[i]) > value(sorted[j])
+		}
+		return value(sorted[i]) < value(sorted[j])
+	})
+
+	start, end, info, err := paginate(q, len(sorted), maxLimit)
+	if err != nil {
+		return nil, err
+	}
+	return &dexpaprika.PoolsResponse{Pools: append([]dexpaprika.Pool{}, sorted[start:end]...), PageInfo: info}, nil
+}
+
+// poolDetails returns the seeded details of a pool, or details derived from
+// its listing, in the requested orientation.
+func (f *Fake) poolDetails(p dexpaprika.Pool, inversed bool) dexpaprika.PoolDetails {
+	d, ok := f.details[p.Key()]
+	if !ok {
+		d = dexpaprika.PoolDetails{
+			ID:                   p.ID,
+			CreatedAtBlockNumber: p.CreatedAtBlockNumber,
+			Chain:                p.Chain,
+			CreatedAt:            p.CreatedAt,
+			DexID:                p.DexID,
+			DexName:              p.DexName,
+			Tokens:               p.Tokens,
+			LastPriceUSD:         p.PriceUSD,
+			Fee:                  p.Fee,
+			PriceTime:            time.Now().UTC().Format(time.RFC3339),
+			Day:                  dexpaprika.TimeIntervalMetrics{VolumeUSD: p.VolumeUSD, Txns: p.Transactions, LastPriceUSDChange: p.LastPriceChangeUSD24h},
+			Hour1:                dexpaprika.TimeIntervalMetrics{LastPriceUSDChange: p.LastPriceChangeUSD1h},
+			Minute5:              dexpaprika.TimeIntervalMetrics{LastPriceUSDChange: p.LastPriceChangeUSD5m},
+		}
+	}
+	if !inversed {
+		return d
+	}
+
+	// Quote the first token in the second instead
+	tokens := make([]dexpaprika.Token, len(d.Tokens))
+	for i, t := range d.Tokens {
+		tokens[len(tokens)-1-i] = t
+	}
+	d.Tokens = tokens
+	if d.LastPrice != 0 {
+		d.LastPriceUSD /= d.LastPrice
+		d.LastPrice = 1 / d.LastPrice
+	}
+	return d
+}
+
+func (f *Fake) getOHLCV(pool dexpaprika.EntityKey, q url.Values) ([]dexpaprika.OHLCVRecord, error) {
+	if q.Get("start") == "" {
+		return nil, errBadRequest("start is required")
+	}
+	start, err := parseTime(q.Get("start"))
+	if err != nil {
+		return nil, errBadRequest("invalid start: " + err.Error())
+	}
+	var end time.Time
+	if s := q.Get("end"); s != "" {
+		if end, err = parseTime(s); err != nil {
+			return nil, errBadRequest("invalid end: " + err.Error())
+		}
+	}
+	limit := 1
+	if s := q.Get("limit"); s != "" {
+		if limit, err = strconv.Atoi(s); err != nil || limit < 1 || limit > maxOHLCVLimit {
+			return nil, errBadRequest(fmt.Sprintf("limit must be between 1 and %d", maxOHLCVLimit))
+		}
+	}
+	interval := q.Get("interval")
+	if interval == "" {
+		interval = dexpaprika.Interval24h
+	}
+
+	records := []dexpaprika.OHLCVRecord{}
+	for _, r := range f.ohlcv[ohlcvKey(pool, interval)] {
+		t, err := r.OpenTime()
+		if err != nil || t.Before(start) || (!end.IsZero() && t.After(end)) {
+			continue
+		}
+		if q.Get("inversed") == "true" {
+			r = inverseCandle(r)
+		}
+		records = append(records, r)
+		if len(records) == limit {
+			break
+		}
+	}
+	return records, nil
+}
+
+// inverseCandle quotes a candle in the other token of its pool.
+func inverseCandle(r dexpaprika.OHLCVRecord) dexpaprika.OHLCVRecord {
+	inv := func(v float64) float64 {
+		if v == 0 {
+			return 0
+		}
+		return 1 / v
+	}
+	r.Open, r.Close = inv(r.Open), inv(r.Close)
+	r.High, r.Low = inv(r.Low), inv(r.High)
+	return r
+}
+
+// parseTime accepts the time formats of the OHLCV endpoint: RFC 3339, a
+// date, or Unix seconds.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unrecognized time %q", s)
+	}
+	return time.Unix(sec, 0).UTC(), nil
+}
+
+// paginateTransactions pages through transactions, continuing after the
+// transaction with the ID given as cursor if there is one.
+func paginateTransactions(txs []dexpaprika.Transaction, q url.Values) (*dexpaprika.TransactionsResponse, error) {
+	if cursor := q.Get("cursor"); cursor != "" {
+		for i, tx := range txs {
+			if tx.ID == cursor {
+				txs = txs[i+1:]
+				q = url.Values{"limit": q["limit"]}
+				break
+			}
+		}
+	}
+	start, end, info, err := paginate(q, len(txs), maxLimit)
+	if err != nil {
+		return nil, err
+	}
+	return &dexpaprika.TransactionsResponse{Transactions: append([]dexpaprika.Transaction{}, txs[start:end]...), PageInfo: info}, nil
+}
+
+// tokenDetails returns a seeded token, with a summary derived from its
+// pools unless one was seeded.
+func (f *Fake) tokenDetails(t dexpaprika.TokenDetails) dexpaprika.TokenDetails {
+	if t.Summary != nil {
+		return t
+	}
+	pools := f.tokenPools(t.Key())
+	summary := &dexpaprika.TokenSummary{Pools: new(int)}
+	*summary.Pools = len(pools)
+	var volume float64
+	var txns int
+	for _, p := range pools {
+		if len(p.Tokens) > 0 && p.Tokens[0].Key() == t.Key() && summary.PriceUSD == 0 {
+			summary.PriceUSD = p.PriceUSD
+		}
+		volume += p.VolumeUSD
+		txns += p.Transactions
+	}
+	summary.Day = &dexpaprika.TimeIntervalMetrics{VolumeUSD: volume, Txns: txns}
+	t.Summary = summary
+	return t
+}
+
+// search matches the query, case-insensitively, against the IDs, names and
+// symbols of tokens, pools and DEXes.
+func (f *Fake) search(q url.Values) *dexpaprika.SearchResult {
+	// The SDK escapes the query before encoding it
+	query := q.Get("query")
+	if unescaped, err := url.QueryUnescape(query); err == nil {
+		query = unescaped
+	}
+	query = strings.ToLower(query)
+	matches := func(values ...string) bool {
+		for _, v := range values {
+			if query != "" && strings.Contains(strings.ToLower(v), query) {
+				return true
+			}
+		}
+		return false
+	}
+
+	result := &dexpaprika.SearchResult{Tokens: []dexpaprika.SearchToken{}, Pools: []dexpaprika.SearchPool{}, Dexes: []dexpaprika.DexInfo{}}
+	keys := make([]dexpaprika.EntityKey, 0, len(f.tokens))
+	for key := range f.tokens {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	for _, key := range keys {
+		t := f.tokenDetails(f.tokens[key])
+		if matches(t.ID, t.Name, t.Symbol) {
+			result.Tokens = append(result.Tokens, dexpaprika.SearchToken{
+				ID: t.ID, Name: t.Name, Symbol: t.Symbol, Chain: t.Chain, Decimals: t.Decimals,
+				TotalSupply: t.TotalSupply, Description: t.Description, Website: t.Website,
+				Explorer: t.Explorer, PriceUSD: t.Summary.PriceUSD, LiquidityUSD: t.Summary.LiquidityUSD,
+			})
+		}
+	}
+	for _, p := range f.pools {
+		values := []string{p.ID, p.DexID, p.DexName}
+		for _, t := range p.Tokens {
+			values = append(values, t.Symbol, t.Name)
+		}
+		if matches(values...) {
+			result.Pools = append(result.Pools, dexpaprika.SearchPool{
+				ID: p.ID, DexID: p.DexID, DexName: p.DexName, Chain: p.Chain, VolumeUSD: p.VolumeUSD,
+				CreatedAt: p.CreatedAt, CreatedAtBlockNumber: p.CreatedAtBlockNumber, Transactions: p.Transactions,
+				PriceUSD: p.PriceUSD, LastPriceChangeUSD5m: p.LastPriceChangeUSD5m, LastPriceChangeUSD1h: p.LastPriceChangeUSD1h,
+				LastPriceChangeUSD24h: p.LastPriceChangeUSD24h, Fee: p.Fee, Tokens: p.Tokens,
+			})
+		}
+	}
+	for _, n := range f.networks {
+		for _, d := range f.dexes[n.ID] {
+			if matches(d.ID, d.Name) {
+				result.Dexes = append(result.Dexes, dexpaprika.DexInfo{
+					ID: d.ID, DexID: d.ID, DexName: d.Name, Chain: d.Chain, Protocol: d.Protocol,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// getStats returns the seeded stats, or counts of the seeded data.
+func (f *Fake) getStats() dexpaprika.Stats {
+	if f.stats != nil {
+		return *f.stats
+	}
+	stats := dexpaprika.Stats{Chains: len(f.networks), Pools: len(f.pools), Tokens: len(f.tokens)}
+	for _, dexes := range f.dexes {
+		stats.Factories += len(dexes)
+	}
+	return stats
+}
+
+// paginate returns the bounds of the requested page of n items.
+func paginate(q url.Values, n, max int) (start, end int, info dexpaprika.PageInfo, err error) {
+	page, limit := 0, defaultLimit
+	if s := q.Get("page"); s != "" {
+		if page, err = strconv.Atoi(s); err != nil || page < 0 {
+			return 0, 0, info, errBadRequest("invalid page: " + s)
+		}
+	}
+	if s := q.Get("limit"); s != "" {
+		if limit, err = strconv.Atoi(s); err != nil || limit < 1 || limit > max {
+			return 0, 0, info, errBadRequest(fmt.Sprintf("limit must be between 1 and %d", max))
+		}
+	}
+	info = dexpaprika.PageInfo{Limit: limit, Page: page, TotalItems: n, TotalPages: (n + limit - 1) / limit}
+	start = min(page*limit, n)
+	end = min(start+limit, n)
+	return start, end, info, nil
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
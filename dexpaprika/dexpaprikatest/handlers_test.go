@@ -0,0 +1,182 @@
+package dexpaprikatest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func seededFake() *Fake {
+	fake := NewFake()
+	weth := dexpaprika.Token{ID: "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", Symbol: "WETH", Name: "Wrapped Ether", Decimals: 18}
+	usdc := dexpaprika.Token{ID: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", Symbol: "USDC", Name: "USD Coin", Decimals: 6}
+	wbtc := dexpaprika.Token{ID: "0x2260fac5e5542a773aa44fbcfedf7c193bc2c599", Symbol: "WBTC", Decimals: 8}
+	fake.AddPool(dexpaprika.Pool{ID: "0xpool1", Chain: "ethereum", DexID: "uniswap_v3", DexName: "Uniswap V3", VolumeUSD: 100, PriceUSD: 3000, Tokens: []dexpaprika.Token{weth, usdc}})
+	fake.AddPool(dexpaprika.Pool{ID: "0xpool2", Chain: "ethereum", DexID: "sushiswap", VolumeUSD: 300, PriceUSD: 60000, Tokens: []dexpaprika.Token{wbtc, weth}})
+	fake.AddPool(dexpaprika.Pool{ID: "0xpool3", Chain: "ethereum", DexID: "uniswap_v3", VolumeUSD: 200, PriceUSD: 3010, Tokens: []dexpaprika.Token{weth, usdc}})
+	fake.AddPool(dexpaprika.Pool{ID: "pool4", Chain: "solana", DexID: "raydium", VolumeUSD: 50})
+	fake.AddTransactions("ethereum", "0xpool1",
+		dexpaprika.Transaction{ID: "a", CreatedAt: "2024-01-01T00:00:01Z"},
+		dexpaprika.Transaction{ID: "c", CreatedAt: "2024-01-01T00:00:03Z"},
+	)
+	fake.AddTransactions("ethereum", "0xpool2", dexpaprika.Transaction{ID: "b", CreatedAt: "2024-01-01T00:00:02Z"})
+	fake.AddOHLCV("ethereum", "0xpool1", "1h",
+		dexpaprika.OHLCVRecord{TimeOpen: "2024-01-01T01:00:00Z", Open: 2, High: 4, Low: 1, Close: 2},
+		dexpaprika.OHLCVRecord{TimeOpen: "2024-01-01T00:00:00Z", Open: 1, High: 2, Low: 1, Close: 2},
+	)
+	return fake
+}
+
+func TestFake_Pools(t *testing.T) {
+	client := seededFake().Start(t)
+	ctx := context.Background()
+
+	all, err := client.Pools.List(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Pools) != 4 || all.Pools[0].ID != "0xpool2" || all.PageInfo.TotalItems != 4 {
+		t.Errorf("Expected all pools by volume, got %+v", all)
+	}
+
+	page, err := client.Pools.ListByNetwork(ctx, "ethereum", &dexpaprika.ListOptions{Page: 1, Limit: 2, OrderBy: "price_usd", Sort: "asc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Pools) != 1 || page.Pools[0].ID != "0xpool2" || page.PageInfo.TotalPages != 2 {
+		t.Errorf("Expected the second page by ascending price, got %+v", page)
+	}
+
+	byDex, err := client.Pools.ListByDex(ctx, "ethereum", "uniswap_v3", nil)
+	if err != nil || len(byDex.Pools) != 2 {
+		t.Errorf("Expected 2 uniswap_v3 pools, got %v, %v", byDex, err)
+	}
+
+	if _, err := client.Pools.ListByNetwork(ctx, "ethereum", &dexpaprika.ListOptions{OrderBy: "bogus"}); !errors.Is(err, dexpaprika.ErrBadRequest) {
+		t.Errorf("Expected a bad request for an unknown order, got %v", err)
+	}
+	if _, err := client.Pools.ListByNetwork(ctx, "unknown", nil); !errors.Is(err, dexpaprika.ErrNotFound) {
+		t.Errorf("Expected not found for an unknown network, got %v", err)
+	}
+}
+
+func TestFake_PoolDetails(t *testing.T) {
+	client := seededFake().Start(t)
+	ctx := context.Background()
+
+	details, err := client.Pools.GetDetails(ctx, "ethereum", "0xpool1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.LastPriceUSD != 3000 || details.Tokens[0].Symbol != "WETH" || details.Day.VolumeUSD != 100 {
+		t.Errorf("Unexpected details: %+v", details)
+	}
+
+	inversed, err := client.Pools.GetDetails(ctx, "ethereum", "0xpool1", &dexpaprika.PoolDetailsOptions{QuoteToken: "WETH"})
+	if err != nil || inversed.Tokens[0].Symbol != "USDC" {
+		t.Errorf("Expected USDC first when quoted in WETH, got %+v, %v", inversed, err)
+	}
+
+	if _, err := client.Pools.GetDetails(ctx, "ethereum", "0xmissing", nil); !errors.Is(err, dexpaprika.ErrNotFound) {
+		t.Errorf("Expected not found, got %v", err)
+	}
+}
+
+func TestFake_OHLCV(t *testing.T) {
+	client := seededFake().Start(t)
+	ctx := context.Background()
+
+	candles, err := client.Pools.GetOHLCV(ctx, "ethereum", "0xpool1", &dexpaprika.OHLCVOptions{Start: "2024-01-01T00:00:00Z", Interval: "1h", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 2 || candles[0].Open != 1 {
+		t.Errorf("Expected both candles in order, got %+v", candles)
+	}
+
+	inversed, err := client.Pools.GetOHLCV(ctx, "ethereum", "0xpool1", &dexpaprika.OHLCVOptions{Start: "2024-01-01T01:00:00Z", Interval: "1h", Inversed: true})
+	if err != nil || len(inversed) != 1 || inversed[0].High != 1 || inversed[0].Low != 0.25 {
+		t.Errorf("Expected the inversed second candle, got %+v, %v", inversed, err)
+	}
+
+	if _, err := client.Pools.GetOHLCV(ctx, "ethereum", "0xpool1", nil); !errors.Is(err, dexpaprika.ErrBadRequest) {
+		t.Errorf("Expected a bad request without start, got %v", err)
+	}
+}
+
+func TestFake_Transactions(t *testing.T) {
+	client := seededFake().Start(t)
+	ctx := context.Background()
+
+	var ids []string
+	p := dexpaprika.NewTransactionsPaginator(client, "ethereum", "0xpool1", 1)
+	for p.HasNextPage() {
+		if err := p.GetNextPage(ctx); err != nil {
+			t.Fatal(err)
+		}
+		for _, tx := range p.GetCurrentPage() {
+			ids = append(ids, tx.ID)
+		}
+	}
+	if len(ids) != 2 || ids[0] != "c" || ids[1] != "a" {
+		t.Errorf("Expected the pool's transactions newest first, got %v", ids)
+	}
+
+	txs, err := client.Tokens.GetTransactions(ctx, "ethereum", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", nil)
+	if err != nil || len(txs.Transactions) != 3 || txs.Transactions[1].ID != "b" {
+		t.Errorf("Expected the token's transactions across pools, got %+v, %v", txs, err)
+	}
+}
+
+func TestFake_Tokens(t *testing.T) {
+	client := seededFake().Start(t)
+	ctx := context.Background()
+
+	token, err := client.Tokens.GetDetails(ctx, "ethereum", "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Symbol != "WETH" || token.Summary == nil || *token.Summary.Pools != 3 || token.Summary.PriceUSD != 3000 {
+		t.Errorf("Unexpected token: %+v", token)
+	}
+
+	pair, err := client.Pools.GetByTokenPair(ctx, "ethereum", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", nil)
+	if err != nil || len(pair.Pools) != 2 || pair.Pools[0].ID != "0xpool3" {
+		t.Errorf("Expected the WETH/USDC pools by volume, got %+v, %v", pair, err)
+	}
+
+	price, err := client.Prices.Get(ctx, "ethereum", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2")
+	if err != nil || price.PriceUSD <= 0 {
+		t.Errorf("Expected a derived price, got %+v, %v", price, err)
+	}
+}
+
+func TestFake_SearchAndStats(t *testing.T) {
+	fake := seededFake()
+	client := fake.Start(t)
+	ctx := context.Background()
+
+	result, err := client.Search.Search(ctx, "wrapped ether")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Tokens) != 1 || result.Tokens[0].Symbol != "WETH" || len(result.Pools) != 3 {
+		t.Errorf("Unexpected search result: %+v", result)
+	}
+
+	stats, err := client.Utils.GetStats(ctx)
+	if err != nil || stats.Chains != 2 || stats.Pools != 4 || stats.Tokens != 3 || stats.Factories != 3 {
+		t.Errorf("Unexpected stats: %+v, %v", stats, err)
+	}
+	fake.SetStats(dexpaprika.Stats{Pools: 1})
+	if stats, _ := client.Utils.GetStats(ctx); stats.Pools != 1 {
+		t.Errorf("Expected the seeded stats, got %+v", stats)
+	}
+
+	networks, err := client.Networks.List(ctx)
+	if err != nil || len(networks) != 2 {
+		t.Errorf("Expected the pools' networks, got %v, %v", networks, err)
+	}
+}
@@ -0,0 +1,95 @@
+package dexpaprika
+
+// FieldChange is a field whose value differs between two versions of a
+// model. Fields are named after flattened columns (see Flatten), e.g.
+// "price_usd", "24h_volume_usd" or "token0_symbol".
+type FieldChange struct {
+	Field string
+	Old   interface{} // Nil if the field is new
+	New   interface{} // Nil if the field was removed
+}
+
+// DiffRecords returns the fields whose formatted values differ between two
+// records, in the column order of newer followed by columns only in older.
+func DiffRecords(older, newer Record) []FieldChange {
+	oldValues := make(map[string]interface{}, len(older))
+	for _, c := range older {
+		oldValues[c.Name] = c.Value
+	}
+
+	var changes []FieldChange
+	seen := make(map[string]bool, len(newer))
+	for _, c := range newer {
+		seen[c.Name] = true
+		old, ok := oldValues[c.Name]
+		if !ok || formatValue(old) != formatValue(c.Value) {
+			changes = append(changes, FieldChange{Field: c.Name, Old: old, New: c.Value})
+		}
+	}
+	for _, c := range older {
+		if !seen[c.Name] {
+			changes = append(changes, FieldChange{Field: c.Name, Old: c.Value})
+		}
+	}
+	return changes
+}
+
+// PoolChange is a pool present in both snapshots with different fields.
+type PoolChange struct {
+	Old     Pool
+	New     Pool
+	Changes []FieldChange
+}
+
+// Changed reports whether the named field changed.
+func (c PoolChange) Changed(field string) bool {
+	for _, f := range c.Changes {
+		if f.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// PoolsDiff is the difference between two snapshots of a pool listing.
+type PoolsDiff struct {
+	Added   []Pool       // Pools only in the newer snapshot, in its order
+	Removed []Pool       // Pools only in the older snapshot, in its order
+	Changed []PoolChange // Pools in both with field changes, in the newer order
+}
+
+// Empty reports whether the snapshots were identical.
+func (d *PoolsDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffPools compares two sequential snapshots of a pool listing, taken with
+// the same filters. Pools are matched by Key, so address casing does not
+// matter, and changes are reported per flattened field.
+func DiffPools(older, newer []Pool) *PoolsDiff {
+	oldByKey := make(map[EntityKey]Pool, len(older))
+	for _, p := range older {
+		oldByKey[p.Key()] = p
+	}
+
+	diff := &PoolsDiff{}
+	newKeys := make(map[EntityKey]bool, len(newer))
+	for _, p := range newer {
+		key := p.Key()
+		newKeys[key] = true
+		old, ok := oldByKey[key]
+		if !ok {
+			diff.Added = append(diff.Added, p)
+			continue
+		}
+		if changes := DiffRecords(old.Flatten(), p.Flatten()); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, PoolChange{Old: old, New: p, Changes: changes})
+		}
+	}
+	for _, p := range older {
+		if !newKeys[p.Key()] {
+			diff.Removed = append(diff.Removed, p)
+		}
+	}
+	return diff
+}
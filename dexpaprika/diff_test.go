@@ -0,0 +1,64 @@
+package dexpaprika
+
+import (
+	"testing"
+)
+
+func TestDiffPools(t *testing.T) {
+	weth := Token{ID: "0xc02a", Symbol: "WETH"}
+	usdc := Token{ID: "0xa0b8", Symbol: "USDC"}
+	older := []Pool{
+		{ID: "0xAAA", Chain: "ethereum", PriceUSD: 3000, VolumeUSD: 1e6, Tokens: []Token{weth, usdc}},
+		{ID: "0xbbb", Chain: "ethereum", PriceUSD: 1, Tokens: []Token{usdc}},
+		{ID: "0xccc", Chain: "ethereum", PriceUSD: 2},
+	}
+	newer := []Pool{
+		{ID: "0xddd", Chain: "ethereum", PriceUSD: 5},
+		{ID: "0xaaa", Chain: "ethereum", PriceUSD: 3100, VolumeUSD: 1e6, Tokens: []Token{weth, usdc}},
+		{ID: "0xccc", Chain: "ethereum", PriceUSD: 2},
+	}
+
+	diff := DiffPools(older, newer)
+	if len(diff.Added) != 1 || diff.Added[0].ID != "0xddd" {
+		t.Errorf("Added = %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].ID != "0xbbb" {
+		t.Errorf("Removed = %v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 {
+		t.Fatalf("Changed = %v", diff.Changed)
+	}
+	c := diff.Changed[0]
+	if len(c.Changes) != 2 || !c.Changed("price_usd") || !c.Changed("id") || c.Changed("volume_usd") {
+		t.Errorf("Unexpected field changes %+v", c.Changes)
+	}
+	if diff.Empty() {
+		t.Error("Expected a non-empty diff")
+	}
+	if !DiffPools(newer, newer).Empty() {
+		t.Error("Expected identical snapshots to produce an empty diff")
+	}
+}
+
+func TestDiffRecords(t *testing.T) {
+	fdv := 1.0
+	older := Token{ID: "0xabc", Symbol: "ABC", FDV: &fdv}.Flatten()
+	newer := Token{ID: "0xabc", Symbol: "ABCD"}.Flatten()
+
+	changes := DiffRecords(older, newer)
+	if len(changes) != 2 {
+		t.Fatalf("Expected symbol and fdv changes, got %+v", changes)
+	}
+	if changes[0].Field != "symbol" || changes[0].Old != "ABC" || changes[0].New != "ABCD" {
+		t.Errorf("Unexpected symbol change %+v", changes[0])
+	}
+	if changes[1].Field != "fdv" {
+		t.Errorf("Unexpected fdv change %+v", changes[1])
+	}
+
+	extra := append(Record{}, older...)
+	extra = append(extra, Column{Name: "extra", Value: 1})
+	if changes := DiffRecords(extra, older); len(changes) != 1 || changes[0].Field != "extra" || changes[0].New != nil {
+		t.Errorf("Expected a removed column, got %+v", changes)
+	}
+}
@@ -0,0 +1,41 @@
+package dexpaprika
+
+import (
+	"context"
+	"time"
+)
+
+// recentlyCreatedPages caps the pool pages scanned by RecentlyCreated.
+const recentlyCreatedPages = 50
+
+// RecentlyCreated returns the pools on a network created after since, newest
+// first. Pools are requested in order of creation time and paging stops at
+// the first older pool, or after 5,000 pools.
+func (s *PoolsService) RecentlyCreated(ctx context.Context, networkID string, since time.Time) ([]Pool, error) {
+	var pools []Pool
+	opts := &ListOptions{Limit: 100, OrderBy: "created_at", Sort: "desc"}
+	for page := 0; page < recentlyCreatedPages; page++ {
+		opts.Page = page
+		resp, err := s.ListByNetwork(ctx, networkID, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		done := len(resp.Pools) < opts.Limit || page+1 >= resp.PageInfo.TotalPages
+		for _, p := range resp.Pools {
+			created, err := time.Parse(time.RFC3339, p.CreatedAt)
+			if err != nil {
+				continue
+			}
+			if !created.After(since) {
+				done = true
+				break
+			}
+			pools = append(pools, p)
+		}
+		if done {
+			break
+		}
+	}
+	return pools, nil
+}
@@ -0,0 +1,49 @@
+package dexpaprika
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/internal/httpassert"
+)
+
+func TestPools_RecentlyCreated(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		httpassert.Path(t, r, "/networks/solana/pools")
+		httpassert.Params(t, r, httpassert.Query{"order_by": "created_at", "sort": "desc"})
+		pages = append(pages, q.Get("page"))
+
+		// Each page holds 100 pools created a minute apart
+		page := 0
+		fmt.Sscan(q.Get("page"), &page)
+		var pools []string
+		for i := 0; i < 100; i++ {
+			created := now.Add(-time.Duration(page*100+i) * time.Minute)
+			pools = append(pools, fmt.Sprintf(`{"id": "p%d", "created_at": %q}`, page*100+i, created.Format(time.RFC3339)))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"pools": [%s], "page_info": {"limit": 100, "page": %d, "total_items": 100000, "total_pages": 1000}}`+"\n", strings.Join(pools, ","), page)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+
+	pools, err := client.Pools.RecentlyCreated(context.Background(), "solana", now.Add(-150*time.Minute))
+	if err != nil {
+		t.Fatalf("RecentlyCreated returned error: %v", err)
+	}
+	if len(pools) != 150 || pools[0].ID != "p0" || pools[149].ID != "p149" {
+		t.Errorf("Expected the 150 newest pools, got %d", len(pools))
+	}
+	if len(pages) != 2 {
+		t.Errorf("Expected paging to stop after 2 pages, got %v", pages)
+	}
+}
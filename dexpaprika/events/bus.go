@@ -0,0 +1,103 @@
+package events
+
+import "sync"
+
+// Bus delivers published events to subscribers. Handlers run synchronously
+// in the publisher's goroutine, in subscription order, so they should return
+// quickly and hand long work off to another goroutine. A nil *Bus discards
+// events, so producers can publish unconditionally.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []handler
+	nextID   int
+}
+
+type handler struct {
+	id int
+	fn func(Event)
+}
+
+// NewBus creates an empty bus.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Publish delivers e to every subscriber interested in its type.
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		h.fn(e)
+	}
+}
+
+// SubscribeAll registers fn for every event and returns a function that
+// removes the subscription.
+func (b *Bus) SubscribeAll(fn func(Event)) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	// Copy on write, so Publish can iterate without holding the lock
+	handlers := make([]handler, len(b.handlers), len(b.handlers)+1)
+	copy(handlers, b.handlers)
+	b.handlers = append(handlers, handler{id: id, fn: fn})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		handlers := make([]handler, 0, len(b.handlers))
+		for _, h := range b.handlers {
+			if h.id != id {
+				handlers = append(handlers, h)
+			}
+		}
+		b.handlers = handlers
+	}
+}
+
+// Subscribe registers fn for events of type E and returns a function that
+// removes the subscription.
+func Subscribe[E Event](b *Bus, fn func(E)) (unsubscribe func()) {
+	return b.SubscribeAll(func(e Event) {
+		if v, ok := e.(E); ok {
+			fn(v)
+		}
+	})
+}
+
+// Chan subscribes to events of type E and returns them on a channel with
+// the given buffer. Events published while the buffer is full are dropped,
+// so a slow consumer never blocks publishers. Call unsubscribe to stop
+// delivery and close the channel.
+func Chan[E Event](b *Bus, buffer int) (events <-chan E, unsubscribe func()) {
+	ch := make(chan E, buffer)
+	var mu sync.Mutex
+	closed := false
+	stop := Subscribe(b, func(e E) {
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case ch <- e:
+		default:
+		}
+	})
+	return ch, func() {
+		stop()
+		mu.Lock()
+		defer mu.Unlock()
+		if !closed {
+			closed = true
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package events
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBus(t *testing.T) {
+	b := NewBus()
+
+	var prices []float64
+	stopPrices := Subscribe(b, func(e PoolPriceChanged) { prices = append(prices, e.PriceUSD) })
+	var names []string
+	b.SubscribeAll(func(e Event) { names = append(names, e.EventName()) })
+
+	b.Publish(PoolPriceChanged{PriceUSD: 1})
+	b.Publish(StaleData{PoolAddress: "0xpool"})
+	stopPrices()
+	b.Publish(PoolPriceChanged{PriceUSD: 2})
+
+	if len(prices) != 1 || prices[0] != 1 {
+		t.Errorf("Typed subscriber got %v, want [1]", prices)
+	}
+	if want := "pool_price_changed stale_data pool_price_changed"; strings.Join(names, " ") != want {
+		t.Errorf("SubscribeAll got %q, want %q", strings.Join(names, " "), want)
+	}
+
+	var nilBus *Bus
+	nilBus.Publish(AlertFired{Name: "ignored"})
+}
+
+func TestChan(t *testing.T) {
+	b := NewBus()
+	ch, stop := Chan[NewTransaction](b, 2)
+
+	for i := 0; i < 3; i++ {
+		b.Publish(NewTransaction{PoolAddress: string(rune('a' + i))})
+	}
+	b.Publish(NewPool{})
+	stop()
+	stop()
+	b.Publish(NewTransaction{})
+
+	var got []string
+	for e := range ch {
+		got = append(got, e.PoolAddress)
+	}
+	if strings.Join(got, " ") != "a b" {
+		t.Errorf("Expected the buffered events and drops beyond the buffer, got %v", got)
+	}
+}
+
+func TestBus_Concurrent(t *testing.T) {
+	b := NewBus()
+	var mu sync.Mutex
+	n := 0
+	Subscribe(b, func(AlertFired) { mu.Lock(); n++; mu.Unlock() })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stop := b.SubscribeAll(func(Event) {})
+			b.Publish(AlertFired{})
+			stop()
+		}()
+	}
+	wg.Wait()
+	if n != 10 {
+		t.Errorf("Expected 10 deliveries, got %d", n)
+	}
+}
@@ -0,0 +1,90 @@
+// Package events provides a typed event bus that decouples the SDK's
+// producers, such as watchers, from the applications consuming their events.
+package events
+
+import (
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// Event is implemented by all events published on a Bus.
+type Event interface {
+	// EventName returns a short, stable name such as "pool_price_changed".
+	EventName() string
+}
+
+// PoolPriceChanged is published when a pool's USD price changes.
+type PoolPriceChanged struct {
+	NetworkID        string    `json:"network_id"`
+	PoolAddress      string    `json:"pool_address"`
+	PriceUSD         float64   `json:"price_usd"`
+	PreviousPriceUSD float64   `json:"previous_price_usd"`
+	ChangePercent    float64   `json:"change_percent"`
+	Time             time.Time `json:"time"`
+}
+
+// TokenPriceChanged is published when a token's USD price moves past a
+// watcher's threshold.
+type TokenPriceChanged struct {
+	NetworkID        string    `json:"network_id"`
+	TokenAddress     string    `json:"token_address"`
+	Symbol           string    `json:"symbol"`
+	PriceUSD         float64   `json:"price_usd"`
+	PreviousPriceUSD float64   `json:"previous_price_usd"`
+	ChangePercent    float64   `json:"change_percent"`
+	Time             time.Time `json:"time"`
+}
+
+// NewPool is published by watch.PoolDiscoveryWatcher when a pool appears
+// that was not seen before.
+type NewPool struct {
+	NetworkID string          `json:"network_id"`
+	Pool      dexpaprika.Pool `json:"pool"`
+	Time      time.Time       `json:"time"`
+}
+
+// NewTransaction is published for each new transaction of a followed pool.
+type NewTransaction struct {
+	NetworkID   string                 `json:"network_id"`
+	PoolAddress string                 `json:"pool_address"`
+	Transaction dexpaprika.Transaction `json:"transaction"`
+	Time        time.Time              `json:"time"`
+}
+
+// AlertFired is published when an alert rule matches, e.g. for each
+// message delivered through notify.Bus.
+type AlertFired struct {
+	Name    string            `json:"name"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	Time    time.Time         `json:"time"`
+}
+
+// StaleData is published when data returned by the API is older than an
+// acceptable age, e.g. a pool whose price_time lags behind.
+type StaleData struct {
+	NetworkID   string        `json:"network_id"`
+	PoolAddress string        `json:"pool_address"`
+	DataTime    time.Time     `json:"data_time"` // When the data was last updated by the API
+	Age         time.Duration `json:"age"`
+	Time        time.Time     `json:"time"`
+}
+
+// EventName implements Event.
+func (PoolPriceChanged) EventName() string { return "pool_price_changed" }
+
+// EventName implements Event.
+func (TokenPriceChanged) EventName() string { return "token_price_changed" }
+
+// EventName implements Event.
+func (NewPool) EventName() string { return "new_pool" }
+
+// EventName implements Event.
+func (NewTransaction) EventName() string { return "new_transaction" }
+
+// EventName implements Event.
+func (AlertFired) EventName() string { return "alert_fired" }
+
+// EventName implements Event.
+func (StaleData) EventName() string { return "stale_data" }
@@ -0,0 +1,165 @@
+package dexpaprika_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http/httptest"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/dexpaprikatest"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/fixtures"
+)
+
+// newClient returns a client of a fake API serving the checked-in fixtures,
+// so the examples run offline with stable output. Outside of examples, use
+// dexpaprika.NewClient() to reach the live API.
+func newClient(opts ...dexpaprika.ClientOption) (*dexpaprika.Client, func()) {
+	fake := dexpaprikatest.NewFake()
+	fixtures.Seed(fake)
+	server := httptest.NewServer(fake)
+	client := dexpaprika.NewClient(append([]dexpaprika.ClientOption{dexpaprika.WithBaseURL(server.URL)}, opts...)...)
+	return client, func() {
+		client.Close()
+		server.Close()
+	}
+}
+
+// This example lists the supported networks and the top pools by volume.
+func Example_basic() {
+	client, done := newClient()
+	defer done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	networks, err := client.Networks.List(ctx)
+	if err != nil {
+		log.Fatalf("Failed to get networks: %v", err)
+	}
+	fmt.Printf("Found %d supported networks\n", len(networks))
+
+	pools, err := client.Pools.List(ctx, &dexpaprika.ListOptions{
+		Limit:   2,
+		OrderBy: "volume_usd",
+		Sort:    "desc",
+	})
+	if err != nil {
+		log.Fatalf("Failed to get pools: %v", err)
+	}
+	for _, pool := range pools.Pools {
+		fmt.Printf("%s/%s on %s (%s)\n", pool.Tokens[0].Symbol, pool.Tokens[1].Symbol, pool.DexName, pool.Chain)
+	}
+	// Output:
+	// Found 3 supported networks
+	// USDC/WETH on Uniswap V3 (ethereum)
+	// WETH/USDT on Uniswap V3 (ethereum)
+}
+
+// This example walks every endpoint of the API: networks and their DEXes,
+// pools with their details, candles and transactions, tokens, search and
+// stats.
+func Example_comprehensive() {
+	client, done := newClient(dexpaprika.WithRetryConfig(3, time.Second, 5*time.Second))
+	defer done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	const network = fixtures.Network
+	networks, err := client.Networks.List(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("networks:", len(networks))
+
+	dexes, err := client.Networks.ListDexes(ctx, network, 0, 5)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("dexes on ethereum:", len(dexes.Dexes))
+
+	pools, err := client.Pools.ListByNetwork(ctx, network, &dexpaprika.ListOptions{Limit: 5, OrderBy: "volume_usd", Sort: "desc"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("pools on ethereum:", len(pools.Pools))
+
+	details, err := client.Pools.GetDetails(ctx, network, fixtures.PoolAddress, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("pool %s/%s on %s\n", details.Tokens[0].Symbol, details.Tokens[1].Symbol, details.DexName)
+
+	candles, err := client.Pools.GetOHLCV(ctx, network, fixtures.PoolAddress, &dexpaprika.OHLCVOptions{
+		Start:    "2024-06-01",
+		Interval: fixtures.OHLCVInterval,
+		Limit:    3,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("candles:", len(candles))
+
+	txs, err := client.Pools.GetTransactions(ctx, network, fixtures.PoolAddress, 0, 3, "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("transactions:", len(txs.Transactions))
+
+	token, err := client.Tokens.GetDetails(ctx, network, fixtures.TokenAddress)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("token %s (%s)\n", token.Name, token.Symbol)
+
+	tokenPools, err := client.Tokens.GetPools(ctx, network, fixtures.TokenAddress, &dexpaprika.ListOptions{Limit: 3}, "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("pools with WETH:", len(tokenPools.Pools))
+
+	results, err := client.Search.Search(ctx, "uniswap")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("search: %d tokens, %d pools, %d dexes\n", len(results.Tokens), len(results.Pools), len(results.Dexes))
+
+	stats, err := client.Utils.GetStats(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("stats: %d chains, %d pools\n", stats.Chains, stats.Pools)
+	// Output:
+	// networks: 3
+	// dexes on ethereum: 3
+	// pools on ethereum: 3
+	// pool USDC/WETH on Uniswap V3
+	// candles: 3
+	// transactions: 3
+	// token Wrapped Ether (WETH)
+	// pools with WETH: 3
+	// search: 0 tokens, 3 pools, 2 dexes
+	// stats: 28 chains, 9120584 pools
+}
+
+// This example handles the errors the API returns for unknown entities.
+func Example_errors() {
+	client, done := newClient(dexpaprika.WithRetryConfig(0, 0, 0))
+	defer done()
+
+	_, err := client.Pools.GetDetails(context.Background(), fixtures.Network, "0x0000000000000000000000000000000000000000", nil)
+	var apiErr *dexpaprika.APIError
+	switch {
+	case errors.Is(err, dexpaprika.ErrNotFound):
+		fmt.Println("pool not found")
+	case errors.As(err, &apiErr):
+		fmt.Println("API error:", apiErr.StatusCode)
+	case err != nil:
+		fmt.Println("request failed:", err)
+	}
+	// Output:
+	// pool not found
+}
@@ -0,0 +1,158 @@
+// Package export writes SDK models to files, databases and Google Sheets for
+// analysis in other tools, such as spreadsheets and data warehouses.
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// Flattener is implemented by the SDK models that flatten into tabular
+// records, such as dexpaprika.Pool, dexpaprika.Transaction,
+// dexpaprika.OHLCVRecord and dexpaprika.TokenDetails.
+type Flattener interface {
+	Flatten() dexpaprika.Record
+}
+
+// CSVOptions configures CSV output.
+type CSVOptions struct {
+	// Columns selects and orders the columns written, by flattened name,
+	// e.g. "id", "24h_volume_usd" or "token0_symbol". Columns missing from
+	// a row are left empty. By default every column is written in the order
+	// of the model's fields.
+	Columns []string
+
+	// NoHeader omits the header row, e.g. when appending to a file.
+	NoHeader bool
+
+	// Comma is the field delimiter. It defaults to ','.
+	Comma rune
+}
+
+// CSV writes rows to w as CSV, one line per row after a header line.
+//
+// Without CSVOptions.Columns, the columns are those of all rows in the
+// order of the model's fields, so rows with more nested elements, such as
+// pools with three tokens, add their extra columns next to the others
+// rather than at the end.
+func CSV[T Flattener](w io.Writer, rows []T, opts *CSVOptions) error {
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+	records := make([]dexpaprika.Record, len(rows))
+	for i, row := range rows {
+		records[i] = row.Flatten()
+	}
+
+	columns := opts.Columns
+	if columns == nil {
+		columns = mergeColumns(records)
+	}
+
+	cw := newCSVWriter(w, opts)
+	if !opts.NoHeader {
+		if err := cw.Write(columns); err != nil {
+			return err
+		}
+	}
+	for _, rec := range records {
+		if err := cw.Write(project(rec, columns)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// CSVWriter writes rows as CSV one at a time, for exports too large to hold
+// in memory. Unless CSVOptions.Columns is set, the columns are those of the
+// first row; columns only present in later rows are dropped.
+type CSVWriter[T Flattener] struct {
+	w           *csv.Writer
+	columns     []string
+	wroteHeader bool
+}
+
+// NewCSVWriter creates a writer of rows of type T to w.
+func NewCSVWriter[T Flattener](w io.Writer, opts *CSVOptions) *CSVWriter[T] {
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+	return &CSVWriter[T]{w: newCSVWriter(w, opts), columns: opts.Columns, wroteHeader: opts.NoHeader}
+}
+
+// Write writes a row, preceded by the header on the first call.
+func (cw *CSVWriter[T]) Write(row T) error {
+	rec := row.Flatten()
+	if cw.columns == nil {
+		cw.columns = rec.Header()
+	}
+	if !cw.wroteHeader {
+		if err := cw.w.Write(cw.columns); err != nil {
+			return err
+		}
+		cw.wroteHeader = true
+	}
+	return cw.w.Write(project(rec, cw.columns))
+}
+
+// Flush writes any buffered rows to the underlying writer and returns the
+// first error encountered.
+func (cw *CSVWriter[T]) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func newCSVWriter(w io.Writer, opts *CSVOptions) *csv.Writer {
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cw.Comma = opts.Comma
+	}
+	return cw
+}
+
+// project returns the formatted values of rec for columns.
+func project(rec dexpaprika.Record, columns []string) []string {
+	values := rec.Strings()
+	index := make(map[string]int, len(rec))
+	for i, c := range rec {
+		index[c.Name] = i
+	}
+	out := make([]string, len(columns))
+	for i, name := range columns {
+		if j, ok := index[name]; ok {
+			out[i] = values[j]
+		}
+	}
+	return out
+}
+
+// mergeColumns returns the union of the records' columns. A column missing
+// from the union is inserted after the record's preceding column, keeping
+// field order.
+func mergeColumns(records []dexpaprika.Record) []string {
+	var columns []string
+	seen := make(map[string]bool)
+	for _, rec := range records {
+		pos := 0
+		for _, c := range rec {
+			if seen[c.Name] {
+				for i, name := range columns {
+					if name == c.Name {
+						pos = i + 1
+						break
+					}
+				}
+				continue
+			}
+			seen[c.Name] = true
+			columns = append(columns, "")
+			copy(columns[pos+1:], columns[pos:])
+			columns[pos] = c.Name
+			pos++
+		}
+	}
+	return columns
+}
@@ -0,0 +1,79 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestCSV_Pools(t *testing.T) {
+	pools := []dexpaprika.Pool{
+		{ID: "0xa", VolumeUSD: 1.5, Tokens: []dexpaprika.Token{{Symbol: "WETH"}, {Symbol: "USDC"}}},
+		{ID: "0xb", VolumeUSD: 2, Tokens: []dexpaprika.Token{{Symbol: "DAI"}, {Symbol: "USDC"}, {Symbol: "USDT"}}},
+	}
+
+	var buf bytes.Buffer
+	if err := CSV(&buf, pools, &CSVOptions{Columns: []string{"id", "volume_usd", "token0_symbol", "token2_symbol"}}); err != nil {
+		t.Fatalf("CSV returned error: %v", err)
+	}
+	want := "id,volume_usd,token0_symbol,token2_symbol\n0xa,1.5,WETH,\n0xb,2,DAI,USDT\n"
+	if buf.String() != want {
+		t.Errorf("CSV wrote\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	// Without explicit columns the third token's columns follow the second's
+	buf.Reset()
+	if err := CSV(&buf, pools, nil); err != nil {
+		t.Fatalf("CSV returned error: %v", err)
+	}
+	header := strings.SplitN(buf.String(), "\n", 2)[0]
+	if !strings.HasPrefix(header, "id,dex_id,") || !strings.HasSuffix(header, "token1_fdv,token2_id,token2_name,token2_symbol,token2_chain,token2_decimals,token2_added_at,token2_fdv") {
+		t.Errorf("Unexpected header %s", header)
+	}
+}
+
+func TestCSV_Options(t *testing.T) {
+	records := []dexpaprika.OHLCVRecord{
+		{TimeOpen: "2024-01-01T00:00:00Z", Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 10},
+	}
+	var buf bytes.Buffer
+	if err := CSV(&buf, records, &CSVOptions{NoHeader: true, Comma: ';'}); err != nil {
+		t.Fatalf("CSV returned error: %v", err)
+	}
+	if want := "2024-01-01T00:00:00Z;;1;2;0.5;1.5;10\n"; buf.String() != want {
+		t.Errorf("CSV wrote %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCSV_TokenDetails(t *testing.T) {
+	// Tokens without a summary still produce the summary columns
+	tokens := []*dexpaprika.TokenDetails{
+		{ID: "0xa", Symbol: "AAA"},
+		{ID: "0xb", Symbol: "BBB", Summary: &dexpaprika.TokenSummary{PriceUSD: 2}},
+	}
+	var buf bytes.Buffer
+	if err := CSV(&buf, tokens, &CSVOptions{Columns: []string{"symbol", "summary_price_usd"}}); err != nil {
+		t.Fatalf("CSV returned error: %v", err)
+	}
+	if want := "symbol,summary_price_usd\nAAA,\nBBB,2\n"; buf.String() != want {
+		t.Errorf("CSV wrote %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVWriter[dexpaprika.Transaction](&buf, &CSVOptions{Columns: []string{"id", "amount_0"}})
+	for _, tx := range []dexpaprika.Transaction{{ID: "0x1", Amount0: "1.5"}, {ID: "0x2", Amount0: -3.0}} {
+		if err := w.Write(tx); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if want := "id,amount_0\n0x1,1.5\n0x2,-3\n"; buf.String() != want {
+		t.Errorf("CSVWriter wrote %q, want %q", buf.String(), want)
+	}
+}
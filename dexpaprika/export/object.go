@@ -0,0 +1,158 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// ObjectFormat encodes rows into the body of one object.
+type ObjectFormat[T any] struct {
+	Ext         string // File extension of the objects, e.g. ".jsonl"
+	ContentType string
+	Encode      func(w io.Writer, rows []T) error
+}
+
+// JSONLFormat encodes rows as JSON Lines.
+func JSONLFormat[T any]() ObjectFormat[T] {
+	return ObjectFormat[T]{
+		Ext:         ".jsonl",
+		ContentType: "application/x-ndjson",
+		Encode: func(w io.Writer, rows []T) error {
+			enc := json.NewEncoder(w)
+			for _, row := range rows {
+				if err := enc.Encode(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// CSVFormat encodes rows as CSV with a header.
+func CSVFormat[T Flattener](opts *CSVOptions) ObjectFormat[T] {
+	return ObjectFormat[T]{
+		Ext:         ".csv",
+		ContentType: "text/csv",
+		Encode: func(w io.Writer, rows []T) error {
+			return CSV(w, rows, opts)
+		},
+	}
+}
+
+// ObjectSink writes rows to an ObjectStore such as an S3 bucket, one object
+// per date partition on every Flush, so backfills and downloads land in a
+// data lake directly. Keys have the form
+//
+//	prefix/dt=2024-05-01/part-20240502T101500Z-1a2b3c4d-00001.jsonl
+//
+// which query engines such as Athena, Trino and Spark read as a dt
+// partition column.
+type ObjectSink[T any] struct {
+	store  ObjectStore
+	prefix string
+	format ObjectFormat[T]
+
+	// Partition returns the time whose UTC date partitions a row, such as
+	// OHLCVTime for candles. By default rows are partitioned by the time
+	// they are flushed.
+	Partition func(T) time.Time
+
+	rows []T
+	id   string
+	seq  int
+	now  func() time.Time
+}
+
+// NewObjectSink creates a sink writing objects in format under prefix.
+func NewObjectSink[T any](store ObjectStore, prefix string, format ObjectFormat[T]) *ObjectSink[T] {
+	id := make([]byte, 4)
+	_, _ = rand.Read(id)
+	return &ObjectSink[T]{store: store, prefix: prefix, format: format, id: hex.EncodeToString(id), now: time.Now}
+}
+
+// Write buffers rows until Flush.
+func (s *ObjectSink[T]) Write(ctx context.Context, rows []T) error {
+	s.rows = append(s.rows, rows...)
+	return nil
+}
+
+// Flush uploads the buffered rows, one object per partition. Rows of
+// partitions that fail to upload stay buffered, so Flush can be retried.
+func (s *ObjectSink[T]) Flush(ctx context.Context) error {
+	if len(s.rows) == 0 {
+		return nil
+	}
+	now := s.now().UTC()
+
+	partitions := make(map[string][]T)
+	for _, row := range s.rows {
+		t := now
+		if s.Partition != nil {
+			if pt := s.Partition(row); !pt.IsZero() {
+				t = pt
+			}
+		}
+		day := t.UTC().Format(time.DateOnly)
+		partitions[day] = append(partitions[day], row)
+	}
+	days := make([]string, 0, len(partitions))
+	for day := range partitions {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	var failed []T
+	var firstErr error
+	for _, day := range days {
+		rows := partitions[day]
+		if firstErr == nil {
+			firstErr = s.upload(ctx, day, now, rows)
+			if firstErr == nil {
+				continue
+			}
+		}
+		failed = append(failed, rows...)
+	}
+	s.rows = failed
+	return firstErr
+}
+
+func (s *ObjectSink[T]) upload(ctx context.Context, day string, now time.Time, rows []T) error {
+	var buf bytes.Buffer
+	if err := s.format.Encode(&buf, rows); err != nil {
+		return err
+	}
+	s.seq++
+	name := fmt.Sprintf("part-%s-%s-%05d%s", now.Format("20060102T150405Z"), s.id, s.seq, s.format.Ext)
+	return s.store.Put(ctx, path.Join(s.prefix, "dt="+day, name), buf.Bytes(), s.format.ContentType)
+}
+
+// Close uploads rows written since the last Flush.
+func (s *ObjectSink[T]) Close() error {
+	return s.Flush(context.Background())
+}
+
+// OHLCVTime returns a candle's open time, for partitioning candles by the
+// day they cover.
+func OHLCVTime(r dexpaprika.OHLCVRecord) time.Time {
+	t, _ := r.OpenTime()
+	return t
+}
+
+// TransactionTime returns a transaction's creation time, for partitioning
+// transactions by the day they happened.
+func TransactionTime(tx dexpaprika.Transaction) time.Time {
+	t, _ := time.Parse(time.RFC3339, tx.CreatedAt)
+	return t
+}
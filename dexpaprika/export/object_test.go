@@ -0,0 +1,96 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+type memoryStore struct {
+	objects map[string]string
+	types   map[string]string
+	fail    bool
+}
+
+func (m *memoryStore) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	if m.fail {
+		return errors.New("unavailable")
+	}
+	if m.objects == nil {
+		m.objects, m.types = make(map[string]string), make(map[string]string)
+	}
+	m.objects[key] = string(body)
+	m.types[key] = contentType
+	return nil
+}
+
+func TestObjectSink_PartitionsByDay(t *testing.T) {
+	store := &memoryStore{}
+	sink := NewObjectSink(store, "lake/ohlcv/ethereum", JSONLFormat[dexpaprika.OHLCVRecord]())
+	sink.Partition = OHLCVTime
+	sink.id = "abcd"
+	sink.now = func() time.Time { return time.Date(2024, 5, 3, 10, 15, 0, 0, time.UTC) }
+
+	ctx := context.Background()
+	sink.Write(ctx, []dexpaprika.OHLCVRecord{
+		{TimeOpen: "2024-05-01T23:00:00Z", Close: 1},
+		{TimeOpen: "2024-05-02T00:00:00Z", Close: 2},
+	})
+	sink.Write(ctx, []dexpaprika.OHLCVRecord{{TimeOpen: "2024-05-02T01:00:00Z", Close: 3}})
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	want := map[string]int{
+		"lake/ohlcv/ethereum/dt=2024-05-01/part-20240503T101500Z-abcd-00001.jsonl": 1,
+		"lake/ohlcv/ethereum/dt=2024-05-02/part-20240503T101500Z-abcd-00002.jsonl": 2,
+	}
+	if len(store.objects) != len(want) {
+		t.Fatalf("Objects %v", store.objects)
+	}
+	for key, lines := range want {
+		body, ok := store.objects[key]
+		if !ok {
+			t.Errorf("Missing object %s in %v", key, store.objects)
+			continue
+		}
+		if n := strings.Count(body, "\n"); n != lines {
+			t.Errorf("%s has %d lines, want %d", key, n, lines)
+		}
+		if store.types[key] != "application/x-ndjson" {
+			t.Errorf("%s content type %q", key, store.types[key])
+		}
+	}
+}
+
+func TestObjectSink_KeepsRowsOnFailure(t *testing.T) {
+	store := &memoryStore{fail: true}
+	sink := NewObjectSink(store, "pools", CSVFormat[dexpaprika.Pool](nil))
+	ctx := context.Background()
+	sink.Write(ctx, []dexpaprika.Pool{{ID: "0xa"}})
+
+	if err := sink.Flush(ctx); err == nil {
+		t.Fatal("Expected an upload error")
+	}
+	store.fail = false
+	if err := sink.Flush(ctx); err != nil {
+		t.Fatalf("Retried Flush returned error: %v", err)
+	}
+	if len(store.objects) != 1 {
+		t.Fatalf("Objects %v", store.objects)
+	}
+	for key, body := range store.objects {
+		if !strings.HasPrefix(key, "pools/dt=") || !strings.HasSuffix(key, ".csv") || !strings.Contains(body, "0xa") {
+			t.Errorf("Unexpected object %s: %q", key, body)
+		}
+	}
+
+	// Nothing buffered: no empty objects
+	if err := sink.Flush(ctx); err != nil || len(store.objects) != 1 {
+		t.Errorf("Empty Flush wrote objects: %v, %v", store.objects, err)
+	}
+}
@@ -0,0 +1,154 @@
+// Package parquetexport writes OHLCV records and transactions as Parquet
+// files with typed columns, for data lakes. It is a module of its own, so
+// that only its importers depend on Apache Arrow:
+//
+//	go get github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/export/parquetexport
+package parquetexport
+
+import (
+	"io"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/export"
+)
+
+var timestampType = &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}
+
+var ohlcvSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "time_open", Type: timestampType, Nullable: true},
+	{Name: "time_close", Type: timestampType, Nullable: true},
+	{Name: "open", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "high", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "low", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "close", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "volume", Type: arrow.PrimitiveTypes.Int64},
+}, nil)
+
+var transactionSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.BinaryTypes.String},
+	{Name: "log_index", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "transaction_index", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "pool_id", Type: arrow.BinaryTypes.String},
+	{Name: "sender", Type: arrow.BinaryTypes.String},
+	{Name: "recipient", Type: arrow.BinaryTypes.String},
+	{Name: "token_0", Type: arrow.BinaryTypes.String},
+	{Name: "token_1", Type: arrow.BinaryTypes.String},
+	{Name: "amount_0", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "amount_1", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "created_at_block_number", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "created_at", Type: timestampType, Nullable: true},
+}, nil)
+
+// WriteOHLCV writes OHLCV records to w as a Parquet file. Times are
+// stored as UTC timestamps. props sets the Parquet writer properties; nil
+// uses Snappy compression.
+func WriteOHLCV(w io.Writer, records []dexpaprika.OHLCVRecord, props *parquet.WriterProperties) error {
+	return writeParquet(w, ohlcvSchema, props, func(b *array.RecordBuilder) {
+		for _, r := range records {
+			appendTimestamp(b.Field(0).(*array.TimestampBuilder), r.TimeOpen)
+			appendTimestamp(b.Field(1).(*array.TimestampBuilder), r.TimeClose)
+			b.Field(2).(*array.Float64Builder).Append(r.Open)
+			b.Field(3).(*array.Float64Builder).Append(r.High)
+			b.Field(4).(*array.Float64Builder).Append(r.Low)
+			b.Field(5).(*array.Float64Builder).Append(r.Close)
+			b.Field(6).(*array.Int64Builder).Append(r.Volume)
+		}
+	})
+}
+
+// WriteTransactions writes transactions to w as a Parquet file. Amounts
+// are stored as numbers, null where the API reported none. props is as for
+// WriteOHLCV.
+func WriteTransactions(w io.Writer, txs []dexpaprika.Transaction, props *parquet.WriterProperties) error {
+	return writeParquet(w, transactionSchema, props, func(b *array.RecordBuilder) {
+		for i := range txs {
+			tx := &txs[i]
+			b.Field(0).(*array.StringBuilder).Append(tx.ID)
+			b.Field(1).(*array.Int64Builder).Append(int64(tx.LogIndex))
+			b.Field(2).(*array.Int64Builder).Append(int64(tx.TransactionIndex))
+			b.Field(3).(*array.StringBuilder).Append(tx.PoolID)
+			b.Field(4).(*array.StringBuilder).Append(tx.Sender)
+			b.Field(5).(*array.StringBuilder).Append(tx.Recipient)
+			b.Field(6).(*array.StringBuilder).Append(tx.Token0)
+			b.Field(7).(*array.StringBuilder).Append(tx.Token1)
+			appendAmount(b.Field(8).(*array.Float64Builder), tx.GetAmount0)
+			appendAmount(b.Field(9).(*array.Float64Builder), tx.GetAmount1)
+			b.Field(10).(*array.Int64Builder).Append(tx.CreatedAtBlockNumber)
+			appendTimestamp(b.Field(11).(*array.TimestampBuilder), tx.CreatedAt)
+		}
+	})
+}
+
+// OHLCVFormat encodes candles as Parquet objects for an
+// export.ObjectSink or export.FormatSink. props is as for WriteOHLCV.
+func OHLCVFormat(props *parquet.WriterProperties) export.ObjectFormat[dexpaprika.OHLCVRecord] {
+	return export.ObjectFormat[dexpaprika.OHLCVRecord]{
+		Ext:         ".parquet",
+		ContentType: "application/vnd.apache.parquet",
+		Encode: func(w io.Writer, rows []dexpaprika.OHLCVRecord) error {
+			return WriteOHLCV(w, rows, props)
+		},
+	}
+}
+
+// TransactionsFormat encodes transactions as Parquet objects for an
+// export.ObjectSink or export.FormatSink. props is as for WriteOHLCV.
+func TransactionsFormat(props *parquet.WriterProperties) export.ObjectFormat[dexpaprika.Transaction] {
+	return export.ObjectFormat[dexpaprika.Transaction]{
+		Ext:         ".parquet",
+		ContentType: "application/vnd.apache.parquet",
+		Encode: func(w io.Writer, rows []dexpaprika.Transaction) error {
+			return WriteTransactions(w, rows, props)
+		},
+	}
+}
+
+// writeParquet builds a single record with schema and writes it to w.
+func writeParquet(w io.Writer, schema *arrow.Schema, props *parquet.WriterProperties, build func(*array.RecordBuilder)) error {
+	if props == nil {
+		props = parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy))
+	}
+
+	b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer b.Release()
+	build(b)
+	rec := b.NewRecordBatch()
+	defer rec.Release()
+
+	// Hide any Close method so the caller keeps ownership of w
+	fw, err := pqarrow.NewFileWriter(schema, struct{ io.Writer }{w}, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return err
+	}
+	if err := fw.Write(rec); err != nil {
+		fw.Close()
+		return err
+	}
+	return fw.Close()
+}
+
+// appendTimestamp appends an RFC3339 time, or null if it does not parse.
+func appendTimestamp(b *array.TimestampBuilder, s string) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		b.AppendNull()
+		return
+	}
+	b.Append(arrow.Timestamp(t.UnixMilli()))
+}
+
+func appendAmount(b *array.Float64Builder, get func() (float64, bool)) {
+	if a, ok := get(); ok {
+		b.Append(a)
+	} else {
+		b.AppendNull()
+	}
+}
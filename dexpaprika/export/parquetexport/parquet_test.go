@@ -0,0 +1,64 @@
+package parquetexport
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestWriteOHLCV(t *testing.T) {
+	records := []dexpaprika.OHLCVRecord{
+		{TimeOpen: "2024-01-01T00:00:00Z", TimeClose: "2024-01-01T01:00:00Z", Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 10},
+		{TimeOpen: "2024-01-01T01:00:00Z", TimeClose: "2024-01-01T02:00:00Z", Open: 1.5, High: 1.6, Low: 1.4, Close: 1.4, Volume: 4},
+	}
+	var buf bytes.Buffer
+	if err := WriteOHLCV(&buf, records, nil); err != nil {
+		t.Fatalf("WriteOHLCV returned error: %v", err)
+	}
+
+	table := readParquet(t, buf.Bytes())
+	if table.NumRows() != 2 || table.Schema().Field(0).Name != "time_open" {
+		t.Errorf("Unexpected table with %d rows and schema %s", table.NumRows(), table.Schema())
+	}
+}
+
+func TestWriteTransactions(t *testing.T) {
+	txs := []dexpaprika.Transaction{
+		{ID: "0x1", Amount0: "-1.5", Amount1: 3000.0, CreatedAt: "2024-01-01T00:00:00Z"},
+		{ID: "0x2"},
+	}
+	var buf bytes.Buffer
+	if err := WriteTransactions(&buf, txs, nil); err != nil {
+		t.Fatalf("WriteTransactions returned error: %v", err)
+	}
+
+	table := readParquet(t, buf.Bytes())
+	if table.NumRows() != 2 || table.NumCols() != 12 {
+		t.Errorf("Unexpected table with %d rows and %d columns", table.NumRows(), table.NumCols())
+	}
+}
+
+func readParquet(t *testing.T, data []byte) arrow.Table {
+	t.Helper()
+	r, err := file.NewParquetReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("NewParquetReader returned error: %v", err)
+	}
+	fr, err := pqarrow.NewFileReader(r, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatalf("NewFileReader returned error: %v", err)
+	}
+	table, err := fr.ReadTable(context.Background())
+	if err != nil {
+		t.Fatalf("ReadTable returned error: %v", err)
+	}
+	t.Cleanup(table.Release)
+	return table
+}
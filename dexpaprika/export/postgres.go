@@ -0,0 +1,347 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// Table maps rows of T to the columns of a database table.
+type Table[T any] struct {
+	Name    string
+	Columns []string
+	Key     []string      // Columns identifying a row, updated on conflict
+	Values  func(T) []any // Column values of a row, in the order of Columns
+}
+
+// PostgresSchema creates the tables of PoolsTable, TokensTable, OHLCVTable
+// and TransactionsTable.
+const PostgresSchema = `
+CREATE TABLE IF NOT EXISTS pools (
+	network_id TEXT NOT NULL,
+	id TEXT NOT NULL,
+	dex_id TEXT NOT NULL,
+	dex_name TEXT NOT NULL,
+	volume_usd DOUBLE PRECISION NOT NULL,
+	price_usd DOUBLE PRECISION NOT NULL,
+	transactions BIGINT NOT NULL,
+	fee DOUBLE PRECISION NOT NULL,
+	token0_id TEXT,
+	token0_symbol TEXT,
+	token1_id TEXT,
+	token1_symbol TEXT,
+	created_at TIMESTAMPTZ,
+	PRIMARY KEY (network_id, id)
+);
+CREATE TABLE IF NOT EXISTS tokens (
+	network_id TEXT NOT NULL,
+	id TEXT NOT NULL,
+	name TEXT NOT NULL,
+	symbol TEXT NOT NULL,
+	decimals INTEGER NOT NULL,
+	total_supply DOUBLE PRECISION NOT NULL,
+	price_usd DOUBLE PRECISION,
+	liquidity_usd DOUBLE PRECISION,
+	fdv DOUBLE PRECISION,
+	added_at TIMESTAMPTZ,
+	last_updated TIMESTAMPTZ,
+	PRIMARY KEY (network_id, id)
+);
+CREATE TABLE IF NOT EXISTS ohlcv (
+	network_id TEXT NOT NULL,
+	pool_id TEXT NOT NULL,
+	interval TEXT NOT NULL,
+	time_open TIMESTAMPTZ NOT NULL,
+	time_close TIMESTAMPTZ NOT NULL,
+	open DOUBLE PRECISION NOT NULL,
+	high DOUBLE PRECISION NOT NULL,
+	low DOUBLE PRECISION NOT NULL,
+	close DOUBLE PRECISION NOT NULL,
+	volume BIGINT NOT NULL,
+	PRIMARY KEY (network_id, pool_id, interval, time_open)
+);
+CREATE TABLE IF NOT EXISTS transactions (
+	pool_id TEXT NOT NULL,
+	id TEXT NOT NULL,
+	log_index INTEGER NOT NULL,
+	transaction_index INTEGER NOT NULL,
+	sender TEXT NOT NULL,
+	recipient TEXT NOT NULL,
+	token_0 TEXT NOT NULL,
+	token_1 TEXT NOT NULL,
+	amount_0 DOUBLE PRECISION,
+	amount_1 DOUBLE PRECISION,
+	block_number BIGINT NOT NULL,
+	created_at TIMESTAMPTZ,
+	PRIMARY KEY (pool_id, id, log_index)
+);
+`
+
+// PoolsTable maps pools to the pools table of PostgresSchema, keeping their
+// first two tokens.
+var PoolsTable = Table[dexpaprika.Pool]{
+	Name: "pools",
+	Columns: []string{"network_id", "id", "dex_id", "dex_name", "volume_usd", "price_usd", "transactions", "fee",
+		"token0_id", "token0_symbol", "token1_id", "token1_symbol", "created_at"},
+	Key: []string{"network_id", "id"},
+	Values: func(p dexpaprika.Pool) []any {
+		tokens := [4]any{}
+		for i, t := range p.Tokens {
+			if i == 2 {
+				break
+			}
+			tokens[2*i], tokens[2*i+1] = t.ID, t.Symbol
+		}
+		return []any{p.Chain, p.ID, p.DexID, p.DexName, p.VolumeUSD, p.PriceUSD, p.Transactions, p.Fee,
+			tokens[0], tokens[1], tokens[2], tokens[3], nullString(p.CreatedAt)}
+	},
+}
+
+// TokensTable maps token details to the tokens table of PostgresSchema.
+var TokensTable = Table[*dexpaprika.TokenDetails]{
+	Name: "tokens",
+	Columns: []string{"network_id", "id", "name", "symbol", "decimals", "total_supply", "price_usd", "liquidity_usd", "fdv",
+		"added_at", "last_updated"},
+	Key: []string{"network_id", "id"},
+	Values: func(t *dexpaprika.TokenDetails) []any {
+		var price, liquidity, fdv any
+		if s, ok := t.GetSummary(); ok {
+			if v, ok := s.GetPriceUSD(); ok {
+				price = v
+			}
+			if v, ok := s.GetLiquidityUSD(); ok {
+				liquidity = v
+			}
+			if v, ok := s.GetFDV(); ok {
+				fdv = v
+			}
+		}
+		return []any{t.Chain, t.ID, t.Name, t.Symbol, t.Decimals, t.TotalSupply, price, liquidity, fdv,
+			nullString(t.AddedAt), nullString(t.LastUpdated)}
+	},
+}
+
+// TransactionsTable maps pool transactions to the transactions table of
+// PostgresSchema.
+var TransactionsTable = Table[dexpaprika.Transaction]{
+	Name: "transactions",
+	Columns: []string{"pool_id", "id", "log_index", "transaction_index", "sender", "recipient", "token_0", "token_1",
+		"amount_0", "amount_1", "block_number", "created_at"},
+	Key: []string{"pool_id", "id", "log_index"},
+	Values: func(tx dexpaprika.Transaction) []any {
+		return []any{tx.PoolID, tx.ID, tx.LogIndex, tx.TransactionIndex, tx.Sender, tx.Recipient, tx.Token0, tx.Token1,
+			nullFloat(tx.GetAmount0), nullFloat(tx.GetAmount1), tx.CreatedAtBlockNumber, nullString(tx.CreatedAt)}
+	},
+}
+
+// OHLCVTable maps the candles of a pool to the ohlcv table of
+// PostgresSchema. OHLCV records do not carry their pool, so the table is
+// specific to one pool and interval.
+func OHLCVTable(networkID, poolAddress, interval string) Table[dexpaprika.OHLCVRecord] {
+	poolID := dexpaprika.NormalizeAddress(networkID, poolAddress)
+	return Table[dexpaprika.OHLCVRecord]{
+		Name:    "ohlcv",
+		Columns: []string{"network_id", "pool_id", "interval", "time_open", "time_close", "open", "high", "low", "close", "volume"},
+		Key:     []string{"network_id", "pool_id", "interval", "time_open"},
+		Values: func(c dexpaprika.OHLCVRecord) []any {
+			return []any{networkID, poolID, interval, c.TimeOpen, c.TimeClose, c.Open, c.High, c.Low, c.Close, c.Volume}
+		},
+	}
+}
+
+// postgresMaxParams is the number of bind parameters Postgres accepts in a
+// single statement.
+const postgresMaxParams = 65535
+
+// PostgresSink upserts rows into a Postgres table in batches. It works with
+// any database/sql driver for Postgres, such as github.com/jackc/pgx/v5/stdlib
+// or github.com/lib/pq.
+//
+// Rows are buffered until BatchSize rows are pending, then written with one
+// multi-row INSERT ... ON CONFLICT DO UPDATE statement per batch, so writing
+// a row again updates it.
+type PostgresSink[T any] struct {
+	db    *sql.DB
+	table Table[T]
+
+	// BatchSize is the number of rows per INSERT statement. It defaults to
+	// 500, and is lowered if a batch would exceed Postgres' limit of bind
+	// parameters.
+	BatchSize int
+
+	pending []T
+}
+
+// NewPostgresSink creates a sink writing rows to table in db. The table must
+// exist and have a unique constraint on the table's Key columns, as created
+// by PostgresSchema for the predefined tables.
+func NewPostgresSink[T any](db *sql.DB, table Table[T]) *PostgresSink[T] {
+	return &PostgresSink[T]{db: db, table: table, BatchSize: 500}
+}
+
+// Write buffers rows, inserting full batches.
+func (s *PostgresSink[T]) Write(ctx context.Context, rows []T) error {
+	s.pending = append(s.pending, rows...)
+	if size := s.batchSize(); len(s.pending) >= size {
+		full := len(s.pending) / size * size
+		if err := s.insert(ctx, s.pending[:full]); err != nil {
+			return err
+		}
+		s.pending = append(s.pending[:0], s.pending[full:]...)
+	}
+	return nil
+}
+
+// Flush inserts the pending rows.
+func (s *PostgresSink[T]) Flush(ctx context.Context) error {
+	if len(s.pending) == 0 {
+		return nil
+	}
+	if err := s.insert(ctx, s.pending); err != nil {
+		return err
+	}
+	s.pending = s.pending[:0]
+	return nil
+}
+
+// Close inserts the pending rows. It does not close the database.
+func (s *PostgresSink[T]) Close() error {
+	return s.Flush(context.Background())
+}
+
+func (s *PostgresSink[T]) batchSize() int {
+	size := s.BatchSize
+	if size <= 0 {
+		size = 500
+	}
+	return max(1, min(size, postgresMaxParams/len(s.table.Columns)))
+}
+
+// insert upserts rows in a transaction, one statement per batch.
+func (s *PostgresSink[T]) insert(ctx context.Context, rows []T) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("export: %s: %w", s.table.Name, err)
+	}
+	defer tx.Rollback()
+
+	size := s.batchSize()
+	for start := 0; start < len(rows); start += size {
+		batch := s.values(rows[start:min(start+size, len(rows))])
+		if _, err := tx.ExecContext(ctx, s.statement(len(batch)), flattenArgs(batch)...); err != nil {
+			return fmt.Errorf("export: %s: %w", s.table.Name, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("export: %s: %w", s.table.Name, err)
+	}
+	return nil
+}
+
+// values returns the column values of rows. Rows sharing a key are reduced
+// to the last one, since Postgres rejects upserts that update a row twice.
+func (s *PostgresSink[T]) values(rows []T) [][]any {
+	keyIndexes := make([]int, len(s.table.Key))
+	for i, key := range s.table.Key {
+		keyIndexes[i] = -1
+		for j, column := range s.table.Columns {
+			if column == key {
+				keyIndexes[i] = j
+			}
+		}
+	}
+
+	values := make([][]any, 0, len(rows))
+	positions := make(map[string]int, len(rows))
+	for _, row := range rows {
+		v := s.table.Values(row)
+		var key strings.Builder
+		for _, i := range keyIndexes {
+			if i >= 0 {
+				fmt.Fprintf(&key, "%v\x00", v[i])
+			}
+		}
+		if i, ok := positions[key.String()]; ok {
+			values[i] = v
+			continue
+		}
+		positions[key.String()] = len(values)
+		values = append(values, v)
+	}
+	return values
+}
+
+// statement returns the upsert of n rows.
+func (s *PostgresSink[T]) statement(n int) string {
+	columns := s.table.Columns
+	var b strings.Builder
+	fmt.Fprintf(&b, "INSERT INTO %s (%s) VALUES ", s.table.Name, strings.Join(columns, ", "))
+	param := 1
+	for row := 0; row < n; row++ {
+		if row > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for i := range columns {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(param))
+			param++
+		}
+		b.WriteByte(')')
+	}
+	fmt.Fprintf(&b, " ON CONFLICT (%s) DO ", strings.Join(s.table.Key, ", "))
+
+	var updates []string
+	for _, column := range columns {
+		if !contains(s.table.Key, column) {
+			updates = append(updates, column+" = EXCLUDED."+column)
+		}
+	}
+	if len(updates) == 0 {
+		b.WriteString("NOTHING")
+	} else {
+		b.WriteString("UPDATE SET " + strings.Join(updates, ", "))
+	}
+	return b.String()
+}
+
+func flattenArgs(rows [][]any) []any {
+	var args []any
+	for _, row := range rows {
+		args = append(args, row...)
+	}
+	return args
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// nullString returns nil for an empty string, so empty timestamps are
+// stored as NULL.
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nullFloat returns the value of get, or nil if it has none.
+func nullFloat(get func() (float64, bool)) any {
+	if v, ok := get(); ok {
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// fakeDB is a database/sql driver recording executed statements.
+type fakeDB struct {
+	mu      sync.Mutex
+	execs   []fakeExec
+	commits int
+}
+
+type fakeExec struct {
+	query string
+	args  []driver.Value
+}
+
+func (d *fakeDB) Connect(context.Context) (driver.Conn, error) { return fakeConn{d}, nil }
+func (d *fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.db, query}, nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{c.db}, nil }
+
+type fakeTx struct{ db *fakeDB }
+
+func (t fakeTx) Commit() error {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+	t.db.commits++
+	return nil
+}
+func (t fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.execs = append(s.db.execs, fakeExec{s.query, args})
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) { return nil, driver.ErrSkip }
+
+func newFakeDB(t *testing.T) (*sql.DB, *fakeDB) {
+	fake := &fakeDB{}
+	db := sql.OpenDB(fake)
+	t.Cleanup(func() { db.Close() })
+	return db, fake
+}
+
+func TestPostgresSink_Batches(t *testing.T) {
+	db, fake := newFakeDB(t)
+	sink := NewPostgresSink(db, OHLCVTable("ethereum", "0xABC", "1h"))
+	sink.BatchSize = 2
+	ctx := context.Background()
+
+	records := []dexpaprika.OHLCVRecord{
+		{TimeOpen: "2024-01-01T00:00:00Z", Close: 1},
+		{TimeOpen: "2024-01-01T01:00:00Z", Close: 2},
+		{TimeOpen: "2024-01-01T02:00:00Z", Close: 3},
+	}
+	if err := sink.Write(ctx, records); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if len(fake.execs) != 1 || len(fake.execs[0].args) != 2*10 {
+		t.Fatalf("Write executed %d statements, want one full batch of 2 rows", len(fake.execs))
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if len(fake.execs) != 2 || len(fake.execs[1].args) != 10 {
+		t.Fatalf("Close did not insert the pending row")
+	}
+	if fake.commits != 2 {
+		t.Errorf("Got %d commits, want 2", fake.commits)
+	}
+
+	query := fake.execs[0].query
+	want := "INSERT INTO ohlcv (network_id, pool_id, interval, time_open, time_close, open, high, low, close, volume) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10), ($11, $12, $13, $14, $15, $16, $17, $18, $19, $20) ON CONFLICT (network_id, pool_id, interval, time_open) DO UPDATE SET time_close = EXCLUDED.time_close"
+	if !strings.HasPrefix(query, want) {
+		t.Errorf("Statement\n%s\nwant prefix\n%s", query, want)
+	}
+	if args := fake.execs[1].args; args[1] != "0xabc" || args[3] != "2024-01-01T02:00:00Z" || args[8] != 3.0 {
+		t.Errorf("Unexpected row %v", args)
+	}
+}
+
+func TestPostgresSink_DuplicateKeys(t *testing.T) {
+	db, fake := newFakeDB(t)
+	sink := NewPostgresSink(db, TransactionsTable)
+	ctx := context.Background()
+
+	txs := []dexpaprika.Transaction{
+		{PoolID: "0xp", ID: "0x1", LogIndex: 1, Amount0: "1.5"},
+		{PoolID: "0xp", ID: "0x1", LogIndex: 2},
+		{PoolID: "0xp", ID: "0x1", LogIndex: 1, Amount0: "2.5"},
+	}
+	if err := sink.Write(ctx, txs); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if len(fake.execs) != 0 {
+		t.Fatalf("Write inserted before the batch was full")
+	}
+	if err := sink.Flush(ctx); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	// The repeated transaction is sent once, with its latest values
+	args := fake.execs[0].args
+	if len(args) != 2*len(TransactionsTable.Columns) {
+		t.Fatalf("Flush sent %d values, want 2 rows", len(args))
+	}
+	if args[8] != 2.5 || args[len(TransactionsTable.Columns)+8] != nil {
+		t.Errorf("Unexpected amounts %v and %v", args[8], args[len(TransactionsTable.Columns)+8])
+	}
+}
+
+func TestPoolsTable(t *testing.T) {
+	pool := dexpaprika.Pool{ID: "0xa", Chain: "ethereum", Tokens: []dexpaprika.Token{{ID: "0x1", Symbol: "WETH"}}}
+	values := PoolsTable.Values(pool)
+	if len(values) != len(PoolsTable.Columns) {
+		t.Fatalf("Got %d values for %d columns", len(values), len(PoolsTable.Columns))
+	}
+	if values[0] != "ethereum" || values[9] != "WETH" || values[10] != nil || values[12] != nil {
+		t.Errorf("Unexpected values %v", values)
+	}
+}
@@ -0,0 +1,280 @@
+package export
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FormatSink buffers rows and encodes them in an ObjectFormat when closed,
+// for formats written as a whole, such as Parquet.
+type FormatSink[T any] struct {
+	w      io.Writer
+	format ObjectFormat[T]
+	rows   []T
+}
+
+// NewFormatSink creates a sink encoding rows to w on Close. Close closes w
+// if it is an io.Closer.
+func NewFormatSink[T any](w io.Writer, format ObjectFormat[T]) *FormatSink[T] {
+	return &FormatSink[T]{w: w, format: format}
+}
+
+// Write buffers rows.
+func (s *FormatSink[T]) Write(ctx context.Context, rows []T) error {
+	s.rows = append(s.rows, rows...)
+	return nil
+}
+
+// Flush does nothing: the rows are encoded on Close.
+func (s *FormatSink[T]) Flush(ctx context.Context) error {
+	return nil
+}
+
+// Close encodes the rows and closes the underlying writer.
+func (s *FormatSink[T]) Close() error {
+	err := s.format.Encode(s.w, s.rows)
+	s.rows = nil
+	return closeWriter(s.w, err)
+}
+
+// inProgressExt marks files still being written by a RotatingSink.
+const inProgressExt = ".inprogress"
+
+// RotatingSink writes rows to a series of files in a directory, closing the
+// current file and starting a new one once it reaches MaxBytes or MaxAge,
+// so long-running exports produce files downstream ingestion can pick up.
+// Files are written under a name ending in ".inprogress" and renamed to
+// their final name only once closed, so consumers that ignore that suffix
+// never read a partial file.
+//
+// With Partition set, rows are routed to separate files per partition
+// directory, e.g. "network=ethereum/dt=2024-05-01".
+type RotatingSink[T any] struct {
+	dir     string
+	ext     string
+	newSink func(w io.Writer) Sink[T]
+
+	// MaxBytes rotates a file once this many bytes were written to it.
+	// Sinks that buffer, such as FormatSink, only write when closed, so
+	// for them rotate by MaxAge or MaxRows instead. Zero means no limit.
+	MaxBytes int64
+
+	// MaxRows rotates a file once this many rows were written to it. Zero
+	// means no limit.
+	MaxRows int
+
+	// MaxAge rotates a file once it has been open this long. It is checked
+	// on Write and Flush, so call Flush periodically to close idle files.
+	// Zero means no limit.
+	MaxAge time.Duration
+
+	// Partition returns the directory, relative to the sink's, that a row
+	// is written under. By default all rows go to the sink's directory.
+	Partition func(T) string
+
+	// OnClose, if set, is called with the final path of every completed
+	// file, e.g. to notify an ingestion pipeline.
+	OnClose func(path string)
+
+	mu    sync.Mutex
+	files map[string]*rotatingFile[T]
+	id    string
+	seq   int
+	now   func() time.Time
+}
+
+type rotatingFile[T any] struct {
+	sink   Sink[T]
+	file   *countingFile
+	path   string // Final path
+	opened time.Time
+	rows   int
+}
+
+// countingFile counts the bytes written to a file.
+type countingFile struct {
+	*os.File
+	n int64
+}
+
+func (f *countingFile) Write(p []byte) (int, error) {
+	n, err := f.File.Write(p)
+	f.n += int64(n)
+	return n, err
+}
+
+// NewRotatingSink creates a sink writing files with extension ext (e.g.
+// ".csv") to dir, each through a sink created by newSink, such as:
+//
+//	func(w io.Writer) export.Sink[dexpaprika.Transaction] { return export.NewJSONLSink[dexpaprika.Transaction](w) }
+func NewRotatingSink[T any](dir, ext string, newSink func(w io.Writer) Sink[T]) *RotatingSink[T] {
+	id := make([]byte, 4)
+	_, _ = rand.Read(id)
+	return &RotatingSink[T]{
+		id:      hex.EncodeToString(id),
+		dir:     dir,
+		ext:     ext,
+		newSink: newSink,
+		files:   make(map[string]*rotatingFile[T]),
+		now:     time.Now,
+	}
+}
+
+// DatePartition returns a Partition function placing rows under a
+// "dt=YYYY-MM-DD" directory for the UTC date of timeOf, such as OHLCVTime
+// or TransactionTime. Rows without a time go under "dt=unknown".
+func DatePartition[T any](timeOf func(T) time.Time) func(T) string {
+	return func(row T) string {
+		t := timeOf(row)
+		if t.IsZero() {
+			return "dt=unknown"
+		}
+		return "dt=" + t.UTC().Format(time.DateOnly)
+	}
+}
+
+// Write writes rows to the files of their partitions, rotating files that
+// reached a limit.
+func (s *RotatingSink[T]) Write(ctx context.Context, rows []T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groups := make(map[string][]T)
+	var order []string
+	for _, row := range rows {
+		var part string
+		if s.Partition != nil {
+			part = filepath.Clean(s.Partition(row))
+		}
+		if _, ok := groups[part]; !ok {
+			order = append(order, part)
+		}
+		groups[part] = append(groups[part], row)
+	}
+
+	for _, part := range order {
+		for rows := groups[part]; len(rows) > 0; {
+			f, err := s.file(part)
+			if err != nil {
+				return err
+			}
+			n := len(rows)
+			if s.MaxRows > 0 {
+				n = min(n, s.MaxRows-f.rows)
+			}
+			if err := f.sink.Write(ctx, rows[:n]); err != nil {
+				return err
+			}
+			f.rows += n
+			rows = rows[n:]
+			if s.due(f) {
+				if err := s.rotate(part); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Flush flushes the open files and rotates those that reached a limit.
+func (s *RotatingSink[T]) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var errs []error
+	for _, part := range s.parts() {
+		f := s.files[part]
+		if err := f.sink.Flush(ctx); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if s.due(f) {
+			errs = append(errs, s.rotate(part))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes every open file, giving each its final name.
+func (s *RotatingSink[T]) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var errs []error
+	for _, part := range s.parts() {
+		errs = append(errs, s.rotate(part))
+	}
+	return errors.Join(errs...)
+}
+
+// parts returns the partitions with open files in a stable order.
+func (s *RotatingSink[T]) parts() []string {
+	parts := make([]string, 0, len(s.files))
+	for part := range s.files {
+		parts = append(parts, part)
+	}
+	sort.Strings(parts)
+	return parts
+}
+
+// file returns the open file of a partition, creating it if needed.
+func (s *RotatingSink[T]) file(part string) (*rotatingFile[T], error) {
+	if f, ok := s.files[part]; ok {
+		return f, nil
+	}
+	dir := filepath.Join(s.dir, part)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	s.seq++
+	final := filepath.Join(dir, fmt.Sprintf("part-%s-%s-%05d%s", now.Format("20060102T150405Z"), s.id, s.seq, s.ext))
+	osFile, err := os.OpenFile(final+inProgressExt, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	cf := &countingFile{File: osFile}
+	f := &rotatingFile[T]{sink: s.newSink(cf), file: cf, path: final, opened: now}
+	s.files[part] = f
+	return f, nil
+}
+
+// due reports whether f reached a rotation limit.
+func (s *RotatingSink[T]) due(f *rotatingFile[T]) bool {
+	return (s.MaxBytes > 0 && f.file.n >= s.MaxBytes) ||
+		(s.MaxRows > 0 && f.rows >= s.MaxRows) ||
+		(s.MaxAge > 0 && s.now().Sub(f.opened) >= s.MaxAge)
+}
+
+// rotate closes the open file of a partition and gives it its final name.
+// The next row for the partition starts a new file.
+func (s *RotatingSink[T]) rotate(part string) error {
+	f, ok := s.files[part]
+	if !ok {
+		return nil
+	}
+	delete(s.files, part)
+	err := f.sink.Close()
+	// Sinks like CSVSink close the file themselves
+	if cerr := f.file.Close(); cerr != nil && !errors.Is(cerr, os.ErrClosed) && err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(f.file.Name(), f.path); err != nil {
+		return err
+	}
+	if s.OnClose != nil {
+		s.OnClose(f.path)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package export
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// listFiles returns the files under dir relative to it, sorted.
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var files []string
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
+
+func TestRotatingSink_Rows(t *testing.T) {
+	dir := t.TempDir()
+	sink := NewRotatingSink(dir, ".csv", func(w io.Writer) Sink[dexpaprika.Pool] {
+		return NewCSVSink[dexpaprika.Pool](w, &CSVOptions{Columns: []string{"id"}})
+	})
+	sink.MaxRows = 2
+	var closed []string
+	sink.OnClose = func(path string) { closed = append(closed, path) }
+
+	ctx := context.Background()
+	if err := sink.Write(ctx, []dexpaprika.Pool{{ID: "a"}, {ID: "b"}, {ID: "c"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first file is complete; the second is still in progress
+	files := listFiles(t, dir)
+	if len(files) != 2 || !strings.HasSuffix(files[0], ".csv") || !strings.HasSuffix(files[1], ".csv.inprogress") {
+		t.Fatalf("Files after Write: %v", files)
+	}
+	if len(closed) != 1 {
+		t.Errorf("OnClose called %d times, want 1", len(closed))
+	}
+
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	files = listFiles(t, dir)
+	if len(files) != 2 || strings.HasSuffix(files[1], inProgressExt) {
+		t.Fatalf("Files after Close: %v", files)
+	}
+	var contents []string
+	for _, f := range files {
+		data, _ := os.ReadFile(filepath.Join(dir, f))
+		contents = append(contents, string(data))
+	}
+	if contents[0] != "id\na\nb\n" || contents[1] != "id\nc\n" {
+		t.Errorf("Contents %q", contents)
+	}
+}
+
+func TestRotatingSink_PartitionAndAge(t *testing.T) {
+	dir := t.TempDir()
+	sink := NewRotatingSink(dir, ".jsonl", func(w io.Writer) Sink[dexpaprika.Transaction] {
+		return NewJSONLSink[dexpaprika.Transaction](w)
+	})
+	date := DatePartition(TransactionTime)
+	sink.Partition = func(tx dexpaprika.Transaction) string {
+		return filepath.Join("pool="+tx.PoolID, date(tx))
+	}
+	sink.MaxAge = time.Hour
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	sink.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	sink.Write(ctx, []dexpaprika.Transaction{
+		{ID: "1", PoolID: "0xa", CreatedAt: "2024-05-01T10:00:00Z"},
+		{ID: "2", PoolID: "0xb", CreatedAt: "2024-05-01T11:00:00Z"},
+		{ID: "3", PoolID: "0xa", CreatedAt: "2024-05-02T00:00:00Z"},
+	})
+	if err := sink.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range listFiles(t, dir) {
+		if !strings.HasSuffix(f, inProgressExt) {
+			t.Errorf("File %s completed before MaxAge", f)
+		}
+	}
+
+	// Flush rotates files past MaxAge
+	now = now.Add(time.Hour)
+	if err := sink.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	files := listFiles(t, dir)
+	var dirs []string
+	for _, f := range files {
+		if strings.HasSuffix(f, inProgressExt) {
+			t.Errorf("File %s still in progress after MaxAge", f)
+		}
+		dirs = append(dirs, filepath.ToSlash(filepath.Dir(f)))
+	}
+	want := "pool=0xa/dt=2024-05-01,pool=0xa/dt=2024-05-02,pool=0xb/dt=2024-05-01"
+	if strings.Join(dirs, ",") != want {
+		t.Errorf("Partitions %v, want %s", dirs, want)
+	}
+}
+
+func TestRotatingSink_Bytes(t *testing.T) {
+	dir := t.TempDir()
+	sink := NewRotatingSink(dir, ".jsonl", func(w io.Writer) Sink[dexpaprika.OHLCVRecord] {
+		return NewJSONLSink[dexpaprika.OHLCVRecord](w)
+	})
+	sink.MaxBytes = 1
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		sink.Write(ctx, []dexpaprika.OHLCVRecord{{Close: float64(i)}})
+		// JSONLSink buffers until Flush, which then reaches MaxBytes
+		if err := sink.Flush(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sink.Close()
+	if files := listFiles(t, dir); len(files) != 3 {
+		t.Errorf("Files %v, want one per flush", files)
+	}
+}
+
+func TestFormatSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.jsonl")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := NewFormatSink(f, JSONLFormat[dexpaprika.Pool]())
+	sink.Write(context.Background(), []dexpaprika.Pool{{ID: "a"}})
+	sink.Write(context.Background(), []dexpaprika.Pool{{ID: "b"}})
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Count(string(data), "\n") != 2 {
+		t.Errorf("Contents %q", data)
+	}
+}
@@ -0,0 +1,186 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ObjectStore stores objects by key, such as an S3 bucket.
+type ObjectStore interface {
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+}
+
+// S3 is an ObjectStore writing to an Amazon S3 bucket or an S3-compatible
+// store such as MinIO or Cloudflare R2. Requests are signed with AWS
+// Signature Version 4.
+type S3 struct {
+	Bucket string
+	Region string
+
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // For temporary credentials, optional
+
+	// Endpoint is the URL of an S3-compatible store, addressed with
+	// path-style URLs (endpoint/bucket/key). When empty, the AWS endpoint
+	// of Region is used with virtual-hosted URLs (bucket.s3.region...).
+	Endpoint string
+
+	// HTTPClient sends the requests. It defaults to a client with a
+	// 60 second timeout.
+	HTTPClient *http.Client
+}
+
+// NewS3 creates a store for a bucket with static credentials.
+func NewS3(bucket, region, accessKeyID, secretAccessKey string) *S3 {
+	return &S3{
+		Bucket:          bucket,
+		Region:          region,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		HTTPClient:      &http.Client{Timeout: time.Minute},
+	}
+}
+
+// NewS3FromEnv creates a store for a bucket configured by the standard AWS
+// environment variables: AWS_REGION (or AWS_DEFAULT_REGION),
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN and
+// AWS_ENDPOINT_URL_S3 (or AWS_ENDPOINT_URL) for S3-compatible stores.
+func NewS3FromEnv(bucket string) *S3 {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	s := NewS3(bucket, region, os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"))
+	s.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	s.Endpoint = os.Getenv("AWS_ENDPOINT_URL_S3")
+	if s.Endpoint == "" {
+		s.Endpoint = os.Getenv("AWS_ENDPOINT_URL")
+	}
+	return s
+}
+
+// Put uploads body under key.
+func (s *S3) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	region := s.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	var u string
+	if s.Endpoint != "" {
+		u = strings.TrimRight(s.Endpoint, "/") + "/" + s3Escape(s.Bucket) + "/" + s3Escape(key)
+	} else {
+		u = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.Bucket, region, s3Escape(key))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, body, region, time.Now())
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("export: s3 put %s returned %d: %s", key, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// sign adds AWS Signature Version 4 headers to req.
+func (s *S3) sign(req *http.Request, body []byte, region string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := day + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+	signature := hex.EncodeToString(hmacSHA256(signingKey(s.SecretAccessKey, day, region, "s3"), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// signingKey derives the Signature Version 4 key for a day, region and
+// service.
+func signingKey(secret, day, region, service string) []byte {
+	k := hmacSHA256([]byte("AWS4"+secret), day)
+	k = hmacSHA256(k, region)
+	k = hmacSHA256(k, service)
+	return hmacSHA256(k, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// s3Escape percent-encodes every byte of an object key except unreserved
+// characters and slashes, as Signature Version 4 canonicalizes paths.
+func s3Escape(key string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-._~/", c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hexDigits[c>>4])
+		b.WriteByte(hexDigits[c&15])
+	}
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigningKey(t *testing.T) {
+	// Example from the AWS Signature Version 4 documentation
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	if got := hex.EncodeToString(key); got != "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d" {
+		t.Errorf("signingKey = %s", got)
+	}
+}
+
+func TestS3_Put(t *testing.T) {
+	var gotPath, gotAuth, gotBody, gotType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Expected PUT, got %s", r.Method)
+		}
+		gotPath = r.URL.EscapedPath()
+		gotAuth = r.Header.Get("Authorization")
+		gotType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		if r.Header.Get("X-Amz-Content-Sha256") != sha256Hex(body) {
+			t.Error("Payload hash does not match the body")
+		}
+		if r.Header.Get("X-Amz-Security-Token") != "session" {
+			t.Error("Missing session token")
+		}
+	}))
+	defer server.Close()
+
+	s := NewS3("lake", "eu-west-1", "AKID", "secret")
+	s.SessionToken = "session"
+	s.Endpoint = server.URL
+	if err := s.Put(context.Background(), "ohlcv/dt=2024-05-01/part 1.jsonl", []byte("{}\n"), "application/x-ndjson"); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	if gotPath != "/lake/ohlcv/dt%3D2024-05-01/part%201.jsonl" {
+		t.Errorf("Path = %s", gotPath)
+	}
+	if gotBody != "{}\n" || gotType != "application/x-ndjson" {
+		t.Errorf("Body %q, type %q", gotBody, gotType)
+	}
+	day := time.Now().UTC().Format("20060102")
+	wantPrefix := "AWS4-HMAC-SHA256 Credential=AKID/" + day + "/eu-west-1/s3/aws4_request, SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date;x-amz-security-token, Signature="
+	if !strings.HasPrefix(gotAuth, wantPrefix) || len(gotAuth) != len(wantPrefix)+64 {
+		t.Errorf("Authorization = %s", gotAuth)
+	}
+}
+
+func TestS3_PutError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	s := NewS3("lake", "", "AKID", "secret")
+	s.Endpoint = server.URL
+	err := s.Put(context.Background(), "key", nil, "")
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Expected AccessDenied error, got %v", err)
+	}
+}
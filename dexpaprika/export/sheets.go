@@ -0,0 +1,304 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+const (
+	sheetsBaseURL = "https://sheets.googleapis.com/v4"
+	sheetsScope   = "https://www.googleapis.com/auth/spreadsheets"
+	googleToken   = "https://oauth2.googleapis.com/token"
+)
+
+// ServiceAccount is a Google service account key as downloaded in JSON from
+// the Cloud console. Share the spreadsheet with its ClientEmail to grant it
+// access.
+type ServiceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// LoadServiceAccount reads a service account key file.
+func LoadServiceAccount(path string) (*ServiceAccount, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var account ServiceAccount
+	if err := json.Unmarshal(data, &account); err != nil {
+		return nil, fmt.Errorf("export: invalid service account key %s: %w", path, err)
+	}
+	return &account, nil
+}
+
+// SheetsOptions configures a SheetsSink.
+type SheetsOptions struct {
+	// Sheet is the name of the sheet (tab) written to. It defaults to
+	// "Sheet1".
+	Sheet string
+
+	// Columns selects and orders the columns written, as in CSVOptions.
+	Columns []string
+}
+
+// SheetsSink keeps a Google Sheets table up to date with the rows written
+// to it, so analysts get tables refreshed by a scheduled job. Each Flush
+// replaces the sheet's contents with a header and the rows written since
+// the previous Flush; numbers are written as numbers. Requests are
+// authenticated as a service account.
+type SheetsSink[T Flattener] struct {
+	spreadsheetID string
+	opts          SheetsOptions
+	auth          *serviceAccountAuth
+
+	// HTTPClient sends the requests. It defaults to a client with a
+	// 30 second timeout.
+	HTTPClient *http.Client
+
+	baseURL string
+	records []dexpaprika.Record
+}
+
+// NewSheetsSink creates a sink writing to a sheet of the spreadsheet with
+// the given ID, the part of its URL after /spreadsheets/d/.
+func NewSheetsSink[T Flattener](account *ServiceAccount, spreadsheetID string, opts *SheetsOptions) (*SheetsSink[T], error) {
+	auth, err := newServiceAccountAuth(account, sheetsScope)
+	if err != nil {
+		return nil, err
+	}
+	s := &SheetsSink[T]{
+		spreadsheetID: spreadsheetID,
+		auth:          auth,
+		HTTPClient:    &http.Client{Timeout: 30 * time.Second},
+		baseURL:       sheetsBaseURL,
+	}
+	if opts != nil {
+		s.opts = *opts
+	}
+	if s.opts.Sheet == "" {
+		s.opts.Sheet = "Sheet1"
+	}
+	return s, nil
+}
+
+// Write buffers rows until Flush.
+func (s *SheetsSink[T]) Write(ctx context.Context, rows []T) error {
+	for _, row := range rows {
+		s.records = append(s.records, row.Flatten())
+	}
+	return nil
+}
+
+// Flush replaces the sheet's contents with the buffered rows. The buffer is
+// kept if the update fails, so Flush can be retried.
+func (s *SheetsSink[T]) Flush(ctx context.Context) error {
+	columns := s.opts.Columns
+	if columns == nil {
+		columns = mergeColumns(s.records)
+	}
+	values := make([][]interface{}, 0, len(s.records)+1)
+	header := make([]interface{}, len(columns))
+	for i, c := range columns {
+		header[i] = c
+	}
+	values = append(values, header)
+	for _, rec := range s.records {
+		values = append(values, sheetRow(rec, columns))
+	}
+
+	sheet := quoteSheet(s.opts.Sheet)
+	if err := s.call(ctx, http.MethodPost, "/values/"+url.PathEscape(sheet)+":clear", struct{}{}); err != nil {
+		return err
+	}
+	body := map[string]interface{}{"range": sheet + "!A1", "majorDimension": "ROWS", "values": values}
+	if err := s.call(ctx, http.MethodPut, "/values/"+url.PathEscape(sheet+"!A1")+"?valueInputOption=RAW", body); err != nil {
+		return err
+	}
+	s.records = nil
+	return nil
+}
+
+// Close flushes rows written since the last Flush, if any.
+func (s *SheetsSink[T]) Close() error {
+	if len(s.records) == 0 {
+		return nil
+	}
+	return s.Flush(context.Background())
+}
+
+// call sends a Sheets API request for the spreadsheet.
+func (s *SheetsSink[T]) call(ctx context.Context, method, path string, body interface{}) error {
+	token, err := s.auth.token(ctx, s.HTTPClient)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	u := s.baseURL + "/spreadsheets/" + url.PathEscape(s.spreadsheetID) + path
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("export: sheets %s returned %d: %s", method, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// sheetRow returns the values of rec for columns, keeping numbers and
+// booleans typed so the sheet can compute with them.
+func sheetRow(rec dexpaprika.Record, columns []string) []interface{} {
+	strs := project(rec, columns)
+	row := make([]interface{}, len(columns))
+	for i, name := range columns {
+		row[i] = strs[i]
+		v, _ := rec.Get(name)
+		switch v := v.(type) {
+		case int, int32, int64, float32, float64, bool:
+			row[i] = v
+		case *float64:
+			if v != nil {
+				row[i] = *v
+			}
+		}
+	}
+	return row
+}
+
+// quoteSheet quotes a sheet name for use in A1 notation.
+func quoteSheet(name string) string {
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// serviceAccountAuth obtains OAuth access tokens for a service account with
+// the JWT bearer grant, reusing each token until shortly before it expires.
+type serviceAccountAuth struct {
+	email    string
+	key      *rsa.PrivateKey
+	tokenURI string
+	scope    string
+
+	mu      sync.Mutex
+	access  string
+	expires time.Time
+}
+
+func newServiceAccountAuth(account *ServiceAccount, scope string) (*serviceAccountAuth, error) {
+	if account == nil || account.ClientEmail == "" {
+		return nil, errors.New("export: service account without client_email")
+	}
+	block, _ := pem.Decode([]byte(account.PrivateKey))
+	if block == nil {
+		return nil, errors.New("export: service account private_key is not PEM encoded")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			return nil, fmt.Errorf("export: invalid service account private_key: %w", err)
+		}
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("export: service account private_key is not an RSA key")
+	}
+	tokenURI := account.TokenURI
+	if tokenURI == "" {
+		tokenURI = googleToken
+	}
+	return &serviceAccountAuth{email: account.ClientEmail, key: key, tokenURI: tokenURI, scope: scope}, nil
+}
+
+// token returns a valid access token.
+func (a *serviceAccountAuth) token(ctx context.Context, client *http.Client) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.access != "" && time.Now().Before(a.expires) {
+		return a.access, nil
+	}
+
+	assertion, err := a.assertion(time.Now())
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("export: service account token request returned %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return "", fmt.Errorf("export: invalid token response: %w", err)
+	}
+	a.access = tok.AccessToken
+	a.expires = time.Now().Add(time.Duration(tok.ExpiresIn)*time.Second - time.Minute)
+	return a.access, nil
+}
+
+// assertion returns a signed JWT asserting the service account's identity.
+func (a *serviceAccountAuth) assertion(now time.Time) (string, error) {
+	enc := base64.RawURLEncoding
+	header := enc.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   a.email,
+		"scope": a.scope,
+		"aud":   a.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signed := header + "." + enc.EncodeToString(claims)
+	sum := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(nil, a.key, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + enc.EncodeToString(sig), nil
+}
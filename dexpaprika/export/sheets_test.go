@@ -0,0 +1,118 @@
+package export
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestSheetsSink(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+
+	var tokenRequests int
+	var requests []string
+	var written [][]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			tokenRequests++
+			r.ParseForm()
+			parts := strings.Split(r.Form.Get("assertion"), ".")
+			if len(parts) != 3 {
+				t.Fatalf("Invalid assertion %q", r.Form.Get("assertion"))
+			}
+			sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+			sum := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, sum[:], sig); err != nil {
+				t.Errorf("Assertion signature invalid: %v", err)
+			}
+			claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+			if !strings.Contains(string(claims), `"iss":"exporter@project.iam.gserviceaccount.com"`) {
+				t.Errorf("Unexpected claims %s", claims)
+			}
+			w.Write([]byte(`{"access_token":"tok","expires_in":3600,"token_type":"Bearer"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			t.Errorf("Missing access token on %s", r.URL.Path)
+		}
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath()+"?"+r.URL.RawQuery)
+		if r.Method == http.MethodPut {
+			var body struct {
+				Values [][]interface{} `json:"values"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			written = body.Values
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	account := &ServiceAccount{
+		ClientEmail: "exporter@project.iam.gserviceaccount.com",
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		TokenURI:    server.URL + "/token",
+	}
+	sink, err := NewSheetsSink[dexpaprika.Pool](account, "sheet-id", &SheetsOptions{Sheet: "Top Pools", Columns: []string{"id", "volume_usd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink.baseURL = server.URL
+
+	ctx := context.Background()
+	for _, batch := range [][]dexpaprika.Pool{{{ID: "0xa", VolumeUSD: 1.5}}, {{ID: "0xb", VolumeUSD: 2}}} {
+		if err := sink.Write(ctx, batch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sink.Flush(ctx); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	want := []string{
+		"POST /spreadsheets/sheet-id/values/%27Top%20Pools%27:clear?",
+		"PUT /spreadsheets/sheet-id/values/%27Top%20Pools%27%21A1?valueInputOption=RAW",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Requests:\n%s\nwant:\n%s", strings.Join(requests, "\n"), strings.Join(want, "\n"))
+	}
+	got, _ := json.Marshal(written)
+	if string(got) != `[["id","volume_usd"],["0xa",1.5],["0xb",2]]` {
+		t.Errorf("Written values %s", got)
+	}
+
+	// The next refresh replaces the table and reuses the access token
+	sink.Write(ctx, []dexpaprika.Pool{{ID: "0xc", VolumeUSD: 3}})
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = json.Marshal(written)
+	if string(got) != `[["id","volume_usd"],["0xc",3]]` {
+		t.Errorf("Refreshed values %s", got)
+	}
+	if tokenRequests != 1 {
+		t.Errorf("Token requested %d times, want 1", tokenRequests)
+	}
+}
+
+func TestNewSheetsSink_InvalidKey(t *testing.T) {
+	_, err := NewSheetsSink[dexpaprika.Pool](&ServiceAccount{ClientEmail: "a@b", PrivateKey: "not a key"}, "id", nil)
+	if err == nil {
+		t.Error("Expected an error for an invalid private key")
+	}
+}
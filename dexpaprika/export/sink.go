@@ -0,0 +1,91 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// Sink receives rows in batches, such as a file or a database table. It is
+// the sink accepted by the paginators' Drain methods and watch.Drain.
+type Sink[T any] = dexpaprika.Sink[T]
+
+// CSVSink writes rows as CSV with a CSVWriter.
+type CSVSink[T Flattener] struct {
+	w  io.Writer
+	cw *CSVWriter[T]
+}
+
+// NewCSVSink creates a sink writing CSV to w. Close closes w if it is an
+// io.Closer, such as an *os.File.
+func NewCSVSink[T Flattener](w io.Writer, opts *CSVOptions) *CSVSink[T] {
+	return &CSVSink[T]{w: w, cw: NewCSVWriter[T](w, opts)}
+}
+
+// Write writes rows, preceded by the header on the first call.
+func (s *CSVSink[T]) Write(ctx context.Context, rows []T) error {
+	for _, row := range rows {
+		if err := s.cw.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes buffered rows to the underlying writer.
+func (s *CSVSink[T]) Flush(ctx context.Context) error {
+	return s.cw.Flush()
+}
+
+// Close flushes the sink and closes the underlying writer.
+func (s *CSVSink[T]) Close() error {
+	return closeWriter(s.w, s.cw.Flush())
+}
+
+// JSONLSink writes rows as JSON Lines, one JSON object per line.
+type JSONLSink[T any] struct {
+	w   io.Writer
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+// NewJSONLSink creates a sink writing JSON Lines to w. Close closes w if it
+// is an io.Closer, such as an *os.File.
+func NewJSONLSink[T any](w io.Writer) *JSONLSink[T] {
+	buf := bufio.NewWriter(w)
+	return &JSONLSink[T]{w: w, buf: buf, enc: json.NewEncoder(buf)}
+}
+
+// Write encodes rows, one per line.
+func (s *JSONLSink[T]) Write(ctx context.Context, rows []T) error {
+	for _, row := range rows {
+		if err := s.enc.Encode(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes buffered lines to the underlying writer.
+func (s *JSONLSink[T]) Flush(ctx context.Context) error {
+	return s.buf.Flush()
+}
+
+// Close flushes the sink and closes the underlying writer.
+func (s *JSONLSink[T]) Close() error {
+	return closeWriter(s.w, s.buf.Flush())
+}
+
+// closeWriter closes w if it is an io.Closer and returns flushErr, or the
+// error closing w.
+func closeWriter(w io.Writer, flushErr error) error {
+	if c, ok := w.(io.Closer); ok {
+		if err := c.Close(); flushErr == nil {
+			return err
+		}
+	}
+	return flushErr
+}
@@ -0,0 +1,67 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+var (
+	_ Sink[dexpaprika.Pool]        = (*CSVSink[dexpaprika.Pool])(nil)
+	_ Sink[dexpaprika.Transaction] = (*JSONLSink[dexpaprika.Transaction])(nil)
+	_ Sink[dexpaprika.Transaction] = (*PostgresSink[dexpaprika.Transaction])(nil)
+)
+
+// closeBuffer records whether it was closed.
+type closeBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *closeBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestCSVSink(t *testing.T) {
+	var buf closeBuffer
+	sink := NewCSVSink[dexpaprika.OHLCVRecord](&buf, &CSVOptions{Columns: []string{"time_open", "close"}})
+	ctx := context.Background()
+
+	for _, close := range []float64{1, 2} {
+		if err := sink.Write(ctx, []dexpaprika.OHLCVRecord{{TimeOpen: "2024-01-01T00:00:00Z", Close: close}}); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if want := "time_open,close\n2024-01-01T00:00:00Z,1\n2024-01-01T00:00:00Z,2\n"; buf.String() != want {
+		t.Errorf("CSVSink wrote %q, want %q", buf.String(), want)
+	}
+	if !buf.closed {
+		t.Error("Close did not close the writer")
+	}
+}
+
+func TestJSONLSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONLSink[dexpaprika.Dex](&buf)
+	ctx := context.Background()
+
+	if err := sink.Write(ctx, []dexpaprika.Dex{{ID: "a"}, {ID: "b"}}); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Error("Expected rows to be buffered until Flush")
+	}
+	if err := sink.Flush(ctx); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 || !bytes.Contains(lines[1], []byte(`"dex_id":"b"`)) {
+		t.Errorf("Unexpected output %s", buf.String())
+	}
+}
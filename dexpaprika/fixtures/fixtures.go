@@ -0,0 +1,336 @@
+// Package fixtures provides canonical DexPaprika API responses, checked in
+// per endpoint, for tests that need realistic data without network access.
+//
+// Each fixture is the raw body of one endpoint in Endpoints. Fixtures can be
+// decoded into SDK models, loaded into a dexpaprikatest.Fake, and checked
+// for model drift: fields the API returns that the SDK models drop.
+//
+//	details := fixtures.MustLoad[dexpaprika.PoolDetails](fixtures.PoolDetails)
+//
+//	fake := dexpaprikatest.NewFake()
+//	fixtures.Seed(fake)
+//	client := fake.Start(t)
+//
+// The responses are refreshed from the live API with go generate.
+package fixtures
+
+//go:generate go run ./internal/refresh -dir responses
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/dexpaprikatest"
+)
+
+//go:embed responses/*.json
+var responses embed.FS
+
+// Sample entities the fixtures were recorded for.
+const (
+	Network      = "ethereum"
+	PoolAddress  = "0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640" // USDC/WETH on Uniswap V3
+	TokenAddress = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2" // WETH
+	// OHLCVInterval is the interval of the PoolOHLCV candles.
+	OHLCVInterval = "1h"
+)
+
+// Fixture names, one per endpoint.
+const (
+	Networks         = "networks"
+	NetworkDexes     = "network_dexes"
+	Pools            = "pools"
+	NetworkPools     = "network_pools"
+	PoolDetails      = "pool_details"
+	PoolOHLCV        = "pool_ohlcv"
+	PoolTransactions = "pool_transactions"
+	TokenDetails     = "token_details"
+	TokenPools       = "token_pools"
+	Search           = "search"
+	Stats            = "stats"
+)
+
+// Endpoint is an API endpoint with a checked-in response.
+type Endpoint struct {
+	Name  string
+	Path  string
+	Query url.Values
+	// New returns a pointer to the SDK model the response decodes into.
+	New func() interface{}
+}
+
+// URL returns the path and query of the endpoint, relative to the API base.
+func (e Endpoint) URL() string {
+	if len(e.Query) == 0 {
+		return e.Path
+	}
+	return e.Path + "?" + e.Query.Encode()
+}
+
+// Endpoints lists the endpoints with a fixture, in refresh order.
+var Endpoints = []Endpoint{
+	{Name: Networks, Path: "/networks", New: func() interface{} { return new([]dexpaprika.Network) }},
+	{
+		Name: NetworkDexes, Path: "/networks/" + Network + "/dexes",
+		Query: url.Values{"limit": {"3"}},
+		New:   func() interface{} { return new(dexpaprika.DexesResponse) },
+	},
+	{
+		Name: Pools, Path: "/pools",
+		Query: url.Values{"limit": {"3"}},
+		New:   func() interface{} { return new(dexpaprika.PoolsResponse) },
+	},
+	{
+		Name: NetworkPools, Path: "/networks/" + Network + "/pools",
+		Query: url.Values{"limit": {"3"}, "order_by": {"volume_usd"}, "sort": {"desc"}},
+		New:   func() interface{} { return new(dexpaprika.PoolsResponse) },
+	},
+	{
+		Name: PoolDetails, Path: "/networks/" + Network + "/pools/" + PoolAddress,
+		New: func() interface{} { return new(dexpaprika.PoolDetails) },
+	},
+	{
+		Name: PoolOHLCV, Path: "/networks/" + Network + "/pools/" + PoolAddress + "/ohlcv",
+		Query: url.Values{"start": {"2024-06-01"}, "interval": {OHLCVInterval}, "limit": {"3"}},
+		New:   func() interface{} { return new([]dexpaprika.OHLCVRecord) },
+	},
+	{
+		Name: PoolTransactions, Path: "/networks/" + Network + "/pools/" + PoolAddress + "/transactions",
+		Query: url.Values{"limit": {"3"}},
+		New:   func() interface{} { return new(dexpaprika.TransactionsResponse) },
+	},
+	{
+		Name: TokenDetails, Path: "/networks/" + Network + "/tokens/" + TokenAddress,
+		New: func() interface{} { return new(dexpaprika.TokenDetails) },
+	},
+	{
+		Name: TokenPools, Path: "/networks/" + Network + "/tokens/" + TokenAddress + "/pools",
+		Query: url.Values{"limit": {"3"}},
+		New:   func() interface{} { return new(dexpaprika.PoolsResponse) },
+	},
+	{
+		Name: Search, Path: "/search",
+		Query: url.Values{"query": {"uniswap"}},
+		New:   func() interface{} { return new(dexpaprika.SearchResult) },
+	},
+	{Name: Stats, Path: "/stats", New: func() interface{} { return new(dexpaprika.Stats) }},
+}
+
+// Lookup returns the endpoint of a fixture.
+func Lookup(name string) (Endpoint, bool) {
+	for _, e := range Endpoints {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Endpoint{}, false
+}
+
+// Raw returns the checked-in response body of a fixture.
+func Raw(name string) ([]byte, error) {
+	data, err := responses.ReadFile("responses/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("fixture %q: %w", name, err)
+	}
+	return data, nil
+}
+
+// Load decodes a fixture into v, as the client would decode the response.
+func Load(name string, v interface{}) error {
+	data, err := Raw(name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("fixture %q: %w", name, err)
+	}
+	return nil
+}
+
+// MustLoad decodes a fixture into a T. It panics if the fixture is missing
+// or does not decode, which means the checked-in data is broken.
+func MustLoad[T any](name string) T {
+	var v T
+	if err := Load(name, &v); err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Drift returns the JSON paths present in a fixture that its model drops
+// when decoded and encoded again, such as "pools[].tokens[].fdv". Paths of
+// null, zero and empty values are ignored, since models omit those.
+func Drift(name string) ([]string, error) {
+	e, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("fixture %q: unknown endpoint", name)
+	}
+	data, err := Raw(name)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := CheckDrift(data, e.New())
+	if err != nil {
+		return nil, fmt.Errorf("fixture %q: %w", name, err)
+	}
+	return paths, nil
+}
+
+// CheckDrift decodes a response body into model, a pointer, and returns
+// the JSON paths of the body the model drops. The refresh tool uses it to
+// report drift of freshly recorded responses.
+func CheckDrift(body []byte, model interface{}) ([]string, error) {
+	var raw interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, model); err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+	var kept interface{}
+	if err := json.Unmarshal(encoded, &kept); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	missing(raw, kept, "", seen)
+	paths := make([]string, 0, len(seen))
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// missing records in seen the paths of non-empty values of raw without a
+// counterpart in kept. Array indexes are collapsed to "[]".
+func missing(raw, kept interface{}, path string, seen map[string]bool) {
+	switch raw := raw.(type) {
+	case map[string]interface{}:
+		keptObj, _ := kept.(map[string]interface{})
+		for key, value := range raw {
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+			keptValue, ok := keptObj[key]
+			if !ok {
+				if !isEmpty(value) {
+					seen[child] = true
+				}
+				continue
+			}
+			missing(value, keptValue, child, seen)
+		}
+	case []interface{}:
+		keptArr, _ := kept.([]interface{})
+		for i, value := range raw {
+			var keptValue interface{}
+			if i < len(keptArr) {
+				keptValue = keptArr[i]
+			}
+			missing(value, keptValue, path+"[]", seen)
+		}
+	}
+}
+
+func isEmpty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v) == ""
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	}
+	return reflect.ValueOf(v).Len() == 0
+}
+
+// Seed loads every fixture into a fake: networks, DEXes, pools, the sample
+// pool's details, candles and transactions, the sample token and stats.
+func Seed(fake *dexpaprikatest.Fake) {
+	if err := seed(fake, Load); err != nil {
+		panic(err)
+	}
+}
+
+// SeedFS is like Seed but loads the fixtures stored in fsys as <name>.json,
+// such as a directory written by the refresh tool. Fixtures missing from
+// fsys fall back to the checked-in ones.
+func SeedFS(fake *dexpaprikatest.Fake, fsys fs.FS) error {
+	return seed(fake, func(name string, v interface{}) error {
+		data, err := fs.ReadFile(fsys, name+".json")
+		if errors.Is(err, fs.ErrNotExist) {
+			return Load(name, v)
+		}
+		if err == nil {
+			err = json.Unmarshal(data, v)
+		}
+		if err != nil {
+			return fmt.Errorf("fixture %q: %w", name, err)
+		}
+		return nil
+	})
+}
+
+func seed(fake *dexpaprikatest.Fake, load func(name string, v interface{}) error) error {
+	var (
+		networks     []dexpaprika.Network
+		dexes        dexpaprika.DexesResponse
+		details      dexpaprika.PoolDetails
+		candles      []dexpaprika.OHLCVRecord
+		transactions dexpaprika.TransactionsResponse
+		token        dexpaprika.TokenDetails
+		stats        dexpaprika.Stats
+	)
+	pools := make([]dexpaprika.PoolsResponse, 3)
+	for name, v := range map[string]interface{}{
+		Networks:         &networks,
+		NetworkDexes:     &dexes,
+		Pools:            &pools[0],
+		NetworkPools:     &pools[1],
+		TokenPools:       &pools[2],
+		PoolDetails:      &details,
+		PoolOHLCV:        &candles,
+		PoolTransactions: &transactions,
+		TokenDetails:     &token,
+		Stats:            &stats,
+	} {
+		if err := load(name, v); err != nil {
+			return err
+		}
+	}
+
+	for _, n := range networks {
+		fake.AddNetwork(n)
+	}
+	for _, d := range dexes.Dexes {
+		fake.AddDex(d)
+	}
+	for _, resp := range pools {
+		for _, p := range resp.Pools {
+			fake.AddPool(p)
+		}
+	}
+	fake.SetPoolDetails(details)
+	fake.AddOHLCV(Network, PoolAddress, OHLCVInterval, candles...)
+	fake.AddTransactions(Network, PoolAddress, transactions.Transactions...)
+	fake.AddToken(token)
+	fake.SetStats(stats)
+	return nil
+}
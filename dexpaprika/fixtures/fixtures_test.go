@@ -0,0 +1,156 @@
+package fixtures
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/dexpaprikatest"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/internal/openapi"
+)
+
+func TestEndpointsLoadWithoutDrift(t *testing.T) {
+	for _, e := range Endpoints {
+		t.Run(e.Name, func(t *testing.T) {
+			if err := Load(e.Name, e.New()); err != nil {
+				t.Fatal(err)
+			}
+			paths, err := Drift(e.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(paths) > 0 {
+				t.Errorf("Drift(%q) = %v, want none; update the models", e.Name, paths)
+			}
+		})
+	}
+}
+
+func TestEndpointsMatchSpec(t *testing.T) {
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range Endpoints {
+		req, _ := http.NewRequest(http.MethodGet, dexpaprika.DefaultBaseURL+e.URL(), nil)
+		route, err := spec.ValidateRequest(req)
+		if err != nil {
+			t.Errorf("%s: %v", e.Name, err)
+			continue
+		}
+		body, err := Raw(e.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := spec.ValidateResponse(route, http.StatusOK, body); err != nil {
+			t.Errorf("%s: %v", e.Name, err)
+		}
+	}
+}
+
+func TestMustLoad(t *testing.T) {
+	details := MustLoad[dexpaprika.PoolDetails](PoolDetails)
+	if details.ID != PoolAddress || details.Chain != Network {
+		t.Errorf("pool details = %s on %s, want %s on %s", details.ID, details.Chain, PoolAddress, Network)
+	}
+	if len(details.Tokens) != 2 || details.Tokens[1].ID != TokenAddress {
+		t.Errorf("pool tokens = %+v, want USDC and WETH", details.Tokens)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustLoad of a missing fixture did not panic")
+		}
+	}()
+	MustLoad[dexpaprika.Stats]("missing")
+}
+
+func TestCheckDrift(t *testing.T) {
+	body := []byte(`{
+		"pools": [{"id": "0xpool", "tvl_usd": 12.5, "tokens": [{"id": "0xtoken", "logo": "https://x"}]}],
+		"page_info": {"limit": 1, "cursor": null, "next": ""},
+		"extra": {}
+	}`)
+	paths, err := CheckDrift(body, new(dexpaprika.PoolsResponse))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"pools[].tokens[].logo", "pools[].tvl_usd"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("CheckDrift() = %v, want %v", paths, want)
+	}
+
+	if _, err := Drift("missing"); err == nil {
+		t.Error("Drift of an unknown fixture succeeded")
+	}
+}
+
+func TestSeed(t *testing.T) {
+	fake := dexpaprikatest.NewFake()
+	Seed(fake)
+	client := fake.Start(t)
+	ctx := context.Background()
+
+	details, err := client.Pools.GetDetails(ctx, Network, PoolAddress, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := MustLoad[dexpaprika.PoolDetails](PoolDetails); !reflect.DeepEqual(*details, want) {
+		t.Errorf("GetDetails() = %+v, want the fixture", details)
+	}
+
+	token, err := client.Tokens.GetDetails(ctx, Network, TokenAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Symbol != "WETH" || token.Summary == nil {
+		t.Errorf("GetDetails() = %+v, want WETH with a summary", token)
+	}
+
+	stats, err := client.Utils.GetStats(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := MustLoad[dexpaprika.Stats](Stats); *stats != want {
+		t.Errorf("GetStats() = %+v, want %+v", stats, want)
+	}
+
+	pools, err := client.Pools.ListByNetwork(ctx, Network, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools.Pools) != 3 {
+		t.Errorf("ListByNetwork() returned %d pools, want 3", len(pools.Pools))
+	}
+}
+
+func TestSeedFS(t *testing.T) {
+	fake := dexpaprikatest.NewFake()
+	fsys := fstest.MapFS{
+		"stats.json": {Data: []byte(`{"chains": 1, "factories": 2, "pools": 3, "tokens": 4}`)},
+	}
+	if err := SeedFS(fake, fsys); err != nil {
+		t.Fatal(err)
+	}
+	client := fake.Start(t)
+
+	stats, err := client.Utils.GetStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *stats != (dexpaprika.Stats{Chains: 1, Factories: 2, Pools: 3, Tokens: 4}) {
+		t.Errorf("GetStats() = %+v, want the stats in fsys", stats)
+	}
+	if _, err := client.Tokens.GetDetails(context.Background(), Network, TokenAddress); err != nil {
+		t.Errorf("GetDetails() = %v, want the checked-in token", err)
+	}
+
+	fsys["networks.json"] = &fstest.MapFile{Data: []byte(`{`)}
+	if err := SeedFS(dexpaprikatest.NewFake(), fsys); err == nil || !strings.Contains(err.Error(), `fixture "networks"`) {
+		t.Errorf("SeedFS() with a broken file = %v, want an error naming it", err)
+	}
+}
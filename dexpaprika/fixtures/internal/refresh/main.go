@@ -0,0 +1,97 @@
+// Command refresh re-records the fixture responses from the live API and
+// reports fields the SDK models drop or the OpenAPI spec does not match.
+// It is invoked via go:generate from the fixtures package directory.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/fixtures"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/internal/openapi"
+)
+
+func main() {
+	dir := flag.String("dir", "responses", "directory to write the responses to")
+	only := flag.String("only", "", "refresh only the named fixture")
+	flag.Parse()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	client := dexpaprika.NewClient(dexpaprika.WithRateLimit(2))
+	defer client.Close()
+
+	spec, err := openapi.Load()
+	if err != nil {
+		log.Fatalf("Failed to load the OpenAPI spec: %v", err)
+	}
+
+	drifted := false
+	for _, e := range fixtures.Endpoints {
+		if *only != "" && e.Name != *only {
+			continue
+		}
+		body, err := fetch(ctx, client, e)
+		if err != nil {
+			log.Fatalf("Failed to fetch %s: %v", e.URL(), err)
+		}
+
+		paths, err := fixtures.CheckDrift(body, e.New())
+		if err != nil {
+			log.Fatalf("Failed to decode %s into its model: %v", e.Name, err)
+		}
+		for _, p := range paths {
+			log.Printf("%s: field %s is not modeled", e.Name, p)
+			drifted = true
+		}
+		if err := validate(spec, e, body); err != nil {
+			log.Printf("%s: response diverges from the spec: %v", e.Name, err)
+			drifted = true
+		}
+
+		var out bytes.Buffer
+		if err := json.Indent(&out, body, "", "  "); err != nil {
+			log.Fatalf("Failed to format %s: %v", e.Name, err)
+		}
+		out.WriteByte('\n')
+		path := filepath.Join(*dir, e.Name+".json")
+		if err := os.WriteFile(path, out.Bytes(), 0o644); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+		log.Printf("Wrote %s", path)
+	}
+	if drifted {
+		log.Print("Models or spec drifted from the API; update them before committing the responses")
+	}
+}
+
+// fetch returns the raw response body of an endpoint.
+func fetch(ctx context.Context, client *dexpaprika.Client, e fixtures.Endpoint) ([]byte, error) {
+	req, err := client.NewRequest(http.MethodGet, e.URL(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var body json.RawMessage
+	if _, err := client.Do(ctx, req, &body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// validate checks a recorded response against the spec's schema.
+func validate(spec *openapi.Spec, e fixtures.Endpoint, body []byte) error {
+	route, _, err := spec.Find(http.MethodGet, e.Path)
+	if err != nil {
+		return err
+	}
+	return spec.ValidateResponse(route, http.StatusOK, body)
+}
@@ -0,0 +1,181 @@
+package dexpaprika
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Column is a single named value of a flattened model.
+type Column struct {
+	Name  string
+	Value interface{}
+}
+
+// Record is the ordered list of columns produced by flattening a model.
+// Column names come from csv struct tags; nested structs are prefixed with
+// their parent's name ("24h_volume_usd") and slice elements with their
+// index ("token0_symbol").
+type Record []Column
+
+// Header returns the column names of the record.
+func (r Record) Header() []string {
+	header := make([]string, len(r))
+	for i, c := range r {
+		header[i] = c.Name
+	}
+	return header
+}
+
+// Strings returns the column values formatted for tabular output. Nil
+// values, such as absent optional fields, become empty strings.
+func (r Record) Strings() []string {
+	values := make([]string, len(r))
+	for i, c := range r {
+		values[i] = formatValue(c.Value)
+	}
+	return values
+}
+
+// Get returns the value of the named column.
+func (r Record) Get(name string) (interface{}, bool) {
+	for _, c := range r {
+		if c.Name == name {
+			return c.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Flatten returns the pool as a tabular record.
+func (p Pool) Flatten() Record { return Flatten(p) }
+
+// Flatten returns the pool details, including interval metrics, as a tabular record.
+func (p PoolDetails) Flatten() Record { return Flatten(p) }
+
+// Flatten returns the token as a tabular record.
+func (t Token) Flatten() Record { return Flatten(t) }
+
+// Flatten returns the token details, including the summary, as a tabular record.
+func (t TokenDetails) Flatten() Record { return Flatten(t) }
+
+// Flatten returns the OHLCV record as a tabular record.
+func (r OHLCVRecord) Flatten() Record { return Flatten(r) }
+
+// Flatten returns the transaction as a tabular record.
+func (t Transaction) Flatten() Record { return Flatten(t) }
+
+// Flatten converts a struct (or pointer to struct) into a Record using its
+// csv struct tags. Fields tagged csv:"-" and unexported fields are skipped,
+// and fields without a csv tag use their Go name. Nil nested structs still
+// produce their columns, with nil values, so records of the same type line up.
+func Flatten(v interface{}) Record {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return flattenType(rv.Type().Elem(), "", nil)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return Record{{Name: "value", Value: rv.Interface()}}
+	}
+	return flattenStruct(rv, "", nil)
+}
+
+func flattenStruct(rv reflect.Value, prefix string, record Record) Record {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := csvName(f)
+		if !ok {
+			continue
+		}
+		record = flattenValue(rv.Field(i), prefix+name, record)
+	}
+	return record
+}
+
+func flattenValue(fv reflect.Value, name string, record Record) Record {
+	switch fv.Kind() {
+	case reflect.Ptr:
+		if fv.IsNil() {
+			if fv.Type().Elem().Kind() == reflect.Struct {
+				return flattenType(fv.Type().Elem(), name+"_", record)
+			}
+			return append(record, Column{Name: name})
+		}
+		return flattenValue(fv.Elem(), name, record)
+	case reflect.Struct:
+		return flattenStruct(fv, name+"_", record)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() == reflect.Struct {
+			for j := 0; j < fv.Len(); j++ {
+				record = flattenStruct(fv.Index(j), name+strconv.Itoa(j)+"_", record)
+			}
+			return record
+		}
+	}
+	return append(record, Column{Name: name, Value: fv.Interface()})
+}
+
+// flattenType emits the columns of struct type t with nil values.
+func flattenType(t reflect.Type, prefix string, record Record) Record {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := csvName(f)
+		if !ok {
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		switch {
+		case ft.Kind() == reflect.Struct:
+			record = flattenType(ft, prefix+name+"_", record)
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.Struct:
+			// Empty slices contribute no columns
+		default:
+			record = append(record, Column{Name: prefix + name})
+		}
+	}
+	return record
+}
+
+// csvName returns the column name for a struct field.
+func csvName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() {
+		return "", false
+	}
+	tag := f.Tag.Get("csv")
+	if tag == "-" {
+		return "", false
+	}
+	if tag == "" {
+		return f.Name, true
+	}
+	return tag, true
+}
+
+// formatValue renders a column value as a string.
+func formatValue(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(x), 'f', -1, 32)
+	case int:
+		return strconv.Itoa(x)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case bool:
+		return strconv.FormatBool(x)
+	default:
+		return fmt.Sprint(x)
+	}
+}
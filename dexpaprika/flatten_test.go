@@ -0,0 +1,89 @@
+package dexpaprika
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPool_Flatten(t *testing.T) {
+	fdv := 1000.5
+	pool := Pool{
+		ID:        "0xpool",
+		Chain:     "ethereum",
+		VolumeUSD: 1234.5,
+		Tokens: []Token{
+			{ID: "0xaaa", Symbol: "WETH", Decimals: 18, FDV: &fdv},
+			{ID: "0xbbb", Symbol: "USDC", Decimals: 6},
+		},
+	}
+
+	record := pool.Flatten()
+	header := record.Header()
+	values := record.Strings()
+
+	if header[0] != "id" || values[0] != "0xpool" {
+		t.Errorf("Expected first column id=0xpool, got %s=%s", header[0], values[0])
+	}
+
+	want := map[string]string{
+		"volume_usd":      "1234.5",
+		"token0_symbol":   "WETH",
+		"token0_fdv":      "1000.5",
+		"token1_symbol":   "USDC",
+		"token1_decimals": "6",
+		"token1_fdv":      "",
+	}
+	for name, wantValue := range want {
+		value, ok := record.Get(name)
+		if !ok {
+			t.Errorf("Missing column %s in %v", name, header)
+			continue
+		}
+		if got := formatValue(value); got != wantValue {
+			t.Errorf("Column %s = %q, want %q", name, got, wantValue)
+		}
+	}
+}
+
+func TestPoolDetails_Flatten(t *testing.T) {
+	details := PoolDetails{
+		ID:  "0xpool",
+		Day: TimeIntervalMetrics{VolumeUSD: 2400, Txns: 12},
+	}
+
+	record := details.Flatten()
+	if v, _ := record.Get("24h_volume_usd"); formatValue(v) != "2400" {
+		t.Errorf("24h_volume_usd = %v, want 2400", v)
+	}
+	if v, _ := record.Get("24h_txns"); formatValue(v) != "12" {
+		t.Errorf("24h_txns = %v, want 12", v)
+	}
+	if _, ok := record.Get("liquidity_usd"); !ok {
+		t.Error("Expected liquidity_usd column even when absent")
+	}
+	for _, name := range record.Header() {
+		if strings.Contains(name, "inversed") {
+			t.Errorf("Unexported field leaked into record: %s", name)
+		}
+	}
+}
+
+func TestTokenDetails_FlattenNilSummary(t *testing.T) {
+	withSummary := TokenDetails{ID: "0x1", Summary: &TokenSummary{PriceUSD: 2}}
+	withoutSummary := TokenDetails{ID: "0x2"}
+
+	a := withSummary.Flatten().Header()
+	b := withoutSummary.Flatten().Header()
+	if len(a) != len(b) {
+		t.Fatalf("Headers differ in length: %d vs %d", len(a), len(b))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Errorf("Header %d differs: %s vs %s", i, a[i], b[i])
+		}
+	}
+
+	if v, ok := withoutSummary.Flatten().Get("summary_price_usd"); !ok || v != nil {
+		t.Errorf("Expected nil summary_price_usd, got %v (present: %t)", v, ok)
+	}
+}
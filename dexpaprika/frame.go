@@ -0,0 +1,262 @@
+package dexpaprika
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Frame is a column-oriented table of flattened records, for moving SDK
+// models into numerical analysis without field-by-field converters. Column
+// values are the records' raw values in row order, nil where a row lacks
+// the column.
+type Frame struct {
+	Names   []string // Column names, in the order of the records' fields
+	Columns map[string][]interface{}
+	rows    int
+}
+
+// ToFrame flattens rows into a frame, e.g. a page of pools or transactions.
+func ToFrame[T interface{ Flatten() Record }](rows []T) *Frame {
+	records := make([]Record, len(rows))
+	for i, row := range rows {
+		records[i] = row.Flatten()
+	}
+	return NewFrame(records)
+}
+
+// NewFrame builds a frame from records. Its columns are the union of the
+// records' columns, in order of first appearance.
+func NewFrame(records []Record) *Frame {
+	f := &Frame{Columns: make(map[string][]interface{}), rows: len(records)}
+	for i, rec := range records {
+		for _, c := range rec {
+			col, ok := f.Columns[c.Name]
+			if !ok {
+				col = make([]interface{}, len(records))
+				f.Names = append(f.Names, c.Name)
+			}
+			col[i] = c.Value
+			f.Columns[c.Name] = col
+		}
+	}
+	return f
+}
+
+// Len returns the number of rows.
+func (f *Frame) Len() int { return f.rows }
+
+// Float64s returns a column as numbers. Numeric strings, such as
+// transaction amounts, are parsed; missing and non-numeric values are NaN.
+// It returns nil for unknown columns.
+func (f *Frame) Float64s(name string) []float64 {
+	col, ok := f.Columns[name]
+	if !ok {
+		return nil
+	}
+	out := make([]float64, len(col))
+	for i, v := range col {
+		out[i] = toFloat(v)
+	}
+	return out
+}
+
+// Strings returns a column formatted as in Record.Strings. It returns nil
+// for unknown columns.
+func (f *Frame) Strings(name string) []string {
+	col, ok := f.Columns[name]
+	if !ok {
+		return nil
+	}
+	out := make([]string, len(col))
+	for i, v := range col {
+		out[i] = formatValue(v)
+	}
+	return out
+}
+
+// Times returns a column of RFC 3339 times, such as "created_at", parsed.
+// Missing and unparseable values are the zero time. It returns nil for
+// unknown columns.
+func (f *Frame) Times(name string) []time.Time {
+	col, ok := f.Columns[name]
+	if !ok {
+		return nil
+	}
+	out := make([]time.Time, len(col))
+	for i, v := range col {
+		if s, ok := v.(string); ok {
+			out[i], _ = time.Parse(time.RFC3339, s)
+		}
+	}
+	return out
+}
+
+// Records returns the frame as a header row followed by formatted rows, the
+// layout loaded by e.g. gota's dataframe.LoadRecords.
+func (f *Frame) Records() [][]string {
+	out := make([][]string, 0, f.rows+1)
+	out = append(out, append([]string(nil), f.Names...))
+	for i := 0; i < f.rows; i++ {
+		row := make([]string, len(f.Names))
+		for j, name := range f.Names {
+			row[j] = formatValue(f.Columns[name][i])
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+// toFloat converts a flattened value to a number, or NaN.
+func toFloat(v interface{}) float64 {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return math.NaN()
+}
+
+// OHLCVFrame holds candles as typed columns, ordered by open time.
+type OHLCVFrame struct {
+	Time   []time.Time // Open time
+	Open   []float64
+	High   []float64
+	Low    []float64
+	Close  []float64
+	Volume []float64
+}
+
+// NewOHLCVFrame converts candles to columns, sorted by open time. Candles
+// with an unparseable open time are dropped.
+func NewOHLCVFrame(records []OHLCVRecord) *OHLCVFrame {
+	type candle struct {
+		t time.Time
+		r OHLCVRecord
+	}
+	candles := make([]candle, 0, len(records))
+	for _, r := range records {
+		if t, err := r.OpenTime(); err == nil {
+			candles = append(candles, candle{t, r})
+		}
+	}
+	sort.SliceStable(candles, func(i, j int) bool { return candles[i].t.Before(candles[j].t) })
+
+	n := len(candles)
+	f := &OHLCVFrame{
+		Time:   make([]time.Time, n),
+		Open:   make([]float64, n),
+		High:   make([]float64, n),
+		Low:    make([]float64, n),
+		Close:  make([]float64, n),
+		Volume: make([]float64, n),
+	}
+	for i, c := range candles {
+		f.Time[i] = c.t
+		f.Open[i] = c.r.Open
+		f.High[i] = c.r.High
+		f.Low[i] = c.r.Low
+		f.Close[i] = c.r.Close
+		f.Volume[i] = float64(c.r.Volume)
+	}
+	return f
+}
+
+// Len returns the number of candles.
+func (f *OHLCVFrame) Len() int { return len(f.Time) }
+
+// Returns returns the close-to-close simple returns, aligned with the
+// candles: the first value is NaN.
+func (f *OHLCVFrame) Returns() []float64 {
+	return f.returns(func(prev, cur float64) float64 { return cur/prev - 1 })
+}
+
+// LogReturns returns the close-to-close log returns, aligned with the
+// candles: the first value is NaN.
+func (f *OHLCVFrame) LogReturns() []float64 {
+	return f.returns(func(prev, cur float64) float64 { return math.Log(cur / prev) })
+}
+
+func (f *OHLCVFrame) returns(fn func(prev, cur float64) float64) []float64 {
+	out := make([]float64, len(f.Close))
+	for i := range out {
+		if i == 0 || f.Close[i-1] == 0 {
+			out[i] = math.NaN()
+			continue
+		}
+		out[i] = fn(f.Close[i-1], f.Close[i])
+	}
+	return out
+}
+
+// PoolFrame holds a pool list as typed columns, in the list's order.
+type PoolFrame struct {
+	ID           []string
+	Chain        []string
+	DexID        []string
+	Token0       []string // Symbol of the pool's first token
+	Token1       []string // Symbol of the pool's second token
+	PriceUSD     []float64
+	VolumeUSD    []float64
+	Transactions []float64
+	Fee          []float64
+	Change5m     []float64 // USD price change over 5 minutes, in percent
+	Change1h     []float64
+	Change24h    []float64
+	CreatedAt    []time.Time
+}
+
+// NewPoolFrame converts pools to columns.
+func NewPoolFrame(pools []Pool) *PoolFrame {
+	n := len(pools)
+	f := &PoolFrame{
+		ID:           make([]string, n),
+		Chain:        make([]string, n),
+		DexID:        make([]string, n),
+		Token0:       make([]string, n),
+		Token1:       make([]string, n),
+		PriceUSD:     make([]float64, n),
+		VolumeUSD:    make([]float64, n),
+		Transactions: make([]float64, n),
+		Fee:          make([]float64, n),
+		Change5m:     make([]float64, n),
+		Change1h:     make([]float64, n),
+		Change24h:    make([]float64, n),
+		CreatedAt:    make([]time.Time, n),
+	}
+	for i, p := range pools {
+		f.ID[i] = p.ID
+		f.Chain[i] = p.Chain
+		f.DexID[i] = p.DexID
+		if len(p.Tokens) > 0 {
+			f.Token0[i] = p.Tokens[0].Symbol
+		}
+		if len(p.Tokens) > 1 {
+			f.Token1[i] = p.Tokens[1].Symbol
+		}
+		f.PriceUSD[i] = p.PriceUSD
+		f.VolumeUSD[i] = p.VolumeUSD
+		f.Transactions[i] = float64(p.Transactions)
+		f.Fee[i] = p.Fee
+		f.Change5m[i] = p.LastPriceChangeUSD5m
+		f.Change1h[i] = p.LastPriceChangeUSD1h
+		f.Change24h[i] = p.LastPriceChangeUSD24h
+		f.CreatedAt[i], _ = time.Parse(time.RFC3339, p.CreatedAt)
+	}
+	return f
+}
+
+// Len returns the number of pools.
+func (f *PoolFrame) Len() int { return len(f.ID) }
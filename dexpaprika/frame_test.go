@@ -0,0 +1,96 @@
+package dexpaprika
+
+import (
+	"math"
+	"testing"
+)
+
+func TestToFrame(t *testing.T) {
+	pools := []Pool{
+		{ID: "0xa", VolumeUSD: 10, Tokens: []Token{{Symbol: "WETH"}, {Symbol: "USDC"}}},
+		{ID: "0xb", VolumeUSD: 20.5, Tokens: []Token{{Symbol: "WBTC"}}},
+	}
+
+	frame := ToFrame(pools)
+	if frame.Len() != 2 {
+		t.Fatalf("Expected 2 rows, got %d", frame.Len())
+	}
+	if ids := frame.Strings("id"); ids[0] != "0xa" || ids[1] != "0xb" {
+		t.Errorf("Unexpected id column: %v", ids)
+	}
+	if volumes := frame.Float64s("volume_usd"); volumes[0] != 10 || volumes[1] != 20.5 {
+		t.Errorf("Unexpected volume_usd column: %v", volumes)
+	}
+	if symbols := frame.Strings("token1_symbol"); symbols[0] != "USDC" || symbols[1] != "" {
+		t.Errorf("Expected token1_symbol missing in second row, got %v", symbols)
+	}
+	if frame.Float64s("missing") != nil {
+		t.Error("Expected nil for unknown column")
+	}
+
+	records := frame.Records()
+	if len(records) != 3 || records[0][0] != "id" || records[2][0] != "0xb" {
+		t.Errorf("Unexpected records: %v", records)
+	}
+	if len(records[2]) != len(records[0]) {
+		t.Errorf("Expected rows as wide as the header, got %d and %d", len(records[2]), len(records[0]))
+	}
+}
+
+func TestFrame_Float64s(t *testing.T) {
+	frame := NewFrame([]Record{
+		{{Name: "amount", Value: "-1.5"}},
+		{{Name: "amount", Value: "n/a"}},
+		{{Name: "amount", Value: int64(3)}},
+		{},
+	})
+
+	got := frame.Float64s("amount")
+	if got[0] != -1.5 || !math.IsNaN(got[1]) || got[2] != 3 || !math.IsNaN(got[3]) {
+		t.Errorf("Unexpected amounts: %v", got)
+	}
+}
+
+func TestNewOHLCVFrame(t *testing.T) {
+	records := []OHLCVRecord{
+		{TimeOpen: "2024-01-02T00:00:00Z", Close: 110, Volume: 5},
+		{TimeOpen: "2024-01-01T00:00:00Z", Close: 100, Volume: 4},
+		{TimeOpen: "invalid", Close: 1},
+	}
+
+	frame := NewOHLCVFrame(records)
+	if frame.Len() != 2 {
+		t.Fatalf("Expected 2 candles, got %d", frame.Len())
+	}
+	if !frame.Time[0].Before(frame.Time[1]) || frame.Close[0] != 100 || frame.Volume[1] != 5 {
+		t.Errorf("Expected candles sorted by open time, got %v %v", frame.Time, frame.Close)
+	}
+
+	returns := frame.Returns()
+	if !math.IsNaN(returns[0]) || math.Abs(returns[1]-0.1) > 1e-9 {
+		t.Errorf("Unexpected returns: %v", returns)
+	}
+	logReturns := frame.LogReturns()
+	if math.Abs(logReturns[1]-math.Log(1.1)) > 1e-9 {
+		t.Errorf("Unexpected log returns: %v", logReturns)
+	}
+}
+
+func TestNewPoolFrame(t *testing.T) {
+	pools := []Pool{{
+		ID:                    "0xa",
+		PriceUSD:              2,
+		Transactions:          7,
+		LastPriceChangeUSD24h: -3.5,
+		CreatedAt:             "2024-01-01T00:00:00Z",
+		Tokens:                []Token{{Symbol: "WETH"}, {Symbol: "USDC"}},
+	}}
+
+	frame := NewPoolFrame(pools)
+	if frame.Len() != 1 || frame.Token0[0] != "WETH" || frame.Token1[0] != "USDC" {
+		t.Errorf("Unexpected frame: %+v", frame)
+	}
+	if frame.Transactions[0] != 7 || frame.Change24h[0] != -3.5 || frame.CreatedAt[0].Year() != 2024 {
+		t.Errorf("Unexpected numeric columns: %+v", frame)
+	}
+}
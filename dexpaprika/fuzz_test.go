@@ -0,0 +1,153 @@
+package dexpaprika
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// The fuzz targets below run their seed corpus as part of go test. Run one
+// with fuzzing enabled to search for malformed payloads that panic:
+//
+//	go test -run '^$' -fuzz FuzzPageInfo ./dexpaprika
+
+func FuzzPageInfo(f *testing.F) {
+	f.Add([]byte(`{"limit":10,"page":0,"total_items":120,"total_pages":12}`))
+	f.Add([]byte(`{"limit":"10","page":"0","total_items":"120","total_pages":""}`))
+	f.Add([]byte(`{"limit":"ten"}`))
+	f.Add([]byte(`{"limit":1e400}`))
+	f.Add([]byte(`{"limit":-1,"page":null}`))
+	f.Add([]byte(`[]`))
+	f.Add([]byte(`{"limit":`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var p PageInfo
+		if err := json.Unmarshal(data, &p); err != nil {
+			return
+		}
+		// Whatever decodes must survive a round trip unchanged
+		encoded, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("Marshal(%+v): %v", p, err)
+		}
+		var again PageInfo
+		if err := json.Unmarshal(encoded, &again); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", encoded, err)
+		}
+		if again != p {
+			t.Fatalf("round trip of %+v = %+v", p, again)
+		}
+	})
+}
+
+func FuzzTransactionAmount(f *testing.F) {
+	f.Add([]byte(`{"id":"0x1","amount_0":"-12041.552311","amount_1":3.43}`))
+	f.Add([]byte(`{"amount_0":"","amount_1":null}`))
+	f.Add([]byte(`{"amount_0":"NaN","amount_1":"Inf"}`))
+	f.Add([]byte(`{"amount_0":"1e999","amount_1":"0x10"}`))
+	f.Add([]byte(`{"amount_0":[1],"amount_1":{"value":1}}`))
+	f.Add([]byte(`{"amount_0":true,"created_at_block_number":"12"}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var tx Transaction
+		if err := json.Unmarshal(data, &tx); err != nil {
+			return
+		}
+		for _, get := range []func() (float64, bool){tx.GetAmount0, tx.GetAmount1} {
+			if a, ok := get(); ok && (math.IsNaN(a) || math.IsInf(a, 0)) {
+				t.Fatalf("amount of %s = %v, want an error for non-finite amounts", data, a)
+			}
+		}
+		pool := &PoolDetails{ID: tx.PoolID, Tokens: []Token{{ID: "0xa"}, {ID: "0xb"}}}
+		if trade, err := EnrichTransaction(tx, pool); err == nil {
+			if math.IsNaN(trade.Price) || math.IsInf(trade.Price, 0) {
+				t.Fatalf("EnrichTransaction(%s) price = %v", data, trade.Price)
+			}
+		}
+	})
+}
+
+func FuzzDecimal(f *testing.F) {
+	f.Add([]byte(`3050.25`))
+	f.Add([]byte(`"3050.25"`))
+	f.Add([]byte(`"-1e-8"`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`""`))
+	f.Add([]byte(`"abc"`))
+	f.Add([]byte(`"1.2.3"`))
+	f.Add([]byte(`"1"`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var d Decimal
+		if err := d.UnmarshalJSON(data); err != nil {
+			return
+		}
+		encoded, err := d.MarshalJSON()
+		if err != nil || !json.Valid(encoded) {
+			t.Fatalf("MarshalJSON of %q = %s, %v; want valid JSON", d, encoded, err)
+		}
+		var again Decimal
+		if err := again.UnmarshalJSON(encoded); err != nil || again != d {
+			t.Fatalf("round trip of %q = %q, %v", d, again, err)
+		}
+		_ = d.Float64()
+	})
+}
+
+func FuzzOHLCVTimestamp(f *testing.F) {
+	f.Add([]byte(`{"time_open":"2024-06-01T00:00:00Z","time_close":"2024-06-01T01:00:00Z","open":1,"high":2,"low":0.5,"close":1.5,"volume":10}`))
+	f.Add([]byte(`{"time_open":"2024-06-01","time_close":"","open":"1","volume":"1e3"}`))
+	f.Add([]byte(`{"time_open":"1717200000","time_close":"2024-13-45T99:99:99Z"}`))
+	f.Add([]byte(`{"time_open":"2024-06-01T00:00:00+25:00","open":"1e400"}`))
+	f.Add([]byte(`{"time_open":null,"volume":-1}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var r OHLCVRecord
+		if err := json.Unmarshal(data, &r); err != nil {
+			return
+		}
+		_, openErr := r.OpenTime()
+		_, _ = r.CloseTime()
+		// Candles with an unparseable open time are dropped, not zero-timed
+		frame := NewOHLCVFrame([]OHLCVRecord{r, r})
+		want := 2
+		if openErr != nil {
+			want = 0
+		}
+		if frame.Len() != want {
+			t.Fatalf("frame of %q has %d candles, want %d", r.TimeOpen, frame.Len(), want)
+		}
+		_ = frame.Returns()
+	})
+}
+
+func FuzzErrorBody(f *testing.F) {
+	f.Add(400, []byte(`{"error":"invalid limit"}`))
+	f.Add(404, []byte(`{"error":{"message":"pool not found","code":"POOL_NOT_FOUND","details":{"pool":"0x1"}}}`))
+	f.Add(429, []byte(`{"message":"slow down","code":429}`))
+	f.Add(500, []byte(`<html>Internal Server Error</html>`))
+	f.Add(503, []byte(``))
+	f.Add(418, []byte(`{"error":null,"details":null}`))
+	f.Add(0, []byte(`{"error":[1,2],"code":{"a":1}}`))
+	f.Add(999, []byte(`{"error":"\ud800"}`))
+
+	f.Fuzz(func(t *testing.T, status int, body []byte) {
+		err := createAPIError(&http.Response{StatusCode: status}, body)
+		_ = err.Error()
+		_ = err.String()
+
+		var apiErr *APIError
+		if !errors.As(error(err), &apiErr) || apiErr.StatusCode != status {
+			t.Fatalf("createAPIError(%d) = %v, want an APIError with the status", status, err)
+		}
+		if len(err.Details) > 0 && !json.Valid(err.Details) {
+			t.Fatalf("Details of %s = %s, want valid JSON", body, err.Details)
+		}
+		if !reflect.DeepEqual(err.RawResponse, body) {
+			t.Fatalf("RawResponse = %q, want %q", err.RawResponse, body)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package dexpaprika
+
+// Optional API fields are modeled as pointers. The getters below report
+// whether a value was present and are safe to call on nil receivers, so
+// chained access such as details.Summary.GetFDV() never panics.
+
+// GetFDV returns the token's fully diluted valuation, if reported.
+func (t *Token) GetFDV() (float64, bool) {
+	if t == nil || t.FDV == nil {
+		return 0, false
+	}
+	return *t.FDV, true
+}
+
+// GetFDV returns the fully diluted valuation, if reported.
+func (s *TokenSummary) GetFDV() (float64, bool) {
+	if s == nil || s.FDV == nil {
+		return 0, false
+	}
+	return *s.FDV, true
+}
+
+// GetPools returns the number of pools containing the token, if reported.
+func (s *TokenSummary) GetPools() (int, bool) {
+	if s == nil || s.Pools == nil {
+		return 0, false
+	}
+	return *s.Pools, true
+}
+
+// GetPriceUSD returns the token's USD price, if a summary was reported.
+func (s *TokenSummary) GetPriceUSD() (float64, bool) {
+	if s == nil {
+		return 0, false
+	}
+	return s.PriceUSD, true
+}
+
+// GetLiquidityUSD returns the token's total USD liquidity, if a summary was reported.
+func (s *TokenSummary) GetLiquidityUSD() (float64, bool) {
+	if s == nil {
+		return 0, false
+	}
+	return s.LiquidityUSD, true
+}
+
+// GetSummary returns the token summary, if reported.
+func (t *TokenDetails) GetSummary() (*TokenSummary, bool) {
+	if t == nil || t.Summary == nil {
+		return nil, false
+	}
+	return t.Summary, true
+}
+
+// GetLiquidityUSD returns the pool's total USD liquidity, if reported.
+func (p *PoolDetails) GetLiquidityUSD() (float64, bool) {
+	if p == nil || p.LiquidityUSD == nil {
+		return 0, false
+	}
+	return *p.LiquidityUSD, true
+}
+
+// GetAmountUSD returns the USD value of the reserve, if reported.
+func (r *TokenReserve) GetAmountUSD() (float64, bool) {
+	if r == nil || r.AmountUSD == nil {
+		return 0, false
+	}
+	return *r.AmountUSD, true
+}
+
+// GetAmount0 returns the transaction's token0 amount as a number, if
+// reported. The API encodes amounts as numbers or strings.
+func (t *Transaction) GetAmount0() (float64, bool) {
+	if t == nil {
+		return 0, false
+	}
+	return amountValue(t.Amount0)
+}
+
+// GetAmount1 returns the transaction's token1 amount as a number, if
+// reported.
+func (t *Transaction) GetAmount1() (float64, bool) {
+	if t == nil {
+		return 0, false
+	}
+	return amountValue(t.Amount1)
+}
+
+func amountValue(v interface{}) (float64, bool) {
+	if v == nil || v == "" {
+		return 0, false
+	}
+	a, err := parseAmount(v)
+	return a, err == nil
+}
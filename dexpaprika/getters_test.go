@@ -0,0 +1,114 @@
+package dexpaprika
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGetters_NilSafety(t *testing.T) {
+	var token *Token
+	if _, ok := token.GetFDV(); ok {
+		t.Error("GetFDV on nil Token should report false")
+	}
+
+	var details TokenDetails
+	if _, ok := details.GetSummary(); ok {
+		t.Error("GetSummary should report false when summary is absent")
+	}
+	if _, ok := details.Summary.GetFDV(); ok {
+		t.Error("GetFDV on nil summary should report false")
+	}
+	if _, ok := details.Summary.GetPools(); ok {
+		t.Error("GetPools on nil summary should report false")
+	}
+	if _, ok := details.Summary.GetPriceUSD(); ok {
+		t.Error("GetPriceUSD on nil summary should report false")
+	}
+	if _, ok := details.Summary.Metrics(Interval24h); ok {
+		t.Error("Metrics on nil summary should report false")
+	}
+}
+
+func TestGetters_Decoded(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantFDV   float64
+		hasFDV    bool
+		wantPools int
+		hasPools  bool
+	}{
+		{
+			name:      "all fields present",
+			body:      `{"summary": {"price_usd": 1.0, "fdv": 39000000000, "pools": 42, "liquidity_usd": 100}}`,
+			wantFDV:   39000000000,
+			hasFDV:    true,
+			wantPools: 42,
+			hasPools:  true,
+		},
+		{
+			name: "optional fields missing",
+			body: `{"summary": {"price_usd": 1.0}}`,
+		},
+		{
+			name: "optional fields null",
+			body: `{"summary": {"price_usd": 1.0, "fdv": null, "pools": null}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var details TokenDetails
+			if err := json.Unmarshal([]byte(tc.body), &details); err != nil {
+				t.Fatalf("Unmarshal returned error: %v", err)
+			}
+
+			summary, ok := details.GetSummary()
+			if !ok {
+				t.Fatal("Expected summary to be present")
+			}
+
+			fdv, ok := summary.GetFDV()
+			if ok != tc.hasFDV || fdv != tc.wantFDV {
+				t.Errorf("GetFDV() = %f, %t, want %f, %t", fdv, ok, tc.wantFDV, tc.hasFDV)
+			}
+
+			pools, ok := summary.GetPools()
+			if ok != tc.hasPools || pools != tc.wantPools {
+				t.Errorf("GetPools() = %d, %t, want %d, %t", pools, ok, tc.wantPools, tc.hasPools)
+			}
+		})
+	}
+}
+
+func TestTransaction_GetAmounts(t *testing.T) {
+	var tx Transaction
+	if err := json.Unmarshal([]byte(`{"amount_0": "-1.5", "amount_1": 3000}`), &tx); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if a, ok := tx.GetAmount0(); !ok || a != -1.5 {
+		t.Errorf("GetAmount0() = %f, %t, want -1.5, true", a, ok)
+	}
+	if a, ok := tx.GetAmount1(); !ok || a != 3000 {
+		t.Errorf("GetAmount1() = %f, %t, want 3000, true", a, ok)
+	}
+
+	tx = Transaction{Amount0: "not a number"}
+	if _, ok := tx.GetAmount0(); ok {
+		t.Error("GetAmount0 should report false for an unparseable amount")
+	}
+	if _, ok := tx.GetAmount1(); ok {
+		t.Error("GetAmount1 should report false when the amount is absent")
+	}
+	tx = Transaction{Amount0: "NaN", Amount1: "Infinity"}
+	if _, ok := tx.GetAmount0(); ok {
+		t.Error("GetAmount0 should report false for a NaN amount")
+	}
+	if _, ok := tx.GetAmount1(); ok {
+		t.Error("GetAmount1 should report false for an infinite amount")
+	}
+	var nilTx *Transaction
+	if _, ok := nilTx.GetAmount0(); ok {
+		t.Error("GetAmount0 on nil Transaction should report false")
+	}
+}
@@ -0,0 +1,138 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Group runs an application's background subsystems, such as watchers,
+// schedulers and streams, and shuts them down together in a fixed order.
+//
+// Functions started with Go share the group's context. Shutdown cancels it,
+// waits for them to return, draining any work they have in flight, and then
+// runs the hooks registered with OnShutdown, e.g. to close streams, caches
+// and clients, in reverse order of registration.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	running  map[string]int
+	errs     []error
+	hooks    []shutdownHook
+	stopping bool
+
+	once sync.Once
+	err  error
+}
+
+type shutdownHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// NewGroup creates a group whose context is derived from ctx.
+func NewGroup(ctx context.Context) *Group {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{ctx: ctx, cancel: cancel, running: make(map[string]int)}
+}
+
+// Context returns the group's context, which is cancelled by Shutdown.
+func (g *Group) Context() context.Context {
+	return g.ctx
+}
+
+// Go runs fn in a goroutine with the group's context. fn should return once
+// the context is done. An error other than context.Canceled is reported by
+// Shutdown; it does not stop the rest of the group. Functions passed to Go
+// after Shutdown has started are not run.
+func (g *Group) Go(name string, fn func(ctx context.Context) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.stopping {
+		return
+	}
+	g.running[name]++
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		err := fn(g.ctx)
+
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		if g.running[name]--; g.running[name] == 0 {
+			delete(g.running, name)
+		}
+		if err != nil && !errors.Is(err, context.Canceled) {
+			g.errs = append(g.errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}()
+}
+
+// OnShutdown registers fn to run during Shutdown, after the functions
+// started with Go have returned. Hooks run in reverse order of
+// registration.
+func (g *Group) OnShutdown(name string, fn func(ctx context.Context) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks = append(g.hooks, shutdownHook{name: name, fn: fn})
+}
+
+// Shutdown cancels the group's context, waits for the functions started
+// with Go to return, and runs the shutdown hooks. If ctx is done first, the
+// hooks still run, with ctx, and the returned error names the functions
+// still running. The errors of functions and hooks are joined into the
+// returned error. Later calls return the result of the first.
+func (g *Group) Shutdown(ctx context.Context) error {
+	g.once.Do(func() {
+		g.err = g.shutdown(ctx)
+	})
+	return g.err
+}
+
+func (g *Group) shutdown(ctx context.Context) error {
+	g.mu.Lock()
+	g.stopping = true
+	g.mu.Unlock()
+	g.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	var timeout error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		g.mu.Lock()
+		names := make([]string, 0, len(g.running))
+		for name := range g.running {
+			names = append(names, name)
+		}
+		g.mu.Unlock()
+		sort.Strings(names)
+		timeout = fmt.Errorf("shutdown: %w; still running: %s", ctx.Err(), strings.Join(names, ", "))
+	}
+
+	g.mu.Lock()
+	errs := append([]error(nil), g.errs...)
+	hooks := g.hooks
+	g.mu.Unlock()
+
+	if timeout != nil {
+		errs = append(errs, timeout)
+	}
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i].fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", hooks[i].name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
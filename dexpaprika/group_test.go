@@ -0,0 +1,84 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGroup_Shutdown(t *testing.T) {
+	g := NewGroup(context.Background())
+
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) {
+		mu.Lock()
+		order = append(order, s)
+		mu.Unlock()
+	}
+
+	g.Go("watcher", func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond) // Drain in-flight work
+		record("watcher")
+		return ctx.Err()
+	})
+	g.Go("failing", func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+	g.OnShutdown("client", func(context.Context) error {
+		record("client")
+		return nil
+	})
+	g.OnShutdown("stream", func(context.Context) error {
+		record("stream")
+		return errors.New("close failed")
+	})
+
+	err := g.Shutdown(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failing: boom") || !strings.Contains(err.Error(), "stream: close failed") {
+		t.Errorf("Expected the function and hook errors, got %v", err)
+	}
+	if strings.Join(order, ",") != "watcher,stream,client" {
+		t.Errorf("Unexpected shutdown order %v", order)
+	}
+	if g.Context().Err() == nil {
+		t.Error("Expected the group context to be cancelled")
+	}
+	if again := g.Shutdown(context.Background()); again != err {
+		t.Errorf("Expected a second Shutdown to return the first result, got %v", again)
+	}
+
+	g.Go("late", func(context.Context) error {
+		t.Error("Expected functions added after Shutdown not to run")
+		return nil
+	})
+}
+
+func TestGroup_ShutdownTimeout(t *testing.T) {
+	g := NewGroup(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+	g.Go("stuck", func(context.Context) error {
+		<-release
+		return nil
+	})
+	hookRan := false
+	g.OnShutdown("hook", func(context.Context) error {
+		hookRan = true
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := g.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "still running: stuck") {
+		t.Errorf("Expected a timeout naming the stuck function, got %v", err)
+	}
+	if !hookRan {
+		t.Error("Expected hooks to run after the timeout")
+	}
+}
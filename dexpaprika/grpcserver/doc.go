@@ -0,0 +1,16 @@
+// Package grpcserver serves the SDK's services over gRPC, so services
+// written in other languages can consume DexPaprika data through one
+// rate-limited, cached gateway.
+//
+// The service is defined in proto/dexpaprika.proto, and its generated Go
+// stubs are committed in package proto. The package is a module of its own,
+// so only its importers depend on gRPC and protobuf:
+//
+//	go get github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/grpcserver
+//
+// Regenerating the stubs after changing the proto file needs protoc with the
+// protoc-gen-go and protoc-gen-go-grpc plugins on the PATH. Clients in other
+// languages generate theirs from the same proto file.
+package grpcserver
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative proto/dexpaprika.proto
@@ -0,0 +1,2101 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: proto/dexpaprika.proto
+
+package dexpaprikapb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PageInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	TotalItems    int32                  `protobuf:"varint,3,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageInfo) Reset() {
+	*x = PageInfo{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageInfo) ProtoMessage() {}
+
+func (x *PageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
+func (*PageInfo) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PageInfo) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PageInfo) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PageInfo) GetTotalItems() int32 {
+	if x != nil {
+		return x.TotalItems
+	}
+	return 0
+}
+
+func (x *PageInfo) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type Network struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Network) Reset() {
+	*x = Network{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Network) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Network) ProtoMessage() {}
+
+func (x *Network) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Network.ProtoReflect.Descriptor instead.
+func (*Network) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Network) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Network) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+type ListNetworksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNetworksRequest) Reset() {
+	*x = ListNetworksRequest{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNetworksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNetworksRequest) ProtoMessage() {}
+
+func (x *ListNetworksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNetworksRequest.ProtoReflect.Descriptor instead.
+func (*ListNetworksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{2}
+}
+
+type ListNetworksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Networks      []*Network             `protobuf:"bytes,1,rep,name=networks,proto3" json:"networks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNetworksResponse) Reset() {
+	*x = ListNetworksResponse{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNetworksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNetworksResponse) ProtoMessage() {}
+
+func (x *ListNetworksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNetworksResponse.ProtoReflect.Descriptor instead.
+func (*ListNetworksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListNetworksResponse) GetNetworks() []*Network {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+type Dex struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Protocol      string                 `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dex) Reset() {
+	*x = Dex{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dex) ProtoMessage() {}
+
+func (x *Dex) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dex.ProtoReflect.Descriptor instead.
+func (*Dex) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Dex) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Dex) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Dex) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+type ListDexesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NetworkId     string                 `protobuf:"bytes,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDexesRequest) Reset() {
+	*x = ListDexesRequest{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDexesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDexesRequest) ProtoMessage() {}
+
+func (x *ListDexesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDexesRequest.ProtoReflect.Descriptor instead.
+func (*ListDexesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListDexesRequest) GetNetworkId() string {
+	if x != nil {
+		return x.NetworkId
+	}
+	return ""
+}
+
+func (x *ListDexesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDexesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListDexesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dexes         []*Dex                 `protobuf:"bytes,1,rep,name=dexes,proto3" json:"dexes,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDexesResponse) Reset() {
+	*x = ListDexesResponse{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDexesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDexesResponse) ProtoMessage() {}
+
+func (x *ListDexesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDexesResponse.ProtoReflect.Descriptor instead.
+func (*ListDexesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListDexesResponse) GetDexes() []*Dex {
+	if x != nil {
+		return x.Dexes
+	}
+	return nil
+}
+
+func (x *ListDexesResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type Token struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Chain         string                 `protobuf:"bytes,4,opt,name=chain,proto3" json:"chain,omitempty"`
+	Decimals      int32                  `protobuf:"varint,5,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Fdv           *float64               `protobuf:"fixed64,6,opt,name=fdv,proto3,oneof" json:"fdv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Token) Reset() {
+	*x = Token{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Token) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Token) ProtoMessage() {}
+
+func (x *Token) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Token.ProtoReflect.Descriptor instead.
+func (*Token) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Token) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Token) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Token) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Token) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *Token) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *Token) GetFdv() float64 {
+	if x != nil && x.Fdv != nil {
+		return *x.Fdv
+	}
+	return 0
+}
+
+type Pool struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DexId         string                 `protobuf:"bytes,2,opt,name=dex_id,json=dexId,proto3" json:"dex_id,omitempty"`
+	DexName       string                 `protobuf:"bytes,3,opt,name=dex_name,json=dexName,proto3" json:"dex_name,omitempty"`
+	Chain         string                 `protobuf:"bytes,4,opt,name=chain,proto3" json:"chain,omitempty"`
+	VolumeUsd     float64                `protobuf:"fixed64,5,opt,name=volume_usd,json=volumeUsd,proto3" json:"volume_usd,omitempty"`
+	PriceUsd      float64                `protobuf:"fixed64,6,opt,name=price_usd,json=priceUsd,proto3" json:"price_usd,omitempty"`
+	Transactions  int64                  `protobuf:"varint,7,opt,name=transactions,proto3" json:"transactions,omitempty"`
+	Fee           float64                `protobuf:"fixed64,8,opt,name=fee,proto3" json:"fee,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Tokens        []*Token               `protobuf:"bytes,10,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pool) Reset() {
+	*x = Pool{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pool) ProtoMessage() {}
+
+func (x *Pool) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pool.ProtoReflect.Descriptor instead.
+func (*Pool) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Pool) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Pool) GetDexId() string {
+	if x != nil {
+		return x.DexId
+	}
+	return ""
+}
+
+func (x *Pool) GetDexName() string {
+	if x != nil {
+		return x.DexName
+	}
+	return ""
+}
+
+func (x *Pool) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *Pool) GetVolumeUsd() float64 {
+	if x != nil {
+		return x.VolumeUsd
+	}
+	return 0
+}
+
+func (x *Pool) GetPriceUsd() float64 {
+	if x != nil {
+		return x.PriceUsd
+	}
+	return 0
+}
+
+func (x *Pool) GetTransactions() int64 {
+	if x != nil {
+		return x.Transactions
+	}
+	return 0
+}
+
+func (x *Pool) GetFee() float64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *Pool) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Pool) GetTokens() []*Token {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type ListPoolsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NetworkId     string                 `protobuf:"bytes,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	DexId         string                 `protobuf:"bytes,2,opt,name=dex_id,json=dexId,proto3" json:"dex_id,omitempty"` // Optional, lists the pools of one dex
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	OrderBy       string                 `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"` // volume_usd by default
+	Sort          string                 `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"`                      // desc by default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPoolsRequest) Reset() {
+	*x = ListPoolsRequest{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPoolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoolsRequest) ProtoMessage() {}
+
+func (x *ListPoolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoolsRequest.ProtoReflect.Descriptor instead.
+func (*ListPoolsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListPoolsRequest) GetNetworkId() string {
+	if x != nil {
+		return x.NetworkId
+	}
+	return ""
+}
+
+func (x *ListPoolsRequest) GetDexId() string {
+	if x != nil {
+		return x.DexId
+	}
+	return ""
+}
+
+func (x *ListPoolsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPoolsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPoolsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListPoolsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListPoolsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pools         []*Pool                `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPoolsResponse) Reset() {
+	*x = ListPoolsResponse{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPoolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoolsResponse) ProtoMessage() {}
+
+func (x *ListPoolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoolsResponse.ProtoReflect.Descriptor instead.
+func (*ListPoolsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListPoolsResponse) GetPools() []*Pool {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+func (x *ListPoolsResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type IntervalMetrics struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	LastPriceUsdChange float64                `protobuf:"fixed64,1,opt,name=last_price_usd_change,json=lastPriceUsdChange,proto3" json:"last_price_usd_change,omitempty"`
+	VolumeUsd          float64                `protobuf:"fixed64,2,opt,name=volume_usd,json=volumeUsd,proto3" json:"volume_usd,omitempty"`
+	BuyUsd             float64                `protobuf:"fixed64,3,opt,name=buy_usd,json=buyUsd,proto3" json:"buy_usd,omitempty"`
+	SellUsd            float64                `protobuf:"fixed64,4,opt,name=sell_usd,json=sellUsd,proto3" json:"sell_usd,omitempty"`
+	Buys               int32                  `protobuf:"varint,5,opt,name=buys,proto3" json:"buys,omitempty"`
+	Sells              int32                  `protobuf:"varint,6,opt,name=sells,proto3" json:"sells,omitempty"`
+	Txns               int32                  `protobuf:"varint,7,opt,name=txns,proto3" json:"txns,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *IntervalMetrics) Reset() {
+	*x = IntervalMetrics{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntervalMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntervalMetrics) ProtoMessage() {}
+
+func (x *IntervalMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntervalMetrics.ProtoReflect.Descriptor instead.
+func (*IntervalMetrics) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *IntervalMetrics) GetLastPriceUsdChange() float64 {
+	if x != nil {
+		return x.LastPriceUsdChange
+	}
+	return 0
+}
+
+func (x *IntervalMetrics) GetVolumeUsd() float64 {
+	if x != nil {
+		return x.VolumeUsd
+	}
+	return 0
+}
+
+func (x *IntervalMetrics) GetBuyUsd() float64 {
+	if x != nil {
+		return x.BuyUsd
+	}
+	return 0
+}
+
+func (x *IntervalMetrics) GetSellUsd() float64 {
+	if x != nil {
+		return x.SellUsd
+	}
+	return 0
+}
+
+func (x *IntervalMetrics) GetBuys() int32 {
+	if x != nil {
+		return x.Buys
+	}
+	return 0
+}
+
+func (x *IntervalMetrics) GetSells() int32 {
+	if x != nil {
+		return x.Sells
+	}
+	return 0
+}
+
+func (x *IntervalMetrics) GetTxns() int32 {
+	if x != nil {
+		return x.Txns
+	}
+	return 0
+}
+
+type GetPoolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NetworkId     string                 `protobuf:"bytes,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Inversed      bool                   `protobuf:"varint,3,opt,name=inversed,proto3" json:"inversed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPoolRequest) Reset() {
+	*x = GetPoolRequest{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoolRequest) ProtoMessage() {}
+
+func (x *GetPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoolRequest.ProtoReflect.Descriptor instead.
+func (*GetPoolRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetPoolRequest) GetNetworkId() string {
+	if x != nil {
+		return x.NetworkId
+	}
+	return ""
+}
+
+func (x *GetPoolRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetPoolRequest) GetInversed() bool {
+	if x != nil {
+		return x.Inversed
+	}
+	return false
+}
+
+type PoolDetails struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Id            string                      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Chain         string                      `protobuf:"bytes,2,opt,name=chain,proto3" json:"chain,omitempty"`
+	DexId         string                      `protobuf:"bytes,3,opt,name=dex_id,json=dexId,proto3" json:"dex_id,omitempty"`
+	DexName       string                      `protobuf:"bytes,4,opt,name=dex_name,json=dexName,proto3" json:"dex_name,omitempty"`
+	Tokens        []*Token                    `protobuf:"bytes,5,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	LastPrice     float64                     `protobuf:"fixed64,6,opt,name=last_price,json=lastPrice,proto3" json:"last_price,omitempty"`
+	LastPriceUsd  float64                     `protobuf:"fixed64,7,opt,name=last_price_usd,json=lastPriceUsd,proto3" json:"last_price_usd,omitempty"`
+	Fee           float64                     `protobuf:"fixed64,8,opt,name=fee,proto3" json:"fee,omitempty"`
+	PriceTime     *timestamppb.Timestamp      `protobuf:"bytes,9,opt,name=price_time,json=priceTime,proto3" json:"price_time,omitempty"`
+	LiquidityUsd  *float64                    `protobuf:"fixed64,10,opt,name=liquidity_usd,json=liquidityUsd,proto3,oneof" json:"liquidity_usd,omitempty"`
+	Metrics       map[string]*IntervalMetrics `protobuf:"bytes,11,rep,name=metrics,proto3" json:"metrics,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // By interval, e.g. "24h"
+	CreatedAt     *timestamppb.Timestamp      `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolDetails) Reset() {
+	*x = PoolDetails{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolDetails) ProtoMessage() {}
+
+func (x *PoolDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolDetails.ProtoReflect.Descriptor instead.
+func (*PoolDetails) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PoolDetails) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PoolDetails) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *PoolDetails) GetDexId() string {
+	if x != nil {
+		return x.DexId
+	}
+	return ""
+}
+
+func (x *PoolDetails) GetDexName() string {
+	if x != nil {
+		return x.DexName
+	}
+	return ""
+}
+
+func (x *PoolDetails) GetTokens() []*Token {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *PoolDetails) GetLastPrice() float64 {
+	if x != nil {
+		return x.LastPrice
+	}
+	return 0
+}
+
+func (x *PoolDetails) GetLastPriceUsd() float64 {
+	if x != nil {
+		return x.LastPriceUsd
+	}
+	return 0
+}
+
+func (x *PoolDetails) GetFee() float64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *PoolDetails) GetPriceTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PriceTime
+	}
+	return nil
+}
+
+func (x *PoolDetails) GetLiquidityUsd() float64 {
+	if x != nil && x.LiquidityUsd != nil {
+		return *x.LiquidityUsd
+	}
+	return 0
+}
+
+func (x *PoolDetails) GetMetrics() map[string]*IntervalMetrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *PoolDetails) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetPoolOHLCVRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NetworkId     string                 `protobuf:"bytes,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	Interval      string                 `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"` // 24h by default
+	Inversed      bool                   `protobuf:"varint,6,opt,name=inversed,proto3" json:"inversed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPoolOHLCVRequest) Reset() {
+	*x = GetPoolOHLCVRequest{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPoolOHLCVRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoolOHLCVRequest) ProtoMessage() {}
+
+func (x *GetPoolOHLCVRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoolOHLCVRequest.ProtoReflect.Descriptor instead.
+func (*GetPoolOHLCVRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetPoolOHLCVRequest) GetNetworkId() string {
+	if x != nil {
+		return x.NetworkId
+	}
+	return ""
+}
+
+func (x *GetPoolOHLCVRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetPoolOHLCVRequest) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *GetPoolOHLCVRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *GetPoolOHLCVRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *GetPoolOHLCVRequest) GetInversed() bool {
+	if x != nil {
+		return x.Inversed
+	}
+	return false
+}
+
+type Candle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TimeOpen      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time_open,json=timeOpen,proto3" json:"time_open,omitempty"`
+	TimeClose     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time_close,json=timeClose,proto3" json:"time_close,omitempty"`
+	Open          float64                `protobuf:"fixed64,3,opt,name=open,proto3" json:"open,omitempty"`
+	High          float64                `protobuf:"fixed64,4,opt,name=high,proto3" json:"high,omitempty"`
+	Low           float64                `protobuf:"fixed64,5,opt,name=low,proto3" json:"low,omitempty"`
+	Close         float64                `protobuf:"fixed64,6,opt,name=close,proto3" json:"close,omitempty"`
+	Volume        int64                  `protobuf:"varint,7,opt,name=volume,proto3" json:"volume,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Candle) Reset() {
+	*x = Candle{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candle) ProtoMessage() {}
+
+func (x *Candle) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candle.ProtoReflect.Descriptor instead.
+func (*Candle) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Candle) GetTimeOpen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeOpen
+	}
+	return nil
+}
+
+func (x *Candle) GetTimeClose() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeClose
+	}
+	return nil
+}
+
+func (x *Candle) GetOpen() float64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *Candle) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *Candle) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *Candle) GetClose() float64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *Candle) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+type GetPoolOHLCVResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candles       []*Candle              `protobuf:"bytes,1,rep,name=candles,proto3" json:"candles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPoolOHLCVResponse) Reset() {
+	*x = GetPoolOHLCVResponse{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPoolOHLCVResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoolOHLCVResponse) ProtoMessage() {}
+
+func (x *GetPoolOHLCVResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoolOHLCVResponse.ProtoReflect.Descriptor instead.
+func (*GetPoolOHLCVResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetPoolOHLCVResponse) GetCandles() []*Candle {
+	if x != nil {
+		return x.Candles
+	}
+	return nil
+}
+
+type ListPoolTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NetworkId     string                 `protobuf:"bytes,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPoolTransactionsRequest) Reset() {
+	*x = ListPoolTransactionsRequest{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPoolTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoolTransactionsRequest) ProtoMessage() {}
+
+func (x *ListPoolTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoolTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPoolTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListPoolTransactionsRequest) GetNetworkId() string {
+	if x != nil {
+		return x.NetworkId
+	}
+	return ""
+}
+
+func (x *ListPoolTransactionsRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListPoolTransactionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPoolTransactionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPoolTransactionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type Transaction struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LogIndex             int32                  `protobuf:"varint,2,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	TransactionIndex     int32                  `protobuf:"varint,3,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	PoolId               string                 `protobuf:"bytes,4,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	Sender               string                 `protobuf:"bytes,5,opt,name=sender,proto3" json:"sender,omitempty"`
+	Recipient            string                 `protobuf:"bytes,6,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Token0               string                 `protobuf:"bytes,7,opt,name=token0,proto3" json:"token0,omitempty"`
+	Token1               string                 `protobuf:"bytes,8,opt,name=token1,proto3" json:"token1,omitempty"`
+	Amount0              *float64               `protobuf:"fixed64,9,opt,name=amount0,proto3,oneof" json:"amount0,omitempty"`
+	Amount1              *float64               `protobuf:"fixed64,10,opt,name=amount1,proto3,oneof" json:"amount1,omitempty"`
+	CreatedAtBlockNumber int64                  `protobuf:"varint,11,opt,name=created_at_block_number,json=createdAtBlockNumber,proto3" json:"created_at_block_number,omitempty"`
+	CreatedAt            *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Transaction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Transaction) GetLogIndex() int32 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *Transaction) GetTransactionIndex() int32 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *Transaction) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+func (x *Transaction) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *Transaction) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *Transaction) GetToken0() string {
+	if x != nil {
+		return x.Token0
+	}
+	return ""
+}
+
+func (x *Transaction) GetToken1() string {
+	if x != nil {
+		return x.Token1
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount0() float64 {
+	if x != nil && x.Amount0 != nil {
+		return *x.Amount0
+	}
+	return 0
+}
+
+func (x *Transaction) GetAmount1() float64 {
+	if x != nil && x.Amount1 != nil {
+		return *x.Amount1
+	}
+	return 0
+}
+
+func (x *Transaction) GetCreatedAtBlockNumber() int64 {
+	if x != nil {
+		return x.CreatedAtBlockNumber
+	}
+	return 0
+}
+
+func (x *Transaction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListPoolTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPoolTransactionsResponse) Reset() {
+	*x = ListPoolTransactionsResponse{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPoolTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoolTransactionsResponse) ProtoMessage() {}
+
+func (x *ListPoolTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoolTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPoolTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListPoolTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListPoolTransactionsResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type GetTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NetworkId     string                 `protobuf:"bytes,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenRequest) Reset() {
+	*x = GetTokenRequest{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenRequest) ProtoMessage() {}
+
+func (x *GetTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenRequest.ProtoReflect.Descriptor instead.
+func (*GetTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetTokenRequest) GetNetworkId() string {
+	if x != nil {
+		return x.NetworkId
+	}
+	return ""
+}
+
+func (x *GetTokenRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type TokenDetails struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Id            string                      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol        string                      `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Chain         string                      `protobuf:"bytes,4,opt,name=chain,proto3" json:"chain,omitempty"`
+	Decimals      int32                       `protobuf:"varint,5,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	TotalSupply   float64                     `protobuf:"fixed64,6,opt,name=total_supply,json=totalSupply,proto3" json:"total_supply,omitempty"`
+	PriceUsd      *float64                    `protobuf:"fixed64,7,opt,name=price_usd,json=priceUsd,proto3,oneof" json:"price_usd,omitempty"`
+	LiquidityUsd  *float64                    `protobuf:"fixed64,8,opt,name=liquidity_usd,json=liquidityUsd,proto3,oneof" json:"liquidity_usd,omitempty"`
+	Fdv           *float64                    `protobuf:"fixed64,9,opt,name=fdv,proto3,oneof" json:"fdv,omitempty"`
+	Metrics       map[string]*IntervalMetrics `protobuf:"bytes,10,rep,name=metrics,proto3" json:"metrics,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // By interval, e.g. "24h"
+	LastUpdated   *timestamppb.Timestamp      `protobuf:"bytes,11,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenDetails) Reset() {
+	*x = TokenDetails{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenDetails) ProtoMessage() {}
+
+func (x *TokenDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenDetails.ProtoReflect.Descriptor instead.
+func (*TokenDetails) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TokenDetails) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TokenDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TokenDetails) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *TokenDetails) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *TokenDetails) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *TokenDetails) GetTotalSupply() float64 {
+	if x != nil {
+		return x.TotalSupply
+	}
+	return 0
+}
+
+func (x *TokenDetails) GetPriceUsd() float64 {
+	if x != nil && x.PriceUsd != nil {
+		return *x.PriceUsd
+	}
+	return 0
+}
+
+func (x *TokenDetails) GetLiquidityUsd() float64 {
+	if x != nil && x.LiquidityUsd != nil {
+		return *x.LiquidityUsd
+	}
+	return 0
+}
+
+func (x *TokenDetails) GetFdv() float64 {
+	if x != nil && x.Fdv != nil {
+		return *x.Fdv
+	}
+	return 0
+}
+
+func (x *TokenDetails) GetMetrics() map[string]*IntervalMetrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *TokenDetails) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	NetworkId     string                 `protobuf:"bytes,2,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"` // Optional, keeps results on one network
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                         // Per category
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetNetworkId() string {
+	if x != nil {
+		return x.NetworkId
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*Token               `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	Pools         []*Pool                `protobuf:"bytes,2,rep,name=pools,proto3" json:"pools,omitempty"`
+	Dexes         []*Dex                 `protobuf:"bytes,3,rep,name=dexes,proto3" json:"dexes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_proto_dexpaprika_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dexpaprika_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dexpaprika_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SearchResponse) GetTokens() []*Token {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetPools() []*Pool {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetDexes() []*Dex {
+	if x != nil {
+		return x.Dexes
+	}
+	return nil
+}
+
+var File_proto_dexpaprika_proto protoreflect.FileDescriptor
+
+const file_proto_dexpaprika_proto_rawDesc = "" +
+	"\n" +
+	"\x16proto/dexpaprika.proto\x12\rdexpaprika.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"v\n" +
+	"\bPageInfo\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vtotal_items\x18\x03 \x01(\x05R\n" +
+	"totalItems\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
+	"totalPages\"<\n" +
+	"\aNetwork\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\"\x15\n" +
+	"\x13ListNetworksRequest\"J\n" +
+	"\x14ListNetworksResponse\x122\n" +
+	"\bnetworks\x18\x01 \x03(\v2\x16.dexpaprika.v1.NetworkR\bnetworks\"E\n" +
+	"\x03Dex\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bprotocol\x18\x03 \x01(\tR\bprotocol\"[\n" +
+	"\x10ListDexesRequest\x12\x1d\n" +
+	"\n" +
+	"network_id\x18\x01 \x01(\tR\tnetworkId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"s\n" +
+	"\x11ListDexesResponse\x12(\n" +
+	"\x05dexes\x18\x01 \x03(\v2\x12.dexpaprika.v1.DexR\x05dexes\x124\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x17.dexpaprika.v1.PageInfoR\bpageInfo\"\x94\x01\n" +
+	"\x05Token\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05chain\x18\x04 \x01(\tR\x05chain\x12\x1a\n" +
+	"\bdecimals\x18\x05 \x01(\x05R\bdecimals\x12\x15\n" +
+	"\x03fdv\x18\x06 \x01(\x01H\x00R\x03fdv\x88\x01\x01B\x06\n" +
+	"\x04_fdv\"\xb9\x02\n" +
+	"\x04Pool\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06dex_id\x18\x02 \x01(\tR\x05dexId\x12\x19\n" +
+	"\bdex_name\x18\x03 \x01(\tR\adexName\x12\x14\n" +
+	"\x05chain\x18\x04 \x01(\tR\x05chain\x12\x1d\n" +
+	"\n" +
+	"volume_usd\x18\x05 \x01(\x01R\tvolumeUsd\x12\x1b\n" +
+	"\tprice_usd\x18\x06 \x01(\x01R\bpriceUsd\x12\"\n" +
+	"\ftransactions\x18\a \x01(\x03R\ftransactions\x12\x10\n" +
+	"\x03fee\x18\b \x01(\x01R\x03fee\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12,\n" +
+	"\x06tokens\x18\n" +
+	" \x03(\v2\x14.dexpaprika.v1.TokenR\x06tokens\"\xa1\x01\n" +
+	"\x10ListPoolsRequest\x12\x1d\n" +
+	"\n" +
+	"network_id\x18\x01 \x01(\tR\tnetworkId\x12\x15\n" +
+	"\x06dex_id\x18\x02 \x01(\tR\x05dexId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x19\n" +
+	"\border_by\x18\x05 \x01(\tR\aorderBy\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\tR\x04sort\"t\n" +
+	"\x11ListPoolsResponse\x12)\n" +
+	"\x05pools\x18\x01 \x03(\v2\x13.dexpaprika.v1.PoolR\x05pools\x124\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x17.dexpaprika.v1.PageInfoR\bpageInfo\"\xd5\x01\n" +
+	"\x0fIntervalMetrics\x121\n" +
+	"\x15last_price_usd_change\x18\x01 \x01(\x01R\x12lastPriceUsdChange\x12\x1d\n" +
+	"\n" +
+	"volume_usd\x18\x02 \x01(\x01R\tvolumeUsd\x12\x17\n" +
+	"\abuy_usd\x18\x03 \x01(\x01R\x06buyUsd\x12\x19\n" +
+	"\bsell_usd\x18\x04 \x01(\x01R\asellUsd\x12\x12\n" +
+	"\x04buys\x18\x05 \x01(\x05R\x04buys\x12\x14\n" +
+	"\x05sells\x18\x06 \x01(\x05R\x05sells\x12\x12\n" +
+	"\x04txns\x18\a \x01(\x05R\x04txns\"e\n" +
+	"\x0eGetPoolRequest\x12\x1d\n" +
+	"\n" +
+	"network_id\x18\x01 \x01(\tR\tnetworkId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1a\n" +
+	"\binversed\x18\x03 \x01(\bR\binversed\"\xbb\x04\n" +
+	"\vPoolDetails\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05chain\x18\x02 \x01(\tR\x05chain\x12\x15\n" +
+	"\x06dex_id\x18\x03 \x01(\tR\x05dexId\x12\x19\n" +
+	"\bdex_name\x18\x04 \x01(\tR\adexName\x12,\n" +
+	"\x06tokens\x18\x05 \x03(\v2\x14.dexpaprika.v1.TokenR\x06tokens\x12\x1d\n" +
+	"\n" +
+	"last_price\x18\x06 \x01(\x01R\tlastPrice\x12$\n" +
+	"\x0elast_price_usd\x18\a \x01(\x01R\flastPriceUsd\x12\x10\n" +
+	"\x03fee\x18\b \x01(\x01R\x03fee\x129\n" +
+	"\n" +
+	"price_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tpriceTime\x12(\n" +
+	"\rliquidity_usd\x18\n" +
+	" \x01(\x01H\x00R\fliquidityUsd\x88\x01\x01\x12A\n" +
+	"\ametrics\x18\v \x03(\v2'.dexpaprika.v1.PoolDetails.MetricsEntryR\ametrics\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x1aZ\n" +
+	"\fMetricsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x124\n" +
+	"\x05value\x18\x02 \x01(\v2\x1e.dexpaprika.v1.IntervalMetricsR\x05value:\x028\x01B\x10\n" +
+	"\x0e_liquidity_usd\"\xe6\x01\n" +
+	"\x13GetPoolOHLCVRequest\x12\x1d\n" +
+	"\n" +
+	"network_id\x18\x01 \x01(\tR\tnetworkId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x1a\n" +
+	"\binterval\x18\x05 \x01(\tR\binterval\x12\x1a\n" +
+	"\binversed\x18\x06 \x01(\bR\binversed\"\xe4\x01\n" +
+	"\x06Candle\x127\n" +
+	"\ttime_open\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\btimeOpen\x129\n" +
+	"\n" +
+	"time_close\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimeClose\x12\x12\n" +
+	"\x04open\x18\x03 \x01(\x01R\x04open\x12\x12\n" +
+	"\x04high\x18\x04 \x01(\x01R\x04high\x12\x10\n" +
+	"\x03low\x18\x05 \x01(\x01R\x03low\x12\x14\n" +
+	"\x05close\x18\x06 \x01(\x01R\x05close\x12\x16\n" +
+	"\x06volume\x18\a \x01(\x03R\x06volume\"G\n" +
+	"\x14GetPoolOHLCVResponse\x12/\n" +
+	"\acandles\x18\x01 \x03(\v2\x15.dexpaprika.v1.CandleR\acandles\"\x98\x01\n" +
+	"\x1bListPoolTransactionsRequest\x12\x1d\n" +
+	"\n" +
+	"network_id\x18\x01 \x01(\tR\tnetworkId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\"\xae\x03\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tlog_index\x18\x02 \x01(\x05R\blogIndex\x12+\n" +
+	"\x11transaction_index\x18\x03 \x01(\x05R\x10transactionIndex\x12\x17\n" +
+	"\apool_id\x18\x04 \x01(\tR\x06poolId\x12\x16\n" +
+	"\x06sender\x18\x05 \x01(\tR\x06sender\x12\x1c\n" +
+	"\trecipient\x18\x06 \x01(\tR\trecipient\x12\x16\n" +
+	"\x06token0\x18\a \x01(\tR\x06token0\x12\x16\n" +
+	"\x06token1\x18\b \x01(\tR\x06token1\x12\x1d\n" +
+	"\aamount0\x18\t \x01(\x01H\x00R\aamount0\x88\x01\x01\x12\x1d\n" +
+	"\aamount1\x18\n" +
+	" \x01(\x01H\x01R\aamount1\x88\x01\x01\x125\n" +
+	"\x17created_at_block_number\x18\v \x01(\x03R\x14createdAtBlockNumber\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\n" +
+	"\n" +
+	"\b_amount0B\n" +
+	"\n" +
+	"\b_amount1\"\x94\x01\n" +
+	"\x1cListPoolTransactionsResponse\x12>\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1a.dexpaprika.v1.TransactionR\ftransactions\x124\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x17.dexpaprika.v1.PageInfoR\bpageInfo\"J\n" +
+	"\x0fGetTokenRequest\x12\x1d\n" +
+	"\n" +
+	"network_id\x18\x01 \x01(\tR\tnetworkId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\"\x89\x04\n" +
+	"\fTokenDetails\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05chain\x18\x04 \x01(\tR\x05chain\x12\x1a\n" +
+	"\bdecimals\x18\x05 \x01(\x05R\bdecimals\x12!\n" +
+	"\ftotal_supply\x18\x06 \x01(\x01R\vtotalSupply\x12 \n" +
+	"\tprice_usd\x18\a \x01(\x01H\x00R\bpriceUsd\x88\x01\x01\x12(\n" +
+	"\rliquidity_usd\x18\b \x01(\x01H\x01R\fliquidityUsd\x88\x01\x01\x12\x15\n" +
+	"\x03fdv\x18\t \x01(\x01H\x02R\x03fdv\x88\x01\x01\x12B\n" +
+	"\ametrics\x18\n" +
+	" \x03(\v2(.dexpaprika.v1.TokenDetails.MetricsEntryR\ametrics\x12=\n" +
+	"\flast_updated\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x1aZ\n" +
+	"\fMetricsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x124\n" +
+	"\x05value\x18\x02 \x01(\v2\x1e.dexpaprika.v1.IntervalMetricsR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_price_usdB\x10\n" +
+	"\x0e_liquidity_usdB\x06\n" +
+	"\x04_fdv\"Z\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1d\n" +
+	"\n" +
+	"network_id\x18\x02 \x01(\tR\tnetworkId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x93\x01\n" +
+	"\x0eSearchResponse\x12,\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x14.dexpaprika.v1.TokenR\x06tokens\x12)\n" +
+	"\x05pools\x18\x02 \x03(\v2\x13.dexpaprika.v1.PoolR\x05pools\x12(\n" +
+	"\x05dexes\x18\x03 \x03(\v2\x12.dexpaprika.v1.DexR\x05dexes2\xa5\x05\n" +
+	"\n" +
+	"DexPaprika\x12W\n" +
+	"\fListNetworks\x12\".dexpaprika.v1.ListNetworksRequest\x1a#.dexpaprika.v1.ListNetworksResponse\x12N\n" +
+	"\tListDexes\x12\x1f.dexpaprika.v1.ListDexesRequest\x1a .dexpaprika.v1.ListDexesResponse\x12N\n" +
+	"\tListPools\x12\x1f.dexpaprika.v1.ListPoolsRequest\x1a .dexpaprika.v1.ListPoolsResponse\x12D\n" +
+	"\aGetPool\x12\x1d.dexpaprika.v1.GetPoolRequest\x1a\x1a.dexpaprika.v1.PoolDetails\x12W\n" +
+	"\fGetPoolOHLCV\x12\".dexpaprika.v1.GetPoolOHLCVRequest\x1a#.dexpaprika.v1.GetPoolOHLCVResponse\x12o\n" +
+	"\x14ListPoolTransactions\x12*.dexpaprika.v1.ListPoolTransactionsRequest\x1a+.dexpaprika.v1.ListPoolTransactionsResponse\x12G\n" +
+	"\bGetToken\x12\x1e.dexpaprika.v1.GetTokenRequest\x1a\x1b.dexpaprika.v1.TokenDetails\x12E\n" +
+	"\x06Search\x12\x1c.dexpaprika.v1.SearchRequest\x1a\x1d.dexpaprika.v1.SearchResponseBSZQgithub.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/grpcserver/proto;dexpaprikapbb\x06proto3"
+
+var (
+	file_proto_dexpaprika_proto_rawDescOnce sync.Once
+	file_proto_dexpaprika_proto_rawDescData []byte
+)
+
+func file_proto_dexpaprika_proto_rawDescGZIP() []byte {
+	file_proto_dexpaprika_proto_rawDescOnce.Do(func() {
+		file_proto_dexpaprika_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_dexpaprika_proto_rawDesc), len(file_proto_dexpaprika_proto_rawDesc)))
+	})
+	return file_proto_dexpaprika_proto_rawDescData
+}
+
+var file_proto_dexpaprika_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_proto_dexpaprika_proto_goTypes = []any{
+	(*PageInfo)(nil),                     // 0: dexpaprika.v1.PageInfo
+	(*Network)(nil),                      // 1: dexpaprika.v1.Network
+	(*ListNetworksRequest)(nil),          // 2: dexpaprika.v1.ListNetworksRequest
+	(*ListNetworksResponse)(nil),         // 3: dexpaprika.v1.ListNetworksResponse
+	(*Dex)(nil),                          // 4: dexpaprika.v1.Dex
+	(*ListDexesRequest)(nil),             // 5: dexpaprika.v1.ListDexesRequest
+	(*ListDexesResponse)(nil),            // 6: dexpaprika.v1.ListDexesResponse
+	(*Token)(nil),                        // 7: dexpaprika.v1.Token
+	(*Pool)(nil),                         // 8: dexpaprika.v1.Pool
+	(*ListPoolsRequest)(nil),             // 9: dexpaprika.v1.ListPoolsRequest
+	(*ListPoolsResponse)(nil),            // 10: dexpaprika.v1.ListPoolsResponse
+	(*IntervalMetrics)(nil),              // 11: dexpaprika.v1.IntervalMetrics
+	(*GetPoolRequest)(nil),               // 12: dexpaprika.v1.GetPoolRequest
+	(*PoolDetails)(nil),                  // 13: dexpaprika.v1.PoolDetails
+	(*GetPoolOHLCVRequest)(nil),          // 14: dexpaprika.v1.GetPoolOHLCVRequest
+	(*Candle)(nil),                       // 15: dexpaprika.v1.Candle
+	(*GetPoolOHLCVResponse)(nil),         // 16: dexpaprika.v1.GetPoolOHLCVResponse
+	(*ListPoolTransactionsRequest)(nil),  // 17: dexpaprika.v1.ListPoolTransactionsRequest
+	(*Transaction)(nil),                  // 18: dexpaprika.v1.Transaction
+	(*ListPoolTransactionsResponse)(nil), // 19: dexpaprika.v1.ListPoolTransactionsResponse
+	(*GetTokenRequest)(nil),              // 20: dexpaprika.v1.GetTokenRequest
+	(*TokenDetails)(nil),                 // 21: dexpaprika.v1.TokenDetails
+	(*SearchRequest)(nil),                // 22: dexpaprika.v1.SearchRequest
+	(*SearchResponse)(nil),               // 23: dexpaprika.v1.SearchResponse
+	nil,                                  // 24: dexpaprika.v1.PoolDetails.MetricsEntry
+	nil,                                  // 25: dexpaprika.v1.TokenDetails.MetricsEntry
+	(*timestamppb.Timestamp)(nil),        // 26: google.protobuf.Timestamp
+}
+var file_proto_dexpaprika_proto_depIdxs = []int32{
+	1,  // 0: dexpaprika.v1.ListNetworksResponse.networks:type_name -> dexpaprika.v1.Network
+	4,  // 1: dexpaprika.v1.ListDexesResponse.dexes:type_name -> dexpaprika.v1.Dex
+	0,  // 2: dexpaprika.v1.ListDexesResponse.page_info:type_name -> dexpaprika.v1.PageInfo
+	26, // 3: dexpaprika.v1.Pool.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 4: dexpaprika.v1.Pool.tokens:type_name -> dexpaprika.v1.Token
+	8,  // 5: dexpaprika.v1.ListPoolsResponse.pools:type_name -> dexpaprika.v1.Pool
+	0,  // 6: dexpaprika.v1.ListPoolsResponse.page_info:type_name -> dexpaprika.v1.PageInfo
+	7,  // 7: dexpaprika.v1.PoolDetails.tokens:type_name -> dexpaprika.v1.Token
+	26, // 8: dexpaprika.v1.PoolDetails.price_time:type_name -> google.protobuf.Timestamp
+	24, // 9: dexpaprika.v1.PoolDetails.metrics:type_name -> dexpaprika.v1.PoolDetails.MetricsEntry
+	26, // 10: dexpaprika.v1.PoolDetails.created_at:type_name -> google.protobuf.Timestamp
+	26, // 11: dexpaprika.v1.GetPoolOHLCVRequest.start:type_name -> google.protobuf.Timestamp
+	26, // 12: dexpaprika.v1.GetPoolOHLCVRequest.end:type_name -> google.protobuf.Timestamp
+	26, // 13: dexpaprika.v1.Candle.time_open:type_name -> google.protobuf.Timestamp
+	26, // 14: dexpaprika.v1.Candle.time_close:type_name -> google.protobuf.Timestamp
+	15, // 15: dexpaprika.v1.GetPoolOHLCVResponse.candles:type_name -> dexpaprika.v1.Candle
+	26, // 16: dexpaprika.v1.Transaction.created_at:type_name -> google.protobuf.Timestamp
+	18, // 17: dexpaprika.v1.ListPoolTransactionsResponse.transactions:type_name -> dexpaprika.v1.Transaction
+	0,  // 18: dexpaprika.v1.ListPoolTransactionsResponse.page_info:type_name -> dexpaprika.v1.PageInfo
+	25, // 19: dexpaprika.v1.TokenDetails.metrics:type_name -> dexpaprika.v1.TokenDetails.MetricsEntry
+	26, // 20: dexpaprika.v1.TokenDetails.last_updated:type_name -> google.protobuf.Timestamp
+	7,  // 21: dexpaprika.v1.SearchResponse.tokens:type_name -> dexpaprika.v1.Token
+	8,  // 22: dexpaprika.v1.SearchResponse.pools:type_name -> dexpaprika.v1.Pool
+	4,  // 23: dexpaprika.v1.SearchResponse.dexes:type_name -> dexpaprika.v1.Dex
+	11, // 24: dexpaprika.v1.PoolDetails.MetricsEntry.value:type_name -> dexpaprika.v1.IntervalMetrics
+	11, // 25: dexpaprika.v1.TokenDetails.MetricsEntry.value:type_name -> dexpaprika.v1.IntervalMetrics
+	2,  // 26: dexpaprika.v1.DexPaprika.ListNetworks:input_type -> dexpaprika.v1.ListNetworksRequest
+	5,  // 27: dexpaprika.v1.DexPaprika.ListDexes:input_type -> dexpaprika.v1.ListDexesRequest
+	9,  // 28: dexpaprika.v1.DexPaprika.ListPools:input_type -> dexpaprika.v1.ListPoolsRequest
+	12, // 29: dexpaprika.v1.DexPaprika.GetPool:input_type -> dexpaprika.v1.GetPoolRequest
+	14, // 30: dexpaprika.v1.DexPaprika.GetPoolOHLCV:input_type -> dexpaprika.v1.GetPoolOHLCVRequest
+	17, // 31: dexpaprika.v1.DexPaprika.ListPoolTransactions:input_type -> dexpaprika.v1.ListPoolTransactionsRequest
+	20, // 32: dexpaprika.v1.DexPaprika.GetToken:input_type -> dexpaprika.v1.GetTokenRequest
+	22, // 33: dexpaprika.v1.DexPaprika.Search:input_type -> dexpaprika.v1.SearchRequest
+	3,  // 34: dexpaprika.v1.DexPaprika.ListNetworks:output_type -> dexpaprika.v1.ListNetworksResponse
+	6,  // 35: dexpaprika.v1.DexPaprika.ListDexes:output_type -> dexpaprika.v1.ListDexesResponse
+	10, // 36: dexpaprika.v1.DexPaprika.ListPools:output_type -> dexpaprika.v1.ListPoolsResponse
+	13, // 37: dexpaprika.v1.DexPaprika.GetPool:output_type -> dexpaprika.v1.PoolDetails
+	16, // 38: dexpaprika.v1.DexPaprika.GetPoolOHLCV:output_type -> dexpaprika.v1.GetPoolOHLCVResponse
+	19, // 39: dexpaprika.v1.DexPaprika.ListPoolTransactions:output_type -> dexpaprika.v1.ListPoolTransactionsResponse
+	21, // 40: dexpaprika.v1.DexPaprika.GetToken:output_type -> dexpaprika.v1.TokenDetails
+	23, // 41: dexpaprika.v1.DexPaprika.Search:output_type -> dexpaprika.v1.SearchResponse
+	34, // [34:42] is the sub-list for method output_type
+	26, // [26:34] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_proto_dexpaprika_proto_init() }
+func file_proto_dexpaprika_proto_init() {
+	if File_proto_dexpaprika_proto != nil {
+		return
+	}
+	file_proto_dexpaprika_proto_msgTypes[7].OneofWrappers = []any{}
+	file_proto_dexpaprika_proto_msgTypes[13].OneofWrappers = []any{}
+	file_proto_dexpaprika_proto_msgTypes[18].OneofWrappers = []any{}
+	file_proto_dexpaprika_proto_msgTypes[21].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_dexpaprika_proto_rawDesc), len(file_proto_dexpaprika_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_dexpaprika_proto_goTypes,
+		DependencyIndexes: file_proto_dexpaprika_proto_depIdxs,
+		MessageInfos:      file_proto_dexpaprika_proto_msgTypes,
+	}.Build()
+	File_proto_dexpaprika_proto = out.File
+	file_proto_dexpaprika_proto_goTypes = nil
+	file_proto_dexpaprika_proto_depIdxs = nil
+}
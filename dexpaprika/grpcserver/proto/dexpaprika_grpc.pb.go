@@ -0,0 +1,393 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/dexpaprika.proto
+
+package dexpaprikapb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DexPaprika_ListNetworks_FullMethodName         = "/dexpaprika.v1.DexPaprika/ListNetworks"
+	DexPaprika_ListDexes_FullMethodName            = "/dexpaprika.v1.DexPaprika/ListDexes"
+	DexPaprika_ListPools_FullMethodName            = "/dexpaprika.v1.DexPaprika/ListPools"
+	DexPaprika_GetPool_FullMethodName              = "/dexpaprika.v1.DexPaprika/GetPool"
+	DexPaprika_GetPoolOHLCV_FullMethodName         = "/dexpaprika.v1.DexPaprika/GetPoolOHLCV"
+	DexPaprika_ListPoolTransactions_FullMethodName = "/dexpaprika.v1.DexPaprika/ListPoolTransactions"
+	DexPaprika_GetToken_FullMethodName             = "/dexpaprika.v1.DexPaprika/GetToken"
+	DexPaprika_Search_FullMethodName               = "/dexpaprika.v1.DexPaprika/Search"
+)
+
+// DexPaprikaClient is the client API for DexPaprika service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DexPaprika serves DexPaprika data through the Go SDK, sharing one
+// rate-limited, cached client among all callers.
+type DexPaprikaClient interface {
+	ListNetworks(ctx context.Context, in *ListNetworksRequest, opts ...grpc.CallOption) (*ListNetworksResponse, error)
+	ListDexes(ctx context.Context, in *ListDexesRequest, opts ...grpc.CallOption) (*ListDexesResponse, error)
+	ListPools(ctx context.Context, in *ListPoolsRequest, opts ...grpc.CallOption) (*ListPoolsResponse, error)
+	GetPool(ctx context.Context, in *GetPoolRequest, opts ...grpc.CallOption) (*PoolDetails, error)
+	GetPoolOHLCV(ctx context.Context, in *GetPoolOHLCVRequest, opts ...grpc.CallOption) (*GetPoolOHLCVResponse, error)
+	ListPoolTransactions(ctx context.Context, in *ListPoolTransactionsRequest, opts ...grpc.CallOption) (*ListPoolTransactionsResponse, error)
+	GetToken(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*TokenDetails, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+}
+
+type dexPaprikaClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDexPaprikaClient(cc grpc.ClientConnInterface) DexPaprikaClient {
+	return &dexPaprikaClient{cc}
+}
+
+func (c *dexPaprikaClient) ListNetworks(ctx context.Context, in *ListNetworksRequest, opts ...grpc.CallOption) (*ListNetworksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNetworksResponse)
+	err := c.cc.Invoke(ctx, DexPaprika_ListNetworks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dexPaprikaClient) ListDexes(ctx context.Context, in *ListDexesRequest, opts ...grpc.CallOption) (*ListDexesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDexesResponse)
+	err := c.cc.Invoke(ctx, DexPaprika_ListDexes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dexPaprikaClient) ListPools(ctx context.Context, in *ListPoolsRequest, opts ...grpc.CallOption) (*ListPoolsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPoolsResponse)
+	err := c.cc.Invoke(ctx, DexPaprika_ListPools_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dexPaprikaClient) GetPool(ctx context.Context, in *GetPoolRequest, opts ...grpc.CallOption) (*PoolDetails, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PoolDetails)
+	err := c.cc.Invoke(ctx, DexPaprika_GetPool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dexPaprikaClient) GetPoolOHLCV(ctx context.Context, in *GetPoolOHLCVRequest, opts ...grpc.CallOption) (*GetPoolOHLCVResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPoolOHLCVResponse)
+	err := c.cc.Invoke(ctx, DexPaprika_GetPoolOHLCV_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dexPaprikaClient) ListPoolTransactions(ctx context.Context, in *ListPoolTransactionsRequest, opts ...grpc.CallOption) (*ListPoolTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPoolTransactionsResponse)
+	err := c.cc.Invoke(ctx, DexPaprika_ListPoolTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dexPaprikaClient) GetToken(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*TokenDetails, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenDetails)
+	err := c.cc.Invoke(ctx, DexPaprika_GetToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dexPaprikaClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, DexPaprika_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DexPaprikaServer is the server API for DexPaprika service.
+// All implementations must embed UnimplementedDexPaprikaServer
+// for forward compatibility.
+//
+// DexPaprika serves DexPaprika data through the Go SDK, sharing one
+// rate-limited, cached client among all callers.
+type DexPaprikaServer interface {
+	ListNetworks(context.Context, *ListNetworksRequest) (*ListNetworksResponse, error)
+	ListDexes(context.Context, *ListDexesRequest) (*ListDexesResponse, error)
+	ListPools(context.Context, *ListPoolsRequest) (*ListPoolsResponse, error)
+	GetPool(context.Context, *GetPoolRequest) (*PoolDetails, error)
+	GetPoolOHLCV(context.Context, *GetPoolOHLCVRequest) (*GetPoolOHLCVResponse, error)
+	ListPoolTransactions(context.Context, *ListPoolTransactionsRequest) (*ListPoolTransactionsResponse, error)
+	GetToken(context.Context, *GetTokenRequest) (*TokenDetails, error)
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	mustEmbedUnimplementedDexPaprikaServer()
+}
+
+// UnimplementedDexPaprikaServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDexPaprikaServer struct{}
+
+func (UnimplementedDexPaprikaServer) ListNetworks(context.Context, *ListNetworksRequest) (*ListNetworksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNetworks not implemented")
+}
+func (UnimplementedDexPaprikaServer) ListDexes(context.Context, *ListDexesRequest) (*ListDexesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDexes not implemented")
+}
+func (UnimplementedDexPaprikaServer) ListPools(context.Context, *ListPoolsRequest) (*ListPoolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPools not implemented")
+}
+func (UnimplementedDexPaprikaServer) GetPool(context.Context, *GetPoolRequest) (*PoolDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPool not implemented")
+}
+func (UnimplementedDexPaprikaServer) GetPoolOHLCV(context.Context, *GetPoolOHLCVRequest) (*GetPoolOHLCVResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPoolOHLCV not implemented")
+}
+func (UnimplementedDexPaprikaServer) ListPoolTransactions(context.Context, *ListPoolTransactionsRequest) (*ListPoolTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPoolTransactions not implemented")
+}
+func (UnimplementedDexPaprikaServer) GetToken(context.Context, *GetTokenRequest) (*TokenDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetToken not implemented")
+}
+func (UnimplementedDexPaprikaServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedDexPaprikaServer) mustEmbedUnimplementedDexPaprikaServer() {}
+func (UnimplementedDexPaprikaServer) testEmbeddedByValue()                    {}
+
+// UnsafeDexPaprikaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DexPaprikaServer will
+// result in compilation errors.
+type UnsafeDexPaprikaServer interface {
+	mustEmbedUnimplementedDexPaprikaServer()
+}
+
+func RegisterDexPaprikaServer(s grpc.ServiceRegistrar, srv DexPaprikaServer) {
+	// If the following call pancis, it indicates UnimplementedDexPaprikaServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DexPaprika_ServiceDesc, srv)
+}
+
+func _DexPaprika_ListNetworks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNetworksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DexPaprikaServer).ListNetworks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DexPaprika_ListNetworks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DexPaprikaServer).ListNetworks(ctx, req.(*ListNetworksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DexPaprika_ListDexes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDexesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DexPaprikaServer).ListDexes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DexPaprika_ListDexes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DexPaprikaServer).ListDexes(ctx, req.(*ListDexesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DexPaprika_ListPools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPoolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DexPaprikaServer).ListPools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DexPaprika_ListPools_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DexPaprikaServer).ListPools(ctx, req.(*ListPoolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DexPaprika_GetPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DexPaprikaServer).GetPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DexPaprika_GetPool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DexPaprikaServer).GetPool(ctx, req.(*GetPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DexPaprika_GetPoolOHLCV_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPoolOHLCVRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DexPaprikaServer).GetPoolOHLCV(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DexPaprika_GetPoolOHLCV_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DexPaprikaServer).GetPoolOHLCV(ctx, req.(*GetPoolOHLCVRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DexPaprika_ListPoolTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPoolTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DexPaprikaServer).ListPoolTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DexPaprika_ListPoolTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DexPaprikaServer).ListPoolTransactions(ctx, req.(*ListPoolTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DexPaprika_GetToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DexPaprikaServer).GetToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DexPaprika_GetToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DexPaprikaServer).GetToken(ctx, req.(*GetTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DexPaprika_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DexPaprikaServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DexPaprika_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DexPaprikaServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DexPaprika_ServiceDesc is the grpc.ServiceDesc for DexPaprika service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DexPaprika_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dexpaprika.v1.DexPaprika",
+	HandlerType: (*DexPaprikaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListNetworks",
+			Handler:    _DexPaprika_ListNetworks_Handler,
+		},
+		{
+			MethodName: "ListDexes",
+			Handler:    _DexPaprika_ListDexes_Handler,
+		},
+		{
+			MethodName: "ListPools",
+			Handler:    _DexPaprika_ListPools_Handler,
+		},
+		{
+			MethodName: "GetPool",
+			Handler:    _DexPaprika_GetPool_Handler,
+		},
+		{
+			MethodName: "GetPoolOHLCV",
+			Handler:    _DexPaprika_GetPoolOHLCV_Handler,
+		},
+		{
+			MethodName: "ListPoolTransactions",
+			Handler:    _DexPaprika_ListPoolTransactions_Handler,
+		},
+		{
+			MethodName: "GetToken",
+			Handler:    _DexPaprika_GetToken_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _DexPaprika_Search_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/dexpaprika.proto",
+}
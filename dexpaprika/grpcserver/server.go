@@ -0,0 +1,342 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	pb "github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/grpcserver/proto"
+)
+
+// Server implements the DexPaprika gRPC service with an SDK client. Pool,
+// token, network and dex lookups are served from a cache shared by all
+// callers.
+type Server struct {
+	pb.UnimplementedDexPaprikaServer
+
+	client *dexpaprika.Client
+	cached *dexpaprika.CachedClient
+}
+
+// NewServer creates a server calling the API with client, caching results
+// in cache for ttl. A nil cache uses an InMemoryCache; a ttl of zero caches
+// for 5 minutes.
+func NewServer(client *dexpaprika.Client, cache dexpaprika.Cache, ttl time.Duration) *Server {
+	return &Server{client: client, cached: dexpaprika.NewCachedClient(client, cache, ttl)}
+}
+
+// Register registers the service on s.
+func Register(s grpc.ServiceRegistrar, srv *Server) {
+	pb.RegisterDexPaprikaServer(s, srv)
+}
+
+// ListNetworks implements pb.DexPaprikaServer.
+func (s *Server) ListNetworks(ctx context.Context, req *pb.ListNetworksRequest) (*pb.ListNetworksResponse, error) {
+	networks, err := s.cached.GetNetworks(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.ListNetworksResponse{}
+	for _, n := range networks {
+		resp.Networks = append(resp.Networks, &pb.Network{Id: n.ID, DisplayName: n.DisplayName})
+	}
+	return resp, nil
+}
+
+// ListDexes implements pb.DexPaprikaServer.
+func (s *Server) ListDexes(ctx context.Context, req *pb.ListDexesRequest) (*pb.ListDexesResponse, error) {
+	if req.GetNetworkId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "network_id is required")
+	}
+	dexes, err := s.cached.GetDexes(ctx, req.GetNetworkId(), int(req.GetPage()), limit(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.ListDexesResponse{PageInfo: pageInfo(dexes.PageInfo)}
+	for _, d := range dexes.Dexes {
+		resp.Dexes = append(resp.Dexes, &pb.Dex{Id: d.ID, Name: d.Name, Protocol: d.Protocol})
+	}
+	return resp, nil
+}
+
+// ListPools implements pb.DexPaprikaServer.
+func (s *Server) ListPools(ctx context.Context, req *pb.ListPoolsRequest) (*pb.ListPoolsResponse, error) {
+	if req.GetNetworkId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "network_id is required")
+	}
+	opts := &dexpaprika.ListOptions{
+		Page:    int(req.GetPage()),
+		Limit:   limit(req.GetLimit()),
+		OrderBy: req.GetOrderBy(),
+		Sort:    req.GetSort(),
+	}
+	if opts.OrderBy == "" {
+		opts.OrderBy = "volume_usd"
+	}
+	if opts.Sort == "" {
+		opts.Sort = "desc"
+	}
+
+	var pools *dexpaprika.PoolsResponse
+	var err error
+	if req.GetDexId() != "" {
+		pools, err = s.client.Pools.ListByDex(ctx, req.GetNetworkId(), req.GetDexId(), opts)
+	} else {
+		pools, err = s.cached.GetNetworkPools(ctx, req.GetNetworkId(), opts)
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.ListPoolsResponse{PageInfo: pageInfo(pools.PageInfo)}
+	for _, p := range pools.Pools {
+		resp.Pools = append(resp.Pools, poolMessage(p))
+	}
+	return resp, nil
+}
+
+// GetPool implements pb.DexPaprikaServer.
+func (s *Server) GetPool(ctx context.Context, req *pb.GetPoolRequest) (*pb.PoolDetails, error) {
+	if req.GetNetworkId() == "" || req.GetAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "network_id and address are required")
+	}
+	p, err := s.cached.GetPoolDetails(ctx, req.GetNetworkId(), req.GetAddress(), &dexpaprika.PoolDetailsOptions{Inversed: req.GetInversed()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	msg := &pb.PoolDetails{
+		Id:           p.ID,
+		Chain:        p.Chain,
+		DexId:        p.DexID,
+		DexName:      p.DexName,
+		Tokens:       tokenMessages(p.Tokens),
+		LastPrice:    p.LastPrice,
+		LastPriceUsd: p.LastPriceUSD,
+		Fee:          p.Fee,
+		PriceTime:    timestamp(p.PriceTime),
+		CreatedAt:    timestamp(p.CreatedAt),
+		Metrics:      make(map[string]*pb.IntervalMetrics),
+	}
+	if liquidity, ok := p.GetLiquidityUSD(); ok {
+		msg.LiquidityUsd = proto.Float64(liquidity)
+	}
+	for interval, m := range p.Intervals() {
+		msg.Metrics[interval] = intervalMessage(m)
+	}
+	return msg, nil
+}
+
+// GetPoolOHLCV implements pb.DexPaprikaServer. Ranges longer than one
+// request allows are fetched in chunks.
+func (s *Server) GetPoolOHLCV(ctx context.Context, req *pb.GetPoolOHLCVRequest) (*pb.GetPoolOHLCVResponse, error) {
+	if req.GetNetworkId() == "" || req.GetAddress() == "" || req.GetStart() == nil {
+		return nil, status.Error(codes.InvalidArgument, "network_id, address and start are required")
+	}
+	end := time.Now()
+	if req.GetEnd() != nil {
+		end = req.GetEnd().AsTime()
+	}
+	candles, err := s.client.Pools.GetOHLCVRange(ctx, req.GetNetworkId(), req.GetAddress(), req.GetStart().AsTime(), end,
+		&dexpaprika.OHLCVOptions{Interval: req.GetInterval(), Inversed: req.GetInversed()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.GetPoolOHLCVResponse{}
+	for _, c := range candles {
+		resp.Candles = append(resp.Candles, &pb.Candle{
+			TimeOpen:  timestamp(c.TimeOpen),
+			TimeClose: timestamp(c.TimeClose),
+			Open:      c.Open,
+			High:      c.High,
+			Low:       c.Low,
+			Close:     c.Close,
+			Volume:    c.Volume,
+		})
+	}
+	return resp, nil
+}
+
+// ListPoolTransactions implements pb.DexPaprikaServer.
+func (s *Server) ListPoolTransactions(ctx context.Context, req *pb.ListPoolTransactionsRequest) (*pb.ListPoolTransactionsResponse, error) {
+	if req.GetNetworkId() == "" || req.GetAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "network_id and address are required")
+	}
+	txs, err := s.client.Pools.GetTransactions(ctx, req.GetNetworkId(), req.GetAddress(), int(req.GetPage()), limit(req.GetLimit()), req.GetCursor())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.ListPoolTransactionsResponse{PageInfo: pageInfo(txs.PageInfo)}
+	for _, tx := range txs.Transactions {
+		msg := &pb.Transaction{
+			Id:                   tx.ID,
+			LogIndex:             int32(tx.LogIndex),
+			TransactionIndex:     int32(tx.TransactionIndex),
+			PoolId:               tx.PoolID,
+			Sender:               tx.Sender,
+			Recipient:            tx.Recipient,
+			Token0:               tx.Token0,
+			Token1:               tx.Token1,
+			CreatedAtBlockNumber: tx.CreatedAtBlockNumber,
+			CreatedAt:            timestamp(tx.CreatedAt),
+		}
+		if v, ok := tx.GetAmount0(); ok {
+			msg.Amount0 = proto.Float64(v)
+		}
+		if v, ok := tx.GetAmount1(); ok {
+			msg.Amount1 = proto.Float64(v)
+		}
+		resp.Transactions = append(resp.Transactions, msg)
+	}
+	return resp, nil
+}
+
+// GetToken implements pb.DexPaprikaServer.
+func (s *Server) GetToken(ctx context.Context, req *pb.GetTokenRequest) (*pb.TokenDetails, error) {
+	if req.GetNetworkId() == "" || req.GetAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "network_id and address are required")
+	}
+	t, err := s.cached.GetTokenDetails(ctx, req.GetNetworkId(), req.GetAddress())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	msg := &pb.TokenDetails{
+		Id:          t.ID,
+		Name:        t.Name,
+		Symbol:      t.Symbol,
+		Chain:       t.Chain,
+		Decimals:    int32(t.Decimals),
+		TotalSupply: t.TotalSupply,
+		LastUpdated: timestamp(t.LastUpdated),
+		Metrics:     make(map[string]*pb.IntervalMetrics),
+	}
+	if summary, ok := t.GetSummary(); ok {
+		if v, ok := summary.GetPriceUSD(); ok {
+			msg.PriceUsd = proto.Float64(v)
+		}
+		if v, ok := summary.GetLiquidityUSD(); ok {
+			msg.LiquidityUsd = proto.Float64(v)
+		}
+		if v, ok := summary.GetFDV(); ok {
+			msg.Fdv = proto.Float64(v)
+		}
+		for interval, m := range summary.Intervals() {
+			msg.Metrics[interval] = intervalMessage(m)
+		}
+	}
+	return msg, nil
+}
+
+// Search implements pb.DexPaprikaServer.
+func (s *Server) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	result, err := s.client.Search.SearchWithOptions(ctx, req.GetQuery(), &dexpaprika.SearchOptions{
+		Limit: int(req.GetLimit()),
+		Chain: req.GetNetworkId(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.SearchResponse{}
+	for _, t := range result.Tokens {
+		resp.Tokens = append(resp.Tokens, &pb.Token{Id: t.ID, Name: t.Name, Symbol: t.Symbol, Chain: t.Chain, Decimals: int32(t.Decimals)})
+	}
+	for _, p := range result.Pools {
+		resp.Pools = append(resp.Pools, poolMessage(p.Pool()))
+	}
+	for _, d := range result.Dexes {
+		resp.Dexes = append(resp.Dexes, &pb.Dex{Id: d.DexID, Name: d.DexName, Protocol: d.Protocol})
+	}
+	return resp, nil
+}
+
+func poolMessage(p dexpaprika.Pool) *pb.Pool {
+	return &pb.Pool{
+		Id:           p.ID,
+		DexId:        p.DexID,
+		DexName:      p.DexName,
+		Chain:        p.Chain,
+		VolumeUsd:    p.VolumeUSD,
+		PriceUsd:     p.PriceUSD,
+		Transactions: int64(p.Transactions),
+		Fee:          p.Fee,
+		CreatedAt:    timestamp(p.CreatedAt),
+		Tokens:       tokenMessages(p.Tokens),
+	}
+}
+
+func tokenMessages(tokens []dexpaprika.Token) []*pb.Token {
+	msgs := make([]*pb.Token, len(tokens))
+	for i, t := range tokens {
+		msgs[i] = &pb.Token{Id: t.ID, Name: t.Name, Symbol: t.Symbol, Chain: t.Chain, Decimals: int32(t.Decimals), Fdv: t.FDV}
+	}
+	return msgs
+}
+
+func intervalMessage(m *dexpaprika.TimeIntervalMetrics) *pb.IntervalMetrics {
+	return &pb.IntervalMetrics{
+		LastPriceUsdChange: m.LastPriceUSDChange,
+		VolumeUsd:          m.VolumeUSD,
+		BuyUsd:             m.BuyUSD,
+		SellUsd:            m.SellUSD,
+		Buys:               int32(m.Buys),
+		Sells:              int32(m.Sells),
+		Txns:               int32(m.Txns),
+	}
+}
+
+func pageInfo(p dexpaprika.PageInfo) *pb.PageInfo {
+	return &pb.PageInfo{Page: int32(p.Page), Limit: int32(p.Limit), TotalItems: int32(p.TotalItems), TotalPages: int32(p.TotalPages)}
+}
+
+// timestamp converts an RFC 3339 time, or nil if it is empty or invalid.
+func timestamp(s string) *timestamppb.Timestamp {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// limit applies the default page size of 50 and caps it at 100.
+func limit(l int32) int {
+	if l <= 0 {
+		return 50
+	}
+	return min(int(l), 100)
+}
+
+// toStatus maps SDK errors to gRPC status codes.
+func toStatus(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, err.Error())
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	if errors.Is(err, dexpaprika.ErrInvalidInterval) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	var apiErr *dexpaprika.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusNotFound:
+			return status.Error(codes.NotFound, err.Error())
+		case apiErr.StatusCode == http.StatusBadRequest:
+			return status.Error(codes.InvalidArgument, err.Error())
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			return status.Error(codes.ResourceExhausted, err.Error())
+		case apiErr.StatusCode >= 500:
+			return status.Error(codes.Unavailable, err.Error())
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,95 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	pb "github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/grpcserver/proto"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) pb.DexPaprikaClient {
+	t.Helper()
+	api := httptest.NewServer(handler)
+	t.Cleanup(api.Close)
+
+	client := dexpaprika.NewClient()
+	client.SetBaseURL(api.URL)
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	Register(s, NewServer(client, nil, time.Minute))
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewDexPaprikaClient(conn)
+}
+
+func TestGetPool(t *testing.T) {
+	var requests int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/networks/ethereum/pools/0xabc" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"0xabc","chain":"ethereum","dex_id":"uniswap_v3","last_price_usd":2500,"liquidity_usd":1000000,
+			"price_time":"2025-01-02T03:04:05Z","tokens":[{"id":"0xweth","symbol":"WETH","decimals":18}],
+			"24h":{"volume_usd":5000,"txns":12}}`))
+	})
+
+	for range 2 {
+		pool, err := c.GetPool(context.Background(), &pb.GetPoolRequest{NetworkId: "ethereum", Address: "0xabc"})
+		if err != nil {
+			t.Fatalf("GetPool: %v", err)
+		}
+		if pool.GetLastPriceUsd() != 2500 || pool.GetLiquidityUsd() != 1000000 {
+			t.Errorf("pool = %v", pool)
+		}
+		if len(pool.GetTokens()) != 1 || pool.GetTokens()[0].GetSymbol() != "WETH" {
+			t.Errorf("tokens = %v", pool.GetTokens())
+		}
+		if m := pool.GetMetrics()["24h"]; m.GetVolumeUsd() != 5000 || m.GetTxns() != 12 {
+			t.Errorf("24h metrics = %v", m)
+		}
+		if got := pool.GetPriceTime().AsTime(); !got.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)) {
+			t.Errorf("price_time = %v", got)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("API requests = %d, want 1 (second call cached)", requests)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+	})
+
+	_, err := c.GetToken(context.Background(), &pb.GetTokenRequest{NetworkId: "ethereum", Address: "0xmissing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("code = %v, want NotFound (%v)", status.Code(err), err)
+	}
+
+	_, err = c.GetToken(context.Background(), &pb.GetTokenRequest{NetworkId: "ethereum"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("code = %v, want InvalidArgument (%v)", status.Code(err), err)
+	}
+}
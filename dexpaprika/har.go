@@ -0,0 +1,308 @@
+package dexpaprika
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redacted replaces the values of secret headers and query parameters in
+// HAR entries.
+const redacted = "REDACTED"
+
+// harSecrets are the header and query parameter names redacted by default,
+// in lower case.
+var harSecrets = []string{
+	"authorization", "proxy-authorization", "cookie", "set-cookie",
+	"x-api-key", "api-key", "api_key", "apikey", "access_token", "token",
+}
+
+// HARRecorder records the HTTP attempts of clients, including failed and
+// retried ones, in HTTP Archive (HAR 1.2) format, for attaching reproducible
+// traces to issues filed against the API or the SDK:
+//
+//	har := dexpaprika.NewHARRecorder()
+//	client := dexpaprika.NewClient(dexpaprika.WithHAR(har))
+//	// ...
+//	f, _ := os.Create("dexpaprika.har")
+//	har.Save(f)
+//
+// Authorization, cookie and API key headers and query parameters are
+// redacted. Response bodies are only recorded if Bodies is set. Entries are
+// timed by the client's Clock. Server-sent event streams opened with
+// StreamSSE are not recorded: a HAR entry holds a complete response, which
+// a stream never has. A HARRecorder is safe for concurrent use and may be
+// shared by clients.
+type HARRecorder struct {
+	// Bodies records response bodies, which may contain data the traces'
+	// readers should not see.
+	Bodies bool
+
+	// Redact lists more header and query parameter names to redact,
+	// matched case-insensitively.
+	Redact []string
+
+	// MaxEntries, if positive, keeps only the latest entries.
+	MaxEntries int
+
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+// NewHARRecorder creates a recorder without response bodies.
+func NewHARRecorder() *HARRecorder {
+	return &HARRecorder{}
+}
+
+// WithHAR records every HTTP attempt of the client in r.
+func WithHAR(r *HARRecorder) ClientOption {
+	return func(c *Client) {
+		c.har = r
+	}
+}
+
+// Len returns the number of recorded entries.
+func (r *HARRecorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+// Reset discards the recorded entries.
+func (r *HARRecorder) Reset() {
+	r.mu.Lock()
+	r.entries = nil
+	r.mu.Unlock()
+}
+
+// Save writes the recorded entries to w as a HAR file.
+func (r *HARRecorder) Save(w io.Writer) error {
+	r.mu.Lock()
+	entries := append([]harEntry{}, r.entries...)
+	r.mu.Unlock()
+
+	var har harFile
+	har.Log.Version = "1.2"
+	har.Log.Creator = harCreator{Name: sdkName, Version: Version}
+	har.Log.Entries = entries
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(har)
+}
+
+// record sends req with send and records the attempt, timed by clock. The
+// response body is read, and replaced, to record its size and time.
+func (r *HARRecorder) record(req *http.Request, clock Clock, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	start := clock.Now()
+	resp, err := send(req)
+	wait := clock.Now().Sub(start)
+
+	entry := harEntry{
+		StartedDateTime: start.UTC().Format(time.RFC3339Nano),
+		Request:         r.request(req),
+		Response:        harResponse{Headers: []harNameValue{}, Cookies: []harNameValue{}, HeadersSize: -1, BodySize: -1},
+		Cache:           struct{}{},
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		entry.Response.HTTPVersion = "HTTP/1.1"
+		entry.Response.Content.MimeType = "x-unknown"
+	} else {
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		reader := io.Reader(bytes.NewReader(body))
+		if readErr != nil {
+			entry.Error = readErr.Error()
+			reader = io.MultiReader(reader, errReader{readErr})
+		}
+		resp.Body = io.NopCloser(reader)
+		entry.Response = r.response(resp, body)
+	}
+	receive := clock.Now().Sub(start) - wait
+	entry.Time = ms(wait + receive)
+	entry.Timings = harTimings{Send: 0, Wait: ms(wait), Receive: ms(receive)}
+
+	r.mu.Lock()
+	r.entries = append(r.entries, entry)
+	if r.MaxEntries > 0 && len(r.entries) > r.MaxEntries {
+		r.entries = append(r.entries[:0:0], r.entries[len(r.entries)-r.MaxEntries:]...)
+	}
+	r.mu.Unlock()
+	return resp, err
+}
+
+// secret reports whether a header or query parameter is redacted.
+func (r *HARRecorder) secret(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range harSecrets {
+		if name == s {
+			return true
+		}
+	}
+	for _, s := range r.Redact {
+		if strings.EqualFold(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *HARRecorder) headers(h http.Header) []harNameValue {
+	out := []harNameValue{}
+	for name, values := range h {
+		for _, v := range values {
+			if r.secret(name) {
+				v = redacted
+			}
+			out = append(out, harNameValue{Name: name, Value: v})
+		}
+	}
+	sortNameValues(out)
+	return out
+}
+
+func (r *HARRecorder) request(req *http.Request) harRequest {
+	u := *req.URL
+	u.User = nil
+	query := u.Query()
+	queryString := []harNameValue{}
+	redact := false
+	for name, values := range query {
+		for i, v := range values {
+			if r.secret(name) {
+				values[i], v, redact = redacted, redacted, true
+			}
+			queryString = append(queryString, harNameValue{Name: name, Value: v})
+		}
+	}
+	sortNameValues(queryString)
+	if redact {
+		u.RawQuery = query.Encode()
+	}
+	return harRequest{
+		Method:      req.Method,
+		URL:         u.String(),
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []harNameValue{},
+		Headers:     r.headers(req.Header),
+		QueryString: queryString,
+		HeadersSize: -1,
+		BodySize:    0,
+	}
+}
+
+func (r *HARRecorder) response(resp *http.Response, body []byte) harResponse {
+	out := harResponse{
+		Status:      resp.StatusCode,
+		StatusText:  strings.TrimSpace(strings.TrimPrefix(resp.Status, strconv.Itoa(resp.StatusCode))),
+		HTTPVersion: resp.Proto,
+		Cookies:     []harNameValue{},
+		Headers:     r.headers(resp.Header),
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+	if out.HTTPVersion == "" {
+		out.HTTPVersion = "HTTP/1.1"
+	}
+	if out.StatusText == "" {
+		out.StatusText = http.StatusText(resp.StatusCode)
+	}
+	out.Content.Size = len(body)
+	out.Content.MimeType = resp.Header.Get("Content-Type")
+	if out.Content.MimeType == "" {
+		out.Content.MimeType = "x-unknown"
+	}
+	if r.Bodies {
+		out.Content.Text = string(body)
+	} else {
+		out.Content.Comment = "body not recorded"
+	}
+	return out
+}
+
+func sortNameValues(nv []harNameValue) {
+	slices.SortStableFunc(nv, func(a, b harNameValue) int { return strings.Compare(a.Name, b.Name) })
+}
+
+// ms converts a duration into HAR's fractional milliseconds.
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// errReader fails reads with err.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+// The HAR 1.2 format, as far as the recorder fills it in.
+type (
+	harFile struct {
+		Log struct {
+			Version string     `json:"version"`
+			Creator harCreator `json:"creator"`
+			Entries []harEntry `json:"entries"`
+		} `json:"log"`
+	}
+
+	harCreator struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+
+	harEntry struct {
+		StartedDateTime string      `json:"startedDateTime"`
+		Time            float64     `json:"time"`
+		Request         harRequest  `json:"request"`
+		Response        harResponse `json:"response"`
+		Cache           struct{}    `json:"cache"`
+		Timings         harTimings  `json:"timings"`
+		Error           string      `json:"_error,omitempty"` // Transport or body read error
+	}
+
+	harRequest struct {
+		Method      string         `json:"method"`
+		URL         string         `json:"url"`
+		HTTPVersion string         `json:"httpVersion"`
+		Cookies     []harNameValue `json:"cookies"`
+		Headers     []harNameValue `json:"headers"`
+		QueryString []harNameValue `json:"queryString"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+
+	harResponse struct {
+		Status      int            `json:"status"`
+		StatusText  string         `json:"statusText"`
+		HTTPVersion string         `json:"httpVersion"`
+		Cookies     []harNameValue `json:"cookies"`
+		Headers     []harNameValue `json:"headers"`
+		Content     harContent     `json:"content"`
+		RedirectURL string         `json:"redirectURL"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+
+	harContent struct {
+		Size     int    `json:"size"`
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text,omitempty"`
+		Comment  string `json:"comment,omitempty"`
+	}
+
+	harNameValue struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	harTimings struct {
+		Send    float64 `json:"send"`
+		Wait    float64 `json:"wait"`
+		Receive float64 `json:"receive"`
+	}
+)
@@ -0,0 +1,156 @@
+package dexpaprika
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// decodeHAR saves r and decodes the HAR file.
+func decodeHAR(t *testing.T, r *HARRecorder) (harFile, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := r.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var har harFile
+	if err := json.Unmarshal(buf.Bytes(), &har); err != nil {
+		t.Fatal(err)
+	}
+	return har, buf.String()
+}
+
+func TestHARRecorder(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"message": "busy"}`))
+			return
+		}
+		w.Write([]byte(`{"chains": 1, "factories": 2, "pools": 3, "tokens": 4}`))
+	}))
+	defer server.Close()
+
+	har := NewHARRecorder()
+	har.Bodies = true
+	client := NewClient(WithBaseURL(server.URL), WithHAR(har), WithRetryConfig(1, time.Millisecond, time.Millisecond))
+	stats, err := client.Utils.GetStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Pools != 3 {
+		t.Errorf("Recording changed the decoded response: %+v", stats)
+	}
+
+	file, raw := decodeHAR(t, har)
+	if file.Log.Version != "1.2" || file.Log.Creator.Version != Version {
+		t.Errorf("log = %+v, want HAR 1.2 created by this SDK version", file.Log)
+	}
+	entries := file.Log.Entries
+	if len(entries) != 2 {
+		t.Fatalf("Recorded %d entries, want the failed attempt and its retry", len(entries))
+	}
+	if entries[0].Response.Status != 503 || entries[1].Response.Status != 200 {
+		t.Errorf("Statuses = %d, %d, want 503, 200", entries[0].Response.Status, entries[1].Response.Status)
+	}
+	if got := entries[1]; got.Request.Method != http.MethodGet || got.Request.URL != server.URL+"/stats" || got.Response.StatusText != "OK" {
+		t.Errorf("Entry = %+v", got)
+	}
+	if got := entries[1].Response.Content; !strings.Contains(got.Text, `"pools": 3`) || got.MimeType != "application/json" || got.Size != len(got.Text) {
+		t.Errorf("content = %+v, want the response body", got)
+	}
+	if strings.Contains(raw, "session=secret") || !strings.Contains(raw, redacted) {
+		t.Errorf("The Set-Cookie header was not redacted:\n%s", raw)
+	}
+}
+
+func TestHARRecorder_Clock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"chains": 1, "factories": 2, "pools": 3, "tokens": 4}`))
+	}))
+	defer server.Close()
+
+	har := NewHARRecorder()
+	clock := &stepClock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	client := NewClient(WithBaseURL(server.URL), WithHAR(har), WithClock(clock))
+	if _, err := client.Utils.GetStats(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	file, _ := decodeHAR(t, har)
+	if len(file.Log.Entries) != 1 {
+		t.Fatalf("Recorded %d entries, want 1", len(file.Log.Entries))
+	}
+	if got := file.Log.Entries[0]; got.StartedDateTime != "2024-06-01T12:00:00Z" || got.Time != 0 {
+		t.Errorf("Entry started at %s and took %vms, want the client clock's time and no duration", got.StartedDateTime, got.Time)
+	}
+}
+
+func TestHARRecorder_Redaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"secret": "data"}`))
+	}))
+	defer server.Close()
+
+	har := NewHARRecorder()
+	har.Redact = []string{"X-Internal-Trace"}
+	client := NewClient(WithBaseURL(server.URL), WithHAR(har))
+	req, err := client.NewRequest(http.MethodGet, "/stats?api_key=k3y&limit=5", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer t0ken")
+	req.Header.Set("X-Internal-Trace", "tr4ce")
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	file, raw := decodeHAR(t, har)
+	for _, secret := range []string{"k3y", "t0ken", "tr4ce", `\"secret\"`} {
+		if strings.Contains(raw, secret) {
+			t.Errorf("The HAR file contains %q:\n%s", secret, raw)
+		}
+	}
+	entry := file.Log.Entries[0]
+	if !strings.Contains(entry.Request.URL, "limit=5") || len(entry.Request.QueryString) != 2 {
+		t.Errorf("request = %+v, want the other parameters kept", entry.Request)
+	}
+	if entry.Response.Content.Text != "" || entry.Response.Content.Size != len(`{"secret": "data"}`) {
+		t.Errorf("content = %+v, want the size without the body", entry.Response.Content)
+	}
+}
+
+func TestHARRecorder_NetworkErrorsAndLimit(t *testing.T) {
+	har := &HARRecorder{MaxEntries: 2}
+	client := NewClient(
+		WithBaseURL("http://127.0.0.1:1"),
+		WithHAR(har),
+		WithRetryConfig(2, time.Millisecond, time.Millisecond),
+		WithChaos(ChaosProfile{DropRate: 1}),
+	)
+	if _, err := client.Utils.GetStats(context.Background()); !errors.Is(err, ErrChaosDrop) {
+		t.Fatalf("GetStats() = %v, want ErrChaosDrop", err)
+	}
+	if har.Len() != 2 {
+		t.Errorf("Len() = %d, want MaxEntries", har.Len())
+	}
+	file, _ := decodeHAR(t, har)
+	if got := file.Log.Entries[0]; got.Response.Status != 0 || !strings.Contains(got.Error, ErrChaosDrop.Error()) {
+		t.Errorf("Entry = %+v, want the dropped attempt", got)
+	}
+
+	har.Reset()
+	if file, _ := decodeHAR(t, har); har.Len() != 0 || file.Log.Entries == nil {
+		t.Errorf("After Reset, entries = %v, want an empty list", file.Log.Entries)
+	}
+}
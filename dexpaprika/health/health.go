@@ -0,0 +1,193 @@
+// Package health serves standard /healthz and /readyz endpoints for programs
+// built on the SDK, such as the exporter and proxy commands, so they can run
+// under Kubernetes liveness and readiness probes.
+//
+// /healthz reports that the process is serving requests and never checks
+// dependencies, so a slow or unreachable API does not get the process
+// restarted. /readyz checks that the API is reachable with Client.Ping, plus
+// any checks added with Add, and answers 503 Service Unavailable when one
+// fails:
+//
+//	checker := health.New(client)
+//	checker.Cache = cache
+//	checker.Register(mux)
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// Check statuses.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Result is the outcome of one check.
+type Result struct {
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Latency   float64   `json:"latency_seconds"`
+	CheckedAt time.Time `json:"checked_at"`
+
+	// Entries is the number of cached items, reported by the cache check.
+	Entries *int `json:"entries,omitempty"`
+}
+
+// Report is the body of a /readyz response.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+type namedCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// Checker serves the health endpoints of a client.
+type Checker struct {
+	client *dexpaprika.Client
+
+	// Cache, if set, is reported under "cache", with its number of entries
+	// when it has a Len method like dexpaprika.InMemoryCache.
+	Cache dexpaprika.Cache
+
+	// Timeout bounds each check. Defaults to 5 seconds.
+	Timeout time.Duration
+
+	// MaxAge is how long the result of pinging the API is reused, so that
+	// frequent probes from several replicas do not spend the API quota.
+	// Defaults to 15 seconds.
+	MaxAge time.Duration
+
+	mu     sync.Mutex
+	checks []namedCheck
+
+	pingMu   sync.Mutex
+	upstream Result
+}
+
+// New creates a checker for client.
+func New(client *dexpaprika.Client) *Checker {
+	return &Checker{
+		client:  client,
+		Timeout: 5 * time.Second,
+		MaxAge:  15 * time.Second,
+	}
+}
+
+// Add registers a readiness check run on every /readyz request. The process
+// is ready only while check returns nil.
+func (c *Checker) Add(name string, check func(ctx context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Register serves /healthz and /readyz on mux.
+func (c *Checker) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", c.ServeLiveness)
+	mux.HandleFunc("/readyz", c.ServeReadiness)
+}
+
+// ServeLiveness answers 200 OK while the process can serve requests.
+func (c *Checker) ServeLiveness(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, http.StatusOK, Report{Status: StatusOK})
+}
+
+// ServeReadiness answers with the Report of Ready, with status 200 OK when
+// every check passed and 503 Service Unavailable otherwise.
+func (c *Checker) ServeReadiness(w http.ResponseWriter, r *http.Request) {
+	report := c.Ready(r.Context())
+	status := http.StatusOK
+	if report.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	writeReport(w, status, report)
+}
+
+// Ready runs the checks and reports their results. The API counts as
+// reachable when it answers, even if it rate limits the ping.
+func (c *Checker) Ready(ctx context.Context) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]Result)}
+	add := func(name string, res Result) {
+		report.Checks[name] = res
+		if res.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+
+	if c.client != nil {
+		add("upstream", c.pingUpstream(ctx))
+	}
+	if c.Cache != nil {
+		res := Result{Status: StatusOK, CheckedAt: time.Now()}
+		if l, ok := c.Cache.(interface{ Len() int }); ok {
+			n := l.Len()
+			res.Entries = &n
+		}
+		add("cache", res)
+	}
+
+	c.mu.Lock()
+	checks := append([]namedCheck(nil), c.checks...)
+	c.mu.Unlock()
+	for _, nc := range checks {
+		add(nc.name, c.run(ctx, nc.check))
+	}
+	return report
+}
+
+// pingUpstream pings the API, or returns the last result if it is recent.
+// Concurrent probes wait for a single ping.
+func (c *Checker) pingUpstream(ctx context.Context) Result {
+	c.pingMu.Lock()
+	defer c.pingMu.Unlock()
+	if !c.upstream.CheckedAt.IsZero() && time.Since(c.upstream.CheckedAt) < c.MaxAge {
+		return c.upstream
+	}
+	res := c.run(ctx, func(ctx context.Context) error {
+		err := c.client.Ping(ctx)
+		if errors.Is(err, dexpaprika.ErrRateLimit) {
+			return nil
+		}
+		return err
+	})
+	if ctx.Err() == nil {
+		// A probe that gave up says nothing about the API
+		c.upstream = res
+	}
+	return res
+}
+
+// run runs check within the checker's timeout.
+func (c *Checker) run(ctx context.Context, check func(ctx context.Context) error) Result {
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	err := check(ctx)
+	res := Result{Status: StatusOK, Latency: time.Since(start).Seconds(), CheckedAt: start}
+	if err != nil {
+		res.Status = StatusFail
+		res.Error = err.Error()
+	}
+	return res
+}
+
+func writeReport(w http.ResponseWriter, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(report)
+}
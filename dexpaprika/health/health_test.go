@@ -0,0 +1,99 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func newChecker(t *testing.T, status *atomic.Int32, pings *atomic.Int32) *Checker {
+	t.Helper()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pings.Add(1)
+		w.WriteHeader(int(status.Load()))
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(api.Close)
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(api.URL))
+	t.Cleanup(func() { client.Close() })
+	return New(client)
+}
+
+func probe(t *testing.T, c *Checker, path string) (int, Report) {
+	t.Helper()
+	mux := http.NewServeMux()
+	c.Register(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	var report Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("Invalid body %q: %v", rec.Body.String(), err)
+	}
+	return rec.Code, report
+}
+
+func TestReadiness(t *testing.T) {
+	var status, pings atomic.Int32
+	status.Store(http.StatusOK)
+	c := newChecker(t, &status, &pings)
+	cache := dexpaprika.NewInMemoryCache()
+	defer cache.Close()
+	cache.Set("k", 1, time.Minute)
+	c.Cache = cache
+
+	code, report := probe(t, c, "/readyz")
+	if code != http.StatusOK || report.Status != StatusOK {
+		t.Fatalf("Got %d %+v", code, report)
+	}
+	if e := report.Checks["cache"].Entries; e == nil || *e != 1 {
+		t.Errorf("Cache entries = %v, want 1", e)
+	}
+
+	// The ping result is reused within MaxAge
+	status.Store(http.StatusServiceUnavailable)
+	if code, _ := probe(t, c, "/readyz"); code != http.StatusOK || pings.Load() != 1 {
+		t.Errorf("Got %d after %d pings, want a reused result", code, pings.Load())
+	}
+
+	c.MaxAge = 0
+	code, report = probe(t, c, "/readyz")
+	if code != http.StatusServiceUnavailable || report.Status != StatusFail || report.Checks["upstream"].Error == "" {
+		t.Errorf("Got %d %+v, want an upstream failure", code, report)
+	}
+
+	// A rate-limited API is still reachable
+	status.Store(http.StatusTooManyRequests)
+	if code, report := probe(t, c, "/readyz"); code != http.StatusOK {
+		t.Errorf("Got %d %+v for a rate-limited ping", code, report)
+	}
+}
+
+func TestReadiness_CustomCheck(t *testing.T) {
+	c := New(nil)
+	c.Add("queue", func(ctx context.Context) error { return errors.New("backlog too large") })
+
+	code, report := probe(t, c, "/readyz")
+	if code != http.StatusServiceUnavailable || report.Checks["queue"].Error != "backlog too large" {
+		t.Errorf("Got %d %+v", code, report)
+	}
+}
+
+func TestLiveness(t *testing.T) {
+	var status, pings atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	c := newChecker(t, &status, &pings)
+
+	if code, report := probe(t, c, "/healthz"); code != http.StatusOK || report.Status != StatusOK {
+		t.Errorf("Got %d %+v", code, report)
+	}
+	if pings.Load() != 0 {
+		t.Errorf("Liveness pinged the API %d times", pings.Load())
+	}
+}
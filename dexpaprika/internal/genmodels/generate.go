@@ -0,0 +1,408 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/internal/openapi"
+)
+
+// object is a JSON object that keeps the order of its keys, so generated
+// fields follow the spec.
+type object struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+func (o *object) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("expected an object")
+	}
+	o.values = make(map[string]json.RawMessage)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		o.keys = append(o.keys, key)
+		o.values[key] = value
+	}
+	return nil
+}
+
+type spec struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas object `json:"schemas"`
+	} `json:"components"`
+	Options object `json:"x-go-options"`
+}
+
+type schema struct {
+	Doc        string `json:"x-go-doc"`
+	Manual     bool   `json:"x-go-manual"`
+	Skip       bool   `json:"x-go-skip"`
+	CSV        *bool  `json:"x-go-csv"`
+	Properties object `json:"properties"`
+}
+
+type property struct {
+	Ref         string        `json:"$ref"`
+	Type        openapi.Types `json:"type"`
+	Format      string        `json:"format"`
+	Items       *property     `json:"items"`
+	Description string        `json:"description"`
+	Name        string        `json:"x-go-name"`
+	GoType      string        `json:"x-go-type"`
+	Pointer     *bool         `json:"x-go-pointer"`
+	OmitEmpty   bool          `json:"x-go-omitempty"`
+	CSV         string        `json:"x-go-csv"`
+}
+
+type options struct {
+	Doc        string   `json:"x-go-doc"`
+	Manual     bool     `json:"x-go-manual"`
+	Operations []string `json:"operations"`
+}
+
+type operation struct {
+	OperationID string `json:"operationId"`
+	Parameters  []struct {
+		Name        string   `json:"name"`
+		In          string   `json:"in"`
+		Description string   `json:"description"`
+		GoName      string   `json:"x-go-name"`
+		Schema      property `json:"schema"`
+	} `json:"parameters"`
+}
+
+// field is a generated struct field.
+type field struct {
+	name, typ, tag, doc string
+	key                 string // JSON property or query parameter
+}
+
+// generate returns the source of models_gen.go for a spec. Hand-written
+// types are looked up in the Go files of dir.
+func generate(raw []byte, dir string) ([]byte, error) {
+	var s spec
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("parsing spec: %w", err)
+	}
+	manual, err := structTypes(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	var problems []string
+	fmt.Fprintln(&buf, "// Code generated by internal/genmodels from internal/openapi/openapi.json; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package dexpaprika")
+
+	for _, name := range s.Components.Schemas.keys {
+		var sc schema
+		if err := json.Unmarshal(s.Components.Schemas.values[name], &sc); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+		if sc.Skip {
+			continue
+		}
+		fields, err := schemaFields(sc)
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+		if sc.Manual {
+			problems = append(problems, checkManual(name, manual[name], fields, jsonKey)...)
+			continue
+		}
+		writeStruct(&buf, name, sc.Doc, fields)
+	}
+
+	ops, err := operations(s)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range s.Options.keys {
+		var o options
+		if err := json.Unmarshal(s.Options.values[name], &o); err != nil {
+			return nil, fmt.Errorf("options %s: %w", name, err)
+		}
+		fields, err := optionFields(o, ops)
+		if err != nil {
+			return nil, fmt.Errorf("options %s: %w", name, err)
+		}
+		if o.Manual {
+			problems = append(problems, checkManual(name, manual[name], fields, fieldName)...)
+			continue
+		}
+		writeStruct(&buf, name, o.Doc, fields)
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "\n"))
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated source: %w", err)
+	}
+	return src, nil
+}
+
+func schemaFields(sc schema) ([]field, error) {
+	csv := sc.CSV == nil || *sc.CSV
+	var fields []field
+	for _, key := range sc.Properties.keys {
+		var p property
+		if err := json.Unmarshal(sc.Properties.values[key], &p); err != nil {
+			return nil, fmt.Errorf("property %s: %w", key, err)
+		}
+		typ, err := goType(p)
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", key, err)
+		}
+		pointer := p.Pointer != nil && *p.Pointer || p.Pointer == nil && nullable(p)
+		if pointer {
+			typ = "*" + typ
+		}
+		tag := key
+		if pointer || p.OmitEmpty {
+			tag += ",omitempty"
+		}
+		tags := fmt.Sprintf("json:%q", tag)
+		if csv {
+			name := key
+			if p.CSV != "" {
+				name = p.CSV
+			}
+			tags += fmt.Sprintf(" csv:%q", name)
+		}
+		fields = append(fields, field{name: goName(key, p.Name), typ: typ, tag: tags, doc: p.Description, key: key})
+	}
+	return fields, nil
+}
+
+// operations indexes the operations of a spec by ID.
+func operations(s spec) (map[string]operation, error) {
+	ops := make(map[string]operation)
+	for path, item := range s.Paths {
+		for method, raw := range item {
+			var op operation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			ops[op.OperationID] = op
+		}
+	}
+	return ops, nil
+}
+
+// optionFields returns a field per query parameter of the operations, in
+// order of first appearance.
+func optionFields(o options, ops map[string]operation) ([]field, error) {
+	var fields []field
+	seen := make(map[string]bool)
+	for _, id := range o.Operations {
+		op, ok := ops[id]
+		if !ok {
+			return nil, fmt.Errorf("unknown operation %s", id)
+		}
+		for _, param := range op.Parameters {
+			if param.In != "query" || seen[param.Name] {
+				continue
+			}
+			seen[param.Name] = true
+			typ, err := goType(param.Schema)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %s: %w", param.Name, err)
+			}
+			fields = append(fields, field{name: goName(param.Name, param.GoName), typ: typ, doc: param.Description, key: param.Name})
+		}
+	}
+	return fields, nil
+}
+
+func writeStruct(buf *bytes.Buffer, name, doc string, fields []field) {
+	fmt.Fprintln(buf)
+	if doc == "" {
+		doc = name + " is the " + name + " schema of the API."
+	}
+	fmt.Fprintf(buf, "// %s\n", doc)
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+	for _, f := range fields {
+		if f.doc != "" {
+			fmt.Fprintf(buf, "\t// %s\n", f.doc)
+		}
+		if f.tag != "" {
+			fmt.Fprintf(buf, "\t%s %s `%s`\n", f.name, f.typ, f.tag)
+		} else {
+			fmt.Fprintf(buf, "\t%s %s\n", f.name, f.typ)
+		}
+	}
+	fmt.Fprintln(buf, "}")
+}
+
+// goType returns the Go type of a property, without the pointer of
+// nullable properties.
+func goType(p property) (string, error) {
+	if p.GoType != "" {
+		return p.GoType, nil
+	}
+	if p.Ref != "" {
+		return p.Ref[strings.LastIndex(p.Ref, "/")+1:], nil
+	}
+	var types []string
+	for _, t := range p.Type {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	if len(types) != 1 {
+		return "interface{}", nil
+	}
+	switch types[0] {
+	case "string":
+		return "string", nil
+	case "integer":
+		if p.Format == "int64" {
+			return "int64", nil
+		}
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if p.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		item, err := goType(*p.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	}
+	return "", fmt.Errorf("unsupported type %v; set x-go-type", p.Type)
+}
+
+func nullable(p property) bool {
+	for _, t := range p.Type {
+		if t == "null" {
+			return true
+		}
+	}
+	return false
+}
+
+// initialisms are the name parts written in capitals, as in VolumeUSD.
+var initialisms = map[string]bool{"api": true, "fdv": true, "id": true, "ohlcv": true, "url": true, "usd": true}
+
+// goName converts a name such as "volume_usd_24h" into "VolumeUSD24h",
+// unless the spec names the field.
+func goName(key, name string) string {
+	if name != "" {
+		return name
+	}
+	var b strings.Builder
+	for _, part := range strings.Split(key, "_") {
+		switch {
+		case part == "":
+		case initialisms[part]:
+			b.WriteString(strings.ToUpper(part))
+		default:
+			r := []rune(part)
+			r[0] = unicode.ToUpper(r[0])
+			b.WriteString(string(r))
+		}
+	}
+	return b.String()
+}
+
+// structTypes parses the Go files of dir and returns their struct types by
+// name.
+func structTypes(dir string) (map[string]*ast.StructType, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	types := make(map[string]*ast.StructType)
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") || strings.HasSuffix(path, "_gen.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.TypeSpec); ok {
+				if st, ok := spec.Type.(*ast.StructType); ok {
+					types[spec.Name.Name] = st
+				}
+			}
+			return true
+		})
+	}
+	return types, nil
+}
+
+// checkManual reports the fields a hand-written type lacks, matched by
+// their key.
+func checkManual(name string, st *ast.StructType, want []field, key func(*ast.Field) []string) []string {
+	if st == nil {
+		return []string{fmt.Sprintf("%s is marked x-go-manual but is not a struct type of the package", name)}
+	}
+	have := make(map[string]bool)
+	for _, f := range st.Fields.List {
+		for _, k := range key(f) {
+			have[k] = true
+		}
+	}
+	var problems []string
+	for _, f := range want {
+		if !have[f.key] && !have[f.name] {
+			problems = append(problems, fmt.Sprintf("hand-written %s lacks a field for %q, such as %s %s", name, f.key, f.name, f.typ))
+		}
+	}
+	return problems
+}
+
+// jsonKey returns the JSON name of a struct field.
+func jsonKey(f *ast.Field) []string {
+	if f.Tag == nil {
+		return nil
+	}
+	tag, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return nil
+	}
+	name, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+	return []string{name}
+}
+
+// fieldName returns the names of a struct field.
+func fieldName(f *ast.Field) []string {
+	var names []string
+	for _, n := range f.Names {
+		names = append(names, n.Name)
+	}
+	return names
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/internal/openapi"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	src, err := generate(openapi.Raw(), "../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := os.ReadFile("../../models_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(current, src) {
+		t.Error("models_gen.go is out of date with the OpenAPI spec; run go generate ./dexpaprika")
+	}
+}
+
+func TestGenerate_ManualTypes(t *testing.T) {
+	raw := []byte(`{
+		"components": {"schemas": {
+			"Network": {"x-go-manual": true, "properties": {"id": {"type": "string"}, "new_field": {"type": "integer"}}}
+		}}
+	}`)
+	_, err := generate(raw, "../..")
+	if err == nil || !strings.Contains(err.Error(), `hand-written Network lacks a field for "new_field", such as NewField int`) {
+		t.Errorf("Expected the missing field to be reported, got %v", err)
+	}
+
+	raw = []byte(`{"components": {"schemas": {"Missing": {"x-go-manual": true, "properties": {}}}}}`)
+	if _, err := generate(raw, "../.."); err == nil {
+		t.Error("Expected an error for a manual type that does not exist")
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"id":                       "ID",
+		"volume_usd_24h":           "VolumeUSD24h",
+		"last_price_change_usd_5m": "LastPriceChangeUSD5m",
+		"token_0":                  "Token0",
+		"fdv":                      "FDV",
+	}
+	for key, want := range tests {
+		if got := goName(key, ""); got != want {
+			t.Errorf("goName(%q) = %q, want %q", key, got, want)
+		}
+	}
+	if got := goName("24h", "Day"); got != "Day" {
+		t.Errorf("goName with x-go-name = %q, want Day", got)
+	}
+}
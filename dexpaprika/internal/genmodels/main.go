@@ -0,0 +1,53 @@
+// Command genmodels regenerates models_gen.go from the embedded OpenAPI
+// spec: a struct per component schema and a query-option struct per entry
+// of the spec's x-go-options. It is invoked via go:generate from the
+// dexpaprika package directory.
+//
+// The spec's x-go-* extensions tune the Go code:
+//
+//	x-go-doc        schema or option doc comment
+//	x-go-manual     the type is hand-written; genmodels only checks that it
+//	                has a field for every property or query parameter
+//	x-go-skip       the schema has no Go type
+//	x-go-csv        false on a schema to omit csv tags, or a property's csv name
+//	x-go-name       a property's field name
+//	x-go-type       a property's Go type
+//	x-go-pointer    whether a property is a pointer; nullable ones are by default
+//	x-go-omitempty  whether a property's json tag has omitempty; pointers do
+//
+// With -check it writes nothing and fails if the output file is stale.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/internal/openapi"
+)
+
+func main() {
+	out := flag.String("out", "models_gen.go", "output file")
+	dir := flag.String("dir", ".", "directory of the dexpaprika package, for checking hand-written types")
+	check := flag.Bool("check", false, "fail if the output file is not up to date instead of writing it")
+	flag.Parse()
+
+	src, err := generate(openapi.Raw(), *dir)
+	if err != nil {
+		log.Fatalf("Failed to generate models: %v", err)
+	}
+	if *check {
+		current, err := os.ReadFile(*out)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", *out, err)
+		}
+		if !bytes.Equal(current, src) {
+			log.Fatalf("%s is out of date with the OpenAPI spec; run go generate", *out)
+		}
+		return
+	}
+	if err := os.WriteFile(*out, src, 0o600); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+}
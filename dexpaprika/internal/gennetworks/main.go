@@ -0,0 +1,83 @@
+// Command gennetworks regenerates networks_gen.go from the live /networks endpoint.
+// It is invoked via go:generate from the dexpaprika package directory.
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func main() {
+	out := flag.String("out", "networks_gen.go", "output file")
+	flag.Parse()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := dexpaprika.NewClient()
+	networks, err := client.Networks.List(ctx)
+	if err != nil {
+		log.Fatalf("Failed to list networks: %v", err)
+	}
+
+	sort.Slice(networks, func(i, j int) bool { return networks[i].ID < networks[j].ID })
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by internal/gennetworks; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package dexpaprika")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// Network IDs supported by the DexPaprika API at generation time.")
+	fmt.Fprintln(&buf, "const (")
+	for _, n := range networks {
+		fmt.Fprintf(&buf, "\t%s = %q\n", constName(n.ID), n.ID)
+	}
+	fmt.Fprintln(&buf, ")")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// KnownNetworks lists the networks supported by the DexPaprika API at generation time.")
+	fmt.Fprintln(&buf, "// Use NetworksService.Refresh to load the current list at runtime.")
+	fmt.Fprintln(&buf, "var KnownNetworks = []Network{")
+	for _, n := range networks {
+		fmt.Fprintf(&buf, "\t{ID: %s, DisplayName: %q},\n", constName(n.ID), n.DisplayName)
+	}
+	fmt.Fprintln(&buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("Failed to format generated source: %v", err)
+	}
+
+	if err := os.WriteFile(*out, src, 0o600); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+}
+
+// constName converts a network ID such as "polygon_zkevm" into "NetworkPolygonZkevm".
+func constName(id string) string {
+	var b strings.Builder
+	b.WriteString("Network")
+	upper := true
+	for _, r := range id {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,116 @@
+// Package httpassert checks the requests received by test handlers. It is
+// shared by the SDK's own tests and dexpaprikatest, which exports it.
+//
+// Checks report failures with Errorf, never Fatalf, since handlers run
+// outside the test's goroutine, and return whether they passed.
+package httpassert
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Query lists expected query parameters. An empty value expects the
+// parameter to be absent.
+type Query map[string]string
+
+// Method checks the method of a request.
+func Method(tb testing.TB, r *http.Request, want string) bool {
+	tb.Helper()
+	if r.Method != want {
+		tb.Errorf("%s: method = %s, want %s", describe(r), r.Method, want)
+		return false
+	}
+	return true
+}
+
+// Path checks the path of a request.
+func Path(tb testing.TB, r *http.Request, want string) bool {
+	tb.Helper()
+	if r.URL.Path != want {
+		tb.Errorf("%s: path = %q, want %q", describe(r), r.URL.Path, want)
+		return false
+	}
+	return true
+}
+
+// Request checks the method and path of a request.
+func Request(tb testing.TB, r *http.Request, method, path string) bool {
+	tb.Helper()
+	ok := Method(tb, r, method)
+	return Path(tb, r, path) && ok
+}
+
+// Params checks the query parameters listed in want, ignoring others.
+func Params(tb testing.TB, r *http.Request, want Query) bool {
+	tb.Helper()
+	problems := diff(r.URL.Query(), want, false)
+	return report(tb, r, problems)
+}
+
+// ExactParams checks that a request has the query parameters in want and no
+// others.
+func ExactParams(tb testing.TB, r *http.Request, want Query) bool {
+	tb.Helper()
+	problems := diff(r.URL.Query(), want, true)
+	return report(tb, r, problems)
+}
+
+// diff lists how got differs from want, in key order.
+func diff(got url.Values, want Query, exact bool) []string {
+	var problems []string
+	for _, key := range sortedKeys(want) {
+		values, present := got[key]
+		switch {
+		case want[key] == "" && present:
+			problems = append(problems, fmt.Sprintf("%s = %q, want none", key, strings.Join(values, ",")))
+		case want[key] == "":
+		case !present:
+			problems = append(problems, fmt.Sprintf("%s missing, want %q", key, want[key]))
+		case len(values) > 1:
+			problems = append(problems, fmt.Sprintf("%s repeated as %q, want %q once", key, values, want[key]))
+		case values[0] != want[key]:
+			problems = append(problems, fmt.Sprintf("%s = %q, want %q", key, values[0], want[key]))
+		}
+	}
+	if exact {
+		var extra []string
+		for key := range got {
+			if _, ok := want[key]; !ok {
+				extra = append(extra, key)
+			}
+		}
+		sort.Strings(extra)
+		for _, key := range extra {
+			problems = append(problems, fmt.Sprintf("unexpected %s = %q", key, got.Get(key)))
+		}
+	}
+	return problems
+}
+
+func report(tb testing.TB, r *http.Request, problems []string) bool {
+	tb.Helper()
+	if len(problems) == 0 {
+		return true
+	}
+	tb.Errorf("%s: query %s", describe(r), strings.Join(problems, "; "))
+	return false
+}
+
+// describe names a request in failures, such as "GET /pools?limit=10".
+func describe(r *http.Request) string {
+	return r.Method + " " + r.URL.RequestURI()
+}
+
+func sortedKeys(q Query) []string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,60 @@
+package httpassert
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// recorder is a testing.TB recording failures instead of failing.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestChecks(t *testing.T) {
+	req := httptest.NewRequest("GET", "/networks/ethereum/pools?limit=10&sort=desc&page=0&page=1", nil)
+
+	tests := []struct {
+		name  string
+		check func(tb testing.TB) bool
+		want  string // Failure message, or empty if the check passes
+	}{
+		{"method", func(tb testing.TB) bool { return Method(tb, req, "GET") }, ""},
+		{"wrong method", func(tb testing.TB) bool { return Method(tb, req, "POST") }, "method = GET, want POST"},
+		{"path", func(tb testing.TB) bool { return Path(tb, req, "/networks/ethereum/pools") }, ""},
+		{"wrong path", func(tb testing.TB) bool { return Path(tb, req, "/pools") }, `path = "/networks/ethereum/pools", want "/pools"`},
+		{"request", func(tb testing.TB) bool { return Request(tb, req, "GET", "/networks/ethereum/pools") }, ""},
+		{"params", func(tb testing.TB) bool { return Params(tb, req, Query{"limit": "10", "cursor": ""}) }, ""},
+		{"wrong param", func(tb testing.TB) bool { return Params(tb, req, Query{"limit": "5"}) }, `limit = "10", want "5"`},
+		{"missing param", func(tb testing.TB) bool { return Params(tb, req, Query{"order_by": "volume_usd"}) }, `order_by missing, want "volume_usd"`},
+		{"present param", func(tb testing.TB) bool { return Params(tb, req, Query{"sort": ""}) }, `sort = "desc", want none`},
+		{"repeated param", func(tb testing.TB) bool { return Params(tb, req, Query{"page": "0"}) }, `page repeated as ["0" "1"], want "0" once`},
+		{"exact params", func(tb testing.TB) bool { return ExactParams(tb, req, Query{"limit": "10", "sort": "desc"}) }, `unexpected page = "0"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{TB: t}
+			ok := tt.check(rec)
+			if tt.want == "" {
+				if !ok || len(rec.errors) != 0 {
+					t.Errorf("check failed: %v", rec.errors)
+				}
+				return
+			}
+			if ok || len(rec.errors) != 1 {
+				t.Fatalf("check passed or reported %d failures, want one", len(rec.errors))
+			}
+			if !strings.HasPrefix(rec.errors[0], "GET /networks/ethereum/pools?") || !strings.Contains(rec.errors[0], tt.want) {
+				t.Errorf("failure = %q, want the request and %q", rec.errors[0], tt.want)
+			}
+		})
+	}
+}
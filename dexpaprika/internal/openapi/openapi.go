@@ -0,0 +1,477 @@
+// Package openapi embeds the DexPaprika OpenAPI specification and validates
+// requests and responses against it. Contract tests use it to catch
+// divergence between the SDK and the API: requests with paths or parameters
+// the spec does not define, and responses the models cannot decode.
+//
+// Only the subset of OpenAPI 3.1 the spec uses is supported: path and query
+// parameters, $ref to component schemas and responses, and schemas with
+// type, format, enum, minimum, maximum, minLength, properties, required and
+// items.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+// Raw returns the embedded specification as JSON.
+func Raw() []byte {
+	return append([]byte(nil), spec...)
+}
+
+// Load parses the embedded specification.
+func Load() (*Spec, error) {
+	return Parse(spec)
+}
+
+// Spec is an OpenAPI document.
+type Spec struct {
+	OpenAPI string `json:"openapi"`
+	Info    struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components struct {
+		Schemas   map[string]*Schema   `json:"schemas"`
+		Responses map[string]*Response `json:"responses"`
+	} `json:"components"`
+}
+
+// Operation is an operation on a path.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Parameters  []Parameter          `json:"parameters"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path or query parameter of an operation.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+// Response is a response of an operation, or a reference to a component
+// response.
+type Response struct {
+	Ref         string               `json:"$ref"`
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// MediaType is the content of a response in one media type.
+type MediaType struct {
+	Schema  *Schema         `json:"schema"`
+	Example json.RawMessage `json:"example"`
+}
+
+// Schema is a JSON schema, or a reference to a component schema.
+type Schema struct {
+	Ref        string             `json:"$ref"`
+	Type       Types              `json:"type"`
+	Format     string             `json:"format"`
+	Enum       []interface{}      `json:"enum"`
+	Minimum    *float64           `json:"minimum"`
+	Maximum    *float64           `json:"maximum"`
+	MinLength  *int               `json:"minLength"`
+	Properties map[string]*Schema `json:"properties"`
+	Required   []string           `json:"required"`
+	Items      *Schema            `json:"items"`
+}
+
+// Types are the types a schema allows. OpenAPI 3.1 accepts a single type or
+// a list, such as ["number", "null"].
+type Types []string
+
+// UnmarshalJSON accepts a type name or a list of them.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*t = Types{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+// Route is an operation together with its method and path template.
+type Route struct {
+	Method string
+	Path   string
+	*Operation
+}
+
+// ValidationError lists every way a request or response violates the spec.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Problems, "; ")
+}
+
+// Parse parses a specification in JSON.
+func Parse(data []byte) (*Spec, error) {
+	var s Spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse OpenAPI spec: %w", err)
+	}
+	if len(s.Paths) == 0 {
+		return nil, fmt.Errorf("parse OpenAPI spec: no paths")
+	}
+	return &s, nil
+}
+
+// Routes returns every operation, ordered by path and method.
+func (s *Spec) Routes() []Route {
+	var routes []Route
+	for path, ops := range s.Paths {
+		for method, op := range ops {
+			routes = append(routes, Route{Method: strings.ToUpper(method), Path: path, Operation: op})
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// Find returns the route serving a request path, with the values of its
+// path parameters. When several templates match, the one with the most
+// literal segments wins.
+func (s *Spec) Find(method, path string) (Route, map[string]string, error) {
+	segments := split(path)
+	var (
+		best     Route
+		bestVars map[string]string
+		bestLits = -1
+	)
+	for template, ops := range s.Paths {
+		op, ok := ops[strings.ToLower(method)]
+		if !ok {
+			continue
+		}
+		vars, literals, ok := match(split(template), segments)
+		if ok && literals > bestLits {
+			best = Route{Method: strings.ToUpper(method), Path: template, Operation: op}
+			bestVars, bestLits = vars, literals
+		}
+	}
+	if bestLits < 0 {
+		return Route{}, nil, fmt.Errorf("%s %s: no operation in the spec", method, path)
+	}
+	return best, bestVars, nil
+}
+
+func split(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// match matches path segments against a template's, returning the path
+// parameters and the number of literal segments.
+func match(template, segments []string) (map[string]string, int, bool) {
+	if len(template) != len(segments) {
+		return nil, 0, false
+	}
+	vars := make(map[string]string)
+	literals := 0
+	for i, t := range template {
+		if strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}") {
+			if segments[i] == "" {
+				return nil, 0, false
+			}
+			vars[t[1:len(t)-1]] = segments[i]
+			continue
+		}
+		if t != segments[i] {
+			return nil, 0, false
+		}
+		literals++
+	}
+	return vars, literals, true
+}
+
+// ValidateRequest checks that a request targets an operation of the spec,
+// sends only the query parameters it defines, includes the required ones,
+// and that every parameter value matches its schema.
+func (s *Spec) ValidateRequest(r *http.Request) (Route, error) {
+	route, vars, err := s.Find(r.Method, r.URL.Path)
+	if err != nil {
+		return Route{}, err
+	}
+
+	var problems []string
+	query := r.URL.Query()
+	defined := make(map[string]bool)
+	for _, p := range route.Parameters {
+		var values []string
+		switch p.In {
+		case "path":
+			values = []string{vars[p.Name]}
+		case "query":
+			defined[p.Name] = true
+			values = query[p.Name]
+		default:
+			continue
+		}
+		if len(values) == 0 {
+			if p.Required {
+				problems = append(problems, fmt.Sprintf("missing required %s parameter %q", p.In, p.Name))
+			}
+			continue
+		}
+		for _, v := range values {
+			if problem := s.checkParameter(p.Schema, v); problem != "" {
+				problems = append(problems, fmt.Sprintf("%s parameter %q: %s", p.In, p.Name, problem))
+			}
+		}
+	}
+	for name := range query {
+		if !defined[name] {
+			problems = append(problems, fmt.Sprintf("query parameter %q is not defined", name))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return route, fmt.Errorf("%s %s: %w", route.Method, route.Path, &ValidationError{Problems: problems})
+	}
+	return route, nil
+}
+
+// checkParameter validates a raw parameter value, converting it to the
+// schema's type first.
+func (s *Spec) checkParameter(schema *Schema, raw string) string {
+	schema = s.resolve(schema)
+	if schema == nil {
+		return ""
+	}
+	var value interface{} = raw
+	switch {
+	case schema.is("integer"):
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Sprintf("%q is not an integer", raw)
+		}
+		value = float64(n)
+	case schema.is("number"):
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Sprintf("%q is not a number", raw)
+		}
+		value = f
+	case schema.is("boolean"):
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Sprintf("%q is not a boolean", raw)
+		}
+		value = b
+	}
+	var problems []string
+	s.validate(schema, value, "", &problems)
+	if len(problems) > 0 {
+		return strings.TrimPrefix(problems[0], ": ")
+	}
+	return ""
+}
+
+// Example returns the example body of a route's response with a status.
+func (s *Spec) Example(route Route, status int) ([]byte, error) {
+	media, err := s.media(route, status)
+	if err != nil {
+		return nil, err
+	}
+	if len(media.Example) == 0 {
+		return nil, fmt.Errorf("%s %s: no example for status %d", route.Method, route.Path, status)
+	}
+	return media.Example, nil
+}
+
+// ValidateResponse checks a response body against the schema of a route's
+// response with the given status.
+func (s *Spec) ValidateResponse(route Route, status int, body []byte) error {
+	media, err := s.media(route, status)
+	if err != nil {
+		return err
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("%s %s: decode response: %w", route.Method, route.Path, err)
+	}
+	var problems []string
+	s.validate(media.Schema, value, "$", &problems)
+	if len(problems) > 0 {
+		return fmt.Errorf("%s %s: %w", route.Method, route.Path, &ValidationError{Problems: problems})
+	}
+	return nil
+}
+
+// media returns the JSON content of a route's response with a status.
+func (s *Spec) media(route Route, status int) (MediaType, error) {
+	resp, ok := route.Responses[strconv.Itoa(status)]
+	if !ok {
+		resp, ok = route.Responses["default"]
+	}
+	if !ok {
+		return MediaType{}, fmt.Errorf("%s %s: status %d is not documented", route.Method, route.Path, status)
+	}
+	if ref := resp.Ref; ref != "" {
+		if resp, ok = s.Components.Responses[strings.TrimPrefix(ref, "#/components/responses/")]; !ok {
+			return MediaType{}, fmt.Errorf("unresolved reference %s", ref)
+		}
+	}
+	media, ok := resp.Content["application/json"]
+	if !ok {
+		return MediaType{}, fmt.Errorf("%s %s: status %d has no JSON content", route.Method, route.Path, status)
+	}
+	return media, nil
+}
+
+// resolve follows a schema's reference to a component schema.
+func (s *Spec) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = s.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+	return schema
+}
+
+// allows reports whether a schema accepts a type. A schema without types
+// accepts any.
+func (schema *Schema) allows(typ string) bool {
+	if len(schema.Type) == 0 {
+		return true
+	}
+	for _, t := range schema.Type {
+		if t == typ || (t == "number" && typ == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// is reports whether a schema lists a type.
+func (schema *Schema) is(typ string) bool {
+	for _, t := range schema.Type {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// validate appends to problems every violation of schema by a decoded JSON
+// value at path.
+func (s *Spec) validate(schema *Schema, value interface{}, path string, problems *[]string) {
+	if schema != nil && schema.Ref != "" {
+		resolved := s.resolve(schema)
+		if resolved == nil {
+			*problems = append(*problems, fmt.Sprintf("%s: unresolved reference %s", path, schema.Ref))
+			return
+		}
+		schema = resolved
+	}
+	if schema == nil {
+		return
+	}
+	fail := func(format string, args ...interface{}) {
+		*problems = append(*problems, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	typ := typeOf(value)
+	if !schema.allows(typ) {
+		fail("got %s, want %s", typ, strings.Join(schema.Type, " or "))
+		return
+	}
+	if len(schema.Enum) > 0 && !contains(schema.Enum, value) {
+		fail("%v is not one of %v", value, schema.Enum)
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if schema.Minimum != nil && v < *schema.Minimum {
+			fail("%v is below the minimum %v", v, *schema.Minimum)
+		}
+		if schema.Maximum != nil && v > *schema.Maximum {
+			fail("%v is above the maximum %v", v, *schema.Maximum)
+		}
+	case string:
+		if schema.MinLength != nil && len(v) < *schema.MinLength {
+			fail("%q is shorter than %d", v, *schema.MinLength)
+		}
+		if schema.Format == "date-time" && v != "" {
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				fail("%q is not a date-time", v)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if prop, ok := schema.Properties[name]; ok {
+				s.validate(prop, v[name], path+"."+name, problems)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			s.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i), problems)
+		}
+	}
+}
+
+// typeOf returns the JSON schema type of a decoded JSON value. Whole numbers
+// are integers.
+func typeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func contains(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
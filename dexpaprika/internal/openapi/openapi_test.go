@@ -0,0 +1,163 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	spec, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.1") {
+		t.Errorf("OpenAPI = %q, want 3.1", spec.OpenAPI)
+	}
+	seen := make(map[string]bool)
+	for _, route := range spec.Routes() {
+		if route.OperationID == "" || seen[route.OperationID] {
+			t.Errorf("%s %s: missing or duplicate operationId %q", route.Method, route.Path, route.OperationID)
+		}
+		seen[route.OperationID] = true
+	}
+}
+
+// TestExamplesMatchSchemas keeps the spec consistent with itself: every
+// example validates against the schema it documents.
+func TestExamplesMatchSchemas(t *testing.T) {
+	spec, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, route := range spec.Routes() {
+		for _, status := range []int{http.StatusOK, http.StatusBadRequest, http.StatusNotFound} {
+			example, err := spec.Example(route, status)
+			if err != nil {
+				t.Errorf("Example(%s, %d): %v", route.OperationID, status, err)
+				continue
+			}
+			if err := spec.ValidateResponse(route, status, example); err != nil {
+				t.Errorf("example of %s: %v", route.OperationID, err)
+			}
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	spec, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path string
+		want string
+		vars map[string]string
+	}{
+		{"/networks", "getNetworks", nil},
+		{"/networks/ethereum/pools", "getNetworkPools", map[string]string{"network": "ethereum"}},
+		{"/networks/ethereum/dexes/uniswap_v3/pools", "getDexPools", map[string]string{"network": "ethereum", "dex": "uniswap_v3"}},
+		{"/networks/ethereum/pools/0xabc/ohlcv", "getPoolOHLCV", map[string]string{"network": "ethereum", "pool_address": "0xabc"}},
+		{"/networks/ethereum/tokens/0xabc/transactions", "getTokenTransactions", map[string]string{"network": "ethereum", "token_address": "0xabc"}},
+	}
+	for _, tt := range tests {
+		route, vars, err := spec.Find(http.MethodGet, tt.path)
+		if err != nil {
+			t.Errorf("Find(%s): %v", tt.path, err)
+			continue
+		}
+		if route.OperationID != tt.want {
+			t.Errorf("Find(%s) = %s, want %s", tt.path, route.OperationID, tt.want)
+		}
+		for k, v := range tt.vars {
+			if vars[k] != v {
+				t.Errorf("Find(%s) %s = %q, want %q", tt.path, k, vars[k], v)
+			}
+		}
+	}
+
+	for _, path := range []string{"/networks/ethereum/unknown", "/networks//pools"} {
+		if _, _, err := spec.Find(http.MethodGet, path); err == nil {
+			t.Errorf("Find(%s) succeeded", path)
+		}
+	}
+	if _, _, err := spec.Find(http.MethodPost, "/networks"); err == nil {
+		t.Error("Find(POST /networks) succeeded")
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	spec, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		url     string
+		problem string // Empty if the request is valid
+	}{
+		{"/networks/ethereum/pools?page=1&limit=50&order_by=volume_usd&sort=desc", ""},
+		{"/networks/ethereum/pools/0xabc/ohlcv?start=2024-06-01&interval=1h&limit=366&inversed=true", ""},
+		{"/search?query=uniswap", ""},
+		{"/networks/ethereum/pools?limit=500", `query parameter "limit": 500 is above the maximum 100`},
+		{"/networks/ethereum/pools?limit=ten", `query parameter "limit": "ten" is not an integer`},
+		{"/networks/ethereum/pools?sort=up", `query parameter "sort": up is not one of [asc desc]`},
+		{"/networks/ethereum/pools?pageSize=5", `query parameter "pageSize" is not defined`},
+		{"/networks/ethereum/pools/0xabc/ohlcv?interval=1h", `missing required query parameter "start"`},
+		{"/networks/ethereum/pools/0xabc?inversed=yes", `query parameter "inversed": "yes" is not a boolean`},
+		{"/search?query=", `query parameter "query": "" is shorter than 1`},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.dexpaprika.com"+tt.url, nil)
+		_, err := spec.ValidateRequest(req)
+		if tt.problem == "" {
+			if err != nil {
+				t.Errorf("ValidateRequest(%s): %v", tt.url, err)
+			}
+			continue
+		}
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Errorf("ValidateRequest(%s) = %v, want a ValidationError", tt.url, err)
+			continue
+		}
+		if len(verr.Problems) != 1 || verr.Problems[0] != tt.problem {
+			t.Errorf("ValidateRequest(%s) problems = %q, want %q", tt.url, verr.Problems, tt.problem)
+		}
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	spec, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	route, _, err := spec.Find(http.MethodGet, "/networks/ethereum/pools")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{"pools": [{"id": "0xabc", "dex_id": "uniswap_v3", "chain": "ethereum", "volume_usd": "12",
+		"fee": null, "created_at": "yesterday", "tokens": [{"id": "0xdef", "chain": "ethereum"}]}],
+		"page_info": {"limit": 10, "page": 0}}`
+	err = spec.ValidateResponse(route, http.StatusOK, []byte(body))
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("ValidateResponse() = %v, want a ValidationError", err)
+	}
+	want := []string{
+		`$.pools[0].created_at: "yesterday" is not a date-time`,
+		`$.pools[0].tokens[0]: missing required property "symbol"`,
+		`$.pools[0].volume_usd: got string, want number`,
+	}
+	if strings.Join(verr.Problems, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problems = %q, want %q", verr.Problems, want)
+	}
+
+	if err := spec.ValidateResponse(route, http.StatusTeapot, []byte(`{}`)); err == nil {
+		t.Error("ValidateResponse of an undocumented status succeeded")
+	}
+	if err := spec.ValidateResponse(route, http.StatusNotFound, []byte(`{"error": "not found"}`)); err != nil {
+		t.Errorf("ValidateResponse(404): %v", err)
+	}
+}
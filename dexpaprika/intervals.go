@@ -0,0 +1,99 @@
+package dexpaprika
+
+import "iter"
+
+// Time intervals used by pool and token summary metrics.
+const (
+	Interval24h = "24h"
+	Interval6h  = "6h"
+	Interval1h  = "1h"
+	Interval30m = "30m"
+	Interval15m = "15m"
+	Interval5m  = "5m"
+	Interval1m  = "1m"
+)
+
+// MetricsIntervals lists the metric intervals from longest to shortest.
+var MetricsIntervals = []string{
+	Interval24h,
+	Interval6h,
+	Interval1h,
+	Interval30m,
+	Interval15m,
+	Interval5m,
+	Interval1m,
+}
+
+// Metrics returns the metrics for the given interval (e.g. Interval24h).
+// PoolDetails does not carry 1m metrics.
+func (p *PoolDetails) Metrics(interval string) (*TimeIntervalMetrics, bool) {
+	switch interval {
+	case Interval24h:
+		return &p.Day, true
+	case Interval6h:
+		return &p.Hour6, true
+	case Interval1h:
+		return &p.Hour1, true
+	case Interval30m:
+		return &p.Minute30, true
+	case Interval15m:
+		return &p.Minute15, true
+	case Interval5m:
+		return &p.Minute5, true
+	default:
+		return nil, false
+	}
+}
+
+// Intervals iterates over the available interval metrics from longest to shortest.
+func (p *PoolDetails) Intervals() iter.Seq2[string, *TimeIntervalMetrics] {
+	return intervalSeq(p.Metrics)
+}
+
+// Metrics returns the metrics for the given interval (e.g. Interval24h).
+// It reports false when the interval is unknown or absent from the response,
+// and is safe to call on a nil summary.
+func (s *TokenSummary) Metrics(interval string) (*TimeIntervalMetrics, bool) {
+	if s == nil {
+		return nil, false
+	}
+
+	var m *TimeIntervalMetrics
+	switch interval {
+	case Interval24h:
+		m = s.Day
+	case Interval6h:
+		m = s.Hour6
+	case Interval1h:
+		m = s.Hour1
+	case Interval30m:
+		m = s.Minute30
+	case Interval15m:
+		m = s.Minute15
+	case Interval5m:
+		m = s.Minute5
+	case Interval1m:
+		m = s.Minute1
+	}
+	return m, m != nil
+}
+
+// Intervals iterates over the available interval metrics from longest to shortest.
+func (s *TokenSummary) Intervals() iter.Seq2[string, *TimeIntervalMetrics] {
+	return intervalSeq(s.Metrics)
+}
+
+// intervalSeq yields every interval in MetricsIntervals that lookup reports as present.
+func intervalSeq(lookup func(string) (*TimeIntervalMetrics, bool)) iter.Seq2[string, *TimeIntervalMetrics] {
+	return func(yield func(string, *TimeIntervalMetrics) bool) {
+		for _, interval := range MetricsIntervals {
+			m, ok := lookup(interval)
+			if !ok {
+				continue
+			}
+			if !yield(interval, m) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package dexpaprika
+
+import "testing"
+
+func TestPoolDetails_Metrics(t *testing.T) {
+	details := PoolDetails{
+		Day:      TimeIntervalMetrics{VolumeUSD: 2400},
+		Hour1:    TimeIntervalMetrics{VolumeUSD: 100},
+		Minute5:  TimeIntervalMetrics{VolumeUSD: 5},
+		Minute15: TimeIntervalMetrics{VolumeUSD: 15},
+	}
+
+	m, ok := details.Metrics(Interval24h)
+	if !ok || m.VolumeUSD != 2400 {
+		t.Errorf("Metrics(24h) = %v, %t, want volume 2400", m, ok)
+	}
+	if _, ok := details.Metrics(Interval1m); ok {
+		t.Error("Metrics(1m) should not be available on PoolDetails")
+	}
+	if _, ok := details.Metrics("7d"); ok {
+		t.Error("Metrics(7d) should not be available")
+	}
+
+	var intervals []string
+	for interval := range details.Intervals() {
+		intervals = append(intervals, interval)
+	}
+	want := []string{Interval24h, Interval6h, Interval1h, Interval30m, Interval15m, Interval5m}
+	if len(intervals) != len(want) {
+		t.Fatalf("Intervals() yielded %v, want %v", intervals, want)
+	}
+	for i := range want {
+		if intervals[i] != want[i] {
+			t.Errorf("Intervals()[%d] = %s, want %s", i, intervals[i], want[i])
+		}
+	}
+}
+
+func TestTokenSummary_Metrics(t *testing.T) {
+	summary := TokenSummary{
+		Day:     &TimeIntervalMetrics{VolumeUSD: 2400},
+		Minute1: &TimeIntervalMetrics{VolumeUSD: 1},
+	}
+
+	if m, ok := summary.Metrics(Interval1m); !ok || m.VolumeUSD != 1 {
+		t.Errorf("Metrics(1m) = %v, %t, want volume 1", m, ok)
+	}
+	if _, ok := summary.Metrics(Interval6h); ok {
+		t.Error("Metrics(6h) should be absent")
+	}
+
+	total := 0.0
+	count := 0
+	for _, m := range summary.Intervals() {
+		total += m.VolumeUSD
+		count++
+	}
+	if count != 2 || total != 2401 {
+		t.Errorf("Intervals() yielded %d intervals totalling %f, want 2 totalling 2401", count, total)
+	}
+
+	// Stopping early must not panic
+	for range summary.Intervals() {
+		break
+	}
+}
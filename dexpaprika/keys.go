@@ -0,0 +1,63 @@
+package dexpaprika
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EntityKey identifies a pool or token by network and normalized address.
+// It is comparable, so it can be used directly as a map key.
+type EntityKey struct {
+	Chain   string
+	Address string
+}
+
+// NewEntityKey builds a key, normalizing the address for the network so that
+// differently-cased EVM addresses produce equal keys.
+func NewEntityKey(chain, address string) EntityKey {
+	chain = strings.ToLower(strings.TrimSpace(chain))
+	return EntityKey{Chain: chain, Address: NormalizeAddress(chain, address)}
+}
+
+// ParseEntityKey parses a key in the "chain:address" form produced by String.
+func ParseEntityKey(s string) (EntityKey, error) {
+	chain, address, ok := strings.Cut(s, ":")
+	if !ok || chain == "" || address == "" {
+		return EntityKey{}, fmt.Errorf("invalid entity key %q: want chain:address", s)
+	}
+	return NewEntityKey(chain, address), nil
+}
+
+// String returns the key in "chain:address" form.
+func (k EntityKey) String() string {
+	return k.Chain + ":" + k.Address
+}
+
+// IsZero reports whether the key is empty.
+func (k EntityKey) IsZero() bool {
+	return k == EntityKey{}
+}
+
+// Key returns the pool's identity.
+func (p Pool) Key() EntityKey { return NewEntityKey(p.Chain, p.ID) }
+
+// SameAs reports whether both values refer to the same pool.
+func (p Pool) SameAs(other Pool) bool { return p.Key() == other.Key() }
+
+// Key returns the pool's identity.
+func (p PoolDetails) Key() EntityKey { return NewEntityKey(p.Chain, p.ID) }
+
+// Key returns the pool's identity.
+func (p SearchPool) Key() EntityKey { return NewEntityKey(p.Chain, p.ID) }
+
+// Key returns the token's identity.
+func (t Token) Key() EntityKey { return NewEntityKey(t.Chain, t.ID) }
+
+// SameAs reports whether both values refer to the same token.
+func (t Token) SameAs(other Token) bool { return t.Key() == other.Key() }
+
+// Key returns the token's identity.
+func (t TokenDetails) Key() EntityKey { return NewEntityKey(t.Chain, t.ID) }
+
+// Key returns the token's identity.
+func (t SearchToken) Key() EntityKey { return NewEntityKey(t.Chain, t.ID) }
@@ -0,0 +1,45 @@
+package dexpaprika
+
+import "testing"
+
+func TestEntityKeys(t *testing.T) {
+	a := Token{Chain: "ethereum", ID: "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"}
+	b := Token{Chain: "Ethereum", ID: "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"}
+	if !a.SameAs(b) {
+		t.Errorf("Expected %s and %s to be the same token", a.Key(), b.Key())
+	}
+
+	// Solana addresses are case-sensitive
+	c := Token{Chain: "solana", ID: "So11111111111111111111111111111111111111112"}
+	d := Token{Chain: "solana", ID: "so11111111111111111111111111111111111111112"}
+	if c.SameAs(d) {
+		t.Error("Solana addresses differing in case should not be the same token")
+	}
+
+	seen := map[EntityKey]bool{a.Key(): true}
+	if !seen[TokenDetails{Chain: "ethereum", ID: b.ID}.Key()] {
+		t.Error("TokenDetails key should match Token key in maps")
+	}
+
+	pool := Pool{Chain: "ethereum", ID: "0xABC"}
+	if got := pool.Key().String(); got != "ethereum:0xabc" {
+		t.Errorf("Key().String() = %q, want ethereum:0xabc", got)
+	}
+	if !pool.SameAs(Pool{Chain: "ethereum", ID: "0xabc"}) {
+		t.Error("Pools differing in address case should be the same")
+	}
+	if pool.SameAs(Pool{Chain: "base", ID: "0xabc"}) {
+		t.Error("Pools on different chains should differ")
+	}
+
+	parsed, err := ParseEntityKey("ethereum:0xABC")
+	if err != nil || parsed != pool.Key() {
+		t.Errorf("ParseEntityKey() = %v, %v, want %v", parsed, err, pool.Key())
+	}
+	if _, err := ParseEntityKey("ethereum"); err == nil {
+		t.Error("ParseEntityKey should reject keys without an address")
+	}
+	if !(EntityKey{}).IsZero() || pool.Key().IsZero() {
+		t.Error("IsZero returned unexpected result")
+	}
+}
@@ -0,0 +1,180 @@
+package dexpaprika
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// DexMarket summarizes the pools of one DEX.
+type DexMarket struct {
+	DexID        string  `json:"dex_id" csv:"dex_id"`
+	DexName      string  `json:"dex_name" csv:"dex_name"`
+	Pools        int     `json:"pools" csv:"pools"`
+	VolumeUSD24h float64 `json:"volume_usd_24h" csv:"volume_usd_24h"`
+	Transactions int     `json:"transactions" csv:"transactions"`
+	VolumeShare  float64 `json:"volume_share" csv:"volume_share"` // Fraction of the total 24h volume
+	TopPool      string  `json:"top_pool" csv:"top_pool"`         // Address of the DEX's most active pool
+}
+
+// TokenMarkets summarizes where a token trades, like the markets tab of a
+// block explorer.
+type TokenMarkets struct {
+	Network      string      `json:"network" csv:"network"`
+	Token        string      `json:"token" csv:"token"`
+	LiquidityUSD float64     `json:"liquidity_usd" csv:"liquidity_usd"`
+	VolumeUSD24h float64     `json:"volume_usd_24h" csv:"volume_usd_24h"`
+	Transactions int         `json:"transactions" csv:"transactions"`
+	Pools        int         `json:"pools" csv:"pools"`
+	Dexes        []DexMarket `json:"dexes" csv:"-"`
+}
+
+// AggregateMarkets walks a token's pools and returns its total liquidity and
+// 24h volume with a per-DEX breakdown, most active DEX first. Pools are
+// walked in order of volume and the walk stops at the first pool without
+// volume, so per-DEX pool counts include active pools only; Pools is the
+// token's total pool count. Liquidity comes from the token summary, as pool
+// listings do not report it.
+func (s *TokensService) AggregateMarkets(ctx context.Context, networkID, tokenAddress string) (*TokenMarkets, error) {
+	details, err := s.GetDetails(ctx, networkID, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, _ := details.GetSummary()
+	liquidity, _ := summary.GetLiquidityUSD()
+	markets := &TokenMarkets{
+		Network:      networkID,
+		Token:        tokenAddress,
+		LiquidityUSD: liquidity,
+	}
+
+	var active []Pool
+	paginator := NewPoolsPaginator(s.client, &ListOptions{Limit: 100, OrderBy: "volume_usd", Sort: "desc"}).
+		ForToken(networkID, tokenAddress, "")
+	for paginator.HasNextPage() {
+		if err := paginator.GetNextPage(ctx); err != nil {
+			return nil, err
+		}
+		if markets.Pools == 0 {
+			markets.Pools = paginator.currentResp.PageInfo.TotalItems
+		}
+		done := false
+		for _, p := range paginator.GetCurrentPage() {
+			if p.VolumeUSD <= 0 {
+				done = true
+				break
+			}
+			active = append(active, p)
+		}
+		if done {
+			break
+		}
+	}
+	if markets.Pools < len(active) {
+		markets.Pools = len(active)
+	}
+
+	markets.Dexes = aggregateDexes(active)
+	for _, d := range markets.Dexes {
+		markets.VolumeUSD24h += d.VolumeUSD24h
+		markets.Transactions += d.Transactions
+	}
+
+	return markets, nil
+}
+
+// MarketShareReport is the 24h volume share of each DEX on a network.
+type MarketShareReport struct {
+	Network      string      `json:"network" csv:"network"`
+	GeneratedAt  time.Time   `json:"generated_at" csv:"generated_at"`
+	VolumeUSD24h float64     `json:"volume_usd_24h" csv:"volume_usd_24h"`
+	Dexes        []DexMarket `json:"dexes" csv:"-"`
+}
+
+// marketSharePages caps the pool pages scanned for a market share report.
+const marketSharePages = 20
+
+// MarketShare reports each DEX's share of a network's 24h volume, largest
+// first. The network's pools are scanned in order of volume, up to 2,000
+// pools, which carry nearly all of its volume; DEXes without an active pool
+// are listed with a zero share. GeneratedAt records when the report was
+// built, so successive reports can be compared over time. Use
+// CachedClient.GetDexMarketShare to avoid rescanning on every call.
+func (s *DexesService) MarketShare(ctx context.Context, networkID string) (*MarketShareReport, error) {
+	var active []Pool
+	opts := &ListOptions{Limit: 100, OrderBy: "volume_usd", Sort: "desc"}
+	for page := 0; page < marketSharePages; page++ {
+		opts.Page = page
+		resp, err := s.client.Pools.ListByNetwork(ctx, networkID, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		done := len(resp.Pools) < opts.Limit || page+1 >= resp.PageInfo.TotalPages
+		for _, p := range resp.Pools {
+			if p.VolumeUSD <= 0 {
+				done = true
+				break
+			}
+			active = append(active, p)
+		}
+		if done {
+			break
+		}
+	}
+
+	report := &MarketShareReport{
+		Network:     networkID,
+		GeneratedAt: time.Now().UTC(),
+		Dexes:       aggregateDexes(active),
+	}
+	seen := make(map[string]bool, len(report.Dexes))
+	for _, d := range report.Dexes {
+		report.VolumeUSD24h += d.VolumeUSD24h
+		seen[d.DexID] = true
+	}
+
+	err := s.each(ctx, networkID, func(d Dex) bool {
+		if !seen[d.ID] {
+			seen[d.ID] = true
+			report.Dexes = append(report.Dexes, DexMarket{DexID: d.ID, DexName: d.Name})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// aggregateDexes groups pools by DEX, ordered by 24h volume descending.
+func aggregateDexes(pools []Pool) []DexMarket {
+	index := make(map[string]int)
+	var dexes []DexMarket
+	var total float64
+	for _, p := range pools {
+		i, ok := index[p.DexID]
+		if !ok {
+			i = len(dexes)
+			index[p.DexID] = i
+			dexes = append(dexes, DexMarket{DexID: p.DexID, DexName: p.DexName, TopPool: p.ID})
+		}
+		d := &dexes[i]
+		d.Pools++
+		d.VolumeUSD24h += p.VolumeUSD
+		d.Transactions += p.Transactions
+		total += p.VolumeUSD
+	}
+
+	for i := range dexes {
+		if total > 0 {
+			dexes[i].VolumeShare = dexes[i].VolumeUSD24h / total
+		}
+	}
+	sort.SliceStable(dexes, func(i, j int) bool {
+		return dexes[i].VolumeUSD24h > dexes[j].VolumeUSD24h
+	})
+	return dexes
+}
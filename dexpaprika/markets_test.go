@@ -0,0 +1,102 @@
+package dexpaprika
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokens_AggregateMarkets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/networks/ethereum/tokens/0xtoken":
+			fmt.Fprintln(w, `{"id": "0xtoken", "summary": {"price_usd": 1, "liquidity_usd": 5000000}}`)
+		case "/networks/ethereum/tokens/0xtoken/pools":
+			fmt.Fprintln(w, `{"pools": [
+				{"id": "0xa", "dex_id": "uniswap_v3", "dex_name": "Uniswap V3", "volume_usd": 600, "transactions": 6},
+				{"id": "0xb", "dex_id": "sushiswap", "dex_name": "SushiSwap", "volume_usd": 300, "transactions": 3},
+				{"id": "0xc", "dex_id": "uniswap_v3", "dex_name": "Uniswap V3", "volume_usd": 100, "transactions": 1},
+				{"id": "0xd", "dex_id": "curve", "dex_name": "Curve", "volume_usd": 0}
+			], "page_info": {"limit": 100, "page": 0, "total_items": 4, "total_pages": 1}}`)
+		default:
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+
+	markets, err := client.Tokens.AggregateMarkets(context.Background(), "ethereum", "0xtoken")
+	if err != nil {
+		t.Fatalf("AggregateMarkets returned error: %v", err)
+	}
+	if markets.LiquidityUSD != 5000000 || markets.VolumeUSD24h != 1000 || markets.Transactions != 10 || markets.Pools != 4 {
+		t.Errorf("Unexpected totals: %+v", markets)
+	}
+	if len(markets.Dexes) != 2 {
+		t.Fatalf("Expected 2 active dexes, got %+v", markets.Dexes)
+	}
+
+	uni := markets.Dexes[0]
+	if uni.DexID != "uniswap_v3" || uni.Pools != 2 || uni.VolumeUSD24h != 700 || uni.TopPool != "0xa" {
+		t.Errorf("Unexpected Uniswap market: %+v", uni)
+	}
+	if math.Abs(uni.VolumeShare-0.7) > 1e-12 || math.Abs(markets.Dexes[1].VolumeShare-0.3) > 1e-12 {
+		t.Errorf("Unexpected volume shares: %f, %f", uni.VolumeShare, markets.Dexes[1].VolumeShare)
+	}
+}
+
+func TestDexes_MarketShare(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/networks/ethereum/pools":
+			fmt.Fprintln(w, `{"pools": [
+				{"id": "0xa", "dex_id": "uniswap_v3", "dex_name": "Uniswap V3", "volume_usd": 750},
+				{"id": "0xb", "dex_id": "curve", "dex_name": "Curve", "volume_usd": 250}
+			], "page_info": {"limit": 100, "page": 0, "total_items": 2, "total_pages": 1}}`)
+		case "/networks/ethereum/dexes":
+			fmt.Fprintln(w, `{"dexes": [{"dex_id": "uniswap_v3", "dex_name": "Uniswap V3"}, {"dex_id": "balancer", "dex_name": "Balancer"}], "page_info": {"limit": 100, "page": 0, "total_items": 2, "total_pages": 1}}`)
+		default:
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+	cached := NewCachedClient(client, nil, time.Minute)
+	ctx := context.Background()
+
+	report, err := cached.GetDexMarketShare(ctx, "ethereum")
+	if err != nil {
+		t.Fatalf("GetDexMarketShare returned error: %v", err)
+	}
+	if report.VolumeUSD24h != 1000 || report.GeneratedAt.IsZero() {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	var shares []string
+	for _, d := range report.Dexes {
+		shares = append(shares, fmt.Sprintf("%s=%.2f", d.DexID, d.VolumeShare))
+	}
+	if got := strings.Join(shares, " "); got != "uniswap_v3=0.75 curve=0.25 balancer=0.00" {
+		t.Errorf("Unexpected shares %s", got)
+	}
+
+	// The second report is served from the cache
+	before := requests
+	if _, err := cached.GetDexMarketShare(ctx, "ethereum"); err != nil {
+		t.Fatalf("GetDexMarketShare returned error: %v", err)
+	}
+	if requests != before {
+		t.Errorf("Expected cached report, got %d more requests", requests-before)
+	}
+}
@@ -0,0 +1,104 @@
+package dexpaprika
+
+import (
+	"context"
+	"sync"
+)
+
+// TokenMetadata is a token's descriptive data as read from another source,
+// typically the token contract itself.
+type TokenMetadata struct {
+	Name     string
+	Symbol   string
+	Decimals int
+}
+
+// TokenMetadataResolver supplies token metadata the API lacks, such as the
+// symbol and decimals of a token indexed moments ago. Implementations
+// typically call the token contract's name, symbol and decimals methods with
+// an RPC client such as go-ethereum's.
+type TokenMetadataResolver interface {
+	ResolveTokenMetadata(ctx context.Context, networkID, address string) (*TokenMetadata, error)
+}
+
+// TokenMetadataResolverFunc adapts a function to a TokenMetadataResolver.
+type TokenMetadataResolverFunc func(ctx context.Context, networkID, address string) (*TokenMetadata, error)
+
+// ResolveTokenMetadata calls f.
+func (f TokenMetadataResolverFunc) ResolveTokenMetadata(ctx context.Context, networkID, address string) (*TokenMetadata, error) {
+	return f(ctx, networkID, address)
+}
+
+// WithTokenMetadataResolver fills in the symbol, name and decimals of tokens
+// the API returns without them, in Tokens.GetDetails and the tokens of
+// Pools.GetDetails. Only missing fields are filled; a decimals value of zero
+// counts as missing. Resolved metadata is remembered for the life of the
+// client, and resolver errors leave the API's data unchanged.
+func WithTokenMetadataResolver(r TokenMetadataResolver) ClientOption {
+	return func(c *Client) {
+		c.metadata = &metadataResolver{resolver: r}
+	}
+}
+
+// metadataResolver remembers the results of a TokenMetadataResolver.
+type metadataResolver struct {
+	resolver TokenMetadataResolver
+	resolved sync.Map // EntityKey -> *TokenMetadata
+}
+
+// resolve returns the metadata of a token, or nil if it cannot be resolved.
+func (m *metadataResolver) resolve(ctx context.Context, networkID, address string) *TokenMetadata {
+	key := NewEntityKey(networkID, address)
+	if md, ok := m.resolved.Load(key); ok {
+		return md.(*TokenMetadata)
+	}
+	md, err := m.resolver.ResolveTokenMetadata(ctx, networkID, address)
+	if err != nil || md == nil {
+		return nil
+	}
+	m.resolved.Store(key, md)
+	return md
+}
+
+// needsMetadata reports whether a token lacks metadata a resolver can fill.
+func needsMetadata(symbol string, decimals int) bool {
+	return symbol == "" || decimals == 0
+}
+
+// fillTokenDetails fills missing metadata of t.
+func (c *Client) fillTokenDetails(ctx context.Context, networkID string, t *TokenDetails) {
+	if c.metadata == nil || !needsMetadata(t.Symbol, t.Decimals) {
+		return
+	}
+	if md := c.metadata.resolve(ctx, networkID, t.ID); md != nil {
+		mergeMetadata(md, &t.Name, &t.Symbol, &t.Decimals)
+	}
+}
+
+// fillTokens fills missing metadata of tokens.
+func (c *Client) fillTokens(ctx context.Context, networkID string, tokens []Token) {
+	if c.metadata == nil {
+		return
+	}
+	for i := range tokens {
+		t := &tokens[i]
+		if !needsMetadata(t.Symbol, t.Decimals) {
+			continue
+		}
+		if md := c.metadata.resolve(ctx, networkID, t.ID); md != nil {
+			mergeMetadata(md, &t.Name, &t.Symbol, &t.Decimals)
+		}
+	}
+}
+
+func mergeMetadata(md *TokenMetadata, name, symbol *string, decimals *int) {
+	if *name == "" {
+		*name = md.Name
+	}
+	if *symbol == "" {
+		*symbol = md.Symbol
+	}
+	if *decimals == 0 {
+		*decimals = md.Decimals
+	}
+}
@@ -0,0 +1,70 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithTokenMetadataResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/networks/ethereum/tokens/0xnew":
+			fmt.Fprintln(w, `{"id": "0xnew", "name": "", "symbol": "", "decimals": 0, "chain": "ethereum"}`)
+		case "/networks/ethereum/pools/0xpool":
+			fmt.Fprintln(w, `{"id": "0xpool", "tokens": [
+				{"id": "0xnew", "symbol": "", "decimals": 0},
+				{"id": "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", "name": "Wrapped Ether", "symbol": "WETH", "decimals": 18},
+				{"id": "0xbroken", "symbol": "", "decimals": 0}
+			]}`)
+		default:
+			t.Errorf("Unexpected path %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	calls := make(map[string]int)
+	resolver := TokenMetadataResolverFunc(func(ctx context.Context, networkID, address string) (*TokenMetadata, error) {
+		calls[address]++
+		if address == "0xbroken" {
+			return nil, errors.New("rpc unavailable")
+		}
+		return &TokenMetadata{Name: "New Token", Symbol: "NEW", Decimals: 9}, nil
+	})
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0), WithTokenMetadataResolver(resolver))
+	ctx := context.Background()
+
+	token, err := client.Tokens.GetDetails(ctx, "ethereum", "0xnew")
+	if err != nil {
+		t.Fatalf("GetDetails returned error: %v", err)
+	}
+	if token.Name != "New Token" || token.Symbol != "NEW" || token.Decimals != 9 {
+		t.Errorf("Expected resolved metadata, got %+v", token)
+	}
+
+	pool, err := client.Pools.GetDetails(ctx, "ethereum", "0xpool", nil)
+	if err != nil {
+		t.Fatalf("Pools.GetDetails returned error: %v", err)
+	}
+	if pool.Tokens[0].Symbol != "NEW" || pool.Tokens[0].Decimals != 9 {
+		t.Errorf("Expected the pool's new token to be filled, got %+v", pool.Tokens[0])
+	}
+	if pool.Tokens[1].Symbol != "WETH" || pool.Tokens[1].Decimals != 18 {
+		t.Errorf("Complete token changed: %+v", pool.Tokens[1])
+	}
+	if pool.Tokens[2].Symbol != "" {
+		t.Errorf("Expected the failed token unchanged, got %+v", pool.Tokens[2])
+	}
+
+	if calls["0xnew"] != 1 {
+		t.Errorf("Expected the resolved token to be remembered, resolved %d times", calls["0xnew"])
+	}
+	if _, ok := calls["0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"]; ok {
+		t.Error("Resolver called for a token with complete metadata")
+	}
+}
@@ -0,0 +1,116 @@
+package dexpaprika
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Metric names recorded by the client.
+const (
+	MetricRequests        = "dexpaprika_requests_total"           // Counter of HTTP attempts, labelled with their status
+	MetricRequestDuration = "dexpaprika_request_duration_seconds" // Histogram of HTTP attempt durations
+	MetricRetries         = "dexpaprika_retries_total"            // Counter of retried attempts
+)
+
+// Metrics receives measurements from the client and the SDK's background
+// components, such as watchers and schedulers, for export to a monitoring
+// system like Prometheus or StatsD. Implementations must be safe for
+// concurrent use.
+type Metrics interface {
+	// Count adds delta to a counter.
+	Count(name string, delta float64, labels map[string]string)
+
+	// Gauge sets a gauge to value.
+	Gauge(name string, value float64, labels map[string]string)
+
+	// Observe records a sample, such as a duration in seconds.
+	Observe(name string, value float64, labels map[string]string)
+}
+
+// WithMetrics makes the client record every HTTP attempt in m.
+func WithMetrics(m Metrics) ClientOption {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
+// InMemoryMetrics is a Metrics implementation that keeps the latest values
+// in memory, for inspection and tests.
+type InMemoryMetrics struct {
+	mu      sync.Mutex
+	values  map[string]float64
+	samples map[string][]float64
+}
+
+// NewInMemoryMetrics creates an empty InMemoryMetrics.
+func NewInMemoryMetrics() *InMemoryMetrics {
+	return &InMemoryMetrics{
+		values:  make(map[string]float64),
+		samples: make(map[string][]float64),
+	}
+}
+
+// Count implements Metrics.
+func (m *InMemoryMetrics) Count(name string, delta float64, labels map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[metricKey(name, labels)] += delta
+}
+
+// Gauge implements Metrics.
+func (m *InMemoryMetrics) Gauge(name string, value float64, labels map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[metricKey(name, labels)] = value
+}
+
+// Observe implements Metrics.
+func (m *InMemoryMetrics) Observe(name string, value float64, labels map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := metricKey(name, labels)
+	m.samples[key] = append(m.samples[key], value)
+}
+
+// Value returns the value of the counter or gauge with the given name and
+// labels, or zero if it was never recorded.
+func (m *InMemoryMetrics) Value(name string, labels map[string]string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[metricKey(name, labels)]
+}
+
+// Samples returns the samples observed with the given name and labels.
+func (m *InMemoryMetrics) Samples(name string, labels map[string]string) []float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]float64(nil), m.samples[metricKey(name, labels)]...)
+}
+
+// metricKey identifies a series, e.g. `requests{status="200"}`.
+func metricKey(name string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return name
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k)
+		b.WriteString(`="`)
+		b.WriteString(labels[k])
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
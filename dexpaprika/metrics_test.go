@@ -0,0 +1,52 @@
+package dexpaprika
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithMetrics(t *testing.T) {
+	var n atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	m := NewInMemoryMetrics()
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(1, time.Millisecond, time.Millisecond), WithMetrics(m))
+	if _, err := client.Networks.List(context.Background()); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+
+	if v := m.Value(MetricRequests, map[string]string{"status": "500"}); v != 1 {
+		t.Errorf("Expected one 500 attempt, got %v", v)
+	}
+	if v := m.Value(MetricRequests, map[string]string{"status": "200"}); v != 1 {
+		t.Errorf("Expected one 200 attempt, got %v", v)
+	}
+	if v := m.Value(MetricRetries, nil); v != 1 {
+		t.Errorf("Expected one retry, got %v", v)
+	}
+	if samples := m.Samples(MetricRequestDuration, nil); len(samples) != 2 {
+		t.Errorf("Expected two duration samples, got %v", samples)
+	}
+}
+
+func TestMetricKey(t *testing.T) {
+	got := metricKey("polls", map[string]string{"watcher": "pool", "result": "ok"})
+	if want := `polls{result="ok",watcher="pool"}`; got != want {
+		t.Errorf("metricKey = %s, want %s", got, want)
+	}
+	if got := metricKey("polls", nil); got != "polls" {
+		t.Errorf("metricKey without labels = %s", got)
+	}
+}
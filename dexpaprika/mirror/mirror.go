@@ -0,0 +1,295 @@
+// Package mirror keeps a local SQL copy of selected DexPaprika data, such as
+// networks, dexes, top pools and OHLCV history, for offline analysis and
+// backtesting with SQL instead of repeated API calls.
+//
+// The schema targets SQLite. Open the database with any SQLite driver, such
+// as modernc.org/sqlite or github.com/mattn/go-sqlite3, and pass it to New:
+//
+//	db, err := sql.Open("sqlite", "dexpaprika.db")
+//	m := mirror.New(db, client)
+//	err = m.Migrate(ctx)
+//
+// Syncs are incremental: rows are upserted, and OHLCV syncs continue from
+// the latest stored candle.
+package mirror
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// Schema is the SQL executed by Migrate.
+var Schema = []string{
+	`CREATE TABLE IF NOT EXISTS networks (
+		id TEXT PRIMARY KEY,
+		display_name TEXT NOT NULL,
+		synced_at TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS dexes (
+		network_id TEXT NOT NULL,
+		id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		protocol TEXT NOT NULL,
+		synced_at TEXT NOT NULL,
+		PRIMARY KEY (network_id, id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS pools (
+		network_id TEXT NOT NULL,
+		id TEXT NOT NULL,
+		dex_id TEXT NOT NULL,
+		dex_name TEXT NOT NULL,
+		volume_usd REAL NOT NULL,
+		price_usd REAL NOT NULL,
+		transactions INTEGER NOT NULL,
+		fee REAL NOT NULL,
+		created_at TEXT NOT NULL,
+		synced_at TEXT NOT NULL,
+		PRIMARY KEY (network_id, id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS pool_tokens (
+		network_id TEXT NOT NULL,
+		pool_id TEXT NOT NULL,
+		position INTEGER NOT NULL,
+		token_id TEXT NOT NULL,
+		symbol TEXT NOT NULL,
+		name TEXT NOT NULL,
+		decimals INTEGER NOT NULL,
+		PRIMARY KEY (network_id, pool_id, position)
+	)`,
+	`CREATE TABLE IF NOT EXISTS ohlcv (
+		network_id TEXT NOT NULL,
+		pool_id TEXT NOT NULL,
+		interval TEXT NOT NULL,
+		time_open TEXT NOT NULL,
+		time_close TEXT NOT NULL,
+		open REAL NOT NULL,
+		high REAL NOT NULL,
+		low REAL NOT NULL,
+		close REAL NOT NULL,
+		volume INTEGER NOT NULL,
+		PRIMARY KEY (network_id, pool_id, interval, time_open)
+	)`,
+}
+
+// Mirror syncs API data into a database.
+type Mirror struct {
+	db     *sql.DB
+	client *dexpaprika.Client
+}
+
+// New creates a mirror writing to db with data fetched by client.
+func New(db *sql.DB, client *dexpaprika.Client) *Mirror {
+	return &Mirror{db: db, client: client}
+}
+
+// Migrate creates the mirror's tables if they do not exist.
+func (m *Mirror) Migrate(ctx context.Context) error {
+	for _, stmt := range Schema {
+		if _, err := m.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("mirror: migrate: %w", err)
+		}
+	}
+	return nil
+}
+
+// SyncNetworks stores all networks and returns how many were synced.
+func (m *Mirror) SyncNetworks(ctx context.Context) (int, error) {
+	networks, err := m.client.Networks.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	now := timestamp(time.Now())
+	err = m.inTx(ctx, func(tx *sql.Tx) error {
+		for _, n := range networks {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO networks (id, display_name, synced_at) VALUES (?, ?, ?)
+				ON CONFLICT (id) DO UPDATE SET display_name = excluded.display_name, synced_at = excluded.synced_at`,
+				n.ID, n.DisplayName, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(networks), nil
+}
+
+// SyncDexes stores the dexes of a network and returns how many were synced.
+func (m *Mirror) SyncDexes(ctx context.Context, networkID string) (int, error) {
+	var dexes []dexpaprika.Dex
+	paginator := dexpaprika.NewDexesPaginator(m.client, networkID, 100)
+	for paginator.HasNextPage() {
+		if err := paginator.GetNextPage(ctx); err != nil {
+			return 0, err
+		}
+		dexes = append(dexes, paginator.GetCurrentPage()...)
+	}
+
+	now := timestamp(time.Now())
+	err := m.inTx(ctx, func(tx *sql.Tx) error {
+		for _, d := range dexes {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO dexes (network_id, id, name, protocol, synced_at) VALUES (?, ?, ?, ?, ?)
+				ON CONFLICT (network_id, id) DO UPDATE SET name = excluded.name, protocol = excluded.protocol, synced_at = excluded.synced_at`,
+				networkID, d.ID, d.Name, d.Protocol, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(dexes), nil
+}
+
+// PoolsOptions selects the pools synced by SyncPools.
+type PoolsOptions struct {
+	DexID   string // Only pools of this dex
+	Limit   int    // Number of pools, by default 100
+	OrderBy string // Ranking of the pools kept, by default "volume_usd"
+}
+
+// SyncPools stores the top pools of a network and their tokens, replacing
+// the tokens stored for a pool before, and returns how many pools were
+// synced.
+func (m *Mirror) SyncPools(ctx context.Context, networkID string, opts *PoolsOptions) (int, error) {
+	if opts == nil {
+		opts = &PoolsOptions{}
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	orderBy := opts.OrderBy
+	if orderBy == "" {
+		orderBy = "volume_usd"
+	}
+
+	paginator := dexpaprika.NewPoolsPaginator(m.client, &dexpaprika.ListOptions{Limit: min(limit, 100), OrderBy: orderBy, Sort: "desc"})
+	if opts.DexID != "" {
+		paginator.ForDex(networkID, opts.DexID)
+	} else {
+		paginator.ForNetwork(networkID)
+	}
+	var pools []dexpaprika.Pool
+	for len(pools) < limit && paginator.HasNextPage() {
+		if err := paginator.GetNextPage(ctx); err != nil {
+			return 0, err
+		}
+		pools = append(pools, paginator.GetCurrentPage()...)
+	}
+	if len(pools) > limit {
+		pools = pools[:limit]
+	}
+
+	now := timestamp(time.Now())
+	err := m.inTx(ctx, func(tx *sql.Tx) error {
+		for _, p := range pools {
+			// Stored as SyncOHLCV stores it, so the tables join on pool_id
+			poolID := dexpaprika.NormalizeAddress(networkID, p.ID)
+			if _, err := tx.ExecContext(ctx, `INSERT INTO pools (network_id, id, dex_id, dex_name, volume_usd, price_usd, transactions, fee, created_at, synced_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT (network_id, id) DO UPDATE SET dex_id = excluded.dex_id, dex_name = excluded.dex_name,
+					volume_usd = excluded.volume_usd, price_usd = excluded.price_usd, transactions = excluded.transactions,
+					fee = excluded.fee, created_at = excluded.created_at, synced_at = excluded.synced_at`,
+				networkID, poolID, p.DexID, p.DexName, p.VolumeUSD, p.PriceUSD, p.Transactions, p.Fee, p.CreatedAt, now); err != nil {
+				return err
+			}
+			for i, t := range p.Tokens {
+				if _, err := tx.ExecContext(ctx, `INSERT INTO pool_tokens (network_id, pool_id, position, token_id, symbol, name, decimals)
+					VALUES (?, ?, ?, ?, ?, ?, ?)
+					ON CONFLICT (network_id, pool_id, position) DO UPDATE SET token_id = excluded.token_id,
+						symbol = excluded.symbol, name = excluded.name, decimals = excluded.decimals`,
+					networkID, poolID, i, t.ID, t.Symbol, t.Name, t.Decimals); err != nil {
+					return err
+				}
+			}
+			if _, err := tx.ExecContext(ctx, `DELETE FROM pool_tokens WHERE network_id = ? AND pool_id = ? AND position >= ?`,
+				networkID, poolID, len(p.Tokens)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(pools), nil
+}
+
+// SyncOHLCV stores a pool's candles of the given interval up to now and
+// returns how many were synced. It continues from the latest stored candle,
+// which is fetched again in case it was still open; without stored candles
+// it starts at since.
+func (m *Mirror) SyncOHLCV(ctx context.Context, networkID, poolAddress, interval string, since time.Time) (int, error) {
+	poolID := dexpaprika.NormalizeAddress(networkID, poolAddress)
+
+	var last sql.NullString
+	err := m.db.QueryRowContext(ctx, `SELECT MAX(time_open) FROM ohlcv WHERE network_id = ? AND pool_id = ? AND interval = ?`,
+		networkID, poolID, interval).Scan(&last)
+	if err != nil {
+		return 0, fmt.Errorf("mirror: latest candle: %w", err)
+	}
+	start := since
+	if last.Valid {
+		if t, err := time.Parse(time.RFC3339, last.String); err == nil {
+			start = t
+		}
+	}
+
+	candles, err := m.client.Pools.GetOHLCVRange(ctx, networkID, poolAddress, start, time.Now(), &dexpaprika.OHLCVOptions{Interval: interval})
+	if err != nil {
+		return 0, err
+	}
+
+	err = m.inTx(ctx, func(tx *sql.Tx) error {
+		for _, c := range candles {
+			open, err := c.OpenTime()
+			if err != nil {
+				return fmt.Errorf("mirror: candle time %q: %w", c.TimeOpen, err)
+			}
+			closeTime, err := c.CloseTime()
+			if err != nil {
+				return fmt.Errorf("mirror: candle time %q: %w", c.TimeClose, err)
+			}
+			if _, err := tx.ExecContext(ctx, `INSERT INTO ohlcv (network_id, pool_id, interval, time_open, time_close, open, high, low, close, volume)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT (network_id, pool_id, interval, time_open) DO UPDATE SET time_close = excluded.time_close,
+					open = excluded.open, high = excluded.high, low = excluded.low, close = excluded.close, volume = excluded.volume`,
+				networkID, poolID, interval, timestamp(open), timestamp(closeTime), c.Open, c.High, c.Low, c.Close, c.Volume); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(candles), nil
+}
+
+// inTx runs fn in a transaction, committing if it succeeds.
+func (m *Mirror) inTx(ctx context.Context, fn func(*sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("mirror: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("mirror: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("mirror: %w", err)
+	}
+	return nil
+}
+
+// timestamp formats t as stored, in UTC so stored times sort as text.
+func timestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,278 @@
+package mirror
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// fakeDB is a database/sql driver recording executed statements. Queries
+// return latest as their single value; statements fail with execErr if set.
+type fakeDB struct {
+	mu        sync.Mutex
+	execs     []fakeExec
+	commits   int
+	rollbacks int
+	latest    driver.Value
+	execErr   error
+}
+
+type fakeExec struct {
+	query string
+	args  []driver.Value
+}
+
+func (d *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{d}, nil }
+func (d *fakeDB) Driver() driver.Driver                        { return nil }
+
+// inserts returns the arguments of the inserts into table.
+func (d *fakeDB) inserts(table string) [][]driver.Value {
+	return d.statements("INSERT INTO " + table + " ")
+}
+
+// statements returns the arguments of the statements starting with prefix.
+func (d *fakeDB) statements(prefix string) [][]driver.Value {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var rows [][]driver.Value
+	for _, e := range d.execs {
+		if strings.HasPrefix(e.query, prefix) {
+			rows = append(rows, e.args)
+		}
+	}
+	return rows
+}
+
+type fakeConn struct{ db *fakeDB }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{c.db, query}, nil }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{c.db}, nil }
+
+type fakeTx struct{ db *fakeDB }
+
+func (t fakeTx) Commit() error {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+	t.db.commits++
+	return nil
+}
+func (t fakeTx) Rollback() error {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+	t.db.rollbacks++
+	return nil
+}
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if s.db.execErr != nil {
+		return nil, s.db.execErr
+	}
+	s.db.execs = append(s.db.execs, fakeExec{s.query, args})
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{value: s.db.latest}, nil
+}
+
+type fakeRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"value"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+func newTestMirror(t *testing.T, handler http.HandlerFunc) (*Mirror, *fakeDB) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	fake := &fakeDB{}
+	db := sql.OpenDB(fake)
+	t.Cleanup(func() { db.Close() })
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+	return New(db, client), fake
+}
+
+func TestMirror_Migrate(t *testing.T) {
+	m, fake := newTestMirror(t, nil)
+	if err := m.Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate returned error: %v", err)
+	}
+	if len(fake.execs) != len(Schema) {
+		t.Fatalf("Migrate executed %d statements, want %d", len(fake.execs), len(Schema))
+	}
+	for _, e := range fake.execs {
+		if !strings.HasPrefix(e.query, "CREATE TABLE IF NOT EXISTS") {
+			t.Errorf("Unexpected statement %q", e.query)
+		}
+	}
+}
+
+func TestMirror_SyncDexes(t *testing.T) {
+	m, fake := newTestMirror(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/networks/ethereum/dexes" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"dexes":[{"dex_id":"uniswap_v3","dex_name":"Uniswap V3","protocol":"uniswap_v3"}],"page_info":{"page":0,"total_pages":1}}`)
+	})
+
+	n, err := m.SyncDexes(context.Background(), "ethereum")
+	if err != nil {
+		t.Fatalf("SyncDexes returned error: %v", err)
+	}
+	rows := fake.inserts("dexes")
+	if n != 1 || len(rows) != 1 {
+		t.Fatalf("SyncDexes synced %d dexes with %d inserts, want 1", n, len(rows))
+	}
+	if rows[0][0] != "ethereum" || rows[0][1] != "uniswap_v3" || rows[0][2] != "Uniswap V3" {
+		t.Errorf("Unexpected dex row %v", rows[0])
+	}
+	if fake.commits != 1 {
+		t.Errorf("Got %d commits, want 1", fake.commits)
+	}
+}
+
+func TestMirror_SyncPools(t *testing.T) {
+	m, fake := newTestMirror(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/networks/ethereum/dexes/uniswap_v3/pools" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("order_by"); got != "volume_usd" {
+			t.Errorf("order_by = %q, want volume_usd", got)
+		}
+		fmt.Fprint(w, `{"pools":[
+			{"id":"0xa","dex_id":"uniswap_v3","volume_usd":10,"tokens":[{"id":"0x1","symbol":"WETH"},{"id":"0x2","symbol":"USDC"}]},
+			{"id":"0xb","dex_id":"uniswap_v3","volume_usd":5,"tokens":[{"id":"0x3","symbol":"DAI"},{"id":"0x2","symbol":"USDC"}]}
+		],"page_info":{"page":0,"total_pages":3}}`)
+	})
+
+	n, err := m.SyncPools(context.Background(), "ethereum", &PoolsOptions{DexID: "uniswap_v3", Limit: 1})
+	if err != nil {
+		t.Fatalf("SyncPools returned error: %v", err)
+	}
+	pools, tokens := fake.inserts("pools"), fake.inserts("pool_tokens")
+	if n != 1 || len(pools) != 1 || len(tokens) != 2 {
+		t.Fatalf("SyncPools synced %d pools with %d pool and %d token inserts, want 1, 1 and 2", n, len(pools), len(tokens))
+	}
+	if pools[0][1] != "0xa" || pools[0][4] != 10.0 {
+		t.Errorf("Unexpected pool row %v", pools[0])
+	}
+	if tokens[1][2] != int64(1) || tokens[1][4] != "USDC" {
+		t.Errorf("Unexpected token row %v", tokens[1])
+	}
+	// Tokens stored beyond the pool's current ones are removed
+	deletes := fake.statements("DELETE FROM pool_tokens ")
+	if len(deletes) != 1 || deletes[0][1] != "0xa" || deletes[0][2] != int64(2) {
+		t.Errorf("Unexpected pool_tokens deletes %v", deletes)
+	}
+
+	fake.execErr = errors.New("disk full")
+	n, err = m.SyncPools(context.Background(), "ethereum", &PoolsOptions{DexID: "uniswap_v3", Limit: 1})
+	if n != 0 || !errors.Is(err, fake.execErr) || fake.rollbacks != 1 {
+		t.Errorf("Failed SyncPools returned %d, %v after %d rollbacks, want 0, the error and 1", n, err, fake.rollbacks)
+	}
+}
+
+func TestMirror_SyncOHLCV(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Hour)
+	var starts []string
+	var mu sync.Mutex
+	m, fake := newTestMirror(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		starts = append(starts, r.URL.Query().Get("start"))
+		mu.Unlock()
+		start, _ := time.Parse(time.RFC3339, r.URL.Query().Get("start"))
+		fmt.Fprintf(w, `[{"time_open":%q,"time_close":%q,"open":1,"high":2,"low":0.5,"close":1.5,"volume":10}]`,
+			start.Format(time.RFC3339), start.Add(time.Hour).Format(time.RFC3339))
+	})
+	ctx := context.Background()
+
+	// Without stored candles the sync starts at since
+	since := now.Add(-2 * time.Hour)
+	n, err := m.SyncOHLCV(ctx, "ethereum", "0xA", "1h", since)
+	if err != nil {
+		t.Fatalf("SyncOHLCV returned error: %v", err)
+	}
+	rows := fake.inserts("ohlcv")
+	if n != 1 || len(rows) != 1 {
+		t.Fatalf("SyncOHLCV synced %d candles with %d inserts, want 1", n, len(rows))
+	}
+	if rows[0][1] != "0xa" || rows[0][3] != since.Format(time.RFC3339) {
+		t.Errorf("Unexpected candle row %v", rows[0])
+	}
+
+	// With stored candles it continues from the latest
+	latest := now.Add(-time.Hour).Format(time.RFC3339)
+	fake.latest = latest
+	if _, err := m.SyncOHLCV(ctx, "ethereum", "0xA", "1h", since); err != nil {
+		t.Fatalf("SyncOHLCV returned error: %v", err)
+	}
+	if len(starts) != 2 || starts[0] != since.Format(time.RFC3339) || starts[1] != latest {
+		t.Errorf("Requested starts %v, want [%s %s]", starts, since.Format(time.RFC3339), latest)
+	}
+}
+
+func TestMirror_PoolsJoinOHLCV(t *testing.T) {
+	// The API returns the pool's checksummed address and candle times in
+	// any offset; both tables store the normalized forms
+	const address = "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"
+	m, fake := newTestMirror(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/ohlcv") {
+			fmt.Fprint(w, `[{"time_open":"2024-06-01T14:00:00+02:00","time_close":"2024-06-01T15:00:00+02:00","open":1,"high":2,"low":0.5,"close":1.5,"volume":10}]`)
+			return
+		}
+		fmt.Fprintf(w, `{"pools":[{"id":%q,"dex_id":"uniswap_v3","tokens":[{"id":"0x1","symbol":"WETH"}]}],"page_info":{"page":0,"total_pages":1}}`, address)
+	})
+	ctx := context.Background()
+
+	if _, err := m.SyncPools(ctx, "ethereum", &PoolsOptions{Limit: 1}); err != nil {
+		t.Fatalf("SyncPools returned error: %v", err)
+	}
+	if _, err := m.SyncOHLCV(ctx, "ethereum", address, "1h", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("SyncOHLCV returned error: %v", err)
+	}
+
+	pools, tokens, candles := fake.inserts("pools"), fake.inserts("pool_tokens"), fake.inserts("ohlcv")
+	if len(pools) != 1 || len(tokens) != 1 || len(candles) != 1 {
+		t.Fatalf("Got %d pool, %d token and %d candle inserts, want 1 each", len(pools), len(tokens), len(candles))
+	}
+	// ohlcv JOIN pools ON (network_id, pool_id) = (network_id, id)
+	if candles[0][0] != pools[0][0] || candles[0][1] != pools[0][1] || tokens[0][1] != pools[0][1] {
+		t.Errorf("Candle %v and token %v do not join pool %v", candles[0][:2], tokens[0][:2], pools[0][:2])
+	}
+	if candles[0][3] != "2024-06-01T12:00:00Z" || candles[0][4] != "2024-06-01T13:00:00Z" {
+		t.Errorf("Stored candle times %v and %v, want both in UTC", candles[0][3], candles[0][4])
+	}
+}
@@ -0,0 +1,162 @@
+package dexpaprika
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Movers holds the pools with the largest price changes over a window.
+type Movers struct {
+	Window  string `json:"window"`
+	Gainers []Pool `json:"gainers"`
+	Losers  []Pool `json:"losers"`
+}
+
+const (
+	// topMoversPages is the number of pool pages scanned for movers.
+	topMoversPages = 5
+
+	// defaultTopMoversMinVolumeUSD is the default of
+	// TopMoversOptions.MinVolumeUSD.
+	defaultTopMoversMinVolumeUSD = 10000
+)
+
+// TopMoversOptions configures Pools.TopMoversWithOptions.
+type TopMoversOptions struct {
+	// Limit caps the number of gainers and of losers, 10 if zero.
+	Limit int
+
+	// MinVolumeUSD drops thinly traded pools, whose prices swing wildly on
+	// tiny trades. Pool listings carry no liquidity figure, so 24h volume
+	// stands in for it. It defaults to 10,000 USD if zero; a negative value
+	// keeps every pool.
+	MinVolumeUSD float64
+}
+
+// TopMovers returns up to limit gainers and losers on a network over window,
+// which must be Interval5m, Interval1h or Interval24h, skipping pools with
+// less than 10,000 USD of 24h volume. See TopMoversWithOptions.
+func (s *PoolsService) TopMovers(ctx context.Context, networkID, window string, limit int) (*Movers, error) {
+	return s.TopMoversWithOptions(ctx, networkID, window, &TopMoversOptions{Limit: limit})
+}
+
+// TopMoversWithOptions returns the gainers and losers on a network over
+// window, which must be Interval5m, Interval1h or Interval24h, across
+// several pages of pools.
+//
+// Over 24h the pools are requested ordered by their price change, highest
+// first for gainers and lowest first for losers. The API cannot order by
+// the shorter windows' changes, so for those the network's most traded
+// pools are scanned instead, and movers among less traded pools are missed.
+func (s *PoolsService) TopMoversWithOptions(ctx context.Context, networkID, window string, opts *TopMoversOptions) (*Movers, error) {
+	if _, ok := (&Pool{}).PriceChangePercent(window); !ok {
+		return nil, fmt.Errorf("%w: %q (top movers support %s, %s and %s)", ErrInvalidInterval, window, Interval5m, Interval1h, Interval24h)
+	}
+	limit, minVolume := 10, float64(defaultTopMoversMinVolumeUSD)
+	if opts != nil {
+		if opts.Limit > 0 {
+			limit = opts.Limit
+		}
+		if opts.MinVolumeUSD != 0 {
+			minVolume = opts.MinVolumeUSD
+		}
+	}
+
+	movers := &Movers{Window: window}
+	if window == Interval24h {
+		var err error
+		movers.Gainers, err = s.scanMovers(ctx, networkID, "desc", limit, minVolume)
+		if err != nil {
+			return nil, err
+		}
+		movers.Losers, err = s.scanMovers(ctx, networkID, "asc", limit, minVolume)
+		if err != nil {
+			return nil, err
+		}
+		return movers, nil
+	}
+
+	var candidates []Pool
+	listOpts := &ListOptions{Limit: 100, OrderBy: "volume_usd", Sort: "desc"}
+	for page := 0; page < topMoversPages; page++ {
+		listOpts.Page = page
+		resp, err := s.ListByNetwork(ctx, networkID, listOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		done := len(resp.Pools) < listOpts.Limit || page+1 >= resp.PageInfo.TotalPages
+		for _, p := range resp.Pools {
+			if p.VolumeUSD < minVolume {
+				// Pools are ordered by volume, so the rest are thinner still
+				done = true
+				break
+			}
+			candidates = append(candidates, p)
+		}
+		if done {
+			break
+		}
+	}
+
+	change := func(p Pool) float64 {
+		c, _ := p.PriceChangePercent(window)
+		return c
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return change(candidates[i]) > change(candidates[j])
+	})
+
+	for _, p := range candidates {
+		if len(movers.Gainers) == limit || change(p) <= 0 {
+			break
+		}
+		movers.Gainers = append(movers.Gainers, p)
+	}
+	for i := len(candidates) - 1; i >= 0; i-- {
+		p := candidates[i]
+		if len(movers.Losers) == limit || change(p) >= 0 {
+			break
+		}
+		movers.Losers = append(movers.Losers, p)
+	}
+
+	return movers, nil
+}
+
+// scanMovers returns up to limit pools with at least minVolume of 24h
+// volume from the network's pools ordered by 24h price change in the given
+// direction: gainers for "desc", losers for "asc".
+func (s *PoolsService) scanMovers(ctx context.Context, networkID, direction string, limit int, minVolume float64) ([]Pool, error) {
+	var movers []Pool
+	opts := &ListOptions{Limit: 100, OrderBy: "last_price_change_usd_24h", Sort: direction}
+	for page := 0; page < topMoversPages; page++ {
+		opts.Page = page
+		resp, err := s.ListByNetwork(ctx, networkID, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range resp.Pools {
+			change := p.LastPriceChangeUSD24h
+			if direction == "asc" {
+				change = -change
+			}
+			if change <= 0 {
+				// Pools are ordered by change, so the rest moved the other way
+				return movers, nil
+			}
+			if p.VolumeUSD < minVolume {
+				continue
+			}
+			movers = append(movers, p)
+			if len(movers) == limit {
+				return movers, nil
+			}
+		}
+		if len(resp.Pools) < opts.Limit || page+1 >= resp.PageInfo.TotalPages {
+			break
+		}
+	}
+	return movers, nil
+}
@@ -0,0 +1,113 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/internal/httpassert"
+)
+
+func TestPools_TopMovers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpassert.Path(t, r, "/networks/ethereum/pools")
+		httpassert.Params(t, r, httpassert.Query{"order_by": "volume_usd"})
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"pools": [
+			{"id": "flat", "volume_usd": 900000, "last_price_change_usd_1h": 0},
+			{"id": "up5", "volume_usd": 800000, "last_price_change_usd_1h": 5},
+			{"id": "down3", "volume_usd": 700000, "last_price_change_usd_1h": -3},
+			{"id": "up20", "volume_usd": 600000, "last_price_change_usd_1h": 20},
+			{"id": "down40", "volume_usd": 500000, "last_price_change_usd_1h": -40},
+			{"id": "up1", "volume_usd": 400000, "last_price_change_usd_1h": 1},
+			{"id": "dust", "volume_usd": 50, "last_price_change_usd_1h": 900}
+		], "page_info": {"limit": 100, "page": 0, "total_items": 7, "total_pages": 1}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, 1*time.Millisecond, 1*time.Millisecond),
+	)
+	ctx := context.Background()
+
+	movers, err := client.Pools.TopMovers(ctx, "ethereum", Interval1h, 2)
+	if err != nil {
+		t.Fatalf("TopMovers returned error: %v", err)
+	}
+
+	ids := func(pools []Pool) string {
+		var s []string
+		for _, p := range pools {
+			s = append(s, p.ID)
+		}
+		return strings.Join(s, ",")
+	}
+	if got := ids(movers.Gainers); got != "up20,up5" {
+		t.Errorf("Unexpected gainers %s", got)
+	}
+	if got := ids(movers.Losers); got != "down40,down3" {
+		t.Errorf("Unexpected losers %s", got)
+	}
+
+	if _, err := client.Pools.TopMovers(ctx, "ethereum", Interval6h, 2); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("Expected ErrInvalidInterval for 6h, got %v", err)
+	}
+
+	// A negative minimum volume keeps thinly traded pools
+	movers, err = client.Pools.TopMoversWithOptions(ctx, "ethereum", Interval1h, &TopMoversOptions{Limit: 1, MinVolumeUSD: -1})
+	if err != nil {
+		t.Fatalf("TopMoversWithOptions returned error: %v", err)
+	}
+	if got := ids(movers.Gainers); got != "dust" {
+		t.Errorf("Unexpected gainers without a minimum volume %s", got)
+	}
+}
+
+func TestPools_TopMovers24h(t *testing.T) {
+	// Pools ordered by 24h price change, as the API returns them for each
+	// direction
+	byChange := map[string]string{
+		"desc": `{"id": "dust", "volume_usd": 50, "last_price_change_usd_24h": 900},
+			{"id": "up20", "volume_usd": 600000, "last_price_change_usd_24h": 20},
+			{"id": "up5", "volume_usd": 800000, "last_price_change_usd_24h": 5},
+			{"id": "flat", "volume_usd": 900000, "last_price_change_usd_24h": 0},
+			{"id": "down3", "volume_usd": 700000, "last_price_change_usd_24h": -3}`,
+		"asc": `{"id": "down40", "volume_usd": 500000, "last_price_change_usd_24h": -40},
+			{"id": "down3", "volume_usd": 700000, "last_price_change_usd_24h": -3},
+			{"id": "flat", "volume_usd": 900000, "last_price_change_usd_24h": 0},
+			{"id": "up5", "volume_usd": 800000, "last_price_change_usd_24h": 5}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpassert.Path(t, r, "/networks/ethereum/pools")
+		httpassert.Params(t, r, httpassert.Query{"order_by": "last_price_change_usd_24h"})
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"pools": [%s], "page_info": {"limit": 100, "page": 0, "total_items": 5, "total_pages": 1}}`, byChange[r.URL.Query().Get("sort")])
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+	movers, err := client.Pools.TopMoversWithOptions(context.Background(), "ethereum", Interval24h, &TopMoversOptions{Limit: 3, MinVolumeUSD: 1000})
+	if err != nil {
+		t.Fatalf("TopMoversWithOptions returned error: %v", err)
+	}
+
+	ids := func(pools []Pool) string {
+		var s []string
+		for _, p := range pools {
+			s = append(s, p.ID)
+		}
+		return strings.Join(s, ",")
+	}
+	if got := ids(movers.Gainers); got != "up20,up5" {
+		t.Errorf("Unexpected gainers %s", got)
+	}
+	if got := ids(movers.Losers); got != "down40,down3" {
+		t.Errorf("Unexpected losers %s", got)
+	}
+}
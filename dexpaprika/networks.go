@@ -4,86 +4,170 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 )
 
+//go:generate go run ./internal/gennetworks -out networks_gen.go
+
 // NetworksService handles communication with the networks related
 // methods of the DexPaprika API.
 type NetworksService struct {
 	client *Client
+
+	// Runtime registry of supported networks, populated by Refresh
+	mu       sync.RWMutex
+	registry map[string]Network
 }
 
 // Network represents a blockchain network.
 type Network struct {
-	ID          string `json:"id"`
-	DisplayName string `json:"display_name"`
+	ID          string `json:"id" csv:"id"`
+	DisplayName string `json:"display_name" csv:"display_name"`
+
+	// NativeToken and ExplorerURL are not returned by the /networks endpoint;
+	// Get fills them in from the SDK's built-in metadata.
+	NativeToken string `json:"native_token,omitempty" csv:"native_token"`
+	ExplorerURL string `json:"explorer_url,omitempty" csv:"explorer_url"`
+}
+
+// networkMetadata holds details about networks that the API does not expose.
+var networkMetadata = map[string]struct{ nativeToken, explorerURL string }{
+	NetworkAptos:     {"APT", "https://explorer.aptoslabs.com"},
+	NetworkArbitrum:  {"ETH", "https://arbiscan.io"},
+	NetworkAvalanche: {"AVAX", "https://snowtrace.io"},
+	NetworkBase:      {"ETH", "https://basescan.org"},
+	NetworkBerachain: {"BERA", "https://berascan.com"},
+	NetworkBlast:     {"ETH", "https://blastscan.io"},
+	NetworkBsc:       {"BNB", "https://bscscan.com"},
+	NetworkCelo:      {"CELO", "https://celoscan.io"},
+	NetworkCronos:    {"CRO", "https://cronoscan.com"},
+	NetworkEthereum:  {"ETH", "https://etherscan.io"},
+	NetworkFantom:    {"FTM", "https://ftmscan.com"},
+	NetworkLinea:     {"ETH", "https://lineascan.build"},
+	NetworkMantle:    {"MNT", "https://mantlescan.xyz"},
+	NetworkOptimism:  {"ETH", "https://optimistic.etherscan.io"},
+	NetworkPolygon:   {"POL", "https://polygonscan.com"},
+	NetworkScroll:    {"ETH", "https://scrollscan.com"},
+	NetworkSolana:    {"SOL", "https://solscan.io"},
+	NetworkSonic:     {"S", "https://sonicscan.org"},
+	NetworkSui:       {"SUI", "https://suiscan.xyz"},
+	NetworkTon:       {"TON", "https://tonviewer.com"},
+	NetworkTron:      {"TRX", "https://tronscan.org"},
+	NetworkUnichain:  {"ETH", "https://uniscan.xyz"},
+	NetworkZksync:    {"ETH", "https://explorer.zksync.io"},
+}
+
+// withMetadata returns n with empty metadata fields filled from networkMetadata.
+func (n Network) withMetadata() Network {
+	meta, ok := networkMetadata[n.ID]
+	if !ok {
+		return n
+	}
+	if n.NativeToken == "" {
+		n.NativeToken = meta.nativeToken
+	}
+	if n.ExplorerURL == "" {
+		n.ExplorerURL = meta.explorerURL
+	}
+	return n
 }
 
 // List returns a list of all supported blockchain networks.
 // Implements the getNetworks operation from the OpenAPI spec.
-func (s *NetworksService) List(ctx context.Context) ([]Network, error) {
+func (s *NetworksService) List(ctx context.Context, reqOpts ...RequestOption) ([]Network, error) {
 	req, err := s.client.NewRequest(http.MethodGet, "/networks", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var networks []Network
-	r, err := s.client.Do(ctx, req, &networks)
+	cfg := newRequestConfig(reqOpts)
+	r, err := s.client.Do(ctx, req, cfg.decodeInto(&networks))
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
+	if cfg.target != nil {
+		return nil, nil
+	}
+
 	return networks, nil
 }
 
-// Dex represents a decentralized exchange.
-type Dex struct {
-	ID       string `json:"dex_id"`
-	Name     string `json:"dex_name"`
-	Chain    string `json:"chain"`
-	Protocol string `json:"protocol"`
-}
+// Refresh fetches the supported networks from the API and replaces the
+// runtime registry used by Lookup and IsSupported.
+func (s *NetworksService) Refresh(ctx context.Context) ([]Network, error) {
+	networks, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-// DexesResponse represents the response for the dexes endpoint.
-type DexesResponse struct {
-	Dexes    []Dex    `json:"dexes"`
-	PageInfo PageInfo `json:"page_info"`
-}
+	registry := make(map[string]Network, len(networks))
+	for _, n := range networks {
+		registry[n.ID] = n
+	}
+
+	s.mu.Lock()
+	s.registry = registry
+	s.mu.Unlock()
 
-// PageInfo contains pagination information.
-type PageInfo struct {
-	Limit      int `json:"limit"`
-	Page       int `json:"page"`
-	TotalItems int `json:"total_items"`
-	TotalPages int `json:"total_pages"`
+	return networks, nil
 }
 
-// ListDexes returns a list of all available dexes on a specific network.
-// Implements the getNetworkDexes operation from the OpenAPI spec.
-func (s *NetworksService) ListDexes(ctx context.Context, networkID string, page, limit int) (*DexesResponse, error) {
-	path := "/networks/" + networkID + "/dexes"
+// Get returns a single network, including its native token and block
+// explorer where known. The API has no single-network endpoint, so the first
+// call loads the network list via Refresh and later calls are served from the
+// registry; call Refresh to pick up newly added networks. Unknown IDs return
+// an error matching ErrNetworkNotFound.
+func (s *NetworksService) Get(ctx context.Context, networkID string) (*Network, error) {
+	s.mu.RLock()
+	loaded := s.registry != nil
+	s.mu.RUnlock()
 
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
+	if !loaded {
+		if _, err := s.Refresh(ctx); err != nil {
+			return nil, err
+		}
 	}
 
-	// Add query parameters
-	q := req.URL.Query()
-	if page > 0 {
-		q.Add("page", fmt.Sprintf("%d", page))
+	n, ok := s.Lookup(networkID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNetworkNotFound, networkID)
 	}
-	if limit > 0 {
-		q.Add("limit", fmt.Sprintf("%d", limit))
+	n = n.withMetadata()
+	return &n, nil
+}
+
+// Lookup returns the network with the given ID. Until Refresh has been called
+// it consults the generated KnownNetworks list.
+func (s *NetworksService) Lookup(networkID string) (Network, bool) {
+	s.mu.RLock()
+	registry := s.registry
+	s.mu.RUnlock()
+
+	if registry != nil {
+		n, ok := registry[networkID]
+		return n, ok
 	}
-	req.URL.RawQuery = q.Encode()
 
-	var response DexesResponse
-	r, err := s.client.Do(ctx, req, &response)
-	if err != nil {
-		return nil, err
+	for _, n := range KnownNetworks {
+		if n.ID == networkID {
+			return n, true
+		}
 	}
-	defer r.Body.Close()
+	return Network{}, false
+}
+
+// IsSupported reports whether networkID is a known network.
+func (s *NetworksService) IsSupported(networkID string) bool {
+	_, ok := s.Lookup(networkID)
+	return ok
+}
 
-	return &response, nil
+// ListDexes returns a list of all available dexes on a specific network.
+//
+// Deprecated: use Dexes.List.
+func (s *NetworksService) ListDexes(ctx context.Context, networkID string, page, limit int, reqOpts ...RequestOption) (*DexesResponse, error) {
+	return s.client.Dexes.List(ctx, networkID, page, limit, reqOpts...)
 }
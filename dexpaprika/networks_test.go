@@ -2,6 +2,10 @@ package dexpaprika
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -128,3 +132,92 @@ func TestCachedClient_Networks(t *testing.T) {
 		t.Errorf("Cache inconsistency: got %d networks on second call, want %d", len(networksAgain), len(networks))
 	}
 }
+
+func TestNetworks_RefreshAndLookup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/networks" {
+			t.Errorf("Expected request to '/networks', got '%s'", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `[{"id": "ethereum", "display_name": "Ethereum"}, {"id": "newchain", "display_name": "New Chain"}]`)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, 1*time.Millisecond, 1*time.Millisecond),
+	)
+
+	// Before refreshing, lookups fall back to the generated list
+	if !client.Networks.IsSupported(NetworkSolana) {
+		t.Errorf("Expected %q to be supported by the generated list", NetworkSolana)
+	}
+	if client.Networks.IsSupported("etherum") {
+		t.Error("Expected mistyped network ID to be unsupported")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	networks, err := client.Networks.Refresh(ctx)
+	if err != nil {
+		t.Fatalf("Networks.Refresh returned error: %v", err)
+	}
+	if len(networks) != 2 {
+		t.Fatalf("Expected 2 networks, got %d", len(networks))
+	}
+
+	// After refreshing, the registry reflects the API response
+	n, ok := client.Networks.Lookup("newchain")
+	if !ok {
+		t.Fatal("Expected newchain to be found after Refresh")
+	}
+	if n.DisplayName != "New Chain" {
+		t.Errorf("Expected DisplayName 'New Chain', got '%s'", n.DisplayName)
+	}
+	if client.Networks.IsSupported(NetworkSolana) {
+		t.Errorf("Expected %q to be unsupported after Refresh", NetworkSolana)
+	}
+}
+
+func TestNetworks_Get(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `[{"id": "ethereum", "display_name": "Ethereum"}, {"id": "newchain", "display_name": "New Chain", "native_token": "NEW"}]`)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, 1*time.Millisecond, 1*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	eth, err := client.Networks.Get(ctx, NetworkEthereum)
+	if err != nil {
+		t.Fatalf("Networks.Get returned error: %v", err)
+	}
+	if eth.DisplayName != "Ethereum" || eth.NativeToken != "ETH" || eth.ExplorerURL != "https://etherscan.io" {
+		t.Errorf("Unexpected network: %+v", eth)
+	}
+
+	// Metadata returned by the API is kept, and the list is only fetched once
+	n, err := client.Networks.Get(ctx, "newchain")
+	if err != nil {
+		t.Fatalf("Networks.Get returned error: %v", err)
+	}
+	if n.NativeToken != "NEW" || n.ExplorerURL != "" {
+		t.Errorf("Unexpected network: %+v", n)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+
+	if _, err := client.Networks.Get(ctx, NetworkSolana); !errors.Is(err, ErrNetworkNotFound) {
+		t.Errorf("Expected ErrNetworkNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,5 @@
+//go:build !race
+
+package dexpaprika
+
+const raceEnabled = false
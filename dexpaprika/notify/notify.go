@@ -0,0 +1,135 @@
+// Package notify delivers alerts and watcher events to external channels
+// such as webhooks, Slack or standard output.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+// Message is a notification.
+type Message struct {
+	Title  string            `json:"title"`
+	Text   string            `json:"text,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+	Time   time.Time         `json:"time"`
+}
+
+// String formats the message as a single line.
+func (m Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.Title)
+	if m.Text != "" {
+		b.WriteString(": ")
+		b.WriteString(m.Text)
+	}
+	for _, k := range m.fieldKeys() {
+		fmt.Fprintf(&b, " %s=%s", k, m.Fields[k])
+	}
+	return b.String()
+}
+
+// fieldKeys returns the field names in sorted order.
+func (m Message) fieldKeys() []string {
+	keys := make([]string, 0, len(m.Fields))
+	for k := range m.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Notifier delivers messages.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Func adapts a function to the Notifier interface.
+type Func func(ctx context.Context, msg Message) error
+
+// Notify calls f(ctx, msg).
+func (f Func) Notify(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
+
+// Multi returns a Notifier that delivers each message to all notifiers and
+// joins their errors.
+func Multi(notifiers ...Notifier) Notifier {
+	return Func(func(ctx context.Context, msg Message) error {
+		var errs []error
+		for _, n := range notifiers {
+			if err := n.Notify(ctx, msg); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// Writer writes each message as a line to an io.Writer.
+type Writer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriter creates a Notifier that writes to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// NewStdout creates a Notifier that writes to standard output.
+func NewStdout() *Writer {
+	return NewWriter(os.Stdout)
+}
+
+// Notify writes msg, prefixed with its time, as a single line.
+func (n *Writer) Notify(ctx context.Context, msg Message) error {
+	if msg.Time.IsZero() {
+		msg.Time = time.Now()
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, err := fmt.Fprintf(n.w, "%s %s\n", msg.Time.Format(time.RFC3339), msg)
+	return err
+}
+
+// Bus returns a Notifier that publishes each message on bus as an
+// events.AlertFired, so alerts reach the bus's subscribers, such as a
+// publish.Publisher, alongside other notifiers combined with Multi.
+func Bus(bus *events.Bus) Notifier {
+	return Func(func(ctx context.Context, msg Message) error {
+		if msg.Time.IsZero() {
+			msg.Time = time.Now()
+		}
+		bus.Publish(events.AlertFired{Name: msg.Title, Message: msg.Text, Fields: msg.Fields, Time: msg.Time})
+		return nil
+	})
+}
+
+// Forward delivers every event received from events as a message built by
+// format, until the channel is closed or ctx is done. Delivery errors are
+// passed to onError, if set, and do not stop forwarding.
+func Forward[T any](ctx context.Context, n Notifier, events <-chan T, format func(T) Message, onError func(error)) {
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := n.Notify(ctx, format(ev)); err != nil && onError != nil {
+				onError(err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
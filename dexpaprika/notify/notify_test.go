@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	msg := Message{
+		Title:  "Price alert",
+		Text:   "WETH moved 5%",
+		Fields: map[string]string{"pool": "0xpool", "network": "ethereum"},
+		Time:   time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	if err := NewWriter(&buf).Notify(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	want := "2024-06-01T12:00:00Z Price alert: WETH moved 5% network=ethereum pool=0xpool\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestMulti(t *testing.T) {
+	var buf bytes.Buffer
+	failing := Func(func(context.Context, Message) error { return errors.New("boom") })
+
+	err := Multi(failing, NewWriter(&buf)).Notify(context.Background(), Message{Title: "hello"})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Expected joined error, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "hello") {
+		t.Error("Expected delivery to continue after a failing notifier")
+	}
+}
+
+func TestBus(t *testing.T) {
+	bus := events.NewBus()
+	alerts, stop := events.Chan[events.AlertFired](bus, 1)
+	defer stop()
+
+	msg := Message{Title: "Price alert", Text: "WETH moved 5%", Fields: map[string]string{"pool": "0xpool"}}
+	if err := Multi(NewWriter(&bytes.Buffer{}), Bus(bus)).Notify(context.Background(), msg); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	alert := <-alerts
+	if alert.Name != "Price alert" || alert.Message != "WETH moved 5%" || alert.Fields["pool"] != "0xpool" || alert.Time.IsZero() {
+		t.Errorf("Published %+v", alert)
+	}
+}
+
+func TestForward(t *testing.T) {
+	events := make(chan float64, 3)
+	events <- 1.5
+	events <- -2
+	events <- 3
+	close(events)
+
+	var got []string
+	n := Func(func(_ context.Context, msg Message) error {
+		got = append(got, msg.Title)
+		if msg.Title == "-2" {
+			return errors.New("rejected")
+		}
+		return nil
+	})
+	var errs int
+	Forward(context.Background(), n, events, func(v float64) Message {
+		return Message{Title: strconv.FormatFloat(v, 'f', -1, 64)}
+	}, func(error) { errs++ })
+
+	if strings.Join(got, ",") != "1.5,-2,3" || errs != 1 {
+		t.Errorf("Forwarded %v with %d errors", got, errs)
+	}
+}
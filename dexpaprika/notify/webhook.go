@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Webhook posts each message as JSON to a URL.
+type Webhook struct {
+	url string
+
+	// HTTPClient sends the requests. It defaults to a client with a
+	// 10 second timeout.
+	HTTPClient *http.Client
+
+	// Header is added to every request, e.g. for authentication.
+	Header http.Header
+}
+
+// NewWebhook creates a Notifier that posts messages to url.
+func NewWebhook(url string) *Webhook {
+	return &Webhook{url: url, HTTPClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notify posts msg as a JSON object.
+func (n *Webhook) Notify(ctx context.Context, msg Message) error {
+	if msg.Time.IsZero() {
+		msg.Time = time.Now()
+	}
+	return postJSON(ctx, n.HTTPClient, n.url, n.Header, msg)
+}
+
+// Slack posts messages to a Slack incoming webhook.
+type Slack struct {
+	webhookURL string
+
+	// HTTPClient sends the requests. It defaults to a client with a
+	// 10 second timeout.
+	HTTPClient *http.Client
+}
+
+// NewSlack creates a Notifier that posts to a Slack incoming webhook URL.
+func NewSlack(webhookURL string) *Slack {
+	return &Slack{webhookURL: webhookURL, HTTPClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notify posts msg with its title in bold and one line per field.
+func (n *Slack) Notify(ctx context.Context, msg Message) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%s*", msg.Title)
+	if msg.Text != "" {
+		b.WriteString("\n")
+		b.WriteString(msg.Text)
+	}
+	for _, k := range msg.fieldKeys() {
+		fmt.Fprintf(&b, "\n• %s: %s", k, msg.Fields[k])
+	}
+	return postJSON(ctx, n.HTTPClient, n.webhookURL, nil, map[string]string{"text": b.String()})
+}
+
+// postJSON posts v as JSON and fails on non-2xx responses.
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, values := range header {
+		for _, value := range values {
+			req.Header.Add(k, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("notify: %s returned %s: %s", req.URL.Host, resp.Status, bytes.TrimSpace(detail))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
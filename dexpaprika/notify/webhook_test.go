@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhook(t *testing.T) {
+	var got Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("Unexpected request %s with headers %v", r.Method, r.Header)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	n := NewWebhook(server.URL)
+	n.Header = http.Header{"Authorization": {"Bearer secret"}}
+	err := n.Notify(context.Background(), Message{Title: "New pool", Fields: map[string]string{"dex": "uniswap_v3"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "New pool" || got.Fields["dex"] != "uniswap_v3" || got.Time.IsZero() {
+		t.Errorf("Unexpected payload %+v", got)
+	}
+}
+
+func TestSlack(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+
+	err := NewSlack(server.URL).Notify(context.Background(), Message{Title: "Whale buy", Text: "$1.2M of WETH", Fields: map[string]string{"pool": "0xpool"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "*Whale buy*\n$1.2M of WETH\n• pool: 0xpool"; got["text"] != want {
+		t.Errorf("text = %q, want %q", got["text"], want)
+	}
+}
+
+func TestWebhook_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := NewSlack(server.URL).Notify(context.Background(), Message{Title: "x"})
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "invalid_token") {
+		t.Errorf("Expected status error, got %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package dexpaprika
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// OHLCV intervals accepted by the OHLCV endpoint.
+const (
+	OHLCVInterval1m  = "1m"
+	OHLCVInterval5m  = "5m"
+	OHLCVInterval10m = "10m"
+	OHLCVInterval15m = "15m"
+	OHLCVInterval30m = "30m"
+	OHLCVInterval1h  = "1h"
+	OHLCVInterval6h  = "6h"
+	OHLCVInterval12h = "12h"
+	OHLCVInterval24h = "24h"
+)
+
+// ohlcvDurations maps OHLCV intervals to their candle length.
+var ohlcvDurations = map[string]time.Duration{
+	OHLCVInterval1m:  time.Minute,
+	OHLCVInterval5m:  5 * time.Minute,
+	OHLCVInterval10m: 10 * time.Minute,
+	OHLCVInterval15m: 15 * time.Minute,
+	OHLCVInterval30m: 30 * time.Minute,
+	OHLCVInterval1h:  time.Hour,
+	OHLCVInterval6h:  6 * time.Hour,
+	OHLCVInterval12h: 12 * time.Hour,
+	OHLCVInterval24h: 24 * time.Hour,
+}
+
+// ohlcvMaxLimit is the largest number of candles the OHLCV endpoint returns
+// per request.
+const ohlcvMaxLimit = 366
+
+// OHLCVIntervalDuration returns the candle length of an OHLCV interval.
+func OHLCVIntervalDuration(interval string) (time.Duration, bool) {
+	d, ok := ohlcvDurations[interval]
+	return d, ok
+}
+
+// OpenTime returns TimeOpen parsed as RFC 3339.
+func (r OHLCVRecord) OpenTime() (time.Time, error) {
+	return time.Parse(time.RFC3339, r.TimeOpen)
+}
+
+// CloseTime returns TimeClose parsed as RFC 3339.
+func (r OHLCVRecord) CloseTime() (time.Time, error) {
+	return time.Parse(time.RFC3339, r.TimeClose)
+}
+
+// ohlcvIntervalFor picks the finest interval still likely to be retained for
+// candles around a time of the given age.
+func ohlcvIntervalFor(age time.Duration) string {
+	switch {
+	case age <= 24*time.Hour:
+		return OHLCVInterval1m
+	case age <= 7*24*time.Hour:
+		return OHLCVInterval15m
+	case age <= 90*24*time.Hour:
+		return OHLCVInterval1h
+	default:
+		return OHLCVInterval24h
+	}
+}
+
+// GetOHLCVRange returns the candles of a pool opening in [start, end),
+// splitting the range into as many OHLCV requests as the endpoint's
+// per-request limit requires. Only Interval (default 24h) and Inversed are
+// used from opts.
+func (s *PoolsService) GetOHLCVRange(ctx context.Context, networkID, poolAddress string, start, end time.Time, opts *OHLCVOptions) ([]OHLCVRecord, error) {
+	interval, inversed := OHLCVInterval24h, false
+	if opts != nil {
+		if opts.Interval != "" {
+			interval = opts.Interval
+		}
+		inversed = opts.Inversed
+	}
+	step, ok := ohlcvDurations[interval]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidInterval, interval)
+	}
+
+	var candles []OHLCVRecord
+	seen := make(map[string]bool)
+	for chunkStart := start; chunkStart.Before(end); {
+		chunkEnd := chunkStart.Add(ohlcvMaxLimit * step)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+
+		chunk, err := s.GetOHLCV(ctx, networkID, poolAddress, &OHLCVOptions{
+			Start:    chunkStart.UTC().Format(time.RFC3339),
+			End:      chunkEnd.UTC().Format(time.RFC3339),
+			Limit:    ohlcvMaxLimit,
+			Interval: interval,
+			Inversed: inversed,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range chunk {
+			open, err := c.OpenTime()
+			if err != nil || open.Before(start) || !open.Before(end) || seen[c.TimeOpen] {
+				continue
+			}
+			seen[c.TimeOpen] = true
+			candles = append(candles, c)
+		}
+
+		chunkStart = chunkEnd
+	}
+
+	return candles, nil
+}
+
+// AveragePrice is an average price over a range together with the candles
+// it was computed from.
+type AveragePrice struct {
+	Value   float64       `json:"value"`
+	Candles []OHLCVRecord `json:"candles"`
+}
+
+// TWAP returns the time-weighted average price of a pool's first token (the
+// second when opts.Inversed is set) over [start, end), computed from candles
+// of opts.Interval.
+func (s *PoolsService) TWAP(ctx context.Context, networkID, poolAddress string, start, end time.Time, opts *OHLCVOptions) (*AveragePrice, error) {
+	return s.averagePrice(ctx, networkID, poolAddress, start, end, opts, TWAP)
+}
+
+// VWAP returns the volume-weighted average price of a pool's first token
+// (the second when opts.Inversed is set) over [start, end), computed from
+// candles of opts.Interval.
+func (s *PoolsService) VWAP(ctx context.Context, networkID, poolAddress string, start, end time.Time, opts *OHLCVOptions) (*AveragePrice, error) {
+	return s.averagePrice(ctx, networkID, poolAddress, start, end, opts, VWAP)
+}
+
+func (s *PoolsService) averagePrice(ctx context.Context, networkID, poolAddress string, start, end time.Time, opts *OHLCVOptions, average func([]OHLCVRecord) (float64, bool)) (*AveragePrice, error) {
+	candles, err := s.GetOHLCVRange(ctx, networkID, poolAddress, start, end, opts)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := average(candles)
+	if !ok {
+		return nil, fmt.Errorf("%w: no trading in pool %s between %s and %s", ErrNoPrice, poolAddress, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+	return &AveragePrice{Value: value, Candles: candles}, nil
+}
+
+// TWAP returns the time-weighted average of the candles' close prices. All
+// candles of a series span the same time, so each weighs equally. ok is
+// false for an empty series.
+func TWAP(candles []OHLCVRecord) (twap float64, ok bool) {
+	if len(candles) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, c := range candles {
+		sum += c.Close
+	}
+	return sum / float64(len(candles)), true
+}
+
+// VWAP returns the volume-weighted average of the candles' typical prices,
+// (high + low + close) / 3. ok is false when the series has no volume.
+func VWAP(candles []OHLCVRecord) (vwap float64, ok bool) {
+	var sum, volume float64
+	for _, c := range candles {
+		v := float64(c.Volume)
+		sum += (c.High + c.Low + c.Close) / 3 * v
+		volume += v
+	}
+	if volume == 0 {
+		return 0, false
+	}
+	return sum / volume, true
+}
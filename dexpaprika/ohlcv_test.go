@@ -0,0 +1,87 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/internal/httpassert"
+)
+
+func TestTWAPAndVWAP(t *testing.T) {
+	candles := []OHLCVRecord{
+		{High: 12, Low: 8, Close: 10, Volume: 100},
+		{High: 21, Low: 18, Close: 21, Volume: 300},
+	}
+
+	if twap, ok := TWAP(candles); !ok || twap != 15.5 {
+		t.Errorf("TWAP = %f, %t, want 15.5", twap, ok)
+	}
+	// (10*100 + 20*300) / 400
+	if vwap, ok := VWAP(candles); !ok || vwap != 17.5 {
+		t.Errorf("VWAP = %f, %t, want 17.5", vwap, ok)
+	}
+
+	if _, ok := TWAP(nil); ok {
+		t.Error("TWAP of no candles should not be ok")
+	}
+	if _, ok := VWAP([]OHLCVRecord{{Close: 1}}); ok {
+		t.Error("VWAP without volume should not be ok")
+	}
+}
+
+func TestPools_GetOHLCVRangeChunks(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(500 * time.Hour)
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		requests = append(requests, q.Get("start")+"/"+q.Get("end"))
+		httpassert.Params(t, r, httpassert.Query{"interval": "1h", "limit": "366", "inversed": "true"})
+		from, _ := time.Parse(time.RFC3339, q.Get("start"))
+		to, _ := time.Parse(time.RFC3339, q.Get("end"))
+
+		// Return candles for the requested range, including the one at
+		// "end" to exercise de-duplication across chunk boundaries
+		var candles []string
+		for ts := from; !ts.After(to); ts = ts.Add(time.Hour) {
+			candles = append(candles, fmt.Sprintf(`{"time_open": %q, "close": 2, "high": 2, "low": 2, "volume": 10}`, ts.Format(time.RFC3339)))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "[%s]\n", strings.Join(candles, ","))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+	opts := &OHLCVOptions{Interval: OHLCVInterval1h, Inversed: true}
+
+	twap, err := client.Pools.TWAP(context.Background(), "ethereum", "0xpool", start, end, opts)
+	if err != nil {
+		t.Fatalf("TWAP returned error: %v", err)
+	}
+	if len(requests) != 2 {
+		t.Errorf("Expected 2 chunked requests, got %v", requests)
+	}
+	if len(twap.Candles) != 500 || twap.Value != 2 {
+		t.Errorf("Expected 500 candles averaging 2, got %d averaging %f", len(twap.Candles), twap.Value)
+	}
+
+	vwap, err := client.Pools.VWAP(context.Background(), "ethereum", "0xpool", start, end, opts)
+	if err != nil {
+		t.Fatalf("VWAP returned error: %v", err)
+	}
+	if math.Abs(vwap.Value-2) > 1e-9 {
+		t.Errorf("Expected VWAP 2, got %f", vwap.Value)
+	}
+
+	if _, err := client.Pools.GetOHLCVRange(context.Background(), "ethereum", "0xpool", start, end, &OHLCVOptions{Interval: "2h"}); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("Expected ErrInvalidInterval, got %v", err)
+	}
+}
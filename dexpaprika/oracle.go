@@ -0,0 +1,88 @@
+package dexpaprika
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// PriceOracle is a source of current token prices in USD. It matches the
+// shape of the price-feed abstractions DeFi backends use for providers such
+// as Chainlink or CoinGecko, so Client.Prices can be used as one more
+// provider.
+type PriceOracle interface {
+	Price(ctx context.Context, chain, token string) (Decimal, time.Time, error)
+}
+
+// PriceOracleFunc adapts a function to a PriceOracle.
+type PriceOracleFunc func(ctx context.Context, chain, token string) (Decimal, time.Time, error)
+
+// Price calls f.
+func (f PriceOracleFunc) Price(ctx context.Context, chain, token string) (Decimal, time.Time, error) {
+	return f(ctx, chain, token)
+}
+
+var _ PriceOracle = (*PricesService)(nil)
+
+// Price implements PriceOracle with Get, returning the token's
+// liquidity-weighted USD price and the time of the latest pool price it is
+// derived from.
+func (s *PricesService) Price(ctx context.Context, chain, token string) (Decimal, time.Time, error) {
+	p, err := s.Get(ctx, chain, token)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return NewDecimal(p.PriceUSD), p.Time, nil
+}
+
+// Decimal is a price in plain decimal notation, such as "3050.25", so it can
+// be converted without loss into the decimal type of the caller's choice,
+// e.g. decimal.NewFromString(d.String()) with shopspring/decimal.
+type Decimal string
+
+// NewDecimal formats f with the fewest digits that represent it exactly.
+func NewDecimal(f float64) Decimal {
+	return Decimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// String returns the decimal notation.
+func (d Decimal) String() string { return string(d) }
+
+// Float64 returns the value as a float64, or 0 if d is not a number.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(string(d), 64)
+	return f
+}
+
+// Rat returns the exact value as a rational number, or false if d is not a
+// number.
+func (d Decimal) Rat() (*big.Rat, bool) {
+	return new(big.Rat).SetString(string(d))
+}
+
+// MarshalJSON encodes d as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if d == "" {
+		return []byte("null"), nil
+	}
+	return []byte(d), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a string holding one.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		*d = ""
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	if s == "" || (s[0] != '-' && (s[0] < '0' || s[0] > '9')) || !json.Valid([]byte(s)) {
+		return &strconv.NumError{Func: "UnmarshalJSON", Num: s, Err: strconv.ErrSyntax}
+	}
+	*d = Decimal(s)
+	return nil
+}
@@ -0,0 +1,69 @@
+package dexpaprika
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestPrices_Price(t *testing.T) {
+	server := newPricesTestServer(t)
+	defer server.Close()
+
+	var oracle PriceOracle = NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0)).Prices
+	price, at, err := oracle.Price(context.Background(), "ethereum", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2")
+	if err != nil {
+		t.Fatalf("Price returned error: %v", err)
+	}
+	if price != "3050" {
+		t.Errorf("Expected price 3050, got %s", price)
+	}
+	if !at.Equal(time.Date(2024, 5, 1, 10, 5, 0, 0, time.UTC)) {
+		t.Errorf("Expected the latest pool price time, got %s", at)
+	}
+
+	if _, _, err := oracle.Price(context.Background(), "ethereum", "0xdead"); !errors.Is(err, ErrNoPrice) {
+		t.Errorf("Expected ErrNoPrice, got %v", err)
+	}
+}
+
+func TestPriceOracleFunc(t *testing.T) {
+	var oracle PriceOracle = PriceOracleFunc(func(ctx context.Context, chain, token string) (Decimal, time.Time, error) {
+		return "1.5", time.Unix(0, 0), nil
+	})
+	if p, _, _ := oracle.Price(context.Background(), "ethereum", "0x1"); p.Float64() != 1.5 {
+		t.Errorf("Expected 1.5, got %s", p)
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	d := NewDecimal(0.000012345)
+	if d != "0.000012345" {
+		t.Errorf("NewDecimal = %q", d)
+	}
+	r, ok := d.Rat()
+	if !ok || r.Cmp(big.NewRat(12345, 1000000000)) != 0 {
+		t.Errorf("Rat = %v, %v", r, ok)
+	}
+
+	data, err := json.Marshal(struct{ Price Decimal }{d})
+	if err != nil || string(data) != `{"Price":0.000012345}` {
+		t.Errorf("Marshal = %s, %v", data, err)
+	}
+
+	var v struct{ A, B, C Decimal }
+	if err := json.Unmarshal([]byte(`{"A": 1.25, "B": "2.5", "C": null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A != "1.25" || v.B != "2.5" || v.C != "" {
+		t.Errorf("Unmarshal = %+v", v)
+	}
+	for _, bad := range []string{`"abc"`, `"1/2"`, `"NaN"`, `"1e"`} {
+		if err := json.Unmarshal([]byte(`{"A": `+bad+`}`), &v); err == nil {
+			t.Errorf("Expected an error for %s", bad)
+		}
+	}
+}
@@ -21,6 +21,11 @@ type PoolsPaginator struct {
 	options     *ListOptions
 	currentResp *PoolsResponse
 	err         error
+
+	// Keys of the pools returned so far and the current page without the
+	// repeated ones, if deduplicating
+	seen    map[string]bool
+	current []Pool
 }
 
 // NewPoolsPaginator creates a new paginator for pools
@@ -58,6 +63,14 @@ func (p *PoolsPaginator) ForToken(networkID, tokenID string, secondToken string)
 	return p
 }
 
+// Dedup makes the paginator skip pools returned on an earlier page, which
+// happens when pools move between pages while they are fetched, e.g. as
+// their volume changes in a listing ordered by volume
+func (p *PoolsPaginator) Dedup() *PoolsPaginator {
+	p.seen = make(map[string]bool)
+	return p
+}
+
 // HasNextPage returns true if there are more pages to fetch
 func (p *PoolsPaginator) HasNextPage() bool {
 	if p.currentResp == nil {
@@ -116,6 +129,9 @@ func (p *PoolsPaginator) GetNextPage(ctx context.Context) error {
 	}
 
 	p.currentResp = resp
+	if p.seen != nil {
+		p.current = dedupe(resp.Pools, p.seen, func(pool Pool) string { return pool.Key().String() })
+	}
 	return nil
 }
 
@@ -124,6 +140,9 @@ func (p *PoolsPaginator) GetCurrentPage() []Pool {
 	if p.currentResp == nil {
 		return nil
 	}
+	if p.seen != nil {
+		return p.current
+	}
 	return p.currentResp.Pools
 }
 
@@ -132,6 +151,12 @@ func (p *PoolsPaginator) GetError() error {
 	return p.err
 }
 
+// Drain writes the remaining pages of pools to sink and flushes it. It does
+// not close the sink.
+func (p *PoolsPaginator) Drain(ctx context.Context, sink Sink[Pool]) error {
+	return drain(ctx, p, p.GetCurrentPage, sink)
+}
+
 // DexesPaginator provides pagination for DEXes
 type DexesPaginator struct {
 	client      *Client
@@ -140,6 +165,11 @@ type DexesPaginator struct {
 	limit       int
 	currentResp *DexesResponse
 	err         error
+
+	// Keys of the DEXes returned so far and the current page without the
+	// repeated ones, if deduplicating
+	seen    map[string]bool
+	current []Dex
 }
 
 // NewDexesPaginator creates a new paginator for DEXes
@@ -155,6 +185,12 @@ func NewDexesPaginator(client *Client, networkID string, limit int) *DexesPagina
 	}
 }
 
+// Dedup makes the paginator skip DEXes returned on an earlier page
+func (p *DexesPaginator) Dedup() *DexesPaginator {
+	p.seen = make(map[string]bool)
+	return p
+}
+
 // HasNextPage returns true if there are more pages to fetch
 func (p *DexesPaginator) HasNextPage() bool {
 	if p.currentResp == nil {
@@ -184,7 +220,7 @@ func (p *DexesPaginator) GetNextPage(ctx context.Context) error {
 		return fmt.Errorf("no more pages")
 	}
 
-	resp, err := p.client.Networks.ListDexes(ctx, p.networkID, p.page, p.limit)
+	resp, err := p.client.Dexes.List(ctx, p.networkID, p.page, p.limit)
 	if err != nil {
 		p.err = err
 		return err
@@ -192,6 +228,9 @@ func (p *DexesPaginator) GetNextPage(ctx context.Context) error {
 
 	p.currentResp = resp
 	p.page++ // Increment page for next call
+	if p.seen != nil {
+		p.current = dedupe(resp.Dexes, p.seen, func(dex Dex) string { return dex.ID })
+	}
 
 	return nil
 }
@@ -201,6 +240,9 @@ func (p *DexesPaginator) GetCurrentPage() []Dex {
 	if p.currentResp == nil {
 		return nil
 	}
+	if p.seen != nil {
+		return p.current
+	}
 	return p.currentResp.Dexes
 }
 
@@ -209,16 +251,28 @@ func (p *DexesPaginator) GetError() error {
 	return p.err
 }
 
+// Drain writes the remaining pages of DEXes to sink and flushes it. It does
+// not close the sink.
+func (p *DexesPaginator) Drain(ctx context.Context, sink Sink[Dex]) error {
+	return drain(ctx, p, p.GetCurrentPage, sink)
+}
+
 // TransactionsPaginator provides pagination for transactions
 type TransactionsPaginator struct {
 	client      *Client
 	networkID   string
 	poolAddress string
+	tokenID     string // Set for token-level transactions instead of poolAddress
 	page        int
 	limit       int
 	cursor      string // Some APIs use cursor-based pagination
 	currentResp *TransactionsResponse
 	err         error
+
+	// IDs of the transactions returned so far and the current page without
+	// the repeated ones, if deduplicating
+	seen    map[string]bool
+	current []Transaction
 }
 
 // NewTransactionsPaginator creates a new paginator for transactions
@@ -235,6 +289,22 @@ func NewTransactionsPaginator(client *Client, networkID, poolAddress string, lim
 	}
 }
 
+// NewTokenTransactionsPaginator creates a new paginator for the transactions
+// of a token across all of its pools
+func NewTokenTransactionsPaginator(client *Client, networkID, tokenAddress string, limit int) *TransactionsPaginator {
+	p := NewTransactionsPaginator(client, networkID, "", limit)
+	p.tokenID = tokenAddress
+	return p
+}
+
+// Dedup makes the paginator skip transactions returned on an earlier page,
+// which happens when new transactions push older ones to later pages while
+// they are fetched
+func (p *TransactionsPaginator) Dedup() *TransactionsPaginator {
+	p.seen = make(map[string]bool)
+	return p
+}
+
 // HasNextPage returns true if there are more pages to fetch
 func (p *TransactionsPaginator) HasNextPage() bool {
 	if p.currentResp == nil {
@@ -264,7 +334,17 @@ func (p *TransactionsPaginator) GetNextPage(ctx context.Context) error {
 		return fmt.Errorf("no more pages")
 	}
 
-	resp, err := p.client.Pools.GetTransactions(ctx, p.networkID, p.poolAddress, p.page, p.limit, p.cursor)
+	var resp *TransactionsResponse
+	var err error
+	if p.tokenID != "" {
+		resp, err = p.client.Tokens.GetTransactions(ctx, p.networkID, p.tokenID, &TransactionsOptions{
+			Page:   p.page,
+			Limit:  p.limit,
+			Cursor: p.cursor,
+		})
+	} else {
+		resp, err = p.client.Pools.GetTransactions(ctx, p.networkID, p.poolAddress, p.page, p.limit, p.cursor)
+	}
 	if err != nil {
 		p.err = err
 		return err
@@ -278,6 +358,9 @@ func (p *TransactionsPaginator) GetNextPage(ctx context.Context) error {
 		lastTx := p.currentResp.Transactions[len(p.currentResp.Transactions)-1]
 		p.cursor = lastTx.ID // Some APIs use the last ID as cursor
 	}
+	if p.seen != nil {
+		p.current = dedupe(resp.Transactions, p.seen, func(tx Transaction) string { return tx.ID })
+	}
 
 	return nil
 }
@@ -287,6 +370,9 @@ func (p *TransactionsPaginator) GetCurrentPage() []Transaction {
 	if p.currentResp == nil {
 		return nil
 	}
+	if p.seen != nil {
+		return p.current
+	}
 	return p.currentResp.Transactions
 }
 
@@ -294,3 +380,22 @@ func (p *TransactionsPaginator) GetCurrentPage() []Transaction {
 func (p *TransactionsPaginator) GetError() error {
 	return p.err
 }
+
+// Drain writes the remaining pages of transactions to sink and flushes it. It does
+// not close the sink.
+func (p *TransactionsPaginator) Drain(ctx context.Context, sink Sink[Transaction]) error {
+	return drain(ctx, p, p.GetCurrentPage, sink)
+}
+
+// dedupe returns the items whose key is not in seen, and adds their keys.
+func dedupe[T any](items []T, seen map[string]bool, key func(T) string) []T {
+	var fresh []T
+	for _, item := range items {
+		k := key(item)
+		if !seen[k] {
+			seen[k] = true
+			fresh = append(fresh, item)
+		}
+	}
+	return fresh
+}
@@ -0,0 +1,234 @@
+package dexpaprika
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// listing is a synthetic paginated listing. Before each request, Drift[i]
+// new items are inserted at its head, pushing the others to later pages as
+// new pools or transactions do on the live API. TotalPagesSkew is added to
+// the honest total_pages, for an API whose page info is off by one.
+type listing struct {
+	Items          int
+	Limit          int
+	Drift          []int
+	TotalPagesSkew int
+}
+
+// Generate implements quick.Generator.
+func (listing) Generate(r *rand.Rand, size int) reflect.Value {
+	l := listing{
+		Items: r.Intn(size * 3),
+		Limit: 1 + r.Intn(10),
+	}
+	if r.Intn(2) == 0 {
+		l.Drift = make([]int, r.Intn(size))
+		for i := range l.Drift {
+			l.Drift[i] = r.Intn(4)
+		}
+	}
+	if r.Intn(4) == 0 {
+		l.TotalPagesSkew = r.Intn(3) - 1
+	}
+	return reflect.ValueOf(l)
+}
+
+// listingServer serves l under key, such as "pools", and records the page
+// of every request.
+type listingServer struct {
+	l     listing
+	key   string
+	ids   []string
+	next  int
+	pages []int
+}
+
+func newListingServer(l listing, key string) *listingServer {
+	s := &listingServer{l: l, key: key}
+	s.insert(l.Items)
+	return s
+}
+
+// insert adds n new items at the head of the listing.
+func (s *listingServer) insert(n int) {
+	head := make([]string, n)
+	for i := range head {
+		head[n-1-i] = "0x" + strconv.FormatInt(int64(s.next), 16)
+		s.next++
+	}
+	s.ids = append(head, s.ids...)
+}
+
+func (s *listingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if n := len(s.pages); n < len(s.l.Drift) {
+		s.insert(s.l.Drift[n])
+	}
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	s.pages = append(s.pages, page)
+
+	items := []map[string]string{}
+	for i := page * limit; i < (page+1)*limit && i < len(s.ids); i++ {
+		items = append(items, map[string]string{"id": s.ids[i], "dex_id": s.ids[i], "chain": "ethereum"})
+	}
+	totalPages := max((len(s.ids)+limit-1)/limit+s.l.TotalPagesSkew, 0)
+	json.NewEncoder(w).Encode(map[string]any{
+		s.key: items,
+		"page_info": PageInfo{
+			Limit:      limit,
+			Page:       page,
+			TotalItems: len(s.ids),
+			TotalPages: totalPages,
+		},
+	})
+}
+
+// rawPage is the page info and item count of a paginator's last response.
+type rawPage struct {
+	info  PageInfo
+	items int
+}
+
+// checkPagination pages through a listing served by srv and checks the
+// paginator invariants: pages are requested in order from 0, HasNextPage
+// agrees with the page info, deduplicated items are unique and, when items
+// only move to later pages, every original item is returned.
+func checkPagination[T any](l listing, srv *listingServer, p Paginator, current func() []T, last func() rawPage, id func(T) string) error {
+	ctx := context.Background()
+	original := append([]string(nil), srv.ids...)
+	seen := make(map[string]bool)
+
+	for p.HasNextPage() {
+		if len(srv.pages) > l.Items+len(l.Drift)*3+2 {
+			return fmt.Errorf("paginator did not stop after %d pages", len(srv.pages))
+		}
+		if err := p.GetNextPage(ctx); err != nil {
+			return fmt.Errorf("page %d: %w", len(srv.pages), err)
+		}
+		for _, item := range current() {
+			if seen[id(item)] {
+				return fmt.Errorf("%s returned twice", id(item))
+			}
+			seen[id(item)] = true
+		}
+
+		raw := last()
+		want := raw.items >= l.Limit && raw.info.Page+1 < raw.info.TotalPages
+		if p.HasNextPage() != want {
+			return fmt.Errorf("HasNextPage() = %v after %d items of page %+v", !want, raw.items, raw.info)
+		}
+	}
+
+	for i, page := range srv.pages {
+		if page != i {
+			return fmt.Errorf("requested pages %v, want 0, 1, 2, ...", srv.pages)
+		}
+	}
+	if p.GetNextPage(ctx) == nil {
+		return fmt.Errorf("GetNextPage() succeeded after the last page")
+	}
+	if l.TotalPagesSkew >= 0 {
+		for _, id := range original {
+			if !seen[id] {
+				return fmt.Errorf("%s was never returned", id)
+			}
+		}
+	}
+	return nil
+}
+
+func quickConfig() *quick.Config {
+	if testing.Short() {
+		return &quick.Config{MaxCount: 50}
+	}
+	return &quick.Config{MaxCount: 300}
+}
+
+func TestPoolsPaginator_Properties(t *testing.T) {
+	property := func(l listing) error {
+		srv := newListingServer(l, "pools")
+		server := httptest.NewServer(srv)
+		defer server.Close()
+		client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+
+		p := NewPoolsPaginator(client, &ListOptions{Limit: l.Limit}).ForNetwork("ethereum").Dedup()
+		return checkPagination(l, srv, p, p.GetCurrentPage, func() rawPage {
+			return rawPage{p.currentResp.PageInfo, len(p.currentResp.Pools)}
+		}, func(pool Pool) string { return pool.ID })
+	}
+	if err := quick.Check(func(l listing) bool {
+		if err := property(l); err != nil {
+			t.Logf("%+v: %v", l, err)
+			return false
+		}
+		return true
+	}, quickConfig()); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTransactionsPaginator_Properties(t *testing.T) {
+	property := func(l listing) error {
+		srv := newListingServer(l, "transactions")
+		server := httptest.NewServer(srv)
+		defer server.Close()
+		client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+
+		p := NewTransactionsPaginator(client, "ethereum", "0xpool", l.Limit).Dedup()
+		return checkPagination(l, srv, p, p.GetCurrentPage, func() rawPage {
+			return rawPage{p.currentResp.PageInfo, len(p.currentResp.Transactions)}
+		}, func(tx Transaction) string { return tx.ID })
+	}
+	if err := quick.Check(func(l listing) bool {
+		if err := property(l); err != nil {
+			t.Logf("%+v: %v", l, err)
+			return false
+		}
+		return true
+	}, quickConfig()); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDexesPaginator_Dedup(t *testing.T) {
+	for _, tt := range []struct {
+		dedup bool
+		want  string
+	}{
+		// Without Dedup, the DEX pushed to the next page is returned again
+		{false, "0x3,0x2,0x2,0x1,0x0"},
+		{true, "0x3,0x2,0x1,0x0"},
+	} {
+		srv := newListingServer(listing{Items: 4, Limit: 2, Drift: []int{0, 1}}, "dexes")
+		server := httptest.NewServer(srv)
+		client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+
+		var ids []string
+		p := NewDexesPaginator(client, "ethereum", 2)
+		if tt.dedup {
+			p.Dedup()
+		}
+		for p.HasNextPage() {
+			if err := p.GetNextPage(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			for _, dex := range p.GetCurrentPage() {
+				ids = append(ids, dex.ID)
+			}
+		}
+		server.Close()
+		if got := strings.Join(ids, ","); got != tt.want {
+			t.Errorf("Dedup %v: pages returned %s, want %s", tt.dedup, got, tt.want)
+		}
+	}
+}
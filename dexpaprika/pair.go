@@ -0,0 +1,153 @@
+package dexpaprika
+
+import "strings"
+
+// findToken returns the index of the token matching symbolOrAddress. Addresses
+// are compared case-insensitively and take precedence over symbol matches.
+func findToken(tokens []Token, symbolOrAddress string) int {
+	symbolOrAddress = strings.TrimSpace(symbolOrAddress)
+	for i, t := range tokens {
+		if strings.EqualFold(t.ID, symbolOrAddress) {
+			return i
+		}
+	}
+	for i, t := range tokens {
+		if strings.EqualFold(t.Symbol, symbolOrAddress) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Token returns the pool token matching the given symbol or address.
+func (p *Pool) Token(symbolOrAddress string) (Token, bool) {
+	i := findToken(p.Tokens, symbolOrAddress)
+	if i < 0 {
+		return Token{}, false
+	}
+	return p.Tokens[i], true
+}
+
+// Pair returns the base and quote tokens of the pool. PriceUSD is the price
+// of the base token.
+func (p *Pool) Pair() (base, quote Token, ok bool) {
+	if len(p.Tokens) < 2 {
+		return Token{}, Token{}, false
+	}
+	return p.Tokens[0], p.Tokens[1], true
+}
+
+// InversedFor reports whether the pool's details must be requested with
+// inversed pricing for prices to be denominated in quoteToken, given as a
+// symbol or address. ok is false if the token is not part of the pool.
+func (p *Pool) InversedFor(quoteToken string) (inversed, ok bool) {
+	return inversedFor(p.Tokens, quoteToken)
+}
+
+// inversedFor reports whether quoteToken is the first of tokens, whose
+// price is only quoted in it when the pool is inversed.
+func inversedFor(tokens []Token, quoteToken string) (inversed, ok bool) {
+	if len(tokens) < 2 {
+		return false, false
+	}
+	i := findToken(tokens[:2], quoteToken)
+	if i < 0 {
+		return false, false
+	}
+	return i == 0, true
+}
+
+// PriceUSDOf returns the USD price of the given token. Pool listings only
+// carry the base token's price, so quote tokens report false.
+//
+// Pool has no PriceOf: listings carry neither the price of the pair nor the
+// quote token's USD price, so a token's price in the other token cannot be
+// derived from them. Use PoolDetails.PriceOf, or the USD prices in the
+// Summary of each token's TokenDetails, instead.
+func (p *Pool) PriceUSDOf(symbolOrAddress string) (float64, bool) {
+	if findToken(p.Tokens, symbolOrAddress) != 0 {
+		return 0, false
+	}
+	return p.PriceUSD, true
+}
+
+// Token returns the pool token matching the given symbol or address.
+func (p *PoolDetails) Token(symbolOrAddress string) (Token, bool) {
+	i := findToken(p.Tokens, symbolOrAddress)
+	if i < 0 {
+		return Token{}, false
+	}
+	return p.Tokens[i], true
+}
+
+// Pair returns the base and quote tokens of the pool. LastPrice is the price
+// of the base token denominated in the quote token, so the pair is swapped
+// when the details were requested with inversed pricing.
+func (p *PoolDetails) Pair() (base, quote Token, ok bool) {
+	if len(p.Tokens) < 2 {
+		return Token{}, Token{}, false
+	}
+	if p.Inversed {
+		return p.Tokens[1], p.Tokens[0], true
+	}
+	return p.Tokens[0], p.Tokens[1], true
+}
+
+// PriceOf returns the price of the given token denominated in the other token
+// of the pair, inverting LastPrice when the token is on the quote side.
+func (p *PoolDetails) PriceOf(symbolOrAddress string) (float64, bool) {
+	isBase, ok := p.side(symbolOrAddress)
+	if !ok {
+		return 0, false
+	}
+	if isBase {
+		return p.LastPrice, true
+	}
+	if p.LastPrice == 0 {
+		return 0, false
+	}
+	return 1 / p.LastPrice, true
+}
+
+// PriceUSDOf returns the USD price of the given token, deriving the quote
+// token's price from LastPriceUSD and LastPrice.
+func (p *PoolDetails) PriceUSDOf(symbolOrAddress string) (float64, bool) {
+	isBase, ok := p.side(symbolOrAddress)
+	if !ok {
+		return 0, false
+	}
+	if isBase {
+		return p.LastPriceUSD, true
+	}
+	if p.LastPrice == 0 {
+		return 0, false
+	}
+	return p.LastPriceUSD / p.LastPrice, true
+}
+
+// ReserveOf returns the pool's reserve of the given token, if reported.
+func (p *PoolDetails) ReserveOf(symbolOrAddress string) (TokenReserve, bool) {
+	token, ok := p.Token(symbolOrAddress)
+	if !ok {
+		return TokenReserve{}, false
+	}
+	for _, r := range p.TokenReserves {
+		if strings.EqualFold(r.TokenID, token.ID) {
+			return r, true
+		}
+	}
+	return TokenReserve{}, false
+}
+
+// side reports whether the token is the base of the pair.
+func (p *PoolDetails) side(symbolOrAddress string) (isBase bool, ok bool) {
+	base, quote, ok := p.Pair()
+	if !ok {
+		return false, false
+	}
+	i := findToken([]Token{base, quote}, symbolOrAddress)
+	if i < 0 {
+		return false, false
+	}
+	return i == 0, true
+}
@@ -0,0 +1,186 @@
+package dexpaprika
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testPairTokens() []Token {
+	return []Token{
+		{ID: "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", Symbol: "WETH"},
+		{ID: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", Symbol: "USDC"},
+	}
+}
+
+func TestPool_PairHelpers(t *testing.T) {
+	pool := Pool{ID: "0xpool", PriceUSD: 3000, Tokens: testPairTokens()}
+
+	base, quote, ok := pool.Pair()
+	if !ok || base.Symbol != "WETH" || quote.Symbol != "USDC" {
+		t.Errorf("Pair() = %s/%s, %t, want WETH/USDC, true", base.Symbol, quote.Symbol, ok)
+	}
+
+	if tok, ok := pool.Token("usdc"); !ok || tok.Symbol != "USDC" {
+		t.Errorf("Token(usdc) = %v, %t, want USDC", tok, ok)
+	}
+	if tok, ok := pool.Token("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"); !ok || tok.Symbol != "WETH" {
+		t.Errorf("Token(mixed-case address) = %v, %t, want WETH", tok, ok)
+	}
+	if _, ok := pool.Token("DAI"); ok {
+		t.Error("Token(DAI) should not be found")
+	}
+
+	if price, ok := pool.PriceUSDOf("WETH"); !ok || price != 3000 {
+		t.Errorf("PriceUSDOf(WETH) = %f, %t, want 3000, true", price, ok)
+	}
+	if _, ok := pool.PriceUSDOf("USDC"); ok {
+		t.Error("PriceUSDOf(USDC) should be unknown for pool listings")
+	}
+
+	if _, _, ok := (&Pool{}).Pair(); ok {
+		t.Error("Pair() should fail for a pool without tokens")
+	}
+}
+
+func TestPoolDetails_PriceOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		inversed  bool
+		lastPrice float64
+		token     string
+		wantPrice float64
+		wantUSD   float64
+	}{
+		{name: "base token", lastPrice: 3000, token: "WETH", wantPrice: 3000, wantUSD: 3000},
+		{name: "quote token", lastPrice: 3000, token: "USDC", wantPrice: 1.0 / 3000, wantUSD: 1},
+		{name: "inversed base token", inversed: true, lastPrice: 1.0 / 3000, token: "USDC", wantPrice: 1.0 / 3000, wantUSD: 1},
+		{name: "inversed quote token", inversed: true, lastPrice: 1.0 / 3000, token: "WETH", wantPrice: 3000, wantUSD: 3000},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			details := PoolDetails{Tokens: testPairTokens(), LastPrice: tc.lastPrice, Inversed: tc.inversed}
+			// LastPriceUSD is the USD price of whichever token is the base
+			if tc.inversed {
+				details.LastPriceUSD = 1
+			} else {
+				details.LastPriceUSD = 3000
+			}
+
+			price, ok := details.PriceOf(tc.token)
+			if !ok || math.Abs(price-tc.wantPrice) > 1e-9 {
+				t.Errorf("PriceOf(%s) = %f, %t, want %f", tc.token, price, ok, tc.wantPrice)
+			}
+
+			usd, ok := details.PriceUSDOf(tc.token)
+			if !ok || math.Abs(usd-tc.wantUSD) > 1e-9 {
+				t.Errorf("PriceUSDOf(%s) = %f, %t, want %f", tc.token, usd, ok, tc.wantUSD)
+			}
+		})
+	}
+
+	details := PoolDetails{Tokens: testPairTokens()}
+	if _, ok := details.PriceOf("USDC"); ok {
+		t.Error("PriceOf(quote) should fail when LastPrice is zero")
+	}
+	if _, ok := details.PriceOf("DAI"); ok {
+		t.Error("PriceOf(DAI) should not be found")
+	}
+}
+
+func TestPool_InversedFor(t *testing.T) {
+	pool := Pool{Tokens: testPairTokens()}
+
+	if inversed, ok := pool.InversedFor("USDC"); !ok || inversed {
+		t.Errorf("InversedFor(USDC) = %t, %t, want false, true", inversed, ok)
+	}
+	if inversed, ok := pool.InversedFor("weth"); !ok || !inversed {
+		t.Errorf("InversedFor(weth) = %t, %t, want true, true", inversed, ok)
+	}
+	if _, ok := pool.InversedFor("DAI"); ok {
+		t.Error("InversedFor(DAI) should not be found")
+	}
+}
+
+func TestPools_GetDetailsQuoteToken(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		price := 3000.0
+		if r.URL.Query().Get("inversed") == "true" {
+			price = 1.0 / 3000
+		}
+		fmt.Fprintf(w, `{"id": "0xpool", "last_price": %g, "tokens": [
+			{"id": "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", "symbol": "WETH"},
+			{"id": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "symbol": "USDC"}
+		]}`, price)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+	ctx := context.Background()
+
+	// Already quoted in USDC: a single request
+	details, err := client.Pools.GetDetails(ctx, "ethereum", "0xpool", &PoolDetailsOptions{QuoteToken: "USDC"})
+	if err != nil {
+		t.Fatalf("GetDetails returned error: %v", err)
+	}
+	if _, quote, _ := details.Pair(); quote.Symbol != "USDC" || len(queries) != 1 {
+		t.Errorf("Expected USDC quote in one request, got %s after %d", quote.Symbol, len(queries))
+	}
+
+	// Quoting in WETH requires the inversed orientation
+	queries = nil
+	details, err = client.Pools.GetDetails(ctx, "ethereum", "0xpool", &PoolDetailsOptions{QuoteToken: "WETH"})
+	if err != nil {
+		t.Fatalf("GetDetails returned error: %v", err)
+	}
+	if _, quote, _ := details.Pair(); quote.Symbol != "WETH" {
+		t.Errorf("Expected WETH quote, got %s", quote.Symbol)
+	}
+	if len(queries) != 2 || queries[1] != "inversed=true" {
+		t.Errorf("Unexpected queries %q", queries)
+	}
+
+	if _, err := client.Pools.GetDetails(ctx, "ethereum", "0xpool", &PoolDetailsOptions{QuoteToken: "DAI"}); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("Expected ErrTokenNotFound, got %v", err)
+	}
+
+	// A caller's target only receives the response in the wanted orientation
+	for _, tc := range []struct {
+		quote string
+		want  float64
+	}{{"USDC", 3000}, {"WETH", 1.0 / 3000}} {
+		var target struct {
+			LastPrice float64 `json:"last_price"`
+		}
+		details, err := client.Pools.GetDetails(ctx, "ethereum", "0xpool", &PoolDetailsOptions{QuoteToken: tc.quote}, WithTarget(&target))
+		if err != nil || details != nil {
+			t.Fatalf("GetDetails with target returned %v, %v", details, err)
+		}
+		if target.LastPrice != tc.want {
+			t.Errorf("Quoting in %s decoded last price %g, want %g", tc.quote, target.LastPrice, tc.want)
+		}
+	}
+}
+
+func TestPoolDetails_InversedJSON(t *testing.T) {
+	data, err := json.Marshal(PoolDetails{Tokens: testPairTokens(), Inversed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var details PoolDetails
+	if err := json.Unmarshal(data, &details); err != nil {
+		t.Fatal(err)
+	}
+	if base, _, _ := details.Pair(); !details.Inversed || base.Symbol != "USDC" {
+		t.Errorf("Round-tripped details lost their orientation: %s", data)
+	}
+}
@@ -2,88 +2,53 @@ package dexpaprika
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
+	"sort"
 )
 
+//go:generate go run ./internal/genmodels -out models_gen.go
+
 // PoolsService handles communication with the pools related
 // methods of the DexPaprika API.
 type PoolsService struct {
 	client *Client
 }
 
-// Token represents a token in a pool.
-type Token struct {
-	ID       string   `json:"id"`
-	Name     string   `json:"name"`
-	Symbol   string   `json:"symbol"`
-	Chain    string   `json:"chain"`
-	Decimals int      `json:"decimals"`
-	AddedAt  string   `json:"added_at"`
-	FDV      *float64 `json:"fdv,omitempty"`
-}
-
-// Pool represents a liquidity pool.
-type Pool struct {
-	ID                    string  `json:"id"`
-	DexID                 string  `json:"dex_id"`
-	DexName               string  `json:"dex_name"`
-	Chain                 string  `json:"chain"`
-	VolumeUSD             float64 `json:"volume_usd"`
-	CreatedAt             string  `json:"created_at"`
-	CreatedAtBlockNumber  int64   `json:"created_at_block_number"`
-	Transactions          int     `json:"transactions"`
-	PriceUSD              float64 `json:"price_usd"`
-	LastPriceChangeUSD5m  float64 `json:"last_price_change_usd_5m"`
-	LastPriceChangeUSD1h  float64 `json:"last_price_change_usd_1h"`
-	LastPriceChangeUSD24h float64 `json:"last_price_change_usd_24h"`
-	Fee                   float64 `json:"fee"`
-	Tokens                []Token `json:"tokens"`
-}
-
-// PoolsResponse represents the response for the pools endpoint.
-type PoolsResponse struct {
-	Pools    []Pool   `json:"pools"`
-	PageInfo PageInfo `json:"page_info"`
-}
-
 // ListOptions contains common options for listing pools.
 type ListOptions struct {
 	Page    int
 	Limit   int
 	Sort    string
 	OrderBy string
+
+	// Fields restricts the returned pools to the given JSON fields (e.g.
+	// "id", "price_usd", "tokens.symbol"). The API does not support sparse
+	// fieldsets, so the projection is applied client-side after decoding to
+	// reduce the memory retained by large listings.
+	Fields []string
+}
+
+// projectPools applies opts.Fields to a pools response.
+func projectPools(resp *PoolsResponse, opts *ListOptions) {
+	if opts != nil && len(opts.Fields) > 0 {
+		Project(resp.Pools, opts.Fields...)
+	}
 }
 
 // addOptions adds the parameters in opts as URL query parameters to s.
 func addOptions(s string, opts interface{}) (string, error) {
-	v := url.Values{}
-
+	q := newQuery(s)
 	if o, ok := opts.(*ListOptions); ok {
-		if o.Page > 0 {
-			v.Add("page", fmt.Sprintf("%d", o.Page))
-		}
-		if o.Limit > 0 {
-			v.Add("limit", fmt.Sprintf("%d", o.Limit))
-		}
-		if o.Sort != "" {
-			v.Add("sort", o.Sort)
-		}
-		if o.OrderBy != "" {
-			v.Add("order_by", o.OrderBy)
-		}
+		q.addListOptions(o)
 	}
-
-	if len(v) > 0 {
-		return s + "?" + v.Encode(), nil
-	}
-	return s, nil
+	return q.String(), nil
 }
 
 // List returns a list of top pools from all networks.
 // Implements the getTopPools operation from the OpenAPI spec.
-func (s *PoolsService) List(ctx context.Context, opts *ListOptions) (*PoolsResponse, error) {
+func (s *PoolsService) List(ctx context.Context, opts *ListOptions, reqOpts ...RequestOption) (*PoolsResponse, error) {
 	path, err := addOptions("/pools", opts)
 	if err != nil {
 		return nil, err
@@ -95,18 +60,25 @@ func (s *PoolsService) List(ctx context.Context, opts *ListOptions) (*PoolsRespo
 	}
 
 	var response PoolsResponse
-	r, err := s.client.Do(ctx, req, &response)
+	cfg := newRequestConfig(reqOpts)
+	r, err := s.client.Do(ctx, req, cfg.decodeInto(&response))
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
+	if cfg.target != nil {
+		return nil, nil
+	}
+
+	projectPools(&response, opts)
+
 	return &response, nil
 }
 
 // ListByNetwork returns a list of top pools on a specific network.
 // Implements the getNetworkPools operation from the OpenAPI spec.
-func (s *PoolsService) ListByNetwork(ctx context.Context, networkID string, opts *ListOptions) (*PoolsResponse, error) {
+func (s *PoolsService) ListByNetwork(ctx context.Context, networkID string, opts *ListOptions, reqOpts ...RequestOption) (*PoolsResponse, error) {
 	path, err := addOptions(fmt.Sprintf("/networks/%s/pools", networkID), opts)
 	if err != nil {
 		return nil, err
@@ -118,18 +90,25 @@ func (s *PoolsService) ListByNetwork(ctx context.Context, networkID string, opts
 	}
 
 	var response PoolsResponse
-	r, err := s.client.Do(ctx, req, &response)
+	cfg := newRequestConfig(reqOpts)
+	r, err := s.client.Do(ctx, req, cfg.decodeInto(&response))
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
+	if cfg.target != nil {
+		return nil, nil
+	}
+
+	projectPools(&response, opts)
+
 	return &response, nil
 }
 
 // ListByDex returns a list of top pools on a specific network's DEX.
 // Implements the getDexPools operation from the OpenAPI spec.
-func (s *PoolsService) ListByDex(ctx context.Context, networkID, dexID string, opts *ListOptions) (*PoolsResponse, error) {
+func (s *PoolsService) ListByDex(ctx context.Context, networkID, dexID string, opts *ListOptions, reqOpts ...RequestOption) (*PoolsResponse, error) {
 	path, err := addOptions(fmt.Sprintf("/networks/%s/dexes/%s/pools", networkID, dexID), opts)
 	if err != nil {
 		return nil, err
@@ -141,183 +120,216 @@ func (s *PoolsService) ListByDex(ctx context.Context, networkID, dexID string, o
 	}
 
 	var response PoolsResponse
-	r, err := s.client.Do(ctx, req, &response)
+	cfg := newRequestConfig(reqOpts)
+	r, err := s.client.Do(ctx, req, cfg.decodeInto(&response))
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
+	if cfg.target != nil {
+		return nil, nil
+	}
+
+	projectPools(&response, opts)
+
 	return &response, nil
 }
 
-// TimeIntervalMetrics represents metrics for a specific time interval.
-type TimeIntervalMetrics struct {
-	LastPriceUSDChange float64 `json:"last_price_usd_change"`
-	VolumeUSD          float64 `json:"volume_usd"`
-	BuyUSD             float64 `json:"buy_usd"`
-	SellUSD            float64 `json:"sell_usd"`
-	Sells              int     `json:"sells"`
-	Buys               int     `json:"buys"`
-	Txns               int     `json:"txns"`
+// GetByTokenPair returns the pools on a network that trade tokenA against
+// tokenB, best pool first. Unless opts sets OrderBy, pools are requested by
+// 24h volume and the page is re-sorted by volume, then transaction count, so
+// the most active pool for the pair comes first.
+func (s *PoolsService) GetByTokenPair(ctx context.Context, networkID, tokenA, tokenB string, opts *ListOptions, reqOpts ...RequestOption) (*PoolsResponse, error) {
+	var pairOpts ListOptions
+	if opts != nil {
+		pairOpts = *opts
+	}
+	ranked := pairOpts.OrderBy == ""
+	if ranked {
+		pairOpts.OrderBy = "volume_usd"
+		pairOpts.Sort = "desc"
+	}
+
+	resp, err := s.client.Tokens.GetPools(ctx, networkID, tokenA, &pairOpts, tokenB, reqOpts...)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	if ranked {
+		sort.SliceStable(resp.Pools, func(i, j int) bool {
+			a, b := resp.Pools[i], resp.Pools[j]
+			if a.VolumeUSD != b.VolumeUSD {
+				return a.VolumeUSD > b.VolumeUSD
+			}
+			return a.Transactions > b.Transactions
+		})
+	}
+
+	return resp, nil
 }
 
 // PoolDetails represents detailed information about a pool.
 type PoolDetails struct {
-	ID                   string              `json:"id"`
-	CreatedAtBlockNumber int64               `json:"created_at_block_number"`
-	Chain                string              `json:"chain"`
-	CreatedAt            string              `json:"created_at"`
-	FactoryID            string              `json:"factory_id"`
-	DexID                string              `json:"dex_id"`
-	DexName              string              `json:"dex_name"`
-	Tokens               []Token             `json:"tokens"`
-	LastPrice            float64             `json:"last_price"`
-	LastPriceUSD         float64             `json:"last_price_usd"`
-	Fee                  float64             `json:"fee"`
-	PriceTime            string              `json:"price_time"`
-	Day                  TimeIntervalMetrics `json:"24h"`
-	Hour6                TimeIntervalMetrics `json:"6h"`
-	Hour1                TimeIntervalMetrics `json:"1h"`
-	Minute30             TimeIntervalMetrics `json:"30m"`
-	Minute15             TimeIntervalMetrics `json:"15m"`
-	Minute5              TimeIntervalMetrics `json:"5m"`
+	ID                   string              `json:"id" csv:"id"`
+	CreatedAtBlockNumber int64               `json:"created_at_block_number" csv:"created_at_block_number"`
+	Chain                string              `json:"chain" csv:"chain"`
+	CreatedAt            string              `json:"created_at" csv:"created_at"`
+	FactoryID            string              `json:"factory_id" csv:"factory_id"`
+	DexID                string              `json:"dex_id" csv:"dex_id"`
+	DexName              string              `json:"dex_name" csv:"dex_name"`
+	Tokens               []Token             `json:"tokens" csv:"token"`
+	LastPrice            float64             `json:"last_price" csv:"last_price"`
+	LastPriceUSD         float64             `json:"last_price_usd" csv:"last_price_usd"`
+	Fee                  float64             `json:"fee" csv:"fee"`
+	PriceTime            string              `json:"price_time" csv:"price_time"`
+	LiquidityUSD         *float64            `json:"liquidity_usd,omitempty" csv:"liquidity_usd"`
+	TokenReserves        []TokenReserve      `json:"token_reserves,omitempty" csv:"token_reserves"`
+	Day                  TimeIntervalMetrics `json:"24h" csv:"24h"`
+	Hour6                TimeIntervalMetrics `json:"6h" csv:"6h"`
+	Hour1                TimeIntervalMetrics `json:"1h" csv:"1h"`
+	Minute30             TimeIntervalMetrics `json:"30m" csv:"30m"`
+	Minute15             TimeIntervalMetrics `json:"15m" csv:"15m"`
+	Minute5              TimeIntervalMetrics `json:"5m" csv:"5m"`
+
+	// Inversed records whether prices were requested with inversed
+	// orientation. The API does not return it; the SDK sets it so that it
+	// survives snapshots and other JSON round-trips.
+	Inversed bool `json:"inversed,omitempty" csv:"-"`
+}
+
+// PoolDetailsOptions contains options for retrieving pool details.
+type PoolDetailsOptions struct {
+	// Inversed requests prices of the pool's second token in terms of the first.
+	Inversed bool
+
+	// QuoteToken, given as a symbol or address, picks the orientation so
+	// that prices are denominated in this token (e.g. "USDC"). It takes
+	// precedence over Inversed. When the pool's token order is not known in
+	// advance this may cost a second request; use Pool.InversedFor to decide
+	// the orientation from a pool listing instead.
+	QuoteToken string
 }
 
 // GetDetails returns details about a specific pool on a network.
 // Implements the getPoolDetails operation from the OpenAPI spec.
-func (s *PoolsService) GetDetails(ctx context.Context, networkID, poolAddress string, inversed bool) (*PoolDetails, error) {
-	path := fmt.Sprintf("/networks/%s/pools/%s", networkID, poolAddress)
+func (s *PoolsService) GetDetails(ctx context.Context, networkID, poolAddress string, opts *PoolDetailsOptions, reqOpts ...RequestOption) (*PoolDetails, error) {
+	if opts == nil || opts.QuoteToken == "" {
+		return s.getDetails(ctx, networkID, poolAddress, opts != nil && opts.Inversed, reqOpts)
+	}
 
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
-	if err != nil {
+	// The orientation is only known once the token order is, so the first
+	// response is kept raw and decoded into the caller's target, if any,
+	// only when it turns out to be the one wanted.
+	var raw json.RawMessage
+	if _, err := s.getDetails(ctx, networkID, poolAddress, false, append(reqOpts, WithTarget(&raw))); err != nil {
 		return nil, err
 	}
-
+	var details PoolDetails
+	if err := json.Unmarshal(raw, &details); err != nil {
+		return nil, &APIError{Err: fmt.Errorf("error decoding response body: %w", err), RawResponse: raw}
+	}
+	inversed, ok := inversedFor(details.Tokens, opts.QuoteToken)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a token of pool %s", ErrTokenNotFound, opts.QuoteToken, poolAddress)
+	}
 	if inversed {
-		q := req.URL.Query()
-		q.Add("inversed", "true")
-		req.URL.RawQuery = q.Encode()
+		return s.getDetails(ctx, networkID, poolAddress, true, reqOpts)
+	}
+	if cfg := newRequestConfig(reqOpts); cfg.target != nil {
+		if err := json.Unmarshal(raw, cfg.target); err != nil {
+			return nil, &APIError{Err: fmt.Errorf("error decoding response body: %w", err), RawResponse: raw}
+		}
+		return nil, nil
+	}
+	s.client.fillTokens(ctx, networkID, details.Tokens)
+	return &details, nil
+}
+
+// getDetails fetches pool details in the given orientation.
+func (s *PoolsService) getDetails(ctx context.Context, networkID, poolAddress string, inversed bool, reqOpts []RequestOption) (*PoolDetails, error) {
+	q := newQuery(fmt.Sprintf("/networks/%s/pools/%s", networkID, NormalizeAddress(networkID, poolAddress)))
+	q.addBool("inversed", inversed)
+
+	req, err := s.client.NewRequest(http.MethodGet, q.String(), nil)
+	if err != nil {
+		return nil, err
 	}
 
 	var response PoolDetails
-	r, err := s.client.Do(ctx, req, &response)
+	cfg := newRequestConfig(reqOpts)
+	r, err := s.client.Do(ctx, req, cfg.decodeInto(&response))
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
-	return &response, nil
-}
+	if cfg.target != nil {
+		return nil, nil
+	}
 
-// OHLCVRecord represents a single OHLCV (Open-High-Low-Close-Volume) data point.
-type OHLCVRecord struct {
-	TimeOpen  string  `json:"time_open"`
-	TimeClose string  `json:"time_close"`
-	Open      float64 `json:"open"`
-	High      float64 `json:"high"`
-	Low       float64 `json:"low"`
-	Close     float64 `json:"close"`
-	Volume    int64   `json:"volume"`
-}
+	response.Inversed = inversed
+	s.client.fillTokens(ctx, networkID, response.Tokens)
 
-// OHLCVOptions contains options for retrieving OHLCV data.
-type OHLCVOptions struct {
-	Start    string
-	End      string
-	Limit    int
-	Interval string
-	Inversed bool
+	return &response, nil
 }
 
 // GetOHLCV returns OHLCV data for a specific pool.
 // Implements the getPoolOHLCV operation from the OpenAPI spec.
-func (s *PoolsService) GetOHLCV(ctx context.Context, networkID, poolAddress string, opts *OHLCVOptions) ([]OHLCVRecord, error) {
-	path := fmt.Sprintf("/networks/%s/pools/%s/ohlcv", networkID, poolAddress)
+func (s *PoolsService) GetOHLCV(ctx context.Context, networkID, poolAddress string, opts *OHLCVOptions, reqOpts ...RequestOption) ([]OHLCVRecord, error) {
+	q := newQuery(fmt.Sprintf("/networks/%s/pools/%s/ohlcv", networkID, NormalizeAddress(networkID, poolAddress)))
+	if opts != nil {
+		q.addString("end", opts.End)
+		q.addString("interval", opts.Interval)
+		q.addBool("inversed", opts.Inversed)
+		q.addInt("limit", opts.Limit)
+		q.addString("start", opts.Start)
+	}
 
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(http.MethodGet, q.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	q := req.URL.Query()
-	if opts != nil {
-		if opts.Start != "" {
-			q.Add("start", opts.Start)
-		}
-		if opts.End != "" {
-			q.Add("end", opts.End)
-		}
-		if opts.Limit > 0 {
-			q.Add("limit", fmt.Sprintf("%d", opts.Limit))
-		}
-		if opts.Interval != "" {
-			q.Add("interval", opts.Interval)
-		}
-		if opts.Inversed {
-			q.Add("inversed", "true")
-		}
-	}
-	req.URL.RawQuery = q.Encode()
-
 	var response []OHLCVRecord
-	r, err := s.client.Do(ctx, req, &response)
+	cfg := newRequestConfig(reqOpts)
+	r, err := s.client.Do(ctx, req, cfg.decodeInto(&response))
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
-	return response, nil
-}
-
-// Transaction represents a transaction of a pool.
-type Transaction struct {
-	ID                   string      `json:"id"`
-	LogIndex             int         `json:"log_index"`
-	TransactionIndex     int         `json:"transaction_index"`
-	PoolID               string      `json:"pool_id"`
-	Sender               string      `json:"sender"`
-	Recipient            string      `json:"recipient"`
-	Token0               string      `json:"token_0"`
-	Token1               string      `json:"token_1"`
-	Amount0              interface{} `json:"amount_0"`
-	Amount1              interface{} `json:"amount_1"`
-	CreatedAtBlockNumber int64       `json:"created_at_block_number"`
-}
+	if cfg.target != nil {
+		return nil, nil
+	}
 
-// TransactionsResponse represents the response for the transactions endpoint.
-type TransactionsResponse struct {
-	Transactions []Transaction `json:"transactions"`
-	PageInfo     PageInfo      `json:"page_info"`
+	return response, nil
 }
 
 // GetTransactions returns transactions of a pool on a network.
 // Implements the getPoolTransactions operation from the OpenAPI spec.
-func (s *PoolsService) GetTransactions(ctx context.Context, networkID, poolAddress string, page, limit int, cursor string) (*TransactionsResponse, error) {
-	path := fmt.Sprintf("/networks/%s/pools/%s/transactions", networkID, poolAddress)
+func (s *PoolsService) GetTransactions(ctx context.Context, networkID, poolAddress string, page, limit int, cursor string, reqOpts ...RequestOption) (*TransactionsResponse, error) {
+	q := newQuery(fmt.Sprintf("/networks/%s/pools/%s/transactions", networkID, NormalizeAddress(networkID, poolAddress)))
+	q.addString("cursor", cursor)
+	q.addInt("limit", limit)
+	q.addInt("page", page)
 
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(http.MethodGet, q.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	q := req.URL.Query()
-	if page > 0 {
-		q.Add("page", fmt.Sprintf("%d", page))
-	}
-	if limit > 0 {
-		q.Add("limit", fmt.Sprintf("%d", limit))
-	}
-	if cursor != "" {
-		q.Add("cursor", cursor)
-	}
-	req.URL.RawQuery = q.Encode()
-
 	var response TransactionsResponse
-	r, err := s.client.Do(ctx, req, &response)
+	cfg := newRequestConfig(reqOpts)
+	r, err := s.client.Do(ctx, req, cfg.decodeInto(&response))
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
+	if cfg.target != nil {
+		return nil, nil
+	}
+
 	return &response, nil
 }
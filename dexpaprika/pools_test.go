@@ -139,7 +139,7 @@ func TestPools_GetDetails(t *testing.T) {
 	poolID := "0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640"
 
 	// Test getting pool details
-	details, err := client.Pools.GetDetails(ctx, networkID, poolID, false)
+	details, err := client.Pools.GetDetails(ctx, networkID, poolID, nil)
 	if err != nil {
 		t.Fatalf("Pools.GetDetails returned error: %v", err)
 	}
@@ -529,3 +529,60 @@ func TestPools_GetTransactionsWithMock(t *testing.T) {
 		})
 	}
 }
+
+func TestPools_GetByTokenPairWithMock(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/networks/ethereum/tokens/0xweth/pools" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"pools": [
+			{"id": "0xsmall", "volume_usd": 10, "transactions": 5},
+			{"id": "0xbig", "volume_usd": 5000, "transactions": 50},
+			{"id": "0xtie", "volume_usd": 10, "transactions": 9}
+		], "page_info": {"limit": 10, "page": 0, "total_items": 3, "total_pages": 1}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, 1*time.Millisecond, 1*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := &ListOptions{Limit: 10}
+	resp, err := client.Pools.GetByTokenPair(ctx, "ethereum", "0xWETH", "0xUSDC", opts)
+	if err != nil {
+		t.Fatalf("GetByTokenPair returned error: %v", err)
+	}
+	if query != "address=0xusdc&limit=10&order_by=volume_usd&sort=desc" {
+		t.Errorf("Unexpected query %q", query)
+	}
+	if opts.OrderBy != "" {
+		t.Error("GetByTokenPair should not modify the caller's options")
+	}
+
+	var ids []string
+	for _, p := range resp.Pools {
+		ids = append(ids, p.ID)
+	}
+	if fmt.Sprint(ids) != "[0xbig 0xtie 0xsmall]" {
+		t.Errorf("Unexpected pool order %v", ids)
+	}
+
+	// An explicit ordering is passed through and kept as returned
+	resp, err = client.Pools.GetByTokenPair(ctx, "ethereum", "0xweth", "0xusdc", &ListOptions{OrderBy: "created_at", Sort: "asc"})
+	if err != nil {
+		t.Fatalf("GetByTokenPair returned error: %v", err)
+	}
+	if query != "address=0xusdc&order_by=created_at&sort=asc" {
+		t.Errorf("Unexpected query %q", query)
+	}
+	if resp.Pools[0].ID != "0xsmall" {
+		t.Errorf("Expected API order to be kept, got %s first", resp.Pools[0].ID)
+	}
+}
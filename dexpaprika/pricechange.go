@@ -0,0 +1,124 @@
+package dexpaprika
+
+import "math"
+
+// Trend describes the direction of a price movement.
+type Trend int
+
+// Price trends.
+const (
+	TrendFlat Trend = iota
+	TrendUp
+	TrendDown
+)
+
+// String returns a lowercase name for the trend.
+func (t Trend) String() string {
+	switch t {
+	case TrendUp:
+		return "up"
+	case TrendDown:
+		return "down"
+	default:
+		return "flat"
+	}
+}
+
+// trendFlatPercent is the absolute percentage change below which a price is considered flat.
+const trendFlatPercent = 0.01
+
+// trendOf classifies a percentage change.
+func trendOf(percent float64) Trend {
+	switch {
+	case percent >= trendFlatPercent:
+		return TrendUp
+	case percent <= -trendFlatPercent:
+		return TrendDown
+	default:
+		return TrendFlat
+	}
+}
+
+// previousPrice derives the price at the start of an interval from the
+// current price and the percentage change over the interval.
+func previousPrice(current, percent float64) (float64, bool) {
+	divisor := 1 + percent/100
+	if divisor == 0 || math.IsNaN(divisor) {
+		return 0, false
+	}
+	return current / divisor, true
+}
+
+// PriceChangePercent returns the USD price change over the interval, in percent.
+func (s *TokenSummary) PriceChangePercent(interval string) (float64, bool) {
+	m, ok := s.Metrics(interval)
+	if !ok {
+		return 0, false
+	}
+	return m.LastPriceUSDChange, true
+}
+
+// PreviousPriceUSD returns the USD price at the start of the interval.
+func (s *TokenSummary) PreviousPriceUSD(interval string) (float64, bool) {
+	percent, ok := s.PriceChangePercent(interval)
+	if !ok {
+		return 0, false
+	}
+	return previousPrice(s.PriceUSD, percent)
+}
+
+// TrendFor returns the price direction over the interval, or TrendFlat if
+// the interval is not available.
+func (s *TokenSummary) TrendFor(interval string) Trend {
+	percent, _ := s.PriceChangePercent(interval)
+	return trendOf(percent)
+}
+
+// Trend returns the price direction over the last 24 hours.
+func (s *TokenSummary) Trend() Trend {
+	return s.TrendFor(Interval24h)
+}
+
+// PriceChangePercent returns the USD price change over the interval, in percent.
+func (p *PoolDetails) PriceChangePercent(interval string) (float64, bool) {
+	m, ok := p.Metrics(interval)
+	if !ok {
+		return 0, false
+	}
+	return m.LastPriceUSDChange, true
+}
+
+// PreviousPriceUSD returns the USD price at the start of the interval.
+func (p *PoolDetails) PreviousPriceUSD(interval string) (float64, bool) {
+	percent, ok := p.PriceChangePercent(interval)
+	if !ok {
+		return 0, false
+	}
+	return previousPrice(p.LastPriceUSD, percent)
+}
+
+// TrendFor returns the price direction over the interval, or TrendFlat if
+// the interval is not available.
+func (p *PoolDetails) TrendFor(interval string) Trend {
+	percent, _ := p.PriceChangePercent(interval)
+	return trendOf(percent)
+}
+
+// Trend returns the price direction over the last 24 hours.
+func (p *PoolDetails) Trend() Trend {
+	return p.TrendFor(Interval24h)
+}
+
+// PriceChangePercent returns the USD price change over the interval, in
+// percent. Pool listings only carry 5m, 1h and 24h changes.
+func (p *Pool) PriceChangePercent(interval string) (float64, bool) {
+	switch interval {
+	case Interval24h:
+		return p.LastPriceChangeUSD24h, true
+	case Interval1h:
+		return p.LastPriceChangeUSD1h, true
+	case Interval5m:
+		return p.LastPriceChangeUSD5m, true
+	}
+	return 0, false
+}
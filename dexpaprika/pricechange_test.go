@@ -0,0 +1,68 @@
+package dexpaprika
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTokenSummary_PriceChange(t *testing.T) {
+	summary := &TokenSummary{
+		PriceUSD: 110,
+		Day:      &TimeIntervalMetrics{LastPriceUSDChange: 10},
+		Hour1:    &TimeIntervalMetrics{LastPriceUSDChange: -2.5},
+		Minute5:  &TimeIntervalMetrics{LastPriceUSDChange: 0.001},
+	}
+
+	if pct, ok := summary.PriceChangePercent(Interval24h); !ok || pct != 10 {
+		t.Errorf("PriceChangePercent(24h) = %f, %t, want 10, true", pct, ok)
+	}
+	if _, ok := summary.PriceChangePercent(Interval6h); ok {
+		t.Error("PriceChangePercent(6h) should be unavailable")
+	}
+
+	prev, ok := summary.PreviousPriceUSD(Interval24h)
+	if !ok || math.Abs(prev-100) > 1e-9 {
+		t.Errorf("PreviousPriceUSD(24h) = %f, %t, want 100, true", prev, ok)
+	}
+
+	tests := []struct {
+		interval string
+		want     Trend
+	}{
+		{Interval24h, TrendUp},
+		{Interval1h, TrendDown},
+		{Interval5m, TrendFlat},
+		{Interval6h, TrendFlat},
+	}
+	for _, tc := range tests {
+		if got := summary.TrendFor(tc.interval); got != tc.want {
+			t.Errorf("TrendFor(%s) = %s, want %s", tc.interval, got, tc.want)
+		}
+	}
+	if summary.Trend() != TrendUp {
+		t.Errorf("Trend() = %s, want up", summary.Trend())
+	}
+
+	var nilSummary *TokenSummary
+	if nilSummary.Trend() != TrendFlat {
+		t.Error("Trend() on nil summary should be flat")
+	}
+}
+
+func TestPoolDetails_PriceChange(t *testing.T) {
+	details := &PoolDetails{
+		LastPriceUSD: 50,
+		Day:          TimeIntervalMetrics{LastPriceUSDChange: -50},
+		Hour1:        TimeIntervalMetrics{LastPriceUSDChange: -100},
+	}
+
+	if details.Trend() != TrendDown {
+		t.Errorf("Trend() = %s, want down", details.Trend())
+	}
+	if prev, ok := details.PreviousPriceUSD(Interval24h); !ok || prev != 100 {
+		t.Errorf("PreviousPriceUSD(24h) = %f, %t, want 100, true", prev, ok)
+	}
+	if _, ok := details.PreviousPriceUSD(Interval1h); ok {
+		t.Error("PreviousPriceUSD should fail for a -100% change")
+	}
+}
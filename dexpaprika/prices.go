@@ -0,0 +1,148 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PricesService provides token prices derived from the pools that trade them.
+type PricesService struct {
+	client *Client
+}
+
+// ErrNoPrice is returned when none of a token's pools yields a usable price.
+var ErrNoPrice = errors.New("no price available")
+
+// Price is the USD price of a token at a point in time.
+type Price struct {
+	Network  string    `json:"network" csv:"network"`
+	Token    string    `json:"token" csv:"token"`
+	PriceUSD float64   `json:"price_usd" csv:"price_usd"`
+	Time     time.Time `json:"time" csv:"time"`
+
+	// Pools lists the addresses of the pools the price was derived from
+	Pools []string `json:"pools" csv:"-"`
+}
+
+// pricePools is the number of a token's most active pools that contribute
+// to its price.
+const pricePools = 3
+
+// Get returns the current USD price of a token. The price is the average of
+// the token's price in its most active pools, weighted by each pool's
+// liquidity (or its 24h volume where liquidity is not reported), so a single
+// thin or manipulated pool cannot dominate. Time is the most recent price
+// time reported by those pools.
+func (s *PricesService) Get(ctx context.Context, networkID, tokenAddress string) (*Price, error) {
+	pools, err := s.client.Tokens.GetPools(ctx, networkID, tokenAddress, &ListOptions{
+		Limit:   pricePools,
+		OrderBy: "volume_usd",
+		Sort:    "desc",
+	}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	price := &Price{Network: networkID, Token: tokenAddress}
+	var weighted, totalWeight float64
+	for _, p := range pools.Pools {
+		details, err := s.client.Pools.GetDetails(ctx, networkID, p.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		usd, ok := details.PriceUSDOf(NormalizeAddress(networkID, tokenAddress))
+		if !ok || usd <= 0 {
+			continue
+		}
+		weight, _ := details.GetLiquidityUSD()
+		if weight <= 0 {
+			weight = details.Day.VolumeUSD
+		}
+		if weight <= 0 {
+			continue
+		}
+
+		weighted += usd * weight
+		totalWeight += weight
+		price.Pools = append(price.Pools, details.ID)
+		if t, err := time.Parse(time.RFC3339, details.PriceTime); err == nil && t.After(price.Time) {
+			price.Time = t
+		}
+	}
+
+	if totalWeight == 0 {
+		return nil, fmt.Errorf("%w: %s on %s", ErrNoPrice, tokenAddress, networkID)
+	}
+	price.PriceUSD = weighted / totalWeight
+
+	return price, nil
+}
+
+// At returns the USD price of a token at time t: the close of the candle
+// covering t, or else the nearest candle, in the token's most active pool.
+// The candle interval is chosen from the age of t, from one minute for the
+// last day to one day for times older than 90 days. Time is the candle's
+// open time.
+func (s *PricesService) At(ctx context.Context, networkID, tokenAddress string, t time.Time) (*Price, error) {
+	pools, err := s.client.Tokens.GetPools(ctx, networkID, tokenAddress, &ListOptions{
+		Limit:   1,
+		OrderBy: "volume_usd",
+		Sort:    "desc",
+	}, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(pools.Pools) == 0 {
+		return nil, fmt.Errorf("%w: %s on %s has no pools", ErrNoPrice, tokenAddress, networkID)
+	}
+	pool := pools.Pools[0]
+
+	// Candles price the pool's first token; inverse them for the second
+	inversed := findToken(pool.Tokens, NormalizeAddress(networkID, tokenAddress)) == 1
+
+	interval := ohlcvIntervalFor(time.Since(t))
+	step := ohlcvDurations[interval]
+	candles, err := s.client.Pools.GetOHLCV(ctx, networkID, pool.ID, &OHLCVOptions{
+		Start:    t.Add(-2 * step).UTC().Format(time.RFC3339),
+		End:      t.Add(2 * step).UTC().Format(time.RFC3339),
+		Limit:    5,
+		Interval: interval,
+		Inversed: inversed,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	best, bestTime, found := OHLCVRecord{}, time.Time{}, false
+	var bestDistance time.Duration
+	for _, c := range candles {
+		open, err := c.OpenTime()
+		if err != nil {
+			continue
+		}
+		distance := t.Sub(open)
+		if distance < 0 {
+			distance = -distance
+		}
+		if !t.Before(open) && t.Before(open.Add(step)) {
+			distance = 0
+		}
+		if !found || distance < bestDistance {
+			best, bestTime, bestDistance, found = c, open, distance, true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: no %s candles for %s near %s", ErrNoPrice, interval, tokenAddress, t.Format(time.RFC3339))
+	}
+
+	return &Price{
+		Network:  networkID,
+		Token:    tokenAddress,
+		PriceUSD: best.Close,
+		Time:     bestTime,
+		Pools:    []string{pool.ID},
+	}, nil
+}
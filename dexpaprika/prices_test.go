@@ -0,0 +1,196 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newPricesTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	const weth = `{"id": "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", "symbol": "WETH"}`
+	const usdc = `{"id": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "symbol": "USDC"}`
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/networks/ethereum/tokens/0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2/pools":
+			if r.URL.Query().Get("order_by") != "volume_usd" {
+				t.Errorf("Expected pools ordered by volume, got %s", r.URL.RawQuery)
+			}
+			fmt.Fprintln(w, `{"pools": [{"id": "0xpool1"}, {"id": "0xpool2"}, {"id": "0xpool3"}], "page_info": {"limit": 3, "total_pages": 1}}`)
+		case "/networks/ethereum/tokens/0xdead/pools":
+			fmt.Fprintln(w, `{"pools": [], "page_info": {"limit": 3, "total_pages": 0}}`)
+		case "/networks/ethereum/pools/0xpool1":
+			// WETH is the base token, weighted by liquidity
+			fmt.Fprintf(w, `{"id": "0xpool1", "last_price": 3000, "last_price_usd": 3000, "liquidity_usd": 3000000, "price_time": "2024-05-01T10:00:00Z", "tokens": [%s, %s]}`+"\n", weth, usdc)
+		case "/networks/ethereum/pools/0xpool2":
+			// WETH is the quote token, weighted by 24h volume
+			fmt.Fprintf(w, `{"id": "0xpool2", "last_price": 0.0003125, "last_price_usd": 1, "price_time": "2024-05-01T10:05:00Z", "24h": {"volume_usd": 1000000}, "tokens": [%s, %s]}`+"\n", usdc, weth)
+		case "/networks/ethereum/pools/0xpool3":
+			// No liquidity or volume: ignored
+			fmt.Fprintf(w, `{"id": "0xpool3", "last_price": 1, "last_price_usd": 99999, "tokens": [%s, %s]}`+"\n", weth, usdc)
+		default:
+			t.Errorf("Unexpected path %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestPrices_Get(t *testing.T) {
+	server := newPricesTestServer(t)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+	ctx := context.Background()
+
+	price, err := client.Prices.Get(ctx, "ethereum", "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	if err != nil {
+		t.Fatalf("Prices.Get returned error: %v", err)
+	}
+
+	// (3000 * 3M + 3200 * 1M) / 4M
+	if math.Abs(price.PriceUSD-3050) > 1e-6 {
+		t.Errorf("Expected weighted price 3050, got %f", price.PriceUSD)
+	}
+	if len(price.Pools) != 2 || price.Pools[0] != "0xpool1" || price.Pools[1] != "0xpool2" {
+		t.Errorf("Unexpected pools %v", price.Pools)
+	}
+	if want := time.Date(2024, 5, 1, 10, 5, 0, 0, time.UTC); !price.Time.Equal(want) {
+		t.Errorf("Expected time %v, got %v", want, price.Time)
+	}
+
+	if _, err := client.Prices.Get(ctx, "ethereum", "0xdead"); !errors.Is(err, ErrNoPrice) {
+		t.Errorf("Expected ErrNoPrice, got %v", err)
+	}
+}
+
+func TestPrices_Rate(t *testing.T) {
+	const (
+		weth = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
+		usdc = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+		pepe = "0x6982508145454ce325ddbe47a25d4ec3d2311933"
+		link = "0x514910771af9ca656af840dff83e8264ecf986ca"
+	)
+	token := func(id string) string { return fmt.Sprintf(`{"id": %q}`, id) }
+	pairs := map[string]string{
+		pepe + "/" + weth: "0xpepeweth",
+		weth + "/" + link: "0xwethlink",
+	}
+	details := map[string]string{
+		// 1 PEPE = 0.000000004 WETH
+		"0xpepeweth": fmt.Sprintf(`{"id": "0xpepeweth", "last_price": 0.000000004, "tokens": [%s, %s]}`, token(pepe), token(weth)),
+		// LINK is the base: 1 LINK = 0.005 WETH, so 1 WETH = 200 LINK
+		"0xwethlink": fmt.Sprintf(`{"id": "0xwethlink", "last_price": 0.005, "tokens": [%s, %s]}`, token(link), token(weth)),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var a, b string
+		if n, _ := fmt.Sscanf(r.URL.Path, "/networks/ethereum/tokens/%42s", &a); n == 1 {
+			b = r.URL.Query().Get("address")
+			pool, ok := pairs[a+"/"+b]
+			if !ok {
+				pool = pairs[b+"/"+a]
+			}
+			if pool == "" {
+				fmt.Fprintln(w, `{"pools": [], "page_info": {}}`)
+				return
+			}
+			fmt.Fprintf(w, `{"pools": [{"id": %q}], "page_info": {}}`+"\n", pool)
+			return
+		}
+		var pool string
+		if _, err := fmt.Sscanf(r.URL.Path, "/networks/ethereum/pools/%s", &pool); err == nil {
+			fmt.Fprintln(w, details[pool])
+			return
+		}
+		t.Errorf("Unexpected path %q", r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+	ctx := context.Background()
+
+	direct, err := client.Prices.Rate(ctx, "ethereum", weth, link)
+	if err != nil {
+		t.Fatalf("Rate returned error: %v", err)
+	}
+	if math.Abs(direct.Rate-200) > 1e-9 || direct.Via != "" || len(direct.Pools) != 1 {
+		t.Errorf("Unexpected direct rate %+v", direct)
+	}
+
+	routed, err := client.Prices.Rate(ctx, "ethereum", pepe, link)
+	if err != nil {
+		t.Fatalf("Rate returned error: %v", err)
+	}
+	if math.Abs(routed.Rate-0.0000008) > 1e-15 || routed.Via != weth {
+		t.Errorf("Unexpected routed rate %+v", routed)
+	}
+	if fmt.Sprint(routed.Pools) != "[0xpepeweth 0xwethlink]" {
+		t.Errorf("Unexpected route %v", routed.Pools)
+	}
+
+	if _, err := client.Prices.Rate(ctx, "ethereum", pepe, usdc); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("Expected ErrNoRoute, got %v", err)
+	}
+}
+
+func TestPrices_At(t *testing.T) {
+	at := time.Now().Add(-48 * time.Hour).Truncate(15 * time.Minute).Add(7 * time.Minute)
+	open := at.Add(-7 * time.Minute)
+	var ohlcvQuery url.Values
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/networks/ethereum/tokens/0xtoken/pools":
+			fmt.Fprintln(w, `{"pools": [{"id": "0xpool", "tokens": [{"id": "0xusdc"}, {"id": "0xtoken"}]}], "page_info": {}}`)
+		case "/networks/ethereum/pools/0xpool/ohlcv":
+			ohlcvQuery = r.URL.Query()
+			var candles []string
+			for i := -2; i <= 1; i++ {
+				o := open.Add(time.Duration(i) * 15 * time.Minute)
+				candles = append(candles, fmt.Sprintf(`{"time_open": %q, "time_close": %q, "close": %d}`,
+					o.UTC().Format(time.RFC3339), o.Add(15*time.Minute).UTC().Format(time.RFC3339), 100+i))
+			}
+			fmt.Fprintf(w, "[%s]\n", strings.Join(candles, ","))
+		default:
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+
+	price, err := client.Prices.At(context.Background(), "ethereum", "0xtoken", at)
+	if err != nil {
+		t.Fatalf("Prices.At returned error: %v", err)
+	}
+	if price.PriceUSD != 100 || !price.Time.Equal(open.Truncate(time.Second)) {
+		t.Errorf("Expected the covering candle (100 at %v), got %f at %v", open, price.PriceUSD, price.Time)
+	}
+	if ohlcvQuery.Get("interval") != OHLCVInterval15m || ohlcvQuery.Get("inversed") != "true" {
+		t.Errorf("Unexpected OHLCV query %v", ohlcvQuery)
+	}
+}
+
+func TestOHLCVIntervalFor(t *testing.T) {
+	tests := map[time.Duration]string{
+		time.Hour:            OHLCVInterval1m,
+		3 * 24 * time.Hour:   OHLCVInterval15m,
+		30 * 24 * time.Hour:  OHLCVInterval1h,
+		400 * 24 * time.Hour: OHLCVInterval24h,
+	}
+	for age, want := range tests {
+		if got := ohlcvIntervalFor(age); got != want {
+			t.Errorf("ohlcvIntervalFor(%v) = %s, want %s", age, got, want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package dexpaprika
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Project zeroes every field of v that is not listed in fields, reducing the
+// memory retained by large result sets. v must be a pointer to a struct or a
+// slice of structs (or pointers to them). Fields are JSON names; dotted paths
+// such as "tokens.symbol" select fields of nested structs and slices, while
+// naming the parent alone ("tokens") keeps it whole. Unknown names are ignored.
+func Project(v interface{}, fields ...string) {
+	if len(fields) == 0 {
+		return
+	}
+	projectValue(reflect.ValueOf(v), newFieldTree(fields))
+}
+
+// fieldTree is a set of selected JSON field names with their selected children.
+// A nil child tree keeps the whole field.
+type fieldTree map[string]fieldTree
+
+func newFieldTree(fields []string) fieldTree {
+	tree := fieldTree{}
+	for _, f := range fields {
+		node := tree
+		parts := strings.Split(strings.TrimSpace(f), ".")
+		for i, part := range parts {
+			child, seen := node[part]
+			if i == len(parts)-1 {
+				// A shorter path selecting the whole field wins
+				node[part] = nil
+				break
+			}
+			if seen && child == nil {
+				break
+			}
+			if child == nil {
+				child = fieldTree{}
+				node[part] = child
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+func projectValue(rv reflect.Value, tree fieldTree) {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !rv.IsNil() {
+			projectValue(rv.Elem(), tree)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			projectValue(rv.Index(i), tree)
+		}
+	case reflect.Struct:
+		t := rv.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			field := rv.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			child, ok := tree[jsonName(f)]
+			switch {
+			case !ok:
+				field.Set(reflect.Zero(f.Type))
+			case child != nil:
+				projectValue(field, child)
+			}
+		}
+	}
+}
+
+// jsonName returns the JSON name of a struct field.
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
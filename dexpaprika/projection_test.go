@@ -0,0 +1,73 @@
+package dexpaprika
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestProject(t *testing.T) {
+	fdv := 10.0
+	pools := []Pool{
+		{
+			ID:        "0x1",
+			DexName:   "Uniswap",
+			PriceUSD:  1.5,
+			VolumeUSD: 100,
+			Tokens:    []Token{{ID: "0xa", Symbol: "WETH", Name: "Wrapped Ether", FDV: &fdv}},
+		},
+	}
+
+	Project(pools, "id", "price_usd", "tokens.symbol")
+
+	p := pools[0]
+	if p.ID != "0x1" || p.PriceUSD != 1.5 {
+		t.Errorf("Selected fields were cleared: %+v", p)
+	}
+	if p.DexName != "" || p.VolumeUSD != 0 {
+		t.Errorf("Unselected fields were kept: %+v", p)
+	}
+	if len(p.Tokens) != 1 || p.Tokens[0].Symbol != "WETH" {
+		t.Fatalf("Expected token symbol to be kept, got %+v", p.Tokens)
+	}
+	if p.Tokens[0].Name != "" || p.Tokens[0].FDV != nil || p.Tokens[0].ID != "" {
+		t.Errorf("Unselected token fields were kept: %+v", p.Tokens[0])
+	}
+
+	// Selecting the parent keeps nested values whole
+	details := &PoolDetails{ID: "0x1", DexName: "Uniswap", Tokens: []Token{{ID: "0xa", Symbol: "WETH"}}}
+	Project(details, "tokens.symbol", "tokens")
+	if details.DexName != "" || details.ID != "" || details.Tokens[0].ID != "0xa" {
+		t.Errorf("Unexpected projection result: %+v", details)
+	}
+}
+
+func TestListOptions_Fields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fields := r.URL.Query().Get("fields"); fields != "" {
+			t.Errorf("Fields should not be sent to the API, got %q", fields)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"pools": [{"id": "0x1", "dex_name": "Uniswap", "price_usd": 2.5}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, 1*time.Millisecond, 1*time.Millisecond),
+	)
+
+	resp, err := client.Pools.ListByNetwork(context.Background(), "ethereum", &ListOptions{Fields: []string{"id", "price_usd"}})
+	if err != nil {
+		t.Fatalf("Pools.ListByNetwork returned error: %v", err)
+	}
+	if len(resp.Pools) != 1 {
+		t.Fatalf("Expected 1 pool, got %d", len(resp.Pools))
+	}
+	if resp.Pools[0].ID != "0x1" || resp.Pools[0].PriceUSD != 2.5 || resp.Pools[0].DexName != "" {
+		t.Errorf("Unexpected projected pool: %+v", resp.Pools[0])
+	}
+}
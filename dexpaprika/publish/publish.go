@@ -0,0 +1,235 @@
+// Package publish forwards events from an events.Bus to a message broker
+// such as Kafka, so watcher and alert events can feed stream processing
+// without custom glue.
+//
+// The package does not depend on a Kafka client. Adapt the producer of your
+// choice to the Producer interface, e.g. for github.com/segmentio/kafka-go:
+//
+//	w := &kafka.Writer{Addr: kafka.TCP("localhost:9092")}
+//	producer := publish.ProducerFunc(func(ctx context.Context, msgs ...publish.Message) error {
+//		out := make([]kafka.Message, len(msgs))
+//		for i, m := range msgs {
+//			out[i] = kafka.Message{Topic: m.Topic, Key: m.Key, Value: m.Value, Time: m.Time}
+//		}
+//		return w.WriteMessages(ctx, out...)
+//	})
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+// ErrBufferFull is passed to Publisher.OnError for events dropped because
+// the producer could not keep up.
+var ErrBufferFull = errors.New("publish buffer full")
+
+// Message is a record sent to a broker.
+type Message struct {
+	Topic   string
+	Key     []byte // Partition key, keeping events of a pool or token in order
+	Value   []byte
+	Headers map[string]string
+	Time    time.Time
+}
+
+// Producer sends messages to a broker.
+type Producer interface {
+	Produce(ctx context.Context, msgs ...Message) error
+}
+
+// ProducerFunc adapts a function to the Producer interface.
+type ProducerFunc func(ctx context.Context, msgs ...Message) error
+
+// Produce calls f(ctx, msgs...).
+func (f ProducerFunc) Produce(ctx context.Context, msgs ...Message) error {
+	return f(ctx, msgs...)
+}
+
+// Envelope is the JSON encoding of an event, with its name alongside so
+// consumers of a shared topic can tell events apart.
+type Envelope struct {
+	Type string       `json:"type"`
+	Data events.Event `json:"data"`
+}
+
+// JSON encodes an event as an Envelope.
+func JSON(e events.Event) ([]byte, error) {
+	return json.Marshal(Envelope{Type: e.EventName(), Data: e})
+}
+
+// Publisher sends events to topics through a Producer.
+type Publisher struct {
+	producer Producer
+	topic    string
+
+	// Topics maps event names, such as "new_transaction", to the topic
+	// they are sent to. Other events go to the publisher's default topic.
+	Topics map[string]string
+
+	// Encode serializes events. It defaults to JSON; set it to an Avro or
+	// Protobuf encoder of the event structs to use a schema registry.
+	Encode func(events.Event) ([]byte, error)
+
+	// Key returns the partition key of an event. It defaults to EventKey.
+	Key func(events.Event) []byte
+
+	// Buffer is the number of events Run queues for the producer. Events
+	// published while it is full are dropped. It defaults to 1000.
+	Buffer int
+
+	// BatchSize caps the messages per Produce call in Run. It defaults
+	// to 100.
+	BatchSize int
+
+	// OnError, if set, is called with every failed delivery in Run.
+	OnError func(error)
+}
+
+// NewPublisher creates a publisher sending events to topic, unless Topics
+// maps them elsewhere.
+func NewPublisher(producer Producer, topic string) *Publisher {
+	return &Publisher{producer: producer, topic: topic, Buffer: 1000, BatchSize: 100}
+}
+
+// Publish sends events synchronously.
+func (p *Publisher) Publish(ctx context.Context, evs ...events.Event) error {
+	msgs := make([]Message, 0, len(evs))
+	for _, e := range evs {
+		msg, err := p.message(e)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return p.producer.Produce(ctx, msgs...)
+}
+
+// flushTimeout bounds the delivery of queued events after Run's context is
+// done.
+const flushTimeout = 5 * time.Second
+
+// Run subscribes to every event of bus and sends them in batches until ctx
+// is done, then delivers the queued events and returns nil. Delivery runs in
+// its own goroutine, so a slow broker never blocks the bus. Failed batches
+// are passed to OnError and not retried; wrap the Producer to retry.
+func (p *Publisher) Run(ctx context.Context, bus *events.Bus) error {
+	buffer := p.Buffer
+	if buffer <= 0 {
+		buffer = 1000
+	}
+	queue := make(chan events.Event, buffer)
+	unsubscribe := bus.SubscribeAll(func(e events.Event) {
+		select {
+		case queue <- e:
+		default:
+			p.fail(fmt.Errorf("%w: dropped %s", ErrBufferFull, e.EventName()))
+		}
+	})
+	defer unsubscribe()
+
+	for {
+		select {
+		case e := <-queue:
+			p.deliver(ctx, p.batch(e, queue))
+		case <-ctx.Done():
+			unsubscribe()
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+			defer cancel()
+			for len(queue) > 0 {
+				p.deliver(flushCtx, p.batch(<-queue, queue))
+			}
+			return nil
+		}
+	}
+}
+
+// batch returns first followed by the queued events, up to BatchSize.
+func (p *Publisher) batch(first events.Event, queue chan events.Event) []events.Event {
+	size := p.BatchSize
+	if size <= 0 {
+		size = 100
+	}
+	batch := []events.Event{first}
+	for len(batch) < size {
+		select {
+		case e := <-queue:
+			batch = append(batch, e)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (p *Publisher) deliver(ctx context.Context, batch []events.Event) {
+	if err := p.Publish(ctx, batch...); err != nil {
+		p.fail(err)
+	}
+}
+
+func (p *Publisher) fail(err error) {
+	if p.OnError != nil {
+		p.OnError(err)
+	}
+}
+
+// message builds the message of e.
+func (p *Publisher) message(e events.Event) (Message, error) {
+	encode := p.Encode
+	if encode == nil {
+		encode = JSON
+	}
+	value, err := encode(e)
+	if err != nil {
+		return Message{}, fmt.Errorf("encode %s: %w", e.EventName(), err)
+	}
+	key := p.Key
+	if key == nil {
+		key = EventKey
+	}
+	topic, ok := p.Topics[e.EventName()]
+	if !ok {
+		topic = p.topic
+	}
+	return Message{
+		Topic:   topic,
+		Key:     key(e),
+		Value:   value,
+		Headers: map[string]string{"event": e.EventName()},
+		Time:    time.Now(),
+	}, nil
+}
+
+// EventKey returns "<network>:<address>" of the pool or token an event is
+// about, so its events share a partition and stay in order, or the alert
+// name for AlertFired. It returns nil for other events.
+func EventKey(e events.Event) []byte {
+	var network, address string
+	switch e := e.(type) {
+	case events.PoolPriceChanged:
+		network, address = e.NetworkID, e.PoolAddress
+	case events.TokenPriceChanged:
+		network, address = e.NetworkID, e.TokenAddress
+	case events.NewPool:
+		network, address = e.NetworkID, e.Pool.ID
+	case events.NewTransaction:
+		network, address = e.NetworkID, e.PoolAddress
+	case events.StaleData:
+		network, address = e.NetworkID, e.PoolAddress
+	case events.AlertFired:
+		return []byte(e.Name)
+	default:
+		return nil
+	}
+	return []byte(network + ":" + dexpaprika.NormalizeAddress(network, address))
+}
@@ -0,0 +1,163 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+// recorder is a Producer keeping the messages it receives.
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]Message
+	err     error
+}
+
+func (r *recorder) Produce(ctx context.Context, msgs ...Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.batches = append(r.batches, msgs)
+	return nil
+}
+
+func (r *recorder) messages() []Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var msgs []Message
+	for _, b := range r.batches {
+		msgs = append(msgs, b...)
+	}
+	return msgs
+}
+
+func TestPublisher_Publish(t *testing.T) {
+	rec := &recorder{}
+	p := NewPublisher(rec, "dexpaprika.events")
+	p.Topics = map[string]string{"new_transaction": "dexpaprika.trades"}
+
+	err := p.Publish(context.Background(),
+		events.PoolPriceChanged{NetworkID: "ethereum", PoolAddress: "0xABC", PriceUSD: 2},
+		events.NewTransaction{NetworkID: "solana", PoolAddress: "PoolAbc"},
+	)
+	if err != nil {
+		t.Fatalf("Publish returned error: %v", err)
+	}
+	msgs := rec.messages()
+	if len(rec.batches) != 1 || len(msgs) != 2 {
+		t.Fatalf("Got %d batches with %d messages, want one batch of 2", len(rec.batches), len(msgs))
+	}
+
+	if msgs[0].Topic != "dexpaprika.events" || string(msgs[0].Key) != "ethereum:0xabc" || msgs[0].Headers["event"] != "pool_price_changed" {
+		t.Errorf("Unexpected message %+v", msgs[0])
+	}
+	var env struct {
+		Type string `json:"type"`
+		Data struct {
+			PriceUSD float64 `json:"price_usd"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(msgs[0].Value, &env); err != nil || env.Type != "pool_price_changed" || env.Data.PriceUSD != 2 {
+		t.Errorf("Unexpected value %s (%v)", msgs[0].Value, err)
+	}
+
+	// Solana addresses are case-sensitive and kept as is
+	if msgs[1].Topic != "dexpaprika.trades" || string(msgs[1].Key) != "solana:PoolAbc" {
+		t.Errorf("Unexpected message %+v", msgs[1])
+	}
+}
+
+func TestPublisher_Encode(t *testing.T) {
+	rec := &recorder{}
+	p := NewPublisher(rec, "events")
+	p.Encode = func(events.Event) ([]byte, error) { return nil, errors.New("no schema") }
+
+	if err := p.Publish(context.Background(), events.AlertFired{Name: "whale"}); err == nil {
+		t.Fatal("Expected the encoding error")
+	}
+	if len(rec.messages()) != 0 {
+		t.Error("Expected nothing to be produced")
+	}
+}
+
+func TestPublisher_Run(t *testing.T) {
+	rec := &recorder{}
+	p := NewPublisher(rec, "events")
+	bus := events.NewBus()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- p.Run(ctx, bus) }()
+
+	// Wait for the subscription before publishing
+	for deadline := time.Now().Add(time.Second); ; {
+		bus.Publish(events.AlertFired{Name: "probe"})
+		if len(rec.messages()) > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Run did not deliver events")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	before := len(rec.messages())
+	for i := 0; i < 10; i++ {
+		bus.Publish(events.AlertFired{Name: "alert"})
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	// Events queued before cancellation are delivered
+	if got := len(rec.messages()) - before; got < 10 {
+		t.Errorf("Delivered %d of 10 events after the probe", got)
+	}
+}
+
+func TestPublisher_RunBufferFull(t *testing.T) {
+	block := make(chan struct{})
+	producer := ProducerFunc(func(ctx context.Context, msgs ...Message) error {
+		<-block
+		return nil
+	})
+	p := NewPublisher(producer, "events")
+	p.Buffer = 1
+	var mu sync.Mutex
+	var errs []error
+	p.OnError = func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}
+	bus := events.NewBus()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- p.Run(ctx, bus) }()
+
+	for deadline := time.Now().Add(time.Second); ; {
+		bus.Publish(events.AlertFired{Name: "alert"})
+		mu.Lock()
+		dropped := len(errs) > 0 && errors.Is(errs[0], ErrBufferFull)
+		mu.Unlock()
+		if dropped {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected ErrBufferFull while the producer is blocked")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(block)
+	cancel()
+	<-done
+}
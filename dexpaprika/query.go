@@ -0,0 +1,117 @@
+package dexpaprika
+
+import (
+	"strconv"
+	"sync"
+)
+
+// maxPooledQuery is the largest buffer, in bytes, returned to the pool of
+// query builders, so one long URL does not pin its buffer for good.
+const maxPooledQuery = 4 << 10
+
+var queryBuilders = sync.Pool{
+	New: func() any { return &queryBuilder{buf: make([]byte, 0, 256)} },
+}
+
+// queryBuilder appends URL query parameters to a request path in a pooled
+// buffer, without the maps and intermediate strings of url.Values, so
+// building a request URL allocates only the final string.
+//
+// Parameters are written in the order they are added. Callers add them in
+// key order, as url.Values.Encode sorts them, so request URLs, and the cache
+// keys derived from them, are the same as before. Zero values are skipped:
+// the API treats them as absent.
+type queryBuilder struct {
+	path string
+	buf  []byte
+}
+
+// newQuery returns a builder of a query string for path. Its String method
+// returns the builder to the pool.
+func newQuery(path string) *queryBuilder {
+	b := queryBuilders.Get().(*queryBuilder)
+	b.path = path
+	b.buf = append(b.buf[:0], path...)
+	return b
+}
+
+// key starts a parameter.
+func (b *queryBuilder) key(key string) {
+	if len(b.buf) == len(b.path) {
+		b.buf = append(b.buf, '?')
+	} else {
+		b.buf = append(b.buf, '&')
+	}
+	b.buf = appendQueryEscape(b.buf, key)
+	b.buf = append(b.buf, '=')
+}
+
+// addString adds a parameter if value is not empty.
+func (b *queryBuilder) addString(key, value string) {
+	if value == "" {
+		return
+	}
+	b.key(key)
+	b.buf = appendQueryEscape(b.buf, value)
+}
+
+// addInt adds a parameter if value is positive.
+func (b *queryBuilder) addInt(key string, value int) {
+	if value <= 0 {
+		return
+	}
+	b.key(key)
+	b.buf = strconv.AppendInt(b.buf, int64(value), 10)
+}
+
+// addBool adds a parameter set to true if value is.
+func (b *queryBuilder) addBool(key string, value bool) {
+	if !value {
+		return
+	}
+	b.key(key)
+	b.buf = append(b.buf, "true"...)
+}
+
+// addListOptions adds the parameters of opts, which may be nil.
+func (b *queryBuilder) addListOptions(opts *ListOptions) {
+	if opts == nil {
+		return
+	}
+	b.addInt("limit", opts.Limit)
+	b.addString("order_by", opts.OrderBy)
+	b.addInt("page", opts.Page)
+	b.addString("sort", opts.Sort)
+}
+
+// String returns the path with its query string and releases the builder,
+// which must not be used afterwards.
+func (b *queryBuilder) String() string {
+	s := b.path
+	if len(b.buf) > len(b.path) {
+		s = string(b.buf)
+	}
+	b.path = ""
+	if cap(b.buf) <= maxPooledQuery {
+		queryBuilders.Put(b)
+	}
+	return s
+}
+
+// appendQueryEscape appends s to dst escaped as url.QueryEscape does.
+func appendQueryEscape(dst []byte, s string) []byte {
+	const hex = "0123456789ABCDEF"
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			dst = append(dst, c)
+		case c == ' ':
+			dst = append(dst, '+')
+		default:
+			dst = append(dst, '%', hex[c>>4], hex[c&15])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,79 @@
+package dexpaprika
+
+import (
+	"net/url"
+	"testing"
+	"testing/quick"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(q *queryBuilder)
+		want  url.Values
+	}{
+		{"empty", func(q *queryBuilder) {}, nil},
+		{"zero values", func(q *queryBuilder) {
+			q.addString("sort", "")
+			q.addInt("limit", 0)
+			q.addInt("page", -1)
+			q.addBool("inversed", false)
+			q.addListOptions(nil)
+		}, nil},
+		{"list options", func(q *queryBuilder) {
+			q.addListOptions(&ListOptions{Page: 2, Limit: 50, Sort: "desc", OrderBy: "volume_usd"})
+		}, url.Values{"page": {"2"}, "limit": {"50"}, "sort": {"desc"}, "order_by": {"volume_usd"}}},
+		{"escaping", func(q *queryBuilder) {
+			q.addString("cursor", "a b&c=d/é")
+			q.addBool("inversed", true)
+			q.addInt("limit", 1234567)
+		}, url.Values{"cursor": {"a b&c=d/é"}, "inversed": {"true"}, "limit": {"1234567"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newQuery("/pools")
+			tt.build(q)
+			want := "/pools"
+			if len(tt.want) > 0 {
+				want += "?" + tt.want.Encode()
+			}
+			if got := q.String(); got != want {
+				t.Errorf("String() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestAppendQueryEscape(t *testing.T) {
+	check := func(s string) bool {
+		return string(appendQueryEscape(nil, s)) == url.QueryEscape(s)
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+	var all []byte
+	for c := range 256 {
+		all = append(all, byte(c))
+	}
+	if !check(string(all)) {
+		t.Errorf("appendQueryEscape(%q) = %q, want %q", all, appendQueryEscape(nil, string(all)), url.QueryEscape(string(all)))
+	}
+}
+
+func TestQueryBuilder_Reused(t *testing.T) {
+	long := newQuery("/pools")
+	long.addString("cursor", string(make([]byte, 2*maxPooledQuery)))
+	_ = long.String()
+
+	for range 10 {
+		q := newQuery("/search")
+		if got := q.String(); got != "/search" {
+			t.Fatalf("String() of a reused builder = %q, want /search", got)
+		}
+		q = newQuery("/networks")
+		q.addInt("page", 1)
+		if got := q.String(); got != "/networks?page=1" {
+			t.Fatalf("String() of a reused builder = %q, want /networks?page=1", got)
+		}
+	}
+}
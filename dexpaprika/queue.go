@@ -0,0 +1,164 @@
+package dexpaprika
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// Priority orders calls waiting in a Queue. Higher priorities go first;
+// calls of equal priority go in arrival order.
+type Priority int
+
+// Common priorities. Any other value may be used.
+const (
+	PriorityBackground  Priority = -10 // Backfills and bulk syncs
+	PriorityNormal      Priority = 0
+	PriorityInteractive Priority = 10 // Calls a user is waiting on
+)
+
+// Queue paces calls to a fixed rate, granting slots to waiting calls by
+// priority, so interactive requests preempt queued background work. A
+// client created with WithQueue waits on its queue before every request,
+// taking the priority from the request's context; the queue can also pace
+// arbitrary work through Do.
+type Queue struct {
+	interval time.Duration
+
+	mu      sync.Mutex
+	waiters waiterHeap
+	seq     uint64
+	next    time.Time   // Earliest time of the next slot
+	timer   *time.Timer // Armed while waiters wait for the next slot
+}
+
+// NewQueue creates a queue granting at most requestsPerSecond slots per
+// second. A rate of zero or less grants slots immediately.
+func NewQueue(requestsPerSecond float64) *Queue {
+	q := &Queue{}
+	if requestsPerSecond > 0 {
+		q.interval = time.Duration(1e9 / requestsPerSecond)
+	}
+	return q
+}
+
+// Wait blocks until the queue grants a slot to a call of priority p, or ctx
+// is done.
+func (q *Queue) Wait(ctx context.Context, p Priority) error {
+	w := &waiter{priority: p, ready: make(chan struct{})}
+
+	q.mu.Lock()
+	q.seq++
+	w.seq = q.seq
+	heap.Push(&q.waiters, w)
+	q.dispatch()
+	q.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if w.index < 0 {
+			// Granted while being cancelled
+			return nil
+		}
+		heap.Remove(&q.waiters, w.index)
+		return ctx.Err()
+	}
+}
+
+// Do waits for a slot at priority p and then calls fn.
+func (q *Queue) Do(ctx context.Context, p Priority, fn func(ctx context.Context) error) error {
+	if err := q.Wait(ctx, p); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
+
+// Len returns the number of calls waiting for a slot.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.waiters)
+}
+
+// dispatch grants the slots that are due and arms the timer for the next
+// one. It must be called with q.mu held.
+func (q *Queue) dispatch() {
+	for len(q.waiters) > 0 {
+		now := time.Now()
+		if now.Before(q.next) {
+			if q.timer == nil {
+				q.timer = time.AfterFunc(q.next.Sub(now), func() {
+					q.mu.Lock()
+					q.timer = nil
+					q.dispatch()
+					q.mu.Unlock()
+				})
+			}
+			return
+		}
+		w := heap.Pop(&q.waiters).(*waiter)
+		close(w.ready)
+		q.next = now.Add(q.interval)
+	}
+}
+
+// waiter is a call waiting in a Queue.
+type waiter struct {
+	priority Priority
+	seq      uint64
+	index    int // Position in the heap, -1 once granted
+	ready    chan struct{}
+}
+
+// waiterHeap orders waiters by priority, then arrival.
+type waiterHeap []*waiter
+
+func (h waiterHeap) Len() int { return len(h) }
+
+func (h waiterHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h waiterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *waiterHeap) Push(x interface{}) {
+	w := x.(*waiter)
+	w.index = len(*h)
+	*h = append(*h, w)
+}
+
+func (h *waiterHeap) Pop() interface{} {
+	old := *h
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	w.index = -1
+	*h = old[:len(old)-1]
+	return w
+}
+
+type priorityKey struct{}
+
+// ContextWithPriority returns a context whose requests wait in a client's
+// Queue at priority p.
+func ContextWithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the priority set by ContextWithPriority, or
+// PriorityNormal.
+func PriorityFromContext(ctx context.Context) Priority {
+	p, _ := ctx.Value(priorityKey{}).(Priority)
+	return p
+}
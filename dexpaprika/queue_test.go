@@ -0,0 +1,125 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQueue_Priority(t *testing.T) {
+	q := NewQueue(10) // A slot every 100ms
+
+	// Take the first slot so the others queue up behind it
+	if err := q.Wait(context.Background(), PriorityNormal); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	enqueue := func(name string, p Priority) {
+		queued := q.Len()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.Do(context.Background(), p, func(context.Context) error {
+				mu.Lock()
+				order = append(order, name)
+				mu.Unlock()
+				return nil
+			})
+		}()
+		// Wait until the call is queued to fix the arrival order
+		for q.Len() == queued {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	enqueue("backfill-1", PriorityBackground)
+	enqueue("backfill-2", PriorityBackground)
+	enqueue("interactive", PriorityInteractive)
+	wg.Wait()
+
+	want := []string{"interactive", "backfill-1", "backfill-2"}
+	for i := range want {
+		if i >= len(order) || order[i] != want[i] {
+			t.Fatalf("Expected order %v, got %v", want, order)
+		}
+	}
+}
+
+func TestQueue_Pacing(t *testing.T) {
+	q := NewQueue(100)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := q.Wait(context.Background(), PriorityNormal); err != nil {
+			t.Fatalf("Wait returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Expected 5 slots to take at least 40ms, took %s", elapsed)
+	}
+}
+
+func TestQueue_Cancel(t *testing.T) {
+	q := NewQueue(1)
+	q.Wait(context.Background(), PriorityNormal)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := q.Wait(ctx, PriorityInteractive); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the wait to time out, got %v", err)
+	}
+	if q.Len() != 0 {
+		t.Errorf("Expected the cancelled call to leave the queue, got %d waiting", q.Len())
+	}
+}
+
+func TestWithQueue(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "0xtoken"}`))
+	}))
+	defer server.Close()
+
+	q := NewQueue(50)
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0), WithQueue(q))
+
+	// A backfill of five tokens is queued when an interactive request arrives
+	background := ContextWithPriority(context.Background(), PriorityBackground)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Tokens.GetDetails(background, "ethereum", "0xbackfill")
+		}()
+	}
+	for q.Len() < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := client.Tokens.GetDetails(ContextWithPriority(context.Background(), PriorityInteractive), "ethereum", "0xinteractive"); err != nil {
+		t.Fatalf("GetDetails returned error: %v", err)
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, p := range paths {
+		if p == "/networks/ethereum/tokens/0xinteractive" {
+			if i == len(paths)-1 {
+				t.Errorf("Expected the interactive request to preempt the backfill, got %v", paths)
+			}
+			return
+		}
+	}
+	t.Errorf("Interactive request not made: %v", paths)
+}
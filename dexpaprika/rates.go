@@ -0,0 +1,100 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoRoute is returned when no pool path connects two tokens.
+var ErrNoRoute = errors.New("no route between tokens")
+
+// RoutingTokens lists, per network, the addresses of the hub tokens (wrapped
+// native token and main stablecoins) that Prices.Rate routes through when two
+// tokens share no pool. Add entries to route on other networks.
+var RoutingTokens = map[string][]string{
+	NetworkEthereum: {"0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"},
+	NetworkArbitrum: {"0x82af49447d8a07e3bd95bd0d56f35241523fbab1", "0xaf88d065e77c8cc2239327c5edb3a432268e5831"},
+	NetworkBase:     {"0x4200000000000000000000000000000000000006", "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913"},
+	NetworkOptimism: {"0x4200000000000000000000000000000000000006", "0x0b2c639c533813f4aa9d7837caf62653d097ff85"},
+	NetworkPolygon:  {"0x7ceb23fd6bc0add59e62ac25578270cff1b9f619", "0x3c499c542cef5e3811e1192ce70d8cc03d5c3359"},
+	NetworkBsc:      {"0xbb4cdb9cbd36b01bd1cbaebf2de08d9173bc095c", "0x55d398326f99059ff775485246999027b3197955"},
+	NetworkSolana:   {"So11111111111111111111111111111111111111112", "EPjFWdd5AufqSSqeM2qN1xDXJSRNvzVxLdZ8TzmEGkt1v"},
+}
+
+// Rate is the exchange rate between two tokens.
+type Rate struct {
+	From string  `json:"from" csv:"from"`
+	To   string  `json:"to" csv:"to"`
+	Rate float64 `json:"rate" csv:"rate"` // Units of To per unit of From
+	Via  string  `json:"via,omitempty" csv:"via"`
+
+	// Pools lists the pools used, in routing order
+	Pools []string `json:"pools" csv:"-"`
+}
+
+// Rate returns how many units of token to one unit of token from is worth on
+// a network. The most active pool trading the pair directly is preferred;
+// otherwise the rate is routed through one of the network's RoutingTokens.
+func (s *PricesService) Rate(ctx context.Context, networkID, from, to string) (*Rate, error) {
+	rate := &Rate{From: from, To: to}
+
+	direct, pool, err := s.pairRate(ctx, networkID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if pool != "" {
+		rate.Rate = direct
+		rate.Pools = []string{pool}
+		return rate, nil
+	}
+
+	for _, hub := range RoutingTokens[networkID] {
+		if strings.EqualFold(hub, from) || strings.EqualFold(hub, to) {
+			continue
+		}
+		first, firstPool, err := s.pairRate(ctx, networkID, from, hub)
+		if err != nil {
+			return nil, err
+		}
+		if firstPool == "" {
+			continue
+		}
+		second, secondPool, err := s.pairRate(ctx, networkID, hub, to)
+		if err != nil {
+			return nil, err
+		}
+		if secondPool == "" {
+			continue
+		}
+		rate.Rate = first * second
+		rate.Via = hub
+		rate.Pools = []string{firstPool, secondPool}
+		return rate, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s and %s on %s", ErrNoRoute, from, to, networkID)
+}
+
+// pairRate returns the price of from in to from the most active pool
+// trading the pair. An empty pool means the pair has no usable pool.
+func (s *PricesService) pairRate(ctx context.Context, networkID, from, to string) (float64, string, error) {
+	pools, err := s.client.Pools.GetByTokenPair(ctx, networkID, from, to, &ListOptions{Limit: 5})
+	if err != nil {
+		return 0, "", err
+	}
+	if len(pools.Pools) == 0 {
+		return 0, "", nil
+	}
+
+	details, err := s.client.Pools.GetDetails(ctx, networkID, pools.Pools[0].ID, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	rate, ok := details.PriceOf(NormalizeAddress(networkID, from))
+	if !ok || rate <= 0 {
+		return 0, "", nil
+	}
+	return rate, details.ID, nil
+}
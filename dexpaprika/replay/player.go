@@ -0,0 +1,150 @@
+package replay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+// Player replays records in time order, waiting between events for the
+// time that passed between them divided by Speed.
+type Player struct {
+	records []Record
+
+	// Speed scales playback: 1 replays in real time, 60 an hour per minute.
+	// Zero or less replays as fast as the consumers accept events.
+	Speed float64
+
+	// MaxWait, if positive, caps the wait between two events, skipping over
+	// quiet periods such as gaps in the recording.
+	MaxWait time.Duration
+
+	// From and To, if set, restrict playback to records in [From, To).
+	From, To time.Time
+
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewPlayer creates a player of records, which it sorts by time.
+func NewPlayer(records []Record) *Player {
+	sorted := append([]Record(nil), records...)
+	sortRecords(sorted)
+	return &Player{records: sorted, Speed: 1}
+}
+
+// Len returns the number of records.
+func (p *Player) Len() int { return len(p.records) }
+
+// Now returns the time of the record played last, the simulated time of a
+// backtest, or the zero time before playback.
+func (p *Player) Now() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.now
+}
+
+// Play publishes the records on bus, returning when all were published or
+// with ctx's error when it is cancelled.
+func (p *Player) Play(ctx context.Context, bus *events.Bus) error {
+	return p.play(ctx, func(r Record) bool {
+		bus.Publish(r.Event)
+		return true
+	})
+}
+
+// play calls emit for each record in range until emit returns false.
+func (p *Player) play(ctx context.Context, emit func(Record) bool) error {
+	var last time.Time
+	for _, r := range p.records {
+		if (!p.From.IsZero() && r.Time.Before(p.From)) || (!p.To.IsZero() && !r.Time.Before(p.To)) {
+			continue
+		}
+		if err := p.wait(ctx, last, r.Time); err != nil {
+			return err
+		}
+		last = r.Time
+
+		p.mu.Lock()
+		p.now = r.Time
+		p.mu.Unlock()
+		if !emit(r) {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// wait sleeps for the scaled time between two records.
+func (p *Player) wait(ctx context.Context, last, next time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if p.Speed <= 0 || last.IsZero() {
+		return nil
+	}
+	d := time.Duration(float64(next.Sub(last)) / p.Speed)
+	if p.MaxWait > 0 && d > p.MaxWait {
+		d = p.MaxWait
+	}
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Watch replays the events of type E on a channel, in the manner of a
+// watcher's Watch: the channel is closed when playback ends or ctx is
+// cancelled. Unlike events.Chan, slow consumers slow playback down rather
+// than missing events. Each call plays the records from the start; publish
+// on a bus with Play to feed several consumers from one playback.
+func Watch[E events.Event](ctx context.Context, p *Player) <-chan E {
+	ch := make(chan E)
+	go func() {
+		defer close(ch)
+		p.play(ctx, func(r Record) bool {
+			e, ok := r.Event.(E)
+			if !ok {
+				return true
+			}
+			select {
+			case ch <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return ch
+}
+
+// Transactions replays the recorded transactions of a pool, in place of
+// watch.Transactions.
+func Transactions(ctx context.Context, p *Player, networkID, poolAddress string) <-chan dexpaprika.Transaction {
+	pool := dexpaprika.NewEntityKey(networkID, poolAddress)
+	ch := make(chan dexpaprika.Transaction)
+	go func() {
+		defer close(ch)
+		for e := range Watch[events.NewTransaction](ctx, p) {
+			if dexpaprika.NewEntityKey(e.NetworkID, e.PoolAddress) != pool {
+				continue
+			}
+			select {
+			case ch <- e.Transaction:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
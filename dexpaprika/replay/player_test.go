@@ -0,0 +1,99 @@
+package replay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+func testRecords(start time.Time) []Record {
+	return []Record{
+		NewRecord(events.NewTransaction{NetworkID: "ethereum", PoolAddress: "0xb", Transaction: dexpaprika.Transaction{ID: "other"}, Time: start.Add(time.Second)}),
+		NewRecord(events.NewTransaction{NetworkID: "ethereum", PoolAddress: "0xa", Transaction: dexpaprika.Transaction{ID: "2"}, Time: start.Add(time.Hour)}),
+		NewRecord(events.NewTransaction{NetworkID: "ethereum", PoolAddress: "0xa", Transaction: dexpaprika.Transaction{ID: "1"}, Time: start}),
+		NewRecord(events.PoolPriceChanged{NetworkID: "ethereum", PoolAddress: "0xa", PriceUSD: 1, Time: start.Add(2 * time.Second)}),
+	}
+}
+
+func TestPlayer_Play(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	player := NewPlayer(testRecords(start))
+	player.Speed = 1000
+	player.MaxWait = 10 * time.Millisecond
+
+	bus := events.NewBus()
+	var names []string
+	var times []time.Time
+	bus.SubscribeAll(func(e events.Event) {
+		names = append(names, e.EventName())
+		times = append(times, player.Now())
+	})
+
+	began := time.Now()
+	if err := player.Play(context.Background(), bus); err != nil {
+		t.Fatal(err)
+	}
+	elapsed := time.Since(began)
+
+	if len(names) != 4 || names[2] != "pool_price_changed" {
+		t.Errorf("Unexpected events: %v", names)
+	}
+	for i := 1; i < len(times); i++ {
+		if times[i].Before(times[i-1]) {
+			t.Errorf("Events out of order: %v", times)
+		}
+	}
+	// 1s and 1s scaled to 1ms each, the hour capped at 10ms
+	if elapsed < 10*time.Millisecond || elapsed > time.Second {
+		t.Errorf("Expected scaled waits of about 12ms, took %v", elapsed)
+	}
+	if !player.Now().Equal(start.Add(time.Hour)) {
+		t.Errorf("Expected Now at the last record, got %v", player.Now())
+	}
+}
+
+func TestPlayer_Range(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	player := NewPlayer(testRecords(start))
+	player.Speed = 0
+	player.From = start.Add(time.Second)
+	player.To = start.Add(time.Hour)
+
+	n := 0
+	for range Watch[events.Event](context.Background(), player) {
+		n++
+	}
+	if n != 2 {
+		t.Errorf("Expected the 2 records in range, got %d", n)
+	}
+}
+
+func TestTransactions(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	player := NewPlayer(testRecords(start))
+	player.Speed = 0
+
+	var ids []string
+	for tx := range Transactions(context.Background(), player, "ethereum", "0xA") {
+		ids = append(ids, tx.ID)
+	}
+	if len(ids) != 2 || ids[0] != "1" || ids[1] != "2" {
+		t.Errorf("Expected the pool's transactions in order, got %v", ids)
+	}
+}
+
+func TestWatch_Cancel(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	player := NewPlayer(testRecords(start))
+	player.Speed = 1 // An hour between the last records
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := Watch[events.NewTransaction](ctx, player)
+	<-ch
+	cancel()
+	for range ch {
+	}
+}
@@ -0,0 +1,274 @@
+// Package replay feeds recorded market data through the SDK's event
+// interfaces, so strategies built on watcher events can be backtested
+// without calling the API.
+//
+// Events are recorded from a live events.Bus with a Recorder, or derived
+// from the transactions and candles of a dexpaprika.Snapshot, and played
+// back in time order at a configurable speed:
+//
+//	records, err := replay.ReadFile("events.jsonl")
+//	player := replay.NewPlayer(records)
+//	player.Speed = 60 // An hour of data per minute
+//	err = player.Play(ctx, bus)
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/publish"
+)
+
+// Record is an event with the time it occurred.
+type Record struct {
+	Time  time.Time
+	Event events.Event
+}
+
+// NewRecord creates a record of e at the time the event carries.
+func NewRecord(e events.Event) Record {
+	return Record{Time: EventTime(e), Event: e}
+}
+
+// EventTime returns the time carried by one of the events package's events,
+// or the zero time for other events.
+func EventTime(e events.Event) time.Time {
+	switch e := e.(type) {
+	case events.PoolPriceChanged:
+		return e.Time
+	case events.TokenPriceChanged:
+		return e.Time
+	case events.NewPool:
+		return e.Time
+	case events.NewTransaction:
+		return e.Time
+	case events.AlertFired:
+		return e.Time
+	case events.StaleData:
+		return e.Time
+	}
+	return time.Time{}
+}
+
+// Recorder writes events to w as JSON lines in publish's Envelope encoding,
+// the format read by Read. Events consumed from a broker topic written by a
+// publish.Publisher with the default encoding can therefore be replayed too.
+type Recorder struct {
+	mu  sync.Mutex
+	w   io.Writer
+	buf *bufio.Writer
+	err error
+}
+
+// NewRecorder creates a recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w, buf: bufio.NewWriter(w)}
+}
+
+// Attach records every event published on bus until the returned function
+// is called.
+func (r *Recorder) Attach(bus *events.Bus) (detach func()) {
+	return bus.SubscribeAll(func(e events.Event) { r.Record(e) })
+}
+
+// Record writes an event. After a write fails, further events are dropped
+// and the error is returned by Record, Err and Close.
+func (r *Recorder) Record(e events.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	data, err := publish.JSON(e)
+	if err != nil {
+		r.err = fmt.Errorf("record %s: %w", e.EventName(), err)
+		return r.err
+	}
+	if _, err := r.buf.Write(append(data, '\n')); err != nil {
+		r.err = err
+	}
+	return r.err
+}
+
+// Err returns the first error writing events.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close flushes recorded events and closes the underlying writer if it is
+// an io.Closer.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.buf.Flush(); r.err == nil {
+		r.err = err
+	}
+	if c, ok := r.w.(io.Closer); ok {
+		if err := c.Close(); r.err == nil {
+			r.err = err
+		}
+	}
+	return r.err
+}
+
+// decoders creates the events that can be read back, by event name.
+var decoders = map[string]func() events.Event{
+	events.PoolPriceChanged{}.EventName():  func() events.Event { return new(events.PoolPriceChanged) },
+	events.TokenPriceChanged{}.EventName(): func() events.Event { return new(events.TokenPriceChanged) },
+	events.NewPool{}.EventName():           func() events.Event { return new(events.NewPool) },
+	events.NewTransaction{}.EventName():    func() events.Event { return new(events.NewTransaction) },
+	events.AlertFired{}.EventName():        func() events.Event { return new(events.AlertFired) },
+	events.StaleData{}.EventName():         func() events.Event { return new(events.StaleData) },
+}
+
+// Read decodes events written by a Recorder. Lines of unknown event types
+// are skipped.
+func Read(r io.Reader) ([]Record, error) {
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var envelope struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &envelope); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		newEvent, ok := decoders[envelope.Type]
+		if !ok {
+			continue
+		}
+		ptr := newEvent()
+		if err := json.Unmarshal(envelope.Data, ptr); err != nil {
+			return nil, fmt.Errorf("line %d: decode %s: %w", line, envelope.Type, err)
+		}
+		records = append(records, NewRecord(deref(ptr)))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ReadFile reads the events recorded in a file.
+func ReadFile(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// deref returns the event a decoder's pointer points to, so replayed events
+// have the same types as published ones.
+func deref(e events.Event) events.Event {
+	switch e := e.(type) {
+	case *events.PoolPriceChanged:
+		return *e
+	case *events.TokenPriceChanged:
+		return *e
+	case *events.NewPool:
+		return *e
+	case *events.NewTransaction:
+		return *e
+	case *events.AlertFired:
+		return *e
+	case *events.StaleData:
+		return *e
+	}
+	return e
+}
+
+// FromSnapshot derives events from a snapshot: a NewTransaction for each
+// stored transaction at its creation time, and a PoolPriceChanged for each
+// stored candle at its close time, priced at the close. Candles stored for
+// several intervals of a pool are each replayed. Records are sorted by time.
+func FromSnapshot(s *dexpaprika.Snapshot) []Record {
+	var records []Record
+	for _, key := range sortedKeys(s.Transactions) {
+		txs := s.Transactions[key]
+		pool, err := dexpaprika.ParseEntityKey(key)
+		if err != nil {
+			continue
+		}
+		for _, tx := range txs {
+			t, err := time.Parse(time.RFC3339, tx.CreatedAt)
+			if err != nil {
+				continue
+			}
+			records = append(records, NewRecord(events.NewTransaction{
+				NetworkID:   pool.Chain,
+				PoolAddress: pool.Address,
+				Transaction: tx,
+				Time:        t,
+			}))
+		}
+	}
+	for _, key := range sortedKeys(s.OHLCV) {
+		candles := s.OHLCV[key]
+		i := strings.LastIndex(key, ":")
+		if i < 0 {
+			continue
+		}
+		pool, err := dexpaprika.ParseEntityKey(key[:i])
+		if err != nil {
+			continue
+		}
+		var prices []Record
+		for _, c := range candles {
+			t, err := c.CloseTime()
+			if err != nil {
+				continue
+			}
+			prices = append(prices, NewRecord(events.PoolPriceChanged{
+				NetworkID:   pool.Chain,
+				PoolAddress: pool.Address,
+				PriceUSD:    c.Close,
+				Time:        t,
+			}))
+		}
+		sortRecords(prices)
+		for j := 1; j < len(prices); j++ {
+			e := prices[j].Event.(events.PoolPriceChanged)
+			e.PreviousPriceUSD = prices[j-1].Event.(events.PoolPriceChanged).PriceUSD
+			if e.PreviousPriceUSD != 0 {
+				e.ChangePercent = (e.PriceUSD - e.PreviousPriceUSD) / e.PreviousPriceUSD * 100
+			}
+			prices[j].Event = e
+		}
+		records = append(records, prices...)
+	}
+	sortRecords(records)
+	return records
+}
+
+// sortedKeys returns the keys of m in order, so equal times replay in a
+// stable order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortRecords(records []Record) {
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Time.Before(records[j].Time) })
+}
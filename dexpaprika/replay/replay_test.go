@@ -0,0 +1,79 @@
+package replay
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+func TestRecorder_RoundTrip(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bus := events.NewBus()
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	detach := rec.Attach(bus)
+
+	bus.Publish(events.PoolPriceChanged{NetworkID: "ethereum", PoolAddress: "0xpool", PriceUSD: 2, Time: at})
+	bus.Publish(events.NewTransaction{PoolAddress: "0xpool", Transaction: dexpaprika.Transaction{ID: "tx1"}, Time: at.Add(time.Second)})
+	detach()
+	bus.Publish(events.AlertFired{Name: "ignored"})
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := Read(strings.NewReader(buf.String() + `{"type":"unknown","data":{}}` + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d: %s", len(records), buf.String())
+	}
+	price, ok := records[0].Event.(events.PoolPriceChanged)
+	if !ok || price.PriceUSD != 2 || !records[0].Time.Equal(at) {
+		t.Errorf("Unexpected first record: %+v", records[0])
+	}
+	if tx, ok := records[1].Event.(events.NewTransaction); !ok || tx.Transaction.ID != "tx1" {
+		t.Errorf("Unexpected second record: %+v", records[1])
+	}
+
+	if _, err := Read(strings.NewReader("not json\n")); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("Expected an error naming the line, got %v", err)
+	}
+}
+
+func TestFromSnapshot(t *testing.T) {
+	s := dexpaprika.NewSnapshot()
+	s.SetTransactions("ethereum", "0xPool", []dexpaprika.Transaction{
+		{ID: "late", CreatedAt: "2024-01-01T00:10:00Z"},
+		{ID: "early", CreatedAt: "2024-01-01T00:01:00Z"},
+		{ID: "no time"},
+	})
+	s.SetOHLCV("ethereum", "0xpool", "5m", []dexpaprika.OHLCVRecord{
+		{TimeClose: "2024-01-01T00:10:00Z", Close: 110},
+		{TimeClose: "2024-01-01T00:05:00Z", Close: 100},
+	})
+
+	records := FromSnapshot(s)
+	var got []string
+	for _, r := range records {
+		switch e := r.Event.(type) {
+		case events.NewTransaction:
+			got = append(got, "tx:"+e.Transaction.ID)
+		case events.PoolPriceChanged:
+			got = append(got, "price")
+			if e.PriceUSD == 110 && (e.PreviousPriceUSD != 100 || e.ChangePercent < 9.99 || e.ChangePercent > 10.01) {
+				t.Errorf("Unexpected price change: %+v", e)
+			}
+			if e.PoolAddress != "0xpool" {
+				t.Errorf("Expected normalized pool address, got %s", e.PoolAddress)
+			}
+		}
+	}
+	if want := "tx:early price tx:late price"; strings.Join(got, " ") != want {
+		t.Errorf("Got %q, want %q", strings.Join(got, " "), want)
+	}
+}
@@ -0,0 +1,39 @@
+package dexpaprika
+
+// RequestOption configures a single service method call.
+type RequestOption func(*requestConfig)
+
+// requestConfig holds the settings applied by RequestOptions.
+type requestConfig struct {
+	target interface{}
+}
+
+// WithTarget makes the service method decode the response into target, a
+// pointer to a caller-provided value, instead of the SDK model. The method
+// then returns a nil model. This avoids allocating fields the caller does not
+// need, e.g. when only a few fields of a large pool listing are used.
+func WithTarget(target interface{}) RequestOption {
+	return func(c *requestConfig) {
+		c.target = target
+	}
+}
+
+// newRequestConfig applies opts to a fresh requestConfig.
+func newRequestConfig(opts []RequestOption) *requestConfig {
+	cfg := &requestConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+	return cfg
+}
+
+// decodeInto returns the value the response should be decoded into: the
+// caller's target if one was set, otherwise the SDK model.
+func (c *requestConfig) decodeInto(model interface{}) interface{} {
+	if c.target != nil {
+		return c.target
+	}
+	return model
+}
@@ -0,0 +1,69 @@
+package dexpaprika
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithTarget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/pools":
+			fmt.Fprintln(w, `{"pools": [{"id": "0x1", "price_usd": 1.5, "dex_name": "Uniswap"}, {"id": "0x2", "price_usd": 2.5}], "page_info": {"page": 0}}`)
+		case "/stats":
+			fmt.Fprintln(w, `{"chains": 10, "pools": 1000}`)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, 1*time.Millisecond, 1*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Decode only the fields we need
+	var slim struct {
+		Pools []struct {
+			ID       string  `json:"id"`
+			PriceUSD float64 `json:"price_usd"`
+		} `json:"pools"`
+	}
+	resp, err := client.Pools.List(ctx, nil, WithTarget(&slim))
+	if err != nil {
+		t.Fatalf("Pools.List returned error: %v", err)
+	}
+	if resp != nil {
+		t.Error("Expected nil model when decoding into a target")
+	}
+	if len(slim.Pools) != 2 || slim.Pools[1].ID != "0x2" || slim.Pools[1].PriceUSD != 2.5 {
+		t.Errorf("Unexpected target contents: %+v", slim)
+	}
+
+	// Without a target the SDK model is returned as usual
+	resp, err = client.Pools.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Pools.List returned error: %v", err)
+	}
+	if resp == nil || len(resp.Pools) != 2 {
+		t.Errorf("Expected SDK model with 2 pools, got %+v", resp)
+	}
+
+	var chains struct {
+		Chains int `json:"chains"`
+	}
+	stats, err := client.Utils.GetStats(ctx, WithTarget(&chains))
+	if err != nil {
+		t.Fatalf("Utils.GetStats returned error: %v", err)
+	}
+	if stats != nil || chains.Chains != 10 {
+		t.Errorf("Expected nil stats and 10 chains, got %v and %d", stats, chains.Chains)
+	}
+}
@@ -0,0 +1,100 @@
+package dexpaprika
+
+import (
+	"math"
+	"time"
+)
+
+// Returns returns the simple returns between consecutive candle closes.
+// Pairs involving a non-positive close are skipped.
+func Returns(candles []OHLCVRecord) []float64 {
+	if len(candles) < 2 {
+		return nil
+	}
+	returns := make([]float64, 0, len(candles)-1)
+	for i := 1; i < len(candles); i++ {
+		prev, cur := candles[i-1].Close, candles[i].Close
+		if prev <= 0 || cur <= 0 {
+			continue
+		}
+		returns = append(returns, cur/prev-1)
+	}
+	return returns
+}
+
+// Volatility returns the realized volatility of the candle closes: the
+// sample standard deviation of log returns, annualized for candles of the
+// given length (e.g. time.Hour for 1h candles). ok is false when fewer than
+// three closes are usable.
+func Volatility(candles []OHLCVRecord, interval time.Duration) (volatility float64, ok bool) {
+	logReturns := make([]float64, 0, len(candles))
+	for i := 1; i < len(candles); i++ {
+		prev, cur := candles[i-1].Close, candles[i].Close
+		if prev <= 0 || cur <= 0 {
+			continue
+		}
+		logReturns = append(logReturns, math.Log(cur/prev))
+	}
+	_, stddev, ok := meanStddev(logReturns)
+	if !ok || interval <= 0 {
+		return 0, false
+	}
+	return stddev * math.Sqrt(periodsPerYear(interval)), true
+}
+
+// MaxDrawdown returns the largest peak-to-trough decline of the candle
+// closes as a positive fraction (0.25 is a 25% drawdown), along with the
+// indexes of the peak and trough candles.
+func MaxDrawdown(candles []OHLCVRecord) (drawdown float64, peak, trough int) {
+	peakIndex := -1
+	for i, c := range candles {
+		if c.Close <= 0 {
+			continue
+		}
+		if peakIndex < 0 || c.Close > candles[peakIndex].Close {
+			peakIndex = i
+			continue
+		}
+		if dd := 1 - c.Close/candles[peakIndex].Close; dd > drawdown {
+			drawdown, peak, trough = dd, peakIndex, i
+		}
+	}
+	return drawdown, peak, trough
+}
+
+// SharpeRatio returns the annualized Sharpe-style ratio of the candle
+// closes: the mean simple return in excess of riskFreeRate (an annual rate,
+// e.g. 0.04) divided by the standard deviation of returns. ok is false when
+// fewer than three closes are usable or returns do not vary.
+func SharpeRatio(candles []OHLCVRecord, interval time.Duration, riskFreeRate float64) (sharpe float64, ok bool) {
+	mean, stddev, ok := meanStddev(Returns(candles))
+	if !ok || stddev == 0 || interval <= 0 {
+		return 0, false
+	}
+	periods := periodsPerYear(interval)
+	excess := mean - riskFreeRate/periods
+	return excess / stddev * math.Sqrt(periods), true
+}
+
+// periodsPerYear returns the number of intervals in a year. Crypto markets
+// trade continuously, so a year is 365 days.
+func periodsPerYear(interval time.Duration) float64 {
+	return float64(365*24*time.Hour) / float64(interval)
+}
+
+// meanStddev returns the mean and sample standard deviation of values.
+func meanStddev(values []float64) (mean, stddev float64, ok bool) {
+	if len(values) < 2 {
+		return 0, 0, false
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values) - 1)
+	return mean, math.Sqrt(variance), true
+}
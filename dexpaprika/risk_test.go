@@ -0,0 +1,77 @@
+package dexpaprika
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func closes(values ...float64) []OHLCVRecord {
+	candles := make([]OHLCVRecord, len(values))
+	for i, v := range values {
+		candles[i] = OHLCVRecord{Close: v}
+	}
+	return candles
+}
+
+func TestReturns(t *testing.T) {
+	got := Returns(closes(100, 110, 0, 99, 99))
+	want := []float64{0.1, 0}
+	if len(got) != len(want) {
+		t.Fatalf("Returns = %v, want %v", got, want)
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Errorf("Returns[%d] = %f, want %f", i, got[i], want[i])
+		}
+	}
+}
+
+func TestVolatility(t *testing.T) {
+	// Alternating ±10% log moves have a per-period stddev of ~0.1155
+	candles := closes(100, 100*math.Exp(0.1), 100, 100*math.Exp(0.1))
+	vol, ok := Volatility(candles, 24*time.Hour)
+	if !ok {
+		t.Fatal("Volatility should be ok")
+	}
+	want := math.Sqrt(0.04/3) * math.Sqrt(365)
+	if math.Abs(vol-want) > 1e-9 {
+		t.Errorf("Volatility = %f, want %f", vol, want)
+	}
+
+	if _, ok := Volatility(closes(1, 2), time.Hour); ok {
+		t.Error("Volatility of a single return should not be ok")
+	}
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	dd, peak, trough := MaxDrawdown(closes(100, 120, 90, 110, 60, 130, 120))
+	if math.Abs(dd-0.5) > 1e-12 || peak != 1 || trough != 4 {
+		t.Errorf("MaxDrawdown = %f (%d→%d), want 0.5 (1→4)", dd, peak, trough)
+	}
+
+	if dd, _, _ := MaxDrawdown(closes(1, 2, 3)); dd != 0 {
+		t.Errorf("Expected no drawdown for a rising series, got %f", dd)
+	}
+}
+
+func TestSharpeRatio(t *testing.T) {
+	candles := closes(100, 110, 104.5, 114.95)
+	sharpe, ok := SharpeRatio(candles, 24*time.Hour, 0)
+	if !ok {
+		t.Fatal("SharpeRatio should be ok")
+	}
+	mean, stddev, _ := meanStddev(Returns(candles))
+	if want := mean / stddev * math.Sqrt(365); math.Abs(sharpe-want) > 1e-9 {
+		t.Errorf("SharpeRatio = %f, want %f", sharpe, want)
+	}
+
+	withRiskFree, _ := SharpeRatio(candles, 24*time.Hour, 0.05)
+	if withRiskFree >= sharpe {
+		t.Error("A positive risk-free rate should lower the ratio")
+	}
+
+	if _, ok := SharpeRatio(closes(1, 1, 1), time.Hour, 0); ok {
+		t.Error("SharpeRatio of constant prices should not be ok")
+	}
+}
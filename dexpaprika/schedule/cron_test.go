@@ -0,0 +1,56 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	start := time.Date(2024, 5, 31, 10, 2, 30, 0, time.UTC) // A Friday
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"*/5 * * * *", []string{"2024-05-31T10:05:00Z", "2024-05-31T10:10:00Z"}},
+		{"@hourly", []string{"2024-05-31T11:00:00Z", "2024-05-31T12:00:00Z"}},
+		{"30 9 * * 1-5", []string{"2024-06-03T09:30:00Z", "2024-06-04T09:30:00Z"}},
+		{"0 0 1,15 * *", []string{"2024-06-01T00:00:00Z", "2024-06-15T00:00:00Z"}},
+		{"0 12 * 2 7", []string{"2025-02-02T12:00:00Z", "2025-02-09T12:00:00Z"}},
+		{"0 0 13 * 5", []string{"2024-06-07T00:00:00Z", "2024-06-13T00:00:00Z"}},
+		{"@every 90s", []string{"2024-05-31T10:04:00Z", "2024-05-31T10:05:30Z"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+			next := start
+			for _, want := range tt.want {
+				next = s.Next(next)
+				if got := next.Format(time.RFC3339); got != want {
+					t.Errorf("Next = %s, want %s", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@every", "@every -1m"} {
+		if _, err := Parse(expr); !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalidSchedule", expr, err)
+		}
+	}
+}
+
+func TestParse_Never(t *testing.T) {
+	s, err := Parse("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if next := s.Next(time.Now()); !next.IsZero() {
+		t.Errorf("Expected February 31st never to run, got %s", next)
+	}
+}
@@ -0,0 +1,267 @@
+// Package schedule runs recurring sync jobs against the DexPaprika API, such
+// as refreshing the top pools every few minutes or all tokens hourly.
+//
+// Jobs are registered on a Scheduler with a cron expression or interval and
+// share its client, so a client created with dexpaprika.WithRateLimit paces
+// the requests of all jobs together. A job that is still running when it
+// comes due again is skipped rather than started twice.
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// ErrDuplicateJob is returned when adding a job under a name already in use.
+var ErrDuplicateJob = errors.New("schedule: duplicate job")
+
+// Metric names recorded by a Scheduler with Metrics set. Every series is
+// labelled with the job name.
+const (
+	MetricRuns        = "schedule_runs_total"                     // Counter of completed runs, labelled with result "ok" or "error"
+	MetricRunDuration = "schedule_run_duration_seconds"           // Histogram of run durations
+	MetricSkipped     = "schedule_skipped_total"                  // Counter of runs skipped because the previous run was still going
+	MetricLastSuccess = "schedule_last_success_timestamp_seconds" // Gauge of the Unix time the last successful run finished
+)
+
+// JobFunc is the work done by a job on each run.
+type JobFunc func(ctx context.Context, client *dexpaprika.Client) error
+
+// JobStats describes a job and the outcome of its runs.
+type JobStats struct {
+	Name         string
+	Spec         string    // The expression the job was added with
+	Runs         int       // Completed runs, including failed ones
+	Failures     int       // Runs that returned an error
+	Skipped      int       // Runs skipped because the previous run was still going
+	Running      bool      // Whether a run is in progress
+	LastRun      time.Time // Start of the last completed run
+	LastDuration time.Duration
+	LastError    error // Error of the last completed run, or nil
+	Next         time.Time
+}
+
+type job struct {
+	stats    JobStats
+	schedule Schedule
+	fn       JobFunc
+}
+
+// Scheduler runs registered jobs on their schedules.
+type Scheduler struct {
+	client *dexpaprika.Client
+
+	// MaxConcurrent limits how many jobs run at once; jobs that come due
+	// while the limit is reached wait for a slot. Zero means no limit.
+	MaxConcurrent int
+
+	// OnError, if set, is called with the name and error of every failed
+	// run.
+	OnError func(job string, err error)
+
+	// Metrics, if set, receives the stats of every run as metrics.
+	Metrics dexpaprika.Metrics
+
+	mu   sync.Mutex
+	jobs map[string]*job
+	wake chan struct{}
+}
+
+// NewScheduler creates a scheduler whose jobs use client.
+func NewScheduler(client *dexpaprika.Client) *Scheduler {
+	return &Scheduler{
+		client: client,
+		jobs:   make(map[string]*job),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Add registers a job run on the schedule described by spec, which is
+// parsed with Parse.
+func (s *Scheduler) Add(name, spec string, fn JobFunc) error {
+	sched, err := Parse(spec)
+	if err != nil {
+		return err
+	}
+	return s.add(name, spec, sched, fn)
+}
+
+// AddSchedule registers a job run on a custom schedule. The job's Spec is
+// the schedule's String method, if it has one.
+func (s *Scheduler) AddSchedule(name string, sched Schedule, fn JobFunc) error {
+	var spec string
+	if str, ok := sched.(fmt.Stringer); ok {
+		spec = str.String()
+	}
+	return s.add(name, spec, sched, fn)
+}
+
+func (s *Scheduler) add(name, spec string, sched Schedule, fn JobFunc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateJob, name)
+	}
+	s.jobs[name] = &job{
+		stats:    JobStats{Name: name, Spec: spec, Next: sched.Next(time.Now())},
+		schedule: sched,
+		fn:       fn,
+	}
+	s.notify()
+	return nil
+}
+
+// Remove unregisters a job. A run in progress is not interrupted.
+func (s *Scheduler) Remove(name string) {
+	s.mu.Lock()
+	delete(s.jobs, name)
+	s.mu.Unlock()
+	s.notify()
+}
+
+// notify wakes Run to recompute the next due time.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Stats returns the stats of every job, ordered by name.
+func (s *Scheduler) Stats() []JobStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := make([]JobStats, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		stats = append(stats, j.stats)
+	}
+	sort.Slice(stats, func(i, k int) bool { return stats[i].Name < stats[k].Name })
+	return stats
+}
+
+// Run starts due jobs until ctx is done, then waits for runs in progress to
+// return and returns ctx.Err(). Jobs receive ctx; runs that fail after it is
+// done are not recorded.
+func (s *Scheduler) Run(ctx context.Context) error {
+	var sem chan struct{}
+	if s.MaxConcurrent > 0 {
+		sem = make(chan struct{}, s.MaxConcurrent)
+	}
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		now := time.Now()
+		next := s.startDue(ctx, now, sem, &wg)
+
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if !next.IsZero() {
+			timer = time.NewTimer(next.Sub(now))
+			fire = timer.C
+		}
+		select {
+		case <-fire:
+		case <-s.wake:
+		case <-ctx.Done():
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// startDue starts the jobs due at now and returns when the next job is due,
+// or the zero time if none is scheduled.
+func (s *Scheduler) startDue(ctx context.Context, now time.Time, sem chan struct{}, wg *sync.WaitGroup) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var next time.Time
+	for _, j := range s.jobs {
+		if j.stats.Next.IsZero() {
+			continue
+		}
+		if !j.stats.Next.After(now) {
+			if j.stats.Running {
+				j.stats.Skipped++
+				if s.Metrics != nil {
+					s.Metrics.Count(MetricSkipped, 1, map[string]string{"job": j.stats.Name})
+				}
+			} else {
+				j.stats.Running = true
+				wg.Add(1)
+				go func(j *job) {
+					defer wg.Done()
+					s.run(ctx, j, sem)
+				}(j)
+			}
+			j.stats.Next = j.schedule.Next(now)
+			if j.stats.Next.IsZero() {
+				continue
+			}
+		}
+		if next.IsZero() || j.stats.Next.Before(next) {
+			next = j.stats.Next
+		}
+	}
+	return next
+}
+
+// run runs j once, waiting for a slot in sem if set, and records the result.
+func (s *Scheduler) run(ctx context.Context, j *job, sem chan struct{}) {
+	if sem != nil {
+		select {
+		case sem <- struct{}{}:
+			defer func() { <-sem }()
+		case <-ctx.Done():
+			s.mu.Lock()
+			j.stats.Running = false
+			s.mu.Unlock()
+			return
+		}
+	}
+
+	start := time.Now()
+	err := j.fn(ctx, s.client)
+
+	s.mu.Lock()
+	j.stats.Running = false
+	if err != nil && ctx.Err() != nil {
+		// Interrupted by shutdown rather than failed
+		s.mu.Unlock()
+		return
+	}
+	j.stats.Runs++
+	j.stats.LastRun = start
+	j.stats.LastDuration = time.Since(start)
+	j.stats.LastError = err
+	if err != nil {
+		j.stats.Failures++
+	}
+	s.mu.Unlock()
+
+	if s.Metrics != nil {
+		labels := map[string]string{"job": j.stats.Name}
+		result := "ok"
+		if err != nil {
+			result = "error"
+		} else {
+			s.Metrics.Gauge(MetricLastSuccess, float64(time.Now().Unix()), labels)
+		}
+		s.Metrics.Observe(MetricRunDuration, time.Since(start).Seconds(), labels)
+		s.Metrics.Count(MetricRuns, 1, map[string]string{"job": j.stats.Name, "result": result})
+	}
+	if err != nil && s.OnError != nil {
+		s.OnError(j.stats.Name, err)
+	}
+}
@@ -0,0 +1,139 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestScheduler(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id": "ethereum", "display_name": "Ethereum"}]`))
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+	s := NewScheduler(client)
+	m := dexpaprika.NewInMemoryMetrics()
+	s.Metrics = m
+
+	var errs atomic.Int32
+	s.OnError = func(job string, err error) {
+		if job != "failing" {
+			t.Errorf("Unexpected error from %s: %v", job, err)
+		}
+		errs.Add(1)
+	}
+	if err := s.Add("networks", "@every 10ms", func(ctx context.Context, c *dexpaprika.Client) error {
+		_, err := c.Networks.List(ctx)
+		return err
+	}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if err := s.AddSchedule("failing", Every(10*time.Millisecond), func(context.Context, *dexpaprika.Client) error {
+		return errors.New("boom")
+	}); err != nil {
+		t.Fatalf("AddSchedule returned error: %v", err)
+	}
+	if err := s.Add("networks", "@hourly", nil); !errors.Is(err, ErrDuplicateJob) {
+		t.Errorf("Expected ErrDuplicateJob, got %v", err)
+	}
+	if err := s.Add("bad", "* *", nil); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("Expected ErrInvalidSchedule, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 75*time.Millisecond)
+	defer cancel()
+	if err := s.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Run returned %v", err)
+	}
+
+	stats := s.Stats()
+	if len(stats) != 2 || stats[0].Name != "failing" || stats[1].Name != "networks" {
+		t.Fatalf("Unexpected stats %+v", stats)
+	}
+	failing, networks := stats[0], stats[1]
+	if networks.Runs < 3 || networks.Failures != 0 || networks.LastError != nil || int(requests.Load()) < networks.Runs {
+		t.Errorf("Unexpected networks stats %+v after %d requests", networks, requests.Load())
+	}
+	if v := m.Value(MetricRuns, map[string]string{"job": "networks", "result": "ok"}); int(v) != networks.Runs {
+		t.Errorf("Expected %d runs in metrics, got %v", networks.Runs, v)
+	}
+	if v := m.Value(MetricRuns, map[string]string{"job": "failing", "result": "error"}); int(v) != failing.Runs {
+		t.Errorf("Expected %d failed runs in metrics, got %v", failing.Runs, v)
+	}
+	if samples := m.Samples(MetricRunDuration, map[string]string{"job": "networks"}); len(samples) != networks.Runs {
+		t.Errorf("Expected a duration sample per run, got %d", len(samples))
+	}
+	if m.Value(MetricLastSuccess, map[string]string{"job": "networks"}) == 0 || m.Value(MetricLastSuccess, map[string]string{"job": "failing"}) != 0 {
+		t.Error("Expected a last success only for the succeeding job")
+	}
+	if failing.Spec != "@every 10ms" || failing.Runs < 3 || failing.Failures != failing.Runs || int(errs.Load()) != failing.Runs {
+		t.Errorf("Unexpected failing stats %+v", failing)
+	}
+}
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	s := NewScheduler(nil)
+	var running, overlaps atomic.Int32
+	s.Add("slow", "@every 5ms", func(ctx context.Context, _ *dexpaprika.Client) error {
+		if running.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		defer running.Add(-1)
+		select {
+		case <-time.After(30 * time.Millisecond):
+		case <-ctx.Done():
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	s.Run(ctx)
+
+	if overlaps.Load() != 0 {
+		t.Errorf("Expected no overlapping runs, got %d", overlaps.Load())
+	}
+	if st := s.Stats()[0]; st.Skipped == 0 || st.Running {
+		t.Errorf("Expected skipped runs and none in progress after Run, got %+v", st)
+	}
+}
+
+func TestScheduler_MaxConcurrent(t *testing.T) {
+	s := NewScheduler(nil)
+	s.MaxConcurrent = 1
+	var running, peak atomic.Int32
+	job := func(ctx context.Context, _ *dexpaprika.Client) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	}
+	s.Add("a", "@every 5ms", job)
+	s.Add("b", "@every 5ms", job)
+	s.Add("c", "@every 5ms", job)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Millisecond)
+	defer cancel()
+	s.Run(ctx)
+
+	if peak.Load() != 1 {
+		t.Errorf("Expected at most one job at a time, got %d", peak.Load())
+	}
+}
@@ -2,8 +2,9 @@ package dexpaprika
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
-	"net/url"
+	"strings"
 )
 
 // SearchService handles communication with the search related
@@ -12,44 +13,228 @@ type SearchService struct {
 	client *Client
 }
 
-// DexInfo represents basic information about a DEX in search results.
-type DexInfo struct {
-	ID           string  `json:"id"`
-	DexID        string  `json:"dex_id"`
-	DexName      string  `json:"dex_name"`
-	Chain        string  `json:"chain"`
-	VolumeUSD24h float64 `json:"volume_usd_24h"`
-	Txns24h      int     `json:"txns_24h"`
-	PoolsCount   int     `json:"pools_count"`
-	Protocol     string  `json:"protocol"`
-	CreatedAt    string  `json:"created_at"`
+// SearchToken represents a token entry in search results.
+type SearchToken struct {
+	ID             string   `json:"id" csv:"id"`
+	Name           string   `json:"name" csv:"name"`
+	Symbol         string   `json:"symbol" csv:"symbol"`
+	Chain          string   `json:"chain" csv:"chain"`
+	Type           string   `json:"type" csv:"type"`
+	Status         string   `json:"status" csv:"status"`
+	Decimals       int      `json:"decimals" csv:"decimals"`
+	TotalSupply    float64  `json:"total_supply" csv:"total_supply"`
+	Description    string   `json:"description" csv:"description"`
+	Website        string   `json:"website" csv:"website"`
+	Explorer       string   `json:"explorer" csv:"explorer"`
+	PriceUSD       float64  `json:"price_usd" csv:"price_usd"`
+	LiquidityUSD   float64  `json:"liquidity_usd" csv:"liquidity_usd"`
+	VolumeUSD      float64  `json:"volume_usd" csv:"volume_usd"`
+	PriceUSDChange *float64 `json:"price_usd_change,omitempty" csv:"price_usd_change"`
+
+	// Extra holds any fields returned by the API that are not modeled above
+	Extra map[string]json.RawMessage `json:"-" csv:"-"`
+}
+
+// SearchPool represents a pool entry in search results.
+type SearchPool struct {
+	ID                    string  `json:"id" csv:"id"`
+	Name                  string  `json:"name" csv:"name"`
+	DexID                 string  `json:"dex_id" csv:"dex_id"`
+	DexName               string  `json:"dex_name" csv:"dex_name"`
+	Chain                 string  `json:"chain" csv:"chain"`
+	VolumeUSD             float64 `json:"volume_usd" csv:"volume_usd"`
+	VolumeUSD24h          float64 `json:"volume_usd_24h" csv:"volume_usd_24h"`
+	CreatedAt             string  `json:"created_at" csv:"created_at"`
+	CreatedAtBlockNumber  int64   `json:"created_at_block_number" csv:"created_at_block_number"`
+	Transactions          int     `json:"transactions" csv:"transactions"`
+	PriceUSD              float64 `json:"price_usd" csv:"price_usd"`
+	LastPriceChangeUSD5m  float64 `json:"last_price_change_usd_5m" csv:"last_price_change_usd_5m"`
+	LastPriceChangeUSD1h  float64 `json:"last_price_change_usd_1h" csv:"last_price_change_usd_1h"`
+	LastPriceChangeUSD24h float64 `json:"last_price_change_usd_24h" csv:"last_price_change_usd_24h"`
+	Fee                   float64 `json:"fee" csv:"fee"`
+	Tokens                []Token `json:"tokens" csv:"token"`
+
+	// Extra holds any fields returned by the API that are not modeled above
+	Extra map[string]json.RawMessage `json:"-" csv:"-"`
+}
+
+// TokenDetails converts the search entry into the TokenDetails model.
+func (t *SearchToken) TokenDetails() TokenDetails {
+	return TokenDetails{
+		ID:          t.ID,
+		Name:        t.Name,
+		Symbol:      t.Symbol,
+		Chain:       t.Chain,
+		Decimals:    t.Decimals,
+		TotalSupply: t.TotalSupply,
+		Description: t.Description,
+		Website:     t.Website,
+		Explorer:    t.Explorer,
+		Summary: &TokenSummary{
+			PriceUSD:     t.PriceUSD,
+			LiquidityUSD: t.LiquidityUSD,
+		},
+	}
 }
 
-// SearchResult represents the structure of a search response.
-type SearchResult struct {
-	Tokens []TokenDetails `json:"tokens"`
-	Pools  []Pool         `json:"pools"`
-	Dexes  []DexInfo      `json:"dexes"`
+// Pool converts the search entry into the Pool model used by pool listings.
+func (p *SearchPool) Pool() Pool {
+	return Pool{
+		ID:                    p.ID,
+		DexID:                 p.DexID,
+		DexName:               p.DexName,
+		Chain:                 p.Chain,
+		VolumeUSD:             p.VolumeUSD,
+		CreatedAt:             p.CreatedAt,
+		CreatedAtBlockNumber:  p.CreatedAtBlockNumber,
+		Transactions:          p.Transactions,
+		PriceUSD:              p.PriceUSD,
+		LastPriceChangeUSD5m:  p.LastPriceChangeUSD5m,
+		LastPriceChangeUSD1h:  p.LastPriceChangeUSD1h,
+		LastPriceChangeUSD24h: p.LastPriceChangeUSD24h,
+		Fee:                   p.Fee,
+		Tokens:                p.Tokens,
+	}
+}
+
+// SearchOptions narrows search results. The search endpoint accepts only a
+// query, so the options are applied client-side after decoding.
+type SearchOptions struct {
+	// Limit caps the number of results kept per category. Zero keeps all.
+	Limit int
+	// Chain keeps only results on the given network.
+	Chain string
+	// MinLiquidityUSD drops tokens with less liquidity. Pools and DEXes in
+	// search results carry no liquidity figure and are not affected.
+	MinLiquidityUSD float64
 }
 
 // Search performs a search across tokens, pools, and DEXes.
 // Implements the search operation from the OpenAPI spec.
-func (s *SearchService) Search(ctx context.Context, query string) (*SearchResult, error) {
-	req, err := s.client.NewRequest(http.MethodGet, "/search", nil)
-	if err != nil {
+func (s *SearchService) Search(ctx context.Context, query string, reqOpts ...RequestOption) (*SearchResult, error) {
+	return s.SearchWithOptions(ctx, query, nil, reqOpts...)
+}
+
+// SearchWithOptions performs a search across tokens, pools, and DEXes and
+// filters the results with opts.
+func (s *SearchService) SearchWithOptions(ctx context.Context, query string, opts *SearchOptions, reqOpts ...RequestOption) (*SearchResult, error) {
+	var result SearchResult
+	cfg := newRequestConfig(reqOpts)
+	if err := s.search(ctx, query, cfg.decodeInto(&result)); err != nil {
 		return nil, err
 	}
 
-	q := req.URL.Query()
-	q.Add("query", url.QueryEscape(query))
-	req.URL.RawQuery = q.Encode()
+	if cfg.target != nil {
+		return nil, nil
+	}
 
-	var result SearchResult
-	r, err := s.client.Do(ctx, req, &result)
-	if err != nil {
+	result.Tokens = opts.filterTokens(result.Tokens)
+	result.Pools = opts.filterPools(result.Pools)
+	result.Dexes = opts.filterDexes(result.Dexes)
+
+	return &result, nil
+}
+
+// Tokens performs a search and returns only the matching tokens, filtered
+// with opts. The pool and DEX categories of the response are skipped during
+// decoding.
+func (s *SearchService) Tokens(ctx context.Context, query string, opts *SearchOptions) ([]SearchToken, error) {
+	var result struct {
+		Tokens []SearchToken `json:"tokens"`
+	}
+	if err := s.search(ctx, query, &result); err != nil {
 		return nil, err
 	}
+	return opts.filterTokens(result.Tokens), nil
+}
+
+// Pools performs a search and returns only the matching pools, filtered with
+// opts. The token and DEX categories of the response are skipped during
+// decoding.
+func (s *SearchService) Pools(ctx context.Context, query string, opts *SearchOptions) ([]SearchPool, error) {
+	var result struct {
+		Pools []SearchPool `json:"pools"`
+	}
+	if err := s.search(ctx, query, &result); err != nil {
+		return nil, err
+	}
+	return opts.filterPools(result.Pools), nil
+}
+
+// Dexes performs a search and returns only the matching DEXes, filtered with
+// opts. The token and pool categories of the response are skipped during
+// decoding.
+func (s *SearchService) Dexes(ctx context.Context, query string, opts *SearchOptions) ([]DexInfo, error) {
+	var result struct {
+		Dexes []DexInfo `json:"dexes"`
+	}
+	if err := s.search(ctx, query, &result); err != nil {
+		return nil, err
+	}
+	return opts.filterDexes(result.Dexes), nil
+}
+
+func (o *SearchOptions) filterTokens(tokens []SearchToken) []SearchToken {
+	if o == nil {
+		return tokens
+	}
+	return filterSearch(tokens, o.Limit, func(t SearchToken) bool {
+		return o.matchesChain(t.Chain) && t.LiquidityUSD >= o.MinLiquidityUSD
+	})
+}
+
+func (o *SearchOptions) filterPools(pools []SearchPool) []SearchPool {
+	if o == nil {
+		return pools
+	}
+	return filterSearch(pools, o.Limit, func(p SearchPool) bool {
+		return o.matchesChain(p.Chain)
+	})
+}
+
+func (o *SearchOptions) filterDexes(dexes []DexInfo) []DexInfo {
+	if o == nil {
+		return dexes
+	}
+	return filterSearch(dexes, o.Limit, func(d DexInfo) bool {
+		return o.matchesChain(d.Chain)
+	})
+}
+
+func (o *SearchOptions) matchesChain(chain string) bool {
+	return o.Chain == "" || strings.EqualFold(o.Chain, chain)
+}
+
+// filterSearch keeps the items matching keep, in order, up to limit items.
+func filterSearch[T any](items []T, limit int, keep func(T) bool) []T {
+	kept := items[:0]
+	for _, item := range items {
+		if limit > 0 && len(kept) >= limit {
+			break
+		}
+		if keep(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// search calls the search endpoint and decodes the response into v. The API
+// has no category filter, so scoping happens by decoding into a narrower type.
+func (s *SearchService) search(ctx context.Context, query string, v interface{}) error {
+	q := newQuery("/search")
+	q.addString("query", query)
+
+	req, err := s.client.NewRequest(http.MethodGet, q.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	r, err := s.client.Do(ctx, req, v)
+	if err != nil {
+		return err
+	}
 	defer r.Body.Close()
 
-	return &result, nil
+	return nil
 }
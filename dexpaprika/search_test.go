@@ -7,6 +7,8 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/internal/httpassert"
 )
 
 func TestSearch_Search(t *testing.T) {
@@ -309,6 +311,78 @@ func TestSearch_Success(t *testing.T) {
 	}
 }
 
+func TestSearch_DecodesSearchModels(t *testing.T) {
+	mockResponse := `{
+		"tokens": [
+			{
+				"id": "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
+				"name": "Wrapped Ether",
+				"symbol": "WETH",
+				"chain": "ethereum",
+				"type": "token",
+				"status": "active",
+				"price_usd": 3000.5,
+				"liquidity_usd": 1000000,
+				"volume_usd": "250000",
+				"price_usd_change": -1.5,
+				"rank": 3
+			}
+		],
+		"pools": [
+			{
+				"id": "0xpool",
+				"name": "WETH/USDC",
+				"dex_name": "Uniswap V3",
+				"chain": "ethereum",
+				"volume_usd_24h": 5000000,
+				"tokens": [{"id": "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", "symbol": "WETH"}]
+			}
+		],
+		"dexes": []
+	}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, mockResponse)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+
+	results, err := client.Search.Search(context.Background(), "weth")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(results.Tokens) != 1 || len(results.Pools) != 1 {
+		t.Fatalf("Expected 1 token and 1 pool, got %d and %d", len(results.Tokens), len(results.Pools))
+	}
+
+	token := results.Tokens[0]
+	if token.PriceUSD != 3000.5 || token.VolumeUSD != 250000 || token.Type != "token" {
+		t.Errorf("Unexpected token fields: %+v", token)
+	}
+	if token.PriceUSDChange == nil || *token.PriceUSDChange != -1.5 {
+		t.Errorf("Expected price change -1.5, got %v", token.PriceUSDChange)
+	}
+	if _, ok := token.Extra["rank"]; !ok || len(token.Extra) != 1 {
+		t.Errorf("Expected only 'rank' in Extra, got %v", token.Extra)
+	}
+	if details := token.TokenDetails(); details.Summary.PriceUSD != 3000.5 {
+		t.Errorf("Expected converted summary price 3000.5, got %f", details.Summary.PriceUSD)
+	}
+
+	pool := results.Pools[0]
+	if pool.Name != "WETH/USDC" || pool.VolumeUSD24h != 5000000 {
+		t.Errorf("Unexpected pool fields: %+v", pool)
+	}
+	if pool.Extra != nil {
+		t.Errorf("Expected no extra pool fields, got %v", pool.Extra)
+	}
+	if converted := pool.Pool(); converted.ID != "0xpool" || len(converted.Tokens) != 1 {
+		t.Errorf("Unexpected converted pool: %+v", converted)
+	}
+}
+
 func TestSearch_ServerErrors(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -395,3 +469,119 @@ func TestSearch_Timeout(t *testing.T) {
 		t.Fatal("Expected error due to timeout, got nil")
 	}
 }
+
+func TestSearch_ScopedMethods(t *testing.T) {
+	mockResponse := `{
+		"tokens": [{"id": "0xweth", "symbol": "WETH", "chain": "ethereum"}],
+		"pools": [{"id": "0xpool1", "dex_name": "Uniswap V3"}, {"id": "0xpool2", "dex_name": "SushiSwap"}],
+		"dexes": [{"id": "uniswap_v3", "dex_name": "Uniswap V3", "protocol": "uniswap_v3"}]
+	}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpassert.Request(t, r, http.MethodGet, "/search")
+		httpassert.ExactParams(t, r, httpassert.Query{"query": "weth"})
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, mockResponse)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	tokens, err := client.Search.Tokens(ctx, "weth", nil)
+	if err != nil {
+		t.Fatalf("Search.Tokens returned error: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].Symbol != "WETH" {
+		t.Errorf("Unexpected tokens: %+v", tokens)
+	}
+
+	pools, err := client.Search.Pools(ctx, "weth", nil)
+	if err != nil {
+		t.Fatalf("Search.Pools returned error: %v", err)
+	}
+	if len(pools) != 2 || pools[1].DexName != "SushiSwap" {
+		t.Errorf("Unexpected pools: %+v", pools)
+	}
+
+	dexes, err := client.Search.Dexes(ctx, "weth", nil)
+	if err != nil {
+		t.Fatalf("Search.Dexes returned error: %v", err)
+	}
+	if len(dexes) != 1 || dexes[0].Protocol != "uniswap_v3" {
+		t.Errorf("Unexpected dexes: %+v", dexes)
+	}
+}
+
+func TestSearch_EscapesQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpassert.Request(t, r, http.MethodGet, "/search")
+		httpassert.ExactParams(t, r, httpassert.Query{"query": "USD Coin & co/1"})
+		if r.URL.RawQuery != "query=USD+Coin+%26+co%2F1" {
+			t.Errorf("Query sent as %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"tokens": [], "pools": [], "dexes": []}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	if _, err := client.Search.Search(context.Background(), "USD Coin & co/1"); err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+}
+
+func TestSearch_WithOptions(t *testing.T) {
+	mockResponse := `{
+		"tokens": [
+			{"id": "0xa", "chain": "ethereum", "liquidity_usd": 500},
+			{"id": "0xb", "chain": "ethereum", "liquidity_usd": 50000},
+			{"id": "b1", "chain": "solana", "liquidity_usd": 90000},
+			{"id": "0xc", "chain": "ethereum", "liquidity_usd": 70000},
+			{"id": "0xd", "chain": "ethereum", "liquidity_usd": 80000}
+		],
+		"pools": [{"id": "p1", "chain": "solana"}, {"id": "p2", "chain": "ethereum"}],
+		"dexes": [{"id": "d1", "chain": "Ethereum"}, {"id": "d2", "chain": "bsc"}]
+	}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, mockResponse)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	ctx := context.Background()
+	opts := &SearchOptions{Limit: 2, Chain: "ethereum", MinLiquidityUSD: 10000}
+
+	results, err := client.Search.SearchWithOptions(ctx, "x", opts)
+	if err != nil {
+		t.Fatalf("SearchWithOptions returned error: %v", err)
+	}
+	if len(results.Tokens) != 2 || results.Tokens[0].ID != "0xb" || results.Tokens[1].ID != "0xc" {
+		t.Errorf("Unexpected tokens: %+v", results.Tokens)
+	}
+	if len(results.Pools) != 1 || results.Pools[0].ID != "p2" {
+		t.Errorf("Unexpected pools: %+v", results.Pools)
+	}
+	if len(results.Dexes) != 1 || results.Dexes[0].ID != "d1" {
+		t.Errorf("Unexpected dexes: %+v", results.Dexes)
+	}
+
+	tokens, err := client.Search.Tokens(ctx, "x", &SearchOptions{Chain: "solana"})
+	if err != nil {
+		t.Fatalf("Search.Tokens returned error: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].ID != "b1" {
+		t.Errorf("Unexpected tokens: %+v", tokens)
+	}
+
+	// Without options nothing is filtered
+	all, err := client.Search.Search(ctx, "x")
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(all.Tokens) != 5 || len(all.Pools) != 2 || len(all.Dexes) != 2 {
+		t.Errorf("Expected unfiltered results, got %d/%d/%d", len(all.Tokens), len(all.Pools), len(all.Dexes))
+	}
+}
@@ -0,0 +1,27 @@
+package dexpaprika
+
+import "context"
+
+// Sink receives rows in batches, such as a file or a database table.
+// Implementations may buffer written rows until Flush or Close. The export
+// subpackage provides CSV, JSON Lines and Postgres sinks.
+type Sink[T any] interface {
+	Write(ctx context.Context, rows []T) error
+	Flush(ctx context.Context) error
+	Close() error
+}
+
+// drain writes every remaining page of a paginator to sink, then flushes it.
+func drain[T any](ctx context.Context, p Paginator, page func() []T, sink Sink[T]) error {
+	for p.HasNextPage() {
+		if err := p.GetNextPage(ctx); err != nil {
+			return err
+		}
+		if rows := page(); len(rows) > 0 {
+			if err := sink.Write(ctx, rows); err != nil {
+				return err
+			}
+		}
+	}
+	return sink.Flush(ctx)
+}
@@ -0,0 +1,69 @@
+package dexpaprika
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// sliceSink is a Sink collecting rows in memory.
+type sliceSink[T any] struct {
+	rows    []T
+	writes  int
+	flushes int
+}
+
+func (s *sliceSink[T]) Write(ctx context.Context, rows []T) error {
+	s.rows = append(s.rows, rows...)
+	s.writes++
+	return nil
+}
+
+func (s *sliceSink[T]) Flush(ctx context.Context) error {
+	s.flushes++
+	return nil
+}
+
+func (s *sliceSink[T]) Close() error { return nil }
+
+func TestPoolsPaginator_Drain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			fmt.Fprint(w, `{"pools":[{"id":"0xc"}],"page_info":{"page":1,"total_pages":2}}`)
+			return
+		}
+		fmt.Fprint(w, `{"pools":[{"id":"0xa"},{"id":"0xb"}],"page_info":{"page":0,"total_pages":2}}`)
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+
+	sink := &sliceSink[Pool]{}
+	err := NewPoolsPaginator(client, &ListOptions{Limit: 2}).ForNetwork("ethereum").Drain(context.Background(), sink)
+	if err != nil {
+		t.Fatalf("Drain returned error: %v", err)
+	}
+	if len(sink.rows) != 3 || sink.rows[2].ID != "0xc" {
+		t.Errorf("Drain wrote %v, want pools 0xa, 0xb and 0xc", sink.rows)
+	}
+	if sink.writes != 2 || sink.flushes != 1 {
+		t.Errorf("Got %d writes and %d flushes, want one write per page and one flush", sink.writes, sink.flushes)
+	}
+}
+
+func TestDexesPaginator_DrainError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+
+	sink := &sliceSink[Dex]{}
+	if err := NewDexesPaginator(client, "ethereum", 10).Drain(context.Background(), sink); err == nil {
+		t.Fatal("Expected the API error")
+	}
+	if sink.flushes != 0 {
+		t.Error("Expected no flush after a failed page")
+	}
+}
@@ -0,0 +1,178 @@
+//go:build smoke
+
+package dexpaprika_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// TestSmoke checks that the SDK still works against the live API, walking
+// every endpoint with IDs discovered along the way. It only runs with the
+// smoke build tag:
+//
+//	go test -tags smoke -run Smoke ./dexpaprika/
+//
+// DEXPAPRIKA_BASE_URL points it at another deployment, such as staging.
+func TestSmoke(t *testing.T) {
+	opts := []dexpaprika.ClientOption{
+		dexpaprika.WithRetryConfig(3, time.Second, 10*time.Second),
+		dexpaprika.WithRateLimit(2),
+	}
+	if base := os.Getenv("DEXPAPRIKA_BASE_URL"); base != "" {
+		opts = append(opts, dexpaprika.WithBaseURL(base))
+	}
+	client := dexpaprika.NewClient(opts...)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// IDs discovered by earlier steps
+	var network, dex, pool, token string
+
+	steps := []struct {
+		name string
+		fn   func(t *testing.T)
+	}{
+		{"Ping", func(t *testing.T) {
+			if err := client.Ping(ctx); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"Networks", func(t *testing.T) {
+			networks, err := client.Networks.List(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(networks) == 0 {
+				t.Fatal("no networks")
+			}
+			network = networks[0].ID
+			for _, n := range networks {
+				if n.ID == dexpaprika.NetworkEthereum {
+					network = n.ID
+				}
+			}
+		}},
+		{"Dexes", func(t *testing.T) {
+			resp, err := client.Networks.ListDexes(ctx, network, 0, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Dexes) == 0 {
+				t.Fatalf("no DEXes on %s", network)
+			}
+			dex = resp.Dexes[0].ID
+		}},
+		{"TopPools", func(t *testing.T) {
+			resp, err := client.Pools.List(ctx, &dexpaprika.ListOptions{Limit: 5, OrderBy: "volume_usd", Sort: "desc"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Pools) == 0 {
+				t.Fatal("no pools")
+			}
+		}},
+		{"NetworkPools", func(t *testing.T) {
+			resp, err := client.Pools.ListByNetwork(ctx, network, &dexpaprika.ListOptions{Limit: 5, OrderBy: "volume_usd", Sort: "desc"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Pools) == 0 || len(resp.Pools[0].Tokens) == 0 {
+				t.Fatalf("no pools with tokens on %s", network)
+			}
+			pool = resp.Pools[0].ID
+			token = resp.Pools[0].Tokens[0].ID
+		}},
+		{"DexPools", func(t *testing.T) {
+			if _, err := client.Pools.ListByDex(ctx, network, dex, &dexpaprika.ListOptions{Limit: 5}); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"PoolDetails", func(t *testing.T) {
+			details, err := client.Pools.GetDetails(ctx, network, pool, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if details.ID == "" || len(details.Tokens) < 2 {
+				t.Errorf("details of %s are incomplete: %s", pool, details)
+			}
+		}},
+		{"PoolOHLCV", func(t *testing.T) {
+			start := time.Now().Add(-24 * time.Hour).Format("2006-01-02")
+			candles, err := client.Pools.GetOHLCV(ctx, network, pool, &dexpaprika.OHLCVOptions{Start: start, Interval: "1h", Limit: 5})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(candles) == 0 {
+				t.Errorf("no candles for %s since %s", pool, start)
+			}
+		}},
+		{"PoolTransactions", func(t *testing.T) {
+			resp, err := client.Pools.GetTransactions(ctx, network, pool, 0, 5, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Transactions) == 0 {
+				t.Errorf("no transactions in %s", pool)
+			}
+		}},
+		{"TokenDetails", func(t *testing.T) {
+			details, err := client.Tokens.GetDetails(ctx, network, token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if details.Symbol == "" {
+				t.Errorf("token %s has no symbol", token)
+			}
+		}},
+		{"TokenPools", func(t *testing.T) {
+			resp, err := client.Tokens.GetPools(ctx, network, token, &dexpaprika.ListOptions{Limit: 5}, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Pools) == 0 {
+				t.Errorf("no pools with %s", token)
+			}
+		}},
+		{"Search", func(t *testing.T) {
+			results, err := client.Search.Search(ctx, "uniswap")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results.Tokens)+len(results.Pools)+len(results.Dexes) == 0 {
+				t.Error("no search results for uniswap")
+			}
+		}},
+		{"Stats", func(t *testing.T) {
+			stats, err := client.Utils.GetStats(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stats.Chains == 0 || stats.Pools == 0 {
+				t.Errorf("stats are empty: %+v", stats)
+			}
+		}},
+		{"NotFound", func(t *testing.T) {
+			_, err := client.Pools.GetDetails(ctx, network, "0x0000000000000000000000000000000000000000", nil)
+			if !errors.Is(err, dexpaprika.ErrNotFound) {
+				t.Errorf("unknown pool error = %v, want ErrNotFound", err)
+			}
+		}},
+	}
+
+	for _, step := range steps {
+		if !t.Run(step.name, step.fn) {
+			// Later steps depend on the IDs found by earlier ones
+			if network == "" || pool == "" {
+				t.FailNow()
+			}
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package dexpaprika
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// SnapshotVersion is the schema version written by Snapshot.Save. Load
+// accepts snapshots of this and earlier versions.
+const SnapshotVersion = 1
+
+// ErrSnapshotVersion is returned when loading a snapshot with a missing or
+// newer schema version.
+var ErrSnapshotVersion = errors.New("unsupported snapshot version")
+
+// Snapshot is a frozen set of API results, saved as versioned JSON, so
+// research notebooks and tests can work against data captured from
+// production without calling the API.
+type Snapshot struct {
+	Version   int               `json:"version"`
+	CreatedAt time.Time         `json:"created_at"`
+	Labels    map[string]string `json:"labels,omitempty"` // E.g. where and why it was captured
+
+	Networks    []Network        `json:"networks,omitempty"`
+	Dexes       map[string][]Dex `json:"dexes,omitempty"` // By network ID
+	Pools       []Pool           `json:"pools,omitempty"`
+	PoolDetails []*PoolDetails   `json:"pool_details,omitempty"`
+	Tokens      []*TokenDetails  `json:"tokens,omitempty"`
+
+	// Transactions and OHLCV are keyed by pool, see SetTransactions and
+	// SetOHLCV.
+	Transactions map[string][]Transaction `json:"transactions,omitempty"`
+	OHLCV        map[string][]OHLCVRecord `json:"ohlcv,omitempty"`
+
+	// Data holds other results by name, see Put.
+	Data map[string]json.RawMessage `json:"data,omitempty"`
+}
+
+// NewSnapshot creates an empty snapshot of the current version.
+func NewSnapshot() *Snapshot {
+	return &Snapshot{Version: SnapshotVersion, CreatedAt: time.Now().UTC()}
+}
+
+// SetTransactions stores the transactions of a pool.
+func (s *Snapshot) SetTransactions(networkID, poolAddress string, txs []Transaction) {
+	if s.Transactions == nil {
+		s.Transactions = make(map[string][]Transaction)
+	}
+	s.Transactions[NewEntityKey(networkID, poolAddress).String()] = txs
+}
+
+// GetTransactions returns the stored transactions of a pool.
+func (s *Snapshot) GetTransactions(networkID, poolAddress string) ([]Transaction, bool) {
+	txs, ok := s.Transactions[NewEntityKey(networkID, poolAddress).String()]
+	return txs, ok
+}
+
+// SetOHLCV stores the candles of a pool for an interval.
+func (s *Snapshot) SetOHLCV(networkID, poolAddress, interval string, records []OHLCVRecord) {
+	if s.OHLCV == nil {
+		s.OHLCV = make(map[string][]OHLCVRecord)
+	}
+	s.OHLCV[ohlcvSnapshotKey(networkID, poolAddress, interval)] = records
+}
+
+// GetOHLCV returns the stored candles of a pool for an interval.
+func (s *Snapshot) GetOHLCV(networkID, poolAddress, interval string) ([]OHLCVRecord, bool) {
+	records, ok := s.OHLCV[ohlcvSnapshotKey(networkID, poolAddress, interval)]
+	return records, ok
+}
+
+func ohlcvSnapshotKey(networkID, poolAddress, interval string) string {
+	return NewEntityKey(networkID, poolAddress).String() + ":" + interval
+}
+
+// Put stores any JSON-encodable result under name, for results without a
+// field of their own.
+func (s *Snapshot) Put(name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("snapshot %s: %w", name, err)
+	}
+	if s.Data == nil {
+		s.Data = make(map[string]json.RawMessage)
+	}
+	s.Data[name] = data
+	return nil
+}
+
+// Get decodes the result stored under name into v and reports whether it
+// was found.
+func (s *Snapshot) Get(name string, v any) (bool, error) {
+	data, ok := s.Data[name]
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return true, fmt.Errorf("snapshot %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// Save writes the snapshot to w as JSON, with the current schema version.
+func (s *Snapshot) Save(w io.Writer) error {
+	s.Version = SnapshotVersion
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = time.Now().UTC()
+	}
+	return json.NewEncoder(w).Encode(s)
+}
+
+// Load replaces the snapshot with one read from r. It returns an error
+// wrapping ErrSnapshotVersion if the snapshot has no version or a newer one
+// than this SDK supports.
+func (s *Snapshot) Load(r io.Reader) error {
+	var loaded Snapshot
+	if err := json.NewDecoder(r).Decode(&loaded); err != nil {
+		return fmt.Errorf("decode snapshot: %w", err)
+	}
+	if loaded.Version < 1 || loaded.Version > SnapshotVersion {
+		return fmt.Errorf("%w: %d (supported: 1 to %d)", ErrSnapshotVersion, loaded.Version, SnapshotVersion)
+	}
+	*s = loaded
+	return nil
+}
+
+// SaveFile saves the snapshot to a file, gzip-compressed if the path ends
+// in ".gz".
+func (s *Snapshot) SaveFile(path string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	if !strings.HasSuffix(path, ".gz") {
+		return s.Save(f)
+	}
+	zw := gzip.NewWriter(f)
+	if err := s.Save(zw); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// LoadSnapshotFile loads a snapshot saved by SaveFile.
+func LoadSnapshotFile(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("decode snapshot: %w", err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+	s := &Snapshot{}
+	if err := s.Load(r); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,78 @@
+package dexpaprika
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSnapshot_SaveLoad(t *testing.T) {
+	s := NewSnapshot()
+	s.Labels = map[string]string{"source": "production"}
+	s.Networks = []Network{{ID: "ethereum", DisplayName: "Ethereum"}}
+	s.Pools = []Pool{{ID: "0xa", VolumeUSD: 1.5}}
+	s.SetTransactions("ethereum", "0xABC", []Transaction{{ID: "0xt", Amount0: "1.5"}})
+	s.SetOHLCV("ethereum", "0xABC", "1h", []OHLCVRecord{{TimeOpen: "2024-01-01T00:00:00Z", Close: 2}})
+	if err := s.Put("movers", map[string]float64{"WETH": 5}); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := s.Save(&buf); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	loaded := &Snapshot{}
+	if err := loaded.Load(&buf); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if loaded.Version != SnapshotVersion || !loaded.CreatedAt.Equal(s.CreatedAt) || loaded.Labels["source"] != "production" {
+		t.Errorf("Unexpected snapshot metadata %+v", loaded)
+	}
+	if len(loaded.Pools) != 1 || loaded.Pools[0].VolumeUSD != 1.5 {
+		t.Errorf("Unexpected pools %v", loaded.Pools)
+	}
+	// Keys are normalized, so lookups ignore address case
+	if txs, ok := loaded.GetTransactions("ethereum", "0xabc"); !ok || len(txs) != 1 || txs[0].Amount0 != "1.5" {
+		t.Errorf("GetTransactions = %v, %v", txs, ok)
+	}
+	if records, ok := loaded.GetOHLCV("ethereum", "0xabc", "1h"); !ok || records[0].Close != 2 {
+		t.Errorf("GetOHLCV = %v, %v", records, ok)
+	}
+	if _, ok := loaded.GetOHLCV("ethereum", "0xabc", "24h"); ok {
+		t.Error("GetOHLCV found candles of another interval")
+	}
+	var movers map[string]float64
+	if ok, err := loaded.Get("movers", &movers); !ok || err != nil || movers["WETH"] != 5 {
+		t.Errorf("Get = %v, %v, %v", movers, ok, err)
+	}
+}
+
+func TestSnapshot_LoadVersion(t *testing.T) {
+	for _, data := range []string{`{"pools":[]}`, `{"version":99}`} {
+		err := (&Snapshot{}).Load(strings.NewReader(data))
+		if !errors.Is(err, ErrSnapshotVersion) {
+			t.Errorf("Load(%s) returned %v, want ErrSnapshotVersion", data, err)
+		}
+	}
+}
+
+func TestSnapshot_File(t *testing.T) {
+	for _, name := range []string{"snapshot.json", "snapshot.json.gz"} {
+		path := filepath.Join(t.TempDir(), name)
+		s := NewSnapshot()
+		s.Tokens = []*TokenDetails{{ID: "0xt", Symbol: "WETH"}}
+		if err := s.SaveFile(path); err != nil {
+			t.Fatalf("SaveFile(%s) returned error: %v", name, err)
+		}
+		loaded, err := LoadSnapshotFile(path)
+		if err != nil {
+			t.Fatalf("LoadSnapshotFile(%s) returned error: %v", name, err)
+		}
+		if len(loaded.Tokens) != 1 || loaded.Tokens[0].Symbol != "WETH" {
+			t.Errorf("Loaded tokens %v from %s", loaded.Tokens, name)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package dexpaprika
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServerSentEvent is an event received from a text/event-stream response.
+type ServerSentEvent struct {
+	ID    string // Last event ID, carried over from earlier events if not set
+	Event string // Event type, "message" if not set
+	Data  string // Data lines joined with newlines
+	Retry time.Duration
+}
+
+// maxSSELine caps the length of a single line in an event stream.
+const maxSSELine = 1 << 20
+
+// SSEReader parses server-sent events from a text/event-stream body.
+type SSEReader struct {
+	scanner     *bufio.Scanner
+	lastEventID string
+}
+
+// NewSSEReader creates a reader for the event stream r.
+func NewSSEReader(r io.Reader) *SSEReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxSSELine)
+	return &SSEReader{scanner: scanner}
+}
+
+// Next returns the next event. It returns io.EOF when the stream ends, and
+// discards an incomplete trailing event.
+func (r *SSEReader) Next() (*ServerSentEvent, error) {
+	var (
+		data    strings.Builder
+		hasData bool
+		event   string
+		retry   time.Duration
+	)
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+		if line == "" {
+			if !hasData {
+				// Blank line without data: reset and keep reading
+				event, retry = "", 0
+				continue
+			}
+			if event == "" {
+				event = "message"
+			}
+			return &ServerSentEvent{ID: r.lastEventID, Event: event, Data: data.String(), Retry: retry}, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // Comment, often used as a keep-alive
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "event":
+			event = value
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				r.lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// LastEventID returns the ID of the most recent event that set one.
+func (r *SSEReader) LastEventID() string {
+	return r.lastEventID
+}
+
+// ErrStopStream can be returned by an SSE handler to end the stream without
+// an error.
+var ErrStopStream = errors.New("stop stream")
+
+// StreamSSE opens a long-lived GET request to path, which may also be an
+// absolute URL, and calls fn with every server-sent event until ctx is done
+// or fn returns an error. It returns nil if the stream ends through ctx or
+// ErrStopStream.
+//
+// When the connection drops, StreamSSE reconnects after the retry delay sent
+// by the server, or the client's minimum retry wait, and resumes with the
+// Last-Event-ID header. Connection failures count against the client's
+// retry limit, which resets whenever a connection delivers an event;
+// non-retryable API errors are returned immediately. Streams are not
+// recorded by WithHAR.
+func (c *Client) StreamSSE(ctx context.Context, path string, fn func(ServerSentEvent) error) error {
+	// Streams outlive the client's request timeout
+	httpClient := *c.client
+	httpClient.Timeout = 0
+
+	var lastEventID string
+	retry := c.retryWaitMin
+	failures := 0
+	for {
+		if err := c.pace(ctx); err != nil {
+			return nil
+		}
+
+		received, err := c.streamSSEOnce(ctx, &httpClient, path, &lastEventID, &retry, fn)
+		if ctx.Err() != nil || errors.Is(err, ErrStopStream) {
+			return nil
+		}
+		var final streamError
+		if errors.As(err, &final) {
+			return final.err
+		}
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && !IsRetryable(apiErr) {
+			return apiErr
+		}
+
+		if received {
+			failures = 0
+		} else if failures++; failures > c.maxRetries {
+			if apiErr != nil {
+				return apiErr
+			}
+			return &APIError{Err: fmt.Errorf("event stream failed after %d retries: %w", c.maxRetries, err)}
+		}
+
+		timer := time.NewTimer(retry)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		}
+	}
+}
+
+// streamError wraps errors that end a stream instead of reconnecting, such
+// as errors returned by the handler.
+type streamError struct{ err error }
+
+func (e streamError) Error() string { return e.err.Error() }
+
+// streamSSEOnce runs a single connection of StreamSSE and reports whether
+// any event was received.
+func (c *Client) streamSSEOnce(ctx context.Context, httpClient *http.Client, path string, lastEventID *string, retry *time.Duration, fn func(ServerSentEvent) error) (bool, error) {
+	req, err := c.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return false, streamError{err}
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if *lastEventID != "" {
+		req.Header.Set("Last-Event-ID", *lastEventID)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return false, createAPIError(resp, body)
+	}
+
+	reader := NewSSEReader(resp.Body)
+	reader.lastEventID = *lastEventID
+	received := false
+	for {
+		ev, err := reader.Next()
+		*lastEventID = reader.LastEventID()
+		if err != nil {
+			return received, err
+		}
+		received = true
+		if ev.Retry > 0 {
+			*retry = ev.Retry
+		}
+		if err := fn(*ev); err != nil {
+			if errors.Is(err, ErrStopStream) {
+				return received, err
+			}
+			return received, streamError{err}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSSEReader(t *testing.T) {
+	stream := ": keep-alive\n" +
+		"data: first\n\n" +
+		"event: price\nid: 7\nretry: 2500\ndata: {\"a\": 1,\ndata:  \"b\": 2}\n\n" +
+		"id: 8\n\n" +
+		"data:third\r\n\r\n" +
+		"data: incomplete"
+
+	r := NewSSEReader(strings.NewReader(stream))
+	want := []ServerSentEvent{
+		{Event: "message", Data: "first"},
+		{ID: "7", Event: "price", Data: "{\"a\": 1,\n \"b\": 2}", Retry: 2500 * time.Millisecond},
+		{ID: "8", Event: "message", Data: "third"},
+	}
+	for i, w := range want {
+		ev, err := r.Next()
+		if err != nil {
+			t.Fatalf("event %d: %v", i, err)
+		}
+		if *ev != w {
+			t.Errorf("event %d = %+v, want %+v", i, *ev, w)
+		}
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF after the incomplete event, got %v", err)
+	}
+}
+
+func TestClient_StreamSSE(t *testing.T) {
+	var conns atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Unexpected Accept header %q", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch conns.Add(1) {
+		case 1:
+			// Drop the connection after one event
+			fmt.Fprint(w, "id: 1\nretry: 1\ndata: one\n\n")
+		case 2:
+			if got := r.Header.Get("Last-Event-ID"); got != "1" {
+				t.Errorf("Expected Last-Event-ID 1 on reconnect, got %q", got)
+			}
+			fmt.Fprint(w, "id: 2\ndata: two\n\n")
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(1, time.Millisecond, time.Millisecond))
+
+	var got []string
+	err := client.StreamSSE(context.Background(), "/stream", func(ev ServerSentEvent) error {
+		got = append(got, ev.Data)
+		if len(got) == 2 {
+			return ErrStopStream
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamSSE returned error: %v", err)
+	}
+	if strings.Join(got, ",") != "one,two" || conns.Load() != 2 {
+		t.Errorf("Received %v over %d connections", got, conns.Load())
+	}
+}
+
+func TestClient_StreamSSEErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.Error(w, `{"error": "not found"}`, http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "data: x\n\n")
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(3, time.Millisecond, time.Millisecond))
+
+	err := client.StreamSSE(context.Background(), "/missing", func(ServerSentEvent) error { return nil })
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound without retries, got %v", err)
+	}
+
+	boom := errors.New("boom")
+	err = client.StreamSSE(context.Background(), "/stream", func(ServerSentEvent) error { return boom })
+	if err != boom {
+		t.Errorf("Expected the handler error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := client.StreamSSE(ctx, "/stream", func(ServerSentEvent) error { return nil }); err != nil {
+		t.Errorf("Expected nil when the context ends, got %v", err)
+	}
+}
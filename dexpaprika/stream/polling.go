@@ -0,0 +1,220 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/watch"
+)
+
+// PollingOptions configures a polling stream.
+type PollingOptions struct {
+	// Interval is how often each topic is polled. It defaults to 30 seconds.
+	Interval time.Duration
+
+	// RequestsPerSecond is the shared polling budget across pool and token
+	// topics (see watch.Manager). It defaults to 5.
+	RequestsPerSecond float64
+
+	// Tick is how often the shared budget is spent. It defaults to one
+	// second.
+	Tick time.Duration
+
+	// Policy decides how topics are polled after consecutive errors.
+	Policy watch.FailurePolicy
+
+	// OnError, if set, is called with every failed poll.
+	OnError func(error)
+
+	// Buffer is the capacity of the messages channel. It defaults to 256.
+	Buffer int
+}
+
+// Polling is a Stream backed by polling the REST API. Pool and token topics
+// share a watch.Manager polling budget; transaction topics each use a
+// watch.TransactionWatcher.
+type Polling struct {
+	client   *dexpaprika.Client
+	opts     PollingOptions
+	manager  *watch.Manager
+	messages chan Message
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	subs   map[Topic]context.CancelFunc
+	closed bool
+}
+
+var _ Stream = (*Polling)(nil)
+
+// NewPolling creates a polling stream. Its polling runs in the background
+// until Close is called.
+func NewPolling(client *dexpaprika.Client, opts *PollingOptions) *Polling {
+	var o PollingOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = 30 * time.Second
+	}
+	if o.RequestsPerSecond <= 0 {
+		o.RequestsPerSecond = 5
+	}
+	if o.Buffer <= 0 {
+		o.Buffer = 256
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Polling{
+		client:   client,
+		opts:     o,
+		manager:  watch.NewManager(client, o.RequestsPerSecond),
+		messages: make(chan Message, o.Buffer),
+		ctx:      ctx,
+		cancel:   cancel,
+		subs:     make(map[Topic]context.CancelFunc),
+	}
+	if o.Tick > 0 {
+		s.manager.Tick = o.Tick
+	}
+	s.manager.Policy = o.Policy
+	s.manager.OnError = o.OnError
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.manager.Run(ctx)
+	}()
+	return s
+}
+
+// Subscribe implements Stream.
+func (s *Polling) Subscribe(ctx context.Context, topics ...Topic) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	for _, topic := range topics {
+		switch topic.Kind {
+		case KindPool, KindTokenPrice, KindTransactions:
+		default:
+			return fmt.Errorf("%w: %s", ErrUnknownTopic, topic)
+		}
+	}
+
+	for _, topic := range topics {
+		if _, ok := s.subs[topic]; ok {
+			continue
+		}
+		subCtx, cancel := context.WithCancel(s.ctx)
+		s.subs[topic] = cancel
+		s.wg.Add(1)
+		go func(topic Topic) {
+			defer s.wg.Done()
+			s.follow(subCtx, topic)
+		}(topic)
+	}
+	return nil
+}
+
+// Unsubscribe implements Stream.
+func (s *Polling) Unsubscribe(ctx context.Context, topics ...Topic) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	for _, topic := range topics {
+		if cancel, ok := s.subs[topic]; ok {
+			cancel()
+			delete(s.subs, topic)
+		}
+	}
+	return nil
+}
+
+// Messages implements Stream.
+func (s *Polling) Messages() <-chan Message {
+	return s.messages
+}
+
+// Close implements Stream. It waits for background polling to stop.
+func (s *Polling) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.subs = nil
+	s.mu.Unlock()
+
+	s.cancel()
+	s.wg.Wait()
+	close(s.messages)
+	return nil
+}
+
+// follow converts the updates of a topic into messages until ctx is done.
+func (s *Polling) follow(ctx context.Context, topic Topic) {
+	switch topic.Kind {
+	case KindPool:
+		for u := range s.manager.WatchPool(ctx, topic.NetworkID, topic.Address, s.opts.Interval) {
+			e := events.PoolPriceChanged{
+				NetworkID:     u.NetworkID,
+				PoolAddress:   u.PoolAddress,
+				PriceUSD:      u.Details.LastPriceUSD,
+				ChangePercent: u.PriceChangePercent,
+				Time:          u.Time,
+			}
+			if u.Previous != nil {
+				if u.PriceUSDDelta == 0 {
+					continue
+				}
+				e.PreviousPriceUSD = u.Previous.LastPriceUSD
+			}
+			s.send(ctx, topic, e, u.Time)
+		}
+	case KindTokenPrice:
+		for u := range s.manager.WatchTokenPrice(ctx, topic.NetworkID, topic.Address, s.opts.Interval, 0) {
+			s.send(ctx, topic, events.TokenPriceChanged{
+				NetworkID:        u.NetworkID,
+				TokenAddress:     u.TokenAddress,
+				Symbol:           u.Symbol,
+				PriceUSD:         u.PriceUSD,
+				PreviousPriceUSD: u.PreviousPriceUSD,
+				ChangePercent:    u.ChangePercent,
+				Time:             u.Time,
+			}, u.Time)
+		}
+	case KindTransactions:
+		w := watch.NewTransactionWatcher(s.client, topic.NetworkID, topic.Address, s.opts.Interval)
+		w.Policy = s.opts.Policy
+		w.OnError = s.opts.OnError
+		for tx := range w.Watch(ctx) {
+			now := time.Now()
+			s.send(ctx, topic, events.NewTransaction{
+				NetworkID:   topic.NetworkID,
+				PoolAddress: topic.Address,
+				Transaction: tx,
+				Time:        now,
+			}, now)
+		}
+	}
+}
+
+// send delivers a message, blocking until there is room or ctx is done.
+func (s *Polling) send(ctx context.Context, topic Topic, e events.Event, t time.Time) {
+	select {
+	case s.messages <- Message{Topic: topic, Event: e, Time: t}:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,97 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+func TestPolling(t *testing.T) {
+	var pricePolls, txPolls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/networks/ethereum/pools/0xpool":
+			// The price holds for two polls, then rises
+			price := 100
+			if pricePolls.Add(1) > 2 {
+				price = 105
+			}
+			fmt.Fprintf(w, `{"id": "0xpool", "last_price_usd": %d}`, price)
+		case "/networks/ethereum/pools/0xpool/transactions":
+			txs := `{"id": "0x1"}`
+			if txPolls.Add(1) > 1 {
+				txs = `{"id": "0x2"}, {"id": "0x1"}`
+			}
+			fmt.Fprintf(w, `{"transactions": [%s], "page_info": {"total_pages": 1}}`, txs)
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	var s Stream = NewPolling(client, &PollingOptions{Interval: 5 * time.Millisecond, RequestsPerSecond: 1000, Tick: time.Millisecond})
+	ctx := context.Background()
+	if err := s.Subscribe(ctx, PoolTopic("ethereum", "0xpool"), TransactionsTopic("ethereum", "0xpool")); err != nil {
+		t.Fatal(err)
+	}
+
+	var prices []events.PoolPriceChanged
+	var txs []events.NewTransaction
+	timeout := time.After(5 * time.Second)
+	for len(prices) < 2 || len(txs) < 1 {
+		select {
+		case msg := <-s.Messages():
+			switch e := msg.Event.(type) {
+			case events.PoolPriceChanged:
+				if msg.Topic != PoolTopic("ethereum", "0xpool") {
+					t.Errorf("Unexpected topic %s", msg.Topic)
+				}
+				prices = append(prices, e)
+			case events.NewTransaction:
+				txs = append(txs, e)
+			}
+		case <-timeout:
+			t.Fatalf("Timed out with %d price and %d transaction messages", len(prices), len(txs))
+		}
+	}
+
+	if prices[0].PriceUSD != 100 || prices[0].PreviousPriceUSD != 0 || prices[1].PriceUSD != 105 || prices[1].PreviousPriceUSD != 100 {
+		t.Errorf("Unexpected price messages %+v", prices)
+	}
+	if txs[0].Transaction.ID != "0x2" {
+		t.Errorf("Expected only the new transaction, got %+v", txs)
+	}
+
+	if err := s.Unsubscribe(ctx, PoolTopic("ethereum", "0xpool")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for range s.Messages() {
+	}
+	if err := s.Subscribe(ctx, PoolTopic("ethereum", "0xpool")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed after Close, got %v", err)
+	}
+}
+
+func TestPolling_UnknownTopic(t *testing.T) {
+	s := NewPolling(dexpaprika.NewClient(), nil)
+	defer s.Close()
+
+	err := s.Subscribe(context.Background(), Topic{Kind: "orderbook", NetworkID: "ethereum"})
+	if !errors.Is(err, ErrUnknownTopic) {
+		t.Errorf("Expected ErrUnknownTopic, got %v", err)
+	}
+}
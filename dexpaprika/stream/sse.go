@@ -0,0 +1,192 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+// SSEOptions configures a server-sent events stream.
+type SSEOptions struct {
+	// Path returns the request path, relative to the client's base URL, or
+	// the absolute URL of the event stream for a topic. It is required.
+	Path func(Topic) string
+
+	// Decode converts a server-sent event into a typed payload. A nil
+	// event with a nil error skips the event. It defaults to DecodeEvent.
+	Decode func(Topic, dexpaprika.ServerSentEvent) (events.Event, error)
+
+	// OnError, if set, is called with decode errors and with the error
+	// that ends a topic's connection.
+	OnError func(error)
+
+	// Buffer is the capacity of the messages channel. It defaults to 256.
+	Buffer int
+}
+
+// SSE is a Stream backed by server-sent events, with one connection per
+// topic. Connections reconnect with Last-Event-ID as described for
+// Client.StreamSSE.
+type SSE struct {
+	client   *dexpaprika.Client
+	opts     SSEOptions
+	messages chan Message
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	subs   map[Topic]context.CancelFunc
+	closed bool
+}
+
+var _ Stream = (*SSE)(nil)
+
+// ErrNoPath is returned by SSE.Subscribe when no Path function is configured.
+var ErrNoPath = errors.New("stream: SSE options have no Path")
+
+// NewSSE creates a server-sent events stream.
+func NewSSE(client *dexpaprika.Client, opts SSEOptions) *SSE {
+	if opts.Decode == nil {
+		opts.Decode = DecodeEvent
+	}
+	if opts.Buffer <= 0 {
+		opts.Buffer = 256
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &SSE{
+		client:   client,
+		opts:     opts,
+		messages: make(chan Message, opts.Buffer),
+		ctx:      ctx,
+		cancel:   cancel,
+		subs:     make(map[Topic]context.CancelFunc),
+	}
+}
+
+// Subscribe implements Stream by opening a connection per new topic.
+func (s *SSE) Subscribe(ctx context.Context, topics ...Topic) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	if s.opts.Path == nil {
+		return ErrNoPath
+	}
+
+	for _, topic := range topics {
+		if _, ok := s.subs[topic]; ok {
+			continue
+		}
+		subCtx, cancel := context.WithCancel(s.ctx)
+		s.subs[topic] = cancel
+		path := s.opts.Path(topic)
+		s.wg.Add(1)
+		go func(topic Topic) {
+			defer s.wg.Done()
+			err := s.client.StreamSSE(subCtx, path, func(ev dexpaprika.ServerSentEvent) error {
+				e, err := s.opts.Decode(topic, ev)
+				if err != nil {
+					s.onError(err)
+					return nil
+				}
+				if e == nil {
+					return nil
+				}
+				select {
+				case s.messages <- Message{Topic: topic, Event: e, Time: time.Now()}:
+				case <-subCtx.Done():
+				}
+				return nil
+			})
+			if err != nil {
+				s.onError(err)
+			}
+		}(topic)
+	}
+	return nil
+}
+
+// Unsubscribe implements Stream by closing the topics' connections.
+func (s *SSE) Unsubscribe(ctx context.Context, topics ...Topic) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	for _, topic := range topics {
+		if cancel, ok := s.subs[topic]; ok {
+			cancel()
+			delete(s.subs, topic)
+		}
+	}
+	return nil
+}
+
+// Messages implements Stream.
+func (s *SSE) Messages() <-chan Message {
+	return s.messages
+}
+
+// Close implements Stream. It waits for all connections to close.
+func (s *SSE) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.subs = nil
+	s.mu.Unlock()
+
+	s.cancel()
+	s.wg.Wait()
+	close(s.messages)
+	return nil
+}
+
+func (s *SSE) onError(err error) {
+	if s.opts.OnError != nil {
+		s.opts.OnError(err)
+	}
+}
+
+// DecodeEvent decodes the JSON data of a server-sent event into the event
+// type named by its event field, e.g. "pool_price_changed" into
+// events.PoolPriceChanged. Events of other types are skipped.
+func DecodeEvent(_ Topic, ev dexpaprika.ServerSentEvent) (events.Event, error) {
+	var e events.Event
+	var err error
+	switch ev.Event {
+	case events.PoolPriceChanged{}.EventName():
+		e, err = decodeJSON[events.PoolPriceChanged](ev.Data)
+	case events.TokenPriceChanged{}.EventName():
+		e, err = decodeJSON[events.TokenPriceChanged](ev.Data)
+	case events.NewPool{}.EventName():
+		e, err = decodeJSON[events.NewPool](ev.Data)
+	case events.NewTransaction{}.EventName():
+		e, err = decodeJSON[events.NewTransaction](ev.Data)
+	case events.AlertFired{}.EventName():
+		e, err = decodeJSON[events.AlertFired](ev.Data)
+	case events.StaleData{}.EventName():
+		e, err = decodeJSON[events.StaleData](ev.Data)
+	default:
+		return nil, nil
+	}
+	return e, err
+}
+
+func decodeJSON[E events.Event](data string) (events.Event, error) {
+	var e E
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -0,0 +1,59 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+func TestSSE(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stream/ethereum/0xpool" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: heartbeat\ndata: {}\n\n")
+		fmt.Fprint(w, "event: pool_price_changed\ndata: {\"pool_address\": \"0xpool\", \"price_usd\": 3012.5, \"change_percent\": 1.2}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL))
+
+	var s Stream = NewSSE(client, SSEOptions{
+		Path: func(t Topic) string { return "/stream/" + t.NetworkID + "/" + t.Address },
+	})
+	topic := PoolTopic("ethereum", "0xpool")
+	if err := s.Subscribe(context.Background(), topic); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-s.Messages():
+		e, ok := msg.Event.(events.PoolPriceChanged)
+		if msg.Topic != topic || !ok || e.PriceUSD != 3012.5 || e.ChangePercent != 1.2 {
+			t.Errorf("Unexpected message %+v", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a message")
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSSE_NoPath(t *testing.T) {
+	s := NewSSE(dexpaprika.NewClient(), SSEOptions{})
+	defer s.Close()
+	if err := s.Subscribe(context.Background(), PoolTopic("ethereum", "0xpool")); err != ErrNoPath {
+		t.Errorf("Expected ErrNoPath, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+// Package stream defines a transport-independent streaming interface for
+// DexPaprika data.
+//
+// Applications subscribe to topics and receive typed messages on a single
+// channel. The polling implementation works against today's REST API; a
+// WebSocket transport can later implement the same Stream interface without
+// changes to user code.
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+// Errors returned by streams.
+var (
+	ErrClosed       = errors.New("stream: closed")
+	ErrUnknownTopic = errors.New("stream: unknown topic")
+)
+
+// Topic kinds.
+const (
+	KindPool         = "pool"         // Pool price changes, as events.PoolPriceChanged
+	KindTokenPrice   = "token_price"  // Token price changes, as events.TokenPriceChanged
+	KindTransactions = "transactions" // New pool transactions, as events.NewTransaction
+)
+
+// Topic identifies a subscription.
+type Topic struct {
+	Kind      string
+	NetworkID string
+	Address   string
+}
+
+// PoolTopic returns the topic for price changes of a pool.
+func PoolTopic(networkID, poolAddress string) Topic {
+	return Topic{Kind: KindPool, NetworkID: networkID, Address: poolAddress}
+}
+
+// TokenPriceTopic returns the topic for USD price changes of a token.
+func TokenPriceTopic(networkID, tokenAddress string) Topic {
+	return Topic{Kind: KindTokenPrice, NetworkID: networkID, Address: tokenAddress}
+}
+
+// TransactionsTopic returns the topic for new transactions of a pool.
+func TransactionsTopic(networkID, poolAddress string) Topic {
+	return Topic{Kind: KindTransactions, NetworkID: networkID, Address: poolAddress}
+}
+
+// String returns the topic as "kind:network:address".
+func (t Topic) String() string {
+	return fmt.Sprintf("%s:%s:%s", t.Kind, t.NetworkID, t.Address)
+}
+
+// Message is a message received on a stream. Event holds the typed payload,
+// e.g. events.PoolPriceChanged for a pool topic.
+type Message struct {
+	Topic Topic
+	Event events.Event
+	Time  time.Time
+}
+
+// Stream is a subscription-based feed of messages.
+type Stream interface {
+	// Subscribe starts delivering messages for the topics. Subscribing to a
+	// topic twice has no effect.
+	Subscribe(ctx context.Context, topics ...Topic) error
+
+	// Unsubscribe stops delivering messages for the topics.
+	Unsubscribe(ctx context.Context, topics ...Topic) error
+
+	// Messages returns the channel on which messages of all topics are
+	// delivered. It is closed by Close.
+	Messages() <-chan Message
+
+	// Close ends all subscriptions and closes the messages channel.
+	Close() error
+}
@@ -0,0 +1,163 @@
+package dexpaprika
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// String returns a concise description of the token, e.g.
+// "WETH (Wrapped Ether) on ethereum [0xc02a...]".
+func (t Token) String() string {
+	var b strings.Builder
+	b.WriteString(tokenLabel(t))
+	if t.Name != "" && t.Name != t.Symbol {
+		fmt.Fprintf(&b, " (%s)", t.Name)
+	}
+	if t.Chain != "" {
+		fmt.Fprintf(&b, " on %s", t.Chain)
+	}
+	if t.ID != "" && t.ID != tokenLabel(t) {
+		fmt.Fprintf(&b, " [%s]", t.ID)
+	}
+	return b.String()
+}
+
+// String returns a concise description of the pool, e.g.
+// "WETH/USDC on Uniswap V3 (ethereum) price $3,012.55 vol $1.20M".
+func (p Pool) String() string {
+	return poolString(p.Tokens, p.DexName, p.DexID, p.Chain, p.ID) +
+		fmt.Sprintf(" price %s vol %s", formatUSD(p.PriceUSD), formatUSD(p.VolumeUSD))
+}
+
+// String returns a concise description of the pool details, e.g.
+// "WETH/USDC on Uniswap V3 (ethereum) price $3,012.55 liq $45.10M vol24h $1.20M".
+func (p PoolDetails) String() string {
+	s := poolString(p.Tokens, p.DexName, p.DexID, p.Chain, p.ID) +
+		" price " + formatUSD(p.LastPriceUSD)
+	if p.LiquidityUSD != nil {
+		s += " liq " + formatUSD(*p.LiquidityUSD)
+	}
+	return s + " vol24h " + formatUSD(p.Day.VolumeUSD)
+}
+
+// String returns a concise description of the candle, e.g.
+// "2024-01-01T00:00:00Z O:1.0000 H:1.2000 L:0.9000 C:1.1000 V:1000".
+func (r OHLCVRecord) String() string {
+	return fmt.Sprintf("%s O:%s H:%s L:%s C:%s V:%d",
+		r.TimeOpen, formatPrice(r.Open), formatPrice(r.High), formatPrice(r.Low), formatPrice(r.Close), r.Volume)
+}
+
+// String returns the same text as Error.
+func (e *APIError) String() string {
+	return e.Error()
+}
+
+// Dump returns a multi-line, aligned listing of the flattened columns of v,
+// one "name  value" pair per line, with empty values shown as "-". It is
+// intended for debugging and examples; the exact layout is not stable.
+func Dump(v interface{}) string {
+	record := Flatten(v)
+	width := 0
+	for _, c := range record {
+		if len(c.Name) > width {
+			width = len(c.Name)
+		}
+	}
+	var b strings.Builder
+	for _, c := range record {
+		value := formatValue(c.Value)
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(&b, "%-*s  %s\n", width, c.Name, value)
+	}
+	return b.String()
+}
+
+// poolString renders the "BASE/QUOTE on DEX (chain)" prefix shared by pool models.
+func poolString(tokens []Token, dexName, dexID, chain, id string) string {
+	var pair string
+	if len(tokens) == 0 {
+		pair = id
+	} else {
+		labels := make([]string, len(tokens))
+		for i, t := range tokens {
+			labels[i] = tokenLabel(t)
+		}
+		pair = strings.Join(labels, "/")
+	}
+	dex := dexName
+	if dex == "" {
+		dex = dexID
+	}
+	s := pair
+	if dex != "" {
+		s += " on " + dex
+	}
+	if chain != "" {
+		s += " (" + chain + ")"
+	}
+	return s
+}
+
+// tokenLabel returns the symbol of the token, falling back to its address.
+func tokenLabel(t Token) string {
+	if t.Symbol != "" {
+		return t.Symbol
+	}
+	return t.ID
+}
+
+// formatUSD renders a dollar amount, abbreviating values of a million or more.
+func formatUSD(v float64) string {
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	switch {
+	case v >= 1e12:
+		return fmt.Sprintf("%s$%.2fT", sign, v/1e12)
+	case v >= 1e9:
+		return fmt.Sprintf("%s$%.2fB", sign, v/1e9)
+	case v >= 1e6:
+		return fmt.Sprintf("%s$%.2fM", sign, v/1e6)
+	case v >= 1:
+		return sign + "$" + groupThousands(strconv.FormatFloat(v, 'f', 2, 64))
+	}
+	return sign + "$" + formatPrice(v)
+}
+
+// formatPrice renders a price with four decimals, keeping enough significant
+// digits for sub-cent values.
+func formatPrice(v float64) string {
+	if v != 0 && math.Abs(v) < 0.0001 {
+		return strconv.FormatFloat(v, 'g', 4, 64)
+	}
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
+
+// groupThousands inserts commas into the integer part of a decimal string.
+func groupThousands(s string) string {
+	intPart, frac, _ := strings.Cut(s, ".")
+	if len(intPart) <= 3 {
+		return s
+	}
+	var b strings.Builder
+	pre := len(intPart) % 3
+	if pre > 0 {
+		b.WriteString(intPart[:pre])
+	}
+	for i := pre; i < len(intPart); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(intPart[i : i+3])
+	}
+	if frac != "" {
+		b.WriteString("." + frac)
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package dexpaprika
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStringers(t *testing.T) {
+	weth := Token{ID: "0xc02a", Symbol: "WETH", Name: "Wrapped Ether", Chain: "ethereum"}
+	usdc := Token{ID: "0xa0b8", Symbol: "USDC", Name: "USD Coin", Chain: "ethereum"}
+	liq := 45100000.0
+
+	tests := []struct {
+		name string
+		got  fmt.Stringer
+		want string
+	}{
+		{"token", weth, "WETH (Wrapped Ether) on ethereum [0xc02a]"},
+		{"token without symbol", Token{ID: "0xabc"}, "0xabc"},
+		{"pool", Pool{DexName: "Uniswap V3", Chain: "ethereum", PriceUSD: 3012.554, VolumeUSD: 1200000, Tokens: []Token{weth, usdc}},
+			"WETH/USDC on Uniswap V3 (ethereum) price $3,012.55 vol $1.20M"},
+		{"pool without tokens", Pool{ID: "0xpool", DexID: "uniswap_v3", PriceUSD: 0.5},
+			"0xpool on uniswap_v3 price $0.5000 vol $0.0000"},
+		{"pool details", PoolDetails{DexName: "Uniswap V3", Chain: "ethereum", LastPriceUSD: 3012.55, LiquidityUSD: &liq, Tokens: []Token{weth, usdc}, Day: TimeIntervalMetrics{VolumeUSD: 2.5e9}},
+			"WETH/USDC on Uniswap V3 (ethereum) price $3,012.55 liq $45.10M vol24h $2.50B"},
+		{"ohlcv", OHLCVRecord{TimeOpen: "2024-01-01T00:00:00Z", Open: 1, High: 1.2, Low: 0.9, Close: 1.1, Volume: 1000},
+			"2024-01-01T00:00:00Z O:1.0000 H:1.2000 L:0.9000 C:1.1000 V:1000"},
+		{"api error", &APIError{StatusCode: 404, Message: "pool not found", Err: ErrNotFound},
+			"not found: pool not found (status code: 404)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringers_FormatVerbs(t *testing.T) {
+	p := &Pool{DexName: "Raydium", Chain: "solana", Tokens: []Token{{Symbol: "SOL"}, {Symbol: "USDC"}}}
+	if got := fmt.Sprintf("%v", p); !strings.HasPrefix(got, "SOL/USDC on Raydium (solana)") {
+		t.Errorf("%%v of *Pool = %q", got)
+	}
+
+	var err error = &APIError{StatusCode: 429, Err: ErrRateLimit}
+	if fmt.Sprint(err) != err.Error() {
+		t.Errorf("APIError String and Error differ: %q vs %q", fmt.Sprint(err), err.Error())
+	}
+	if !errors.Is(err, ErrRateLimit) {
+		t.Error("APIError should still unwrap to ErrRateLimit")
+	}
+}
+
+func TestFormatUSD(t *testing.T) {
+	tests := map[float64]string{
+		0:          "$0.0000",
+		0.00001234: "$1.234e-05",
+		12.5:       "$12.50",
+		1234567.8:  "$1.23M",
+		999999.99:  "$999,999.99",
+		-2500:      "-$2,500.00",
+		3.2e12:     "$3.20T",
+	}
+	for in, want := range tests {
+		if got := formatUSD(in); got != want {
+			t.Errorf("formatUSD(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDump(t *testing.T) {
+	fdv := 1.5e9
+	out := Dump(&Token{ID: "0xabc", Symbol: "ABC", Decimals: 18, FDV: &fdv})
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("expected 7 lines, got %d:\n%s", len(lines), out)
+	}
+	if lines[0] != "id        0xabc" {
+		t.Errorf("unexpected first line %q", lines[0])
+	}
+	if !strings.Contains(out, "added_at  -\n") {
+		t.Errorf("expected empty values to render as '-':\n%s", out)
+	}
+	if !strings.Contains(out, "fdv       1500000000\n") {
+		t.Errorf("expected fdv line:\n%s", out)
+	}
+
+	details := Dump(&PoolDetails{ID: "0xpool", Tokens: []Token{{Symbol: "A"}, {Symbol: "B"}}})
+	if !strings.Contains(details, "token1_symbol") || !strings.Contains(details, "24h_volume_usd") {
+		t.Errorf("expected nested columns in dump:\n%s", details)
+	}
+}
@@ -12,41 +12,10 @@ type TokensService struct {
 	client *Client
 }
 
-// TokenSummary contains token summary metrics.
-type TokenSummary struct {
-	PriceUSD     float64              `json:"price_usd"`
-	FDV          float64              `json:"fdv"`
-	LiquidityUSD float64              `json:"liquidity_usd"`
-	Pools        *int                 `json:"pools,omitempty"`
-	Day          *TimeIntervalMetrics `json:"24h,omitempty"`
-	Hour6        *TimeIntervalMetrics `json:"6h,omitempty"`
-	Hour1        *TimeIntervalMetrics `json:"1h,omitempty"`
-	Minute30     *TimeIntervalMetrics `json:"30m,omitempty"`
-	Minute15     *TimeIntervalMetrics `json:"15m,omitempty"`
-	Minute5      *TimeIntervalMetrics `json:"5m,omitempty"`
-	Minute1      *TimeIntervalMetrics `json:"1m,omitempty"`
-}
-
-// TokenDetails represents detailed information about a token.
-type TokenDetails struct {
-	ID          string        `json:"id"`
-	Name        string        `json:"name"`
-	Symbol      string        `json:"symbol"`
-	Chain       string        `json:"chain"`
-	Decimals    int           `json:"decimals"`
-	TotalSupply float64       `json:"total_supply"`
-	Description string        `json:"description"`
-	Website     string        `json:"website"`
-	Explorer    string        `json:"explorer"`
-	AddedAt     string        `json:"added_at"`
-	Summary     *TokenSummary `json:"summary,omitempty"`
-	LastUpdated string        `json:"last_updated"` // RFC3339/ISO8601 date-time format when token data was last updated
-}
-
 // GetDetails returns detailed information about a specific token on a network.
 // Implements the getTokenDetails operation from the OpenAPI spec.
-func (s *TokensService) GetDetails(ctx context.Context, networkID, tokenAddress string) (*TokenDetails, error) {
-	path := fmt.Sprintf("/networks/%s/tokens/%s", networkID, tokenAddress)
+func (s *TokensService) GetDetails(ctx context.Context, networkID, tokenAddress string, reqOpts ...RequestOption) (*TokenDetails, error) {
+	path := fmt.Sprintf("/networks/%s/tokens/%s", networkID, NormalizeAddress(networkID, tokenAddress))
 
 	req, err := s.client.NewRequest(http.MethodGet, path, nil)
 	if err != nil {
@@ -54,51 +23,78 @@ func (s *TokensService) GetDetails(ctx context.Context, networkID, tokenAddress
 	}
 
 	var response TokenDetails
-	r, err := s.client.Do(ctx, req, &response)
+	cfg := newRequestConfig(reqOpts)
+	r, err := s.client.Do(ctx, req, cfg.decodeInto(&response))
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
+	if cfg.target != nil {
+		return nil, nil
+	}
+
+	s.client.fillTokenDetails(ctx, networkID, &response)
 	return &response, nil
 }
 
 // GetPools returns a list of top liquidity pools for a specific token on a network.
 // Implements the getTokenPools operation from the OpenAPI spec.
-func (s *TokensService) GetPools(ctx context.Context, networkID, tokenAddress string, opts *ListOptions, additionalTokenAddress string) (*PoolsResponse, error) {
-	path := fmt.Sprintf("/networks/%s/tokens/%s/pools", networkID, tokenAddress)
+func (s *TokensService) GetPools(ctx context.Context, networkID, tokenAddress string, opts *ListOptions, additionalTokenAddress string, reqOpts ...RequestOption) (*PoolsResponse, error) {
+	q := newQuery(fmt.Sprintf("/networks/%s/tokens/%s/pools", networkID, NormalizeAddress(networkID, tokenAddress)))
+	q.addString("address", NormalizeAddress(networkID, additionalTokenAddress))
+	q.addListOptions(opts)
 
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(http.MethodGet, q.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	q := req.URL.Query()
+	var response PoolsResponse
+	cfg := newRequestConfig(reqOpts)
+	r, err := s.client.Do(ctx, req, cfg.decodeInto(&response))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	if cfg.target != nil {
+		return nil, nil
+	}
+
+	projectPools(&response, opts)
+
+	return &response, nil
+}
+
+// GetTransactions returns transactions involving a token across all of its
+// pools on a network. The endpoint is not yet available on every API
+// deployment; where it is missing the call fails with an error matching
+// ErrNotFound.
+func (s *TokensService) GetTransactions(ctx context.Context, networkID, tokenAddress string, opts *TransactionsOptions, reqOpts ...RequestOption) (*TransactionsResponse, error) {
+	q := newQuery(fmt.Sprintf("/networks/%s/tokens/%s/transactions", networkID, NormalizeAddress(networkID, tokenAddress)))
 	if opts != nil {
-		if opts.Page > 0 {
-			q.Add("page", fmt.Sprintf("%d", opts.Page))
-		}
-		if opts.Limit > 0 {
-			q.Add("limit", fmt.Sprintf("%d", opts.Limit))
-		}
-		if opts.Sort != "" {
-			q.Add("sort", opts.Sort)
-		}
-		if opts.OrderBy != "" {
-			q.Add("order_by", opts.OrderBy)
-		}
+		q.addString("cursor", opts.Cursor)
+		q.addInt("limit", opts.Limit)
+		q.addInt("page", opts.Page)
 	}
-	if additionalTokenAddress != "" {
-		q.Add("address", additionalTokenAddress)
+
+	req, err := s.client.NewRequest(http.MethodGet, q.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	req.URL.RawQuery = q.Encode()
 
-	var response PoolsResponse
-	r, err := s.client.Do(ctx, req, &response)
+	var response TransactionsResponse
+	cfg := newRequestConfig(reqOpts)
+	r, err := s.client.Do(ctx, req, cfg.decodeInto(&response))
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
+	if cfg.target != nil {
+		return nil, nil
+	}
+
 	return &response, nil
 }
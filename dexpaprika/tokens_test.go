@@ -2,6 +2,10 @@ package dexpaprika
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -199,3 +203,65 @@ func TestCachedClient_Tokens(t *testing.T) {
 		t.Error("Cache inconsistency: token details changed between calls")
 	}
 }
+
+func TestTokens_GetTransactions(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/networks/ethereum/tokens/0xunsupported/transactions" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, `{"error": "not found"}`)
+			return
+		}
+		if r.URL.Path != "/networks/ethereum/tokens/0xabcdef/transactions" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "" {
+			fmt.Fprintln(w, `{"transactions": [{"id": "tx1", "pool_id": "0xpool1"}, {"id": "tx2", "pool_id": "0xpool2"}], "page_info": {"limit": 2, "page": 0, "total_items": 3, "total_pages": 2}}`)
+			return
+		}
+		fmt.Fprintln(w, `{"transactions": [{"id": "tx3", "pool_id": "0xpool1"}], "page_info": {"limit": 2, "page": 1, "total_items": 3, "total_pages": 2}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, 1*time.Millisecond, 1*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Addresses are normalized on EVM networks
+	resp, err := client.Tokens.GetTransactions(ctx, "ethereum", "0xABCDEF", &TransactionsOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("Tokens.GetTransactions returned error: %v", err)
+	}
+	if len(resp.Transactions) != 2 || resp.Transactions[1].PoolID != "0xpool2" {
+		t.Errorf("Unexpected transactions: %+v", resp.Transactions)
+	}
+
+	// The paginator follows pages and cursors like pool transactions
+	queries = nil
+	paginator := NewTokenTransactionsPaginator(client, "ethereum", "0xabcdef", 2)
+	var ids []string
+	for paginator.HasNextPage() {
+		if err := paginator.GetNextPage(ctx); err != nil {
+			t.Fatalf("GetNextPage returned error: %v", err)
+		}
+		for _, tx := range paginator.GetCurrentPage() {
+			ids = append(ids, tx.ID)
+		}
+	}
+	if fmt.Sprint(ids) != "[tx1 tx2 tx3]" {
+		t.Errorf("Unexpected transaction IDs %v", ids)
+	}
+	if len(queries) != 2 || queries[1] != "cursor=tx2&limit=2&page=1" {
+		t.Errorf("Unexpected queries %v", queries)
+	}
+
+	if _, err := client.Tokens.GetTransactions(ctx, "ethereum", "0xunsupported", nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
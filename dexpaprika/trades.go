@@ -0,0 +1,133 @@
+package dexpaprika
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TradeSide classifies a swap from the trader's point of view.
+type TradeSide string
+
+// Trade sides.
+const (
+	TradeSideBuy  TradeSide = "buy"
+	TradeSideSell TradeSide = "sell"
+)
+
+// ErrUnknownTradeToken is returned when a transaction's tokens do not match the pool.
+var ErrUnknownTradeToken = errors.New("transaction tokens do not match pool")
+
+// Trade is a normalized swap, oriented around the pool's base token.
+type Trade struct {
+	ID          string
+	PoolID      string
+	Side        TradeSide
+	Sender      string
+	Recipient   string
+	BaseToken   Token
+	QuoteToken  Token
+	BaseAmount  float64 // Absolute amount of base token traded
+	QuoteAmount float64 // Absolute amount of quote token traded
+	Price       float64 // Executed price of the base token in quote token units
+	ValueUSD    float64 // USD value of the trade, zero if no USD price is known
+	BlockNumber int64
+	LogIndex    int
+	Time        time.Time // Zero if the API did not report a timestamp
+}
+
+// EnrichTransaction converts a raw pool transaction into a Trade using the
+// pool's token metadata and prices. Amounts follow the pool's perspective:
+// a negative base amount means the base token left the pool, i.e. a buy.
+func EnrichTransaction(tx Transaction, pool *PoolDetails) (*Trade, error) {
+	base, quote, ok := pool.Pair()
+	if !ok {
+		return nil, fmt.Errorf("pool %s has fewer than two tokens", pool.ID)
+	}
+
+	amount0, err := parseAmount(tx.Amount0)
+	if err != nil {
+		return nil, fmt.Errorf("amount_0: %w", err)
+	}
+	amount1, err := parseAmount(tx.Amount1)
+	if err != nil {
+		return nil, fmt.Errorf("amount_1: %w", err)
+	}
+
+	var baseAmount, quoteAmount float64
+	switch {
+	case strings.EqualFold(tx.Token0, base.ID) && strings.EqualFold(tx.Token1, quote.ID):
+		baseAmount, quoteAmount = amount0, amount1
+	case strings.EqualFold(tx.Token1, base.ID) && strings.EqualFold(tx.Token0, quote.ID):
+		baseAmount, quoteAmount = amount1, amount0
+	default:
+		return nil, fmt.Errorf("%w: %s/%s in pool %s", ErrUnknownTradeToken, tx.Token0, tx.Token1, pool.ID)
+	}
+
+	trade := &Trade{
+		ID:          tx.ID,
+		PoolID:      tx.PoolID,
+		Side:        TradeSideSell,
+		Sender:      tx.Sender,
+		Recipient:   tx.Recipient,
+		BaseToken:   base,
+		QuoteToken:  quote,
+		BaseAmount:  math.Abs(baseAmount),
+		QuoteAmount: math.Abs(quoteAmount),
+		BlockNumber: tx.CreatedAtBlockNumber,
+		LogIndex:    tx.LogIndex,
+	}
+	if t, err := time.Parse(time.RFC3339, tx.CreatedAt); err == nil {
+		trade.Time = t
+	}
+	if baseAmount < 0 {
+		trade.Side = TradeSideBuy
+	}
+	if trade.BaseAmount > 0 {
+		trade.Price = trade.QuoteAmount / trade.BaseAmount
+	}
+
+	if priceUSD, ok := pool.PriceUSDOf(base.ID); ok && priceUSD > 0 {
+		trade.ValueUSD = trade.BaseAmount * priceUSD
+	} else if priceUSD, ok := pool.PriceUSDOf(quote.ID); ok {
+		trade.ValueUSD = trade.QuoteAmount * priceUSD
+	}
+
+	return trade, nil
+}
+
+// parseAmount converts a transaction amount, which the API may encode as a
+// number or a string, into a float64. Strings such as "NaN" or "1e999" that
+// parse to non-finite values are rejected.
+func parseAmount(v interface{}) (float64, error) {
+	var (
+		a   float64
+		err error
+	)
+	switch v := v.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		a = v
+	case json.Number:
+		a, err = v.Float64()
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		a, err = strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("unsupported amount type %T", v)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(a) || math.IsInf(a, 0) {
+		return 0, fmt.Errorf("amount %v is not finite", v)
+	}
+	return a, nil
+}
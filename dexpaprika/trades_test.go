@@ -0,0 +1,102 @@
+package dexpaprika
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEnrichTransaction(t *testing.T) {
+	weth := Token{ID: "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", Symbol: "WETH"}
+	usdc := Token{ID: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", Symbol: "USDC"}
+	pool := &PoolDetails{
+		ID:           "0xpool",
+		Tokens:       []Token{weth, usdc},
+		LastPrice:    3000,
+		LastPriceUSD: 3000,
+	}
+
+	tests := []struct {
+		name      string
+		tx        Transaction
+		wantSide  TradeSide
+		wantBase  float64
+		wantQuote float64
+		wantPrice float64
+		wantUSD   float64
+	}{
+		{
+			name:      "buy with numeric amounts",
+			tx:        Transaction{ID: "0x1", Token0: weth.ID, Token1: usdc.ID, Amount0: -2.0, Amount1: 6100.0},
+			wantSide:  TradeSideBuy,
+			wantBase:  2,
+			wantQuote: 6100,
+			wantPrice: 3050,
+			wantUSD:   6000,
+		},
+		{
+			name:      "sell with string amounts",
+			tx:        Transaction{ID: "0x2", Token0: weth.ID, Token1: usdc.ID, Amount0: "0.5", Amount1: "-1490"},
+			wantSide:  TradeSideSell,
+			wantBase:  0.5,
+			wantQuote: 1490,
+			wantPrice: 2980,
+			wantUSD:   1500,
+		},
+		{
+			name:      "tokens reported in swapped order",
+			tx:        Transaction{ID: "0x3", Token0: usdc.ID, Token1: weth.ID, Amount0: "3000", Amount1: "-1"},
+			wantSide:  TradeSideBuy,
+			wantBase:  1,
+			wantQuote: 3000,
+			wantPrice: 3000,
+			wantUSD:   3000,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			trade, err := EnrichTransaction(tc.tx, pool)
+			if err != nil {
+				t.Fatalf("EnrichTransaction returned error: %v", err)
+			}
+			if trade.Side != tc.wantSide {
+				t.Errorf("Expected side %s, got %s", tc.wantSide, trade.Side)
+			}
+			if trade.BaseAmount != tc.wantBase || trade.QuoteAmount != tc.wantQuote {
+				t.Errorf("Expected amounts %f/%f, got %f/%f", tc.wantBase, tc.wantQuote, trade.BaseAmount, trade.QuoteAmount)
+			}
+			if math.Abs(trade.Price-tc.wantPrice) > 1e-9 {
+				t.Errorf("Expected price %f, got %f", tc.wantPrice, trade.Price)
+			}
+			if math.Abs(trade.ValueUSD-tc.wantUSD) > 1e-9 {
+				t.Errorf("Expected USD value %f, got %f", tc.wantUSD, trade.ValueUSD)
+			}
+			if trade.BaseToken.Symbol != "WETH" || trade.QuoteToken.Symbol != "USDC" {
+				t.Errorf("Expected WETH/USDC, got %s/%s", trade.BaseToken.Symbol, trade.QuoteToken.Symbol)
+			}
+		})
+	}
+}
+
+func TestEnrichTransaction_Errors(t *testing.T) {
+	pool := &PoolDetails{
+		ID:     "0xpool",
+		Tokens: []Token{{ID: "0xaaa"}, {ID: "0xbbb"}},
+	}
+
+	_, err := EnrichTransaction(Transaction{Token0: "0xaaa", Token1: "0xccc"}, pool)
+	if !errors.Is(err, ErrUnknownTradeToken) {
+		t.Errorf("Expected ErrUnknownTradeToken, got %v", err)
+	}
+
+	_, err = EnrichTransaction(Transaction{Token0: "0xaaa", Token1: "0xbbb", Amount0: "abc"}, pool)
+	if err == nil {
+		t.Error("Expected an error for a non-numeric amount")
+	}
+
+	_, err = EnrichTransaction(Transaction{}, &PoolDetails{})
+	if err == nil {
+		t.Error("Expected an error for a pool without tokens")
+	}
+}
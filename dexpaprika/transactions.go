@@ -0,0 +1,89 @@
+package dexpaprika
+
+import (
+	"context"
+	"strings"
+)
+
+// TransactionsService provides higher-level views over pool transactions.
+type TransactionsService struct {
+	client *Client
+}
+
+// TradeFilter selects trades. Zero-valued fields match everything.
+type TradeFilter struct {
+	MinValueUSD float64   // Minimum USD value of the trade
+	Side        TradeSide // TradeSideBuy or TradeSideSell
+	Sender      string    // Sender address, compared case-insensitively
+	Recipient   string    // Recipient address, compared case-insensitively
+
+	// Pages is the number of transaction pages, of 100 transactions each,
+	// scanned by Transactions.Filter. It defaults to 1.
+	Pages int
+}
+
+// Match reports whether the trade passes the filter. A nil filter matches
+// every trade.
+func (f *TradeFilter) Match(t *Trade) bool {
+	if f == nil {
+		return true
+	}
+	if t.ValueUSD < f.MinValueUSD {
+		return false
+	}
+	if f.Side != "" && t.Side != f.Side {
+		return false
+	}
+	if f.Sender != "" && !strings.EqualFold(t.Sender, f.Sender) {
+		return false
+	}
+	if f.Recipient != "" && !strings.EqualFold(t.Recipient, f.Recipient) {
+		return false
+	}
+	return true
+}
+
+// FilterTrades returns the trades that pass the filter, in order.
+func FilterTrades(trades []*Trade, f *TradeFilter) []*Trade {
+	var matched []*Trade
+	for _, t := range trades {
+		if f.Match(t) {
+			matched = append(matched, t)
+		}
+	}
+	return matched
+}
+
+// Filter returns the recent trades of a pool that pass the filter, newest
+// first. Transactions are enriched with the pool's token metadata and prices
+// (see EnrichTransaction), so filters such as a minimum USD size work on raw
+// transactions. Transactions that cannot be enriched are skipped.
+func (s *TransactionsService) Filter(ctx context.Context, networkID, poolAddress string, f *TradeFilter) ([]*Trade, error) {
+	pool, err := s.client.Pools.GetDetails(ctx, networkID, poolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := 1
+	if f != nil && f.Pages > 0 {
+		pages = f.Pages
+	}
+
+	var trades []*Trade
+	paginator := NewTransactionsPaginator(s.client, networkID, poolAddress, 100)
+	for page := 0; page < pages && paginator.HasNextPage(); page++ {
+		if err := paginator.GetNextPage(ctx); err != nil {
+			return nil, err
+		}
+		for _, tx := range paginator.GetCurrentPage() {
+			trade, err := EnrichTransaction(tx, pool)
+			if err != nil {
+				continue
+			}
+			if f.Match(trade) {
+				trades = append(trades, trade)
+			}
+		}
+	}
+	return trades, nil
+}
@@ -0,0 +1,68 @@
+package dexpaprika
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTradeFilter_Match(t *testing.T) {
+	trade := &Trade{Side: TradeSideBuy, Sender: "0xAbC", Recipient: "0xdef", ValueUSD: 250000}
+
+	tests := []struct {
+		name   string
+		filter *TradeFilter
+		want   bool
+	}{
+		{"nil filter", nil, true},
+		{"min value met", &TradeFilter{MinValueUSD: 100000}, true},
+		{"min value not met", &TradeFilter{MinValueUSD: 500000}, false},
+		{"buys only", &TradeFilter{Side: TradeSideBuy}, true},
+		{"sells only", &TradeFilter{Side: TradeSideSell}, false},
+		{"sender any case", &TradeFilter{Sender: "0xabc"}, true},
+		{"other sender", &TradeFilter{Sender: "0x123"}, false},
+		{"other recipient", &TradeFilter{Recipient: "0x123"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(trade); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransactions_Filter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/networks/ethereum/pools/0xpool":
+			w.Write([]byte(`{"id": "0xpool", "last_price_usd": 3000, "tokens": [{"id": "0xweth", "symbol": "WETH"}, {"id": "0xusdc", "symbol": "USDC"}]}`))
+		case "/networks/ethereum/pools/0xpool/transactions":
+			w.Write([]byte(`{"transactions": [
+				{"id": "0x1", "sender": "0xwhale", "token_0": "0xweth", "token_1": "0xusdc", "amount_0": -100, "amount_1": 300000},
+				{"id": "0x2", "sender": "0xwhale", "token_0": "0xweth", "token_1": "0xusdc", "amount_0": 50, "amount_1": -150000},
+				{"id": "0x3", "sender": "0xminnow", "token_0": "0xweth", "token_1": "0xusdc", "amount_0": -0.1, "amount_1": 300},
+				{"id": "0x4", "sender": "0xwhale", "token_0": "0xother", "token_1": "0xusdc", "amount_0": -100, "amount_1": 300000}
+			], "page_info": {"limit": 100, "page": 0, "total_items": 4, "total_pages": 1}}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+
+	trades, err := client.Transactions.Filter(context.Background(), "ethereum", "0xpool", &TradeFilter{MinValueUSD: 100000, Side: TradeSideBuy})
+	if err != nil {
+		t.Fatalf("Filter returned error: %v", err)
+	}
+	if len(trades) != 1 || trades[0].ID != "0x1" || trades[0].ValueUSD != 300000 {
+		t.Fatalf("Expected only the 300k buy, got %+v", trades)
+	}
+	if trades[0].BaseToken.Symbol != "WETH" {
+		t.Errorf("Expected token metadata from pool details, got %+v", trades[0].BaseToken)
+	}
+}
@@ -11,28 +11,80 @@ type UtilsService struct {
 	client *Client
 }
 
-// Stats represents high-level statistics about the DexPaprika ecosystem.
-type Stats struct {
-	Chains    int `json:"chains"`
-	Factories int `json:"factories"`
-	Pools     int `json:"pools"`
-	Tokens    int `json:"tokens"`
-}
-
 // GetStats retrieves high-level statistics about the DexPaprika ecosystem.
 // Implements the getStats operation from the OpenAPI spec.
-func (s *UtilsService) GetStats(ctx context.Context) (*Stats, error) {
+func (s *UtilsService) GetStats(ctx context.Context, reqOpts ...RequestOption) (*Stats, error) {
 	req, err := s.client.NewRequest(http.MethodGet, "/stats", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var stats Stats
-	r, err := s.client.Do(ctx, req, &stats)
+	cfg := newRequestConfig(reqOpts)
+	r, err := s.client.Do(ctx, req, cfg.decodeInto(&stats))
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
+	if cfg.target != nil {
+		return nil, nil
+	}
+
 	return &stats, nil
 }
+
+// NetworkStats summarizes a single network.
+type NetworkStats struct {
+	Network      string  `json:"network" csv:"network"`
+	Dexes        int     `json:"dexes" csv:"dexes"`
+	Pools        int     `json:"pools" csv:"pools"`
+	VolumeUSD24h float64 `json:"volume_usd_24h" csv:"volume_usd_24h"`
+}
+
+// networkStatsVolumePages caps the pool pages summed for 24h volume.
+const networkStatsVolumePages = 10
+
+// GetNetworkStats returns the DEX count, pool count and 24h volume of a
+// network. The API has no per-network stats endpoint, so counts are read from
+// the pagination totals of the dexes and pools listings, and volume is summed
+// over the network's most active pools (at most 1,000), which carry nearly all
+// of its volume.
+func (s *UtilsService) GetNetworkStats(ctx context.Context, networkID string) (*NetworkStats, error) {
+	dexes, err := s.client.Dexes.List(ctx, networkID, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &NetworkStats{
+		Network: networkID,
+		Dexes:   dexes.PageInfo.TotalItems,
+	}
+
+	opts := &ListOptions{Limit: 100, OrderBy: "volume_usd", Sort: "desc"}
+	for page := 0; page < networkStatsVolumePages; page++ {
+		opts.Page = page
+		pools, err := s.client.Pools.ListByNetwork(ctx, networkID, opts)
+		if err != nil {
+			return nil, err
+		}
+		if page == 0 {
+			stats.Pools = pools.PageInfo.TotalItems
+		}
+
+		done := len(pools.Pools) < opts.Limit || page+1 >= pools.PageInfo.TotalPages
+		for _, p := range pools.Pools {
+			if p.VolumeUSD <= 0 {
+				// Pools are ordered by volume, so the rest have none either
+				done = true
+				break
+			}
+			stats.VolumeUSD24h += p.VolumeUSD
+		}
+		if done {
+			break
+		}
+	}
+
+	return stats, nil
+}
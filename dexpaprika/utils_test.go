@@ -172,3 +172,55 @@ func TestUtils_GetStatsWithMock(t *testing.T) {
 		})
 	}
 }
+
+func TestUtils_GetNetworkStatsWithMock(t *testing.T) {
+	var poolPages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/networks/ethereum/dexes":
+			fmt.Fprintln(w, `{"dexes": [{"dex_id": "uniswap_v3"}], "page_info": {"limit": 1, "page": 0, "total_items": 42, "total_pages": 42}}`)
+		case "/networks/ethereum/pools":
+			q := r.URL.Query()
+			if q.Get("order_by") != "volume_usd" || q.Get("sort") != "desc" {
+				t.Errorf("Expected pools ordered by volume, got %s", r.URL.RawQuery)
+			}
+			poolPages = append(poolPages, q.Get("page"))
+			pools := ""
+			for i := 0; i < 100; i++ {
+				volume := 10.0
+				if q.Get("page") == "1" && i >= 50 {
+					volume = 0
+				}
+				if i > 0 {
+					pools += ","
+				}
+				pools += fmt.Sprintf(`{"id": "p%d", "volume_usd": %g}`, i, volume)
+			}
+			fmt.Fprintf(w, `{"pools": [%s], "page_info": {"limit": 100, "page": 0, "total_items": 5000, "total_pages": 50}}`+"\n", pools)
+		default:
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, 1*time.Millisecond, 1*time.Millisecond),
+	)
+
+	stats, err := client.Utils.GetNetworkStats(context.Background(), "ethereum")
+	if err != nil {
+		t.Fatalf("GetNetworkStats returned error: %v", err)
+	}
+	if stats.Network != "ethereum" || stats.Dexes != 42 || stats.Pools != 5000 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	// 150 pools with volume, scanning stops at the first pool without any
+	if stats.VolumeUSD24h != 1500 {
+		t.Errorf("Expected volume 1500, got %f", stats.VolumeUSD24h)
+	}
+	if len(poolPages) != 2 {
+		t.Errorf("Expected 2 pool pages, got %v", poolPages)
+	}
+}
@@ -0,0 +1,43 @@
+package dexpaprika
+
+import (
+	"log"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/internal/openapi"
+)
+
+// Version is the version of the SDK. Requests carry it in their User-Agent
+// header, as in "DexPaprika-SDK-Go/1.3.0", so server-side issues can be
+// correlated with SDK releases.
+const Version = "1.3.0"
+
+// sdkName is the product name of the SDK in the User-Agent header.
+const sdkName = "DexPaprika-SDK-Go"
+
+// OpenAPISpec returns the DexPaprika OpenAPI specification, as JSON, that
+// this version of the SDK was built against.
+func OpenAPISpec() []byte {
+	return openapi.Raw()
+}
+
+// userAgentHeader returns the User-Agent of requests: the SDK's name and
+// version, after the user agent set with WithUserAgent or SetUserAgent.
+func userAgentHeader(userAgent string) string {
+	sdk := sdkName + "/" + Version
+	if userAgent == "" || userAgent == sdkName {
+		return sdk
+	}
+	return userAgent + " " + sdk
+}
+
+// WithDebug logs the SDK version and base URL when the client is created,
+// then every HTTP attempt with its status and duration, to logger, or to the
+// standard logger if logger is nil.
+func WithDebug(logger *log.Logger) ClientOption {
+	return func(c *Client) {
+		if logger == nil {
+			logger = log.Default()
+		}
+		c.debug = logger
+	}
+}
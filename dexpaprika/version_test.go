@@ -0,0 +1,68 @@
+package dexpaprika
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_UserAgentVersion(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("User-Agent"))
+		w.Write([]byte(`{"chains": 1}`))
+	}))
+	defer server.Close()
+
+	for _, client := range []*Client{
+		NewClient(WithBaseURL(server.URL)),
+		NewClient(WithBaseURL(server.URL), WithUserAgent("my-app/2.0")),
+	} {
+		if _, err := client.Utils.GetStats(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{"DexPaprika-SDK-Go/" + Version, "my-app/2.0 DexPaprika-SDK-Go/" + Version}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("User-Agent headers = %q, want %q", got, want)
+	}
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	var spec struct {
+		OpenAPI string `json:"openapi"`
+	}
+	if err := json.Unmarshal(OpenAPISpec(), &spec); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		t.Errorf("openapi = %q, want a 3.x document", spec.OpenAPI)
+	}
+	OpenAPISpec()[0] = 'x'
+	if OpenAPISpec()[0] != '{' {
+		t.Error("OpenAPISpec returned the embedded spec instead of a copy")
+	}
+}
+
+func TestClient_WithDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := NewClient(WithBaseURL(server.URL), WithDebug(log.New(&buf, "", 0)))
+	client.Utils.GetStats(context.Background())
+
+	out := buf.String()
+	for _, want := range []string{"DexPaprika-SDK-Go/" + Version + ", base URL " + server.URL, "GET " + server.URL + "/stats (attempt 1): 404 in "} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Debug log lacks %q:\n%s", want, out)
+		}
+	}
+}
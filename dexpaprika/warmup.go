@@ -0,0 +1,100 @@
+package dexpaprika
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// WarmupOptions configures Client.WarmupWithOptions.
+type WarmupOptions struct {
+	// Connections is the number of concurrent requests opening connections,
+	// 1 if zero; a request finishing early may leave its connection to
+	// another. Over HTTP/2 one connection carries all requests, so more
+	// only help HTTP/1.1 transports, which keep at most MaxIdleConnsPerHost
+	// of them idle (2 for http.DefaultTransport).
+	Connections int
+
+	// DNS resolves the API host before connecting. Go does not cache
+	// lookups itself, so this only helps when the system or network has a
+	// caching resolver, which then answers the first request from its
+	// cache.
+	DNS bool
+}
+
+// Warmup opens a connection to the API host, completing the TCP and TLS
+// handshakes, and leaves it idle in the client's transport, so the first
+// user-facing request after process start does not pay for them. Call it
+// during startup, before serving traffic:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	if err := client.Warmup(ctx); err != nil {
+//		log.Printf("dexpaprika warm-up failed: %v", err)
+//	}
+//
+// Like Ping, it neither waits for the rate limiter nor retries.
+func (c *Client) Warmup(ctx context.Context) error {
+	return c.WarmupWithOptions(ctx, nil)
+}
+
+// WarmupWithOptions is Warmup with more connections or DNS priming. The
+// connections are opened concurrently, each with a HEAD request whose
+// status is ignored; the first error is returned.
+func (c *Client) WarmupWithOptions(ctx context.Context, opts *WarmupOptions) error {
+	select {
+	case <-c.closed:
+		return ErrClientClosed
+	default:
+	}
+	if opts == nil {
+		opts = &WarmupOptions{}
+	}
+
+	if opts.DNS {
+		c.mu.RLock()
+		host := c.baseURL.Hostname()
+		c.mu.RUnlock()
+		if net.ParseIP(host) == nil {
+			if _, err := net.DefaultResolver.LookupHost(ctx, host); err != nil {
+				return &APIError{Err: fmt.Errorf("warmup: %w", err)}
+			}
+		}
+	}
+
+	conns := max(opts.Connections, 1)
+	errs := make([]error, conns)
+	var wg sync.WaitGroup
+	for i := range conns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.warmupConn(ctx)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return &APIError{Err: fmt.Errorf("warmup: %w", err)}
+		}
+	}
+	return nil
+}
+
+// warmupConn sends a HEAD request and drains the response, so its
+// connection is returned to the transport's idle pool.
+func (c *Client) warmupConn(ctx context.Context) error {
+	req, err := c.NewRequest(http.MethodHead, "/stats", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.roundTrip(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	return resp.Body.Close()
+}
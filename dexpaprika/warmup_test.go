@@ -0,0 +1,81 @@
+package dexpaprika
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// newWarmupServer returns a TLS server counting the connections opened to it
+// and the HEAD requests it received.
+func newWarmupServer(t *testing.T) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	t.Helper()
+	var conns, heads atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			heads.Add(1)
+		}
+		w.Write([]byte(`{"chains": 1}`))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Config.ErrorLog = log.New(io.Discard, "", 0) // Handshakes failing on purpose
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server, &conns, &heads
+}
+
+func TestClient_Warmup(t *testing.T) {
+	server, conns, heads := newWarmupServer(t)
+	client := NewClient(WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	if err := client.Warmup(context.Background()); err != nil {
+		t.Fatalf("Warmup() = %v", err)
+	}
+	if conns.Load() != 1 || heads.Load() != 1 {
+		t.Fatalf("Warmup() opened %d connections with %d HEAD requests, want 1", conns.Load(), heads.Load())
+	}
+	if _, err := client.Utils.GetStats(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if conns.Load() != 1 {
+		t.Errorf("GetStats() after Warmup() opened a new connection")
+	}
+}
+
+func TestClient_WarmupWithOptions(t *testing.T) {
+	server, conns, heads := newWarmupServer(t)
+	client := NewClient(WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	if err := client.WarmupWithOptions(context.Background(), &WarmupOptions{Connections: 3, DNS: true}); err != nil {
+		t.Fatalf("WarmupWithOptions() = %v", err)
+	}
+	if heads.Load() != 3 || conns.Load() < 1 || conns.Load() > 3 {
+		t.Errorf("WarmupWithOptions() opened %d connections with %d HEAD requests, want up to 3 with 3", conns.Load(), heads.Load())
+	}
+}
+
+func TestClient_Warmup_Errors(t *testing.T) {
+	server, _, _ := newWarmupServer(t)
+	client := NewClient(WithBaseURL(server.URL)) // Does not trust the test certificate
+
+	err := client.Warmup(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Errorf("Warmup() without a trusted certificate = %v, want an *APIError", err)
+	}
+
+	client.Close()
+	if err := client.Warmup(context.Background()); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Warmup() after Close() = %v, want ErrClientClosed", err)
+	}
+}
@@ -0,0 +1,259 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// Candle is an OHLC candle built from trades. Prices are the executed price
+// of the pool's base token in quote token units (see dexpaprika.Trade).
+type Candle struct {
+	Interval  string // OHLCV interval, e.g. dexpaprika.OHLCVInterval1m
+	Start     time.Time
+	End       time.Time
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    float64 // Base token amount traded
+	VolumeUSD float64
+	Trades    int
+}
+
+// CandleBuilder maintains the in-progress candle of each interval from a
+// stream of trades. It is safe for concurrent use.
+type CandleBuilder struct {
+	intervals map[string]time.Duration
+
+	mu      sync.Mutex
+	current map[string]*Candle
+	closed  map[string]time.Time // Start of the last candle closed per interval
+}
+
+// NewCandleBuilder creates a builder for the given OHLCV intervals, such as
+// dexpaprika.OHLCVInterval1m and dexpaprika.OHLCVInterval1h.
+func NewCandleBuilder(intervals ...string) (*CandleBuilder, error) {
+	b := &CandleBuilder{
+		intervals: make(map[string]time.Duration, len(intervals)),
+		current:   make(map[string]*Candle, len(intervals)),
+		closed:    make(map[string]time.Time, len(intervals)),
+	}
+	for _, interval := range intervals {
+		d, ok := dexpaprika.OHLCVIntervalDuration(interval)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", dexpaprika.ErrInvalidInterval, interval)
+		}
+		b.intervals[interval] = d
+	}
+	return b, nil
+}
+
+// Add adds a trade to the in-progress candles and returns the candles it
+// closed, i.e. those whose period ended before the trade. Trades older than
+// an interval's in-progress candle, or in a period already closed, are
+// ignored for that interval.
+func (b *CandleBuilder) Add(trade *dexpaprika.Trade) []Candle {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var closed []Candle
+	for interval, d := range b.intervals {
+		start := trade.Time.Truncate(d)
+		if last, ok := b.closed[interval]; ok && !start.After(last) {
+			continue
+		}
+		c := b.current[interval]
+		if c != nil && start.Before(c.Start) {
+			continue
+		}
+		if c != nil && start.After(c.Start) {
+			closed = append(closed, *c)
+			b.closed[interval] = c.Start
+			c = nil
+		}
+		if c == nil {
+			c = &Candle{Interval: interval, Start: start, End: start.Add(d), Open: trade.Price, High: trade.Price, Low: trade.Price}
+			b.current[interval] = c
+		}
+
+		c.High = max(c.High, trade.Price)
+		c.Low = min(c.Low, trade.Price)
+		c.Close = trade.Price
+		c.Volume += trade.BaseAmount
+		c.VolumeUSD += trade.ValueUSD
+		c.Trades++
+	}
+	sortCandles(closed)
+	return closed
+}
+
+// Flush closes and returns the in-progress candles whose period ended at or
+// before now.
+func (b *CandleBuilder) Flush(now time.Time) []Candle {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var closed []Candle
+	for interval, c := range b.current {
+		if !c.End.After(now) {
+			closed = append(closed, *c)
+			b.closed[interval] = c.Start
+			delete(b.current, interval)
+		}
+	}
+	sortCandles(closed)
+	return closed
+}
+
+// Current returns the in-progress candle of an interval, if any.
+func (b *CandleBuilder) Current(interval string) (Candle, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.current[interval]
+	if !ok {
+		return Candle{}, false
+	}
+	return *c, true
+}
+
+// sortCandles orders candles by end time, then by interval length, so that
+// shorter candles closing at the same time come first.
+func sortCandles(candles []Candle) {
+	sort.Slice(candles, func(i, j int) bool {
+		a, b := candles[i], candles[j]
+		if !a.End.Equal(b.End) {
+			return a.End.Before(b.End)
+		}
+		return a.End.Sub(a.Start) < b.End.Sub(b.Start)
+	})
+}
+
+// CandleWatcher builds live candles for a pool from its transactions and
+// emits each candle when it closes.
+type CandleWatcher struct {
+	client      *dexpaprika.Client
+	networkID   string
+	poolAddress string
+	builder     *CandleBuilder
+
+	// Transactions follows the pool's trades. Its interval also sets how
+	// long a candle is held open after its period ends, to allow for API
+	// lag, and its failure policy applies to all of the watcher's polls.
+	Transactions *TransactionWatcher
+
+	// OnError, if set, is called with every failed poll.
+	OnError func(error)
+
+	lastErr
+}
+
+// NewCandleWatcher creates a watcher that builds candles of the given OHLCV
+// intervals for a pool.
+func NewCandleWatcher(client *dexpaprika.Client, networkID, poolAddress string, intervals ...string) (*CandleWatcher, error) {
+	builder, err := NewCandleBuilder(intervals...)
+	if err != nil {
+		return nil, err
+	}
+	return &CandleWatcher{
+		client:       client,
+		networkID:    networkID,
+		poolAddress:  poolAddress,
+		builder:      builder,
+		Transactions: NewTransactionWatcher(client, networkID, poolAddress, 0),
+	}, nil
+}
+
+// Current returns the in-progress candle of an interval, if any.
+func (w *CandleWatcher) Current(interval string) (Candle, bool) {
+	return w.builder.Current(interval)
+}
+
+// Watch starts following the pool and returns a channel of closed candles.
+// Candles close when a later trade arrives or once their period has ended;
+// intervals without trades produce no candle. The channel is closed when
+// ctx is done.
+func (w *CandleWatcher) Watch(ctx context.Context) <-chan Candle {
+	candles := make(chan Candle)
+	if w.Transactions.OnError == nil {
+		w.Transactions.OnError = w.OnError
+	}
+
+	go func() {
+		defer close(candles)
+
+		pool, err := w.poolDetails(ctx)
+		if pool == nil {
+			w.set(err)
+			return
+		}
+
+		emit := func(closed []Candle) bool {
+			for _, c := range closed {
+				select {
+				case candles <- c:
+				case <-ctx.Done():
+					return false
+				}
+			}
+			return true
+		}
+
+		grace := w.Transactions.interval
+		ticker := time.NewTicker(grace)
+		defer ticker.Stop()
+
+		txs := w.Transactions.Watch(ctx)
+		for {
+			select {
+			case tx, ok := <-txs:
+				if !ok {
+					w.set(w.Transactions.Err())
+					return
+				}
+				trade, err := dexpaprika.EnrichTransaction(tx, pool)
+				if err != nil {
+					continue
+				}
+				if trade.Time.IsZero() {
+					trade.Time = time.Now()
+				}
+				if !emit(w.builder.Add(trade)) {
+					return
+				}
+			case now := <-ticker.C:
+				if !emit(w.builder.Flush(now.Add(-grace))) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return candles
+}
+
+// poolDetails fetches the pool's token metadata, retrying as the transaction
+// watcher's failure policy allows. It returns nil if ctx is done first.
+func (w *CandleWatcher) poolDetails(ctx context.Context) (*dexpaprika.PoolDetails, error) {
+	var pool *dexpaprika.PoolDetails
+	fetchCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	p := poller{interval: w.Transactions.interval, policy: w.Transactions.Policy, onError: w.OnError}
+	err := p.run(fetchCtx, func(ctx context.Context) error {
+		details, err := w.client.Pools.GetDetails(ctx, w.networkID, w.poolAddress, nil)
+		if err != nil {
+			return err
+		}
+		pool = details
+		stop()
+		return nil
+	})
+	return pool, err
+}
@@ -0,0 +1,124 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestCandleBuilder(t *testing.T) {
+	b, err := NewCandleBuilder(dexpaprika.OHLCVInterval1m, dexpaprika.OHLCVInterval5m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	trade := func(offset time.Duration, price float64) *dexpaprika.Trade {
+		return &dexpaprika.Trade{Time: base.Add(offset), Price: price, BaseAmount: 1, ValueUSD: price}
+	}
+
+	for _, tr := range []*dexpaprika.Trade{trade(0, 10), trade(20*time.Second, 12), trade(40*time.Second, 9)} {
+		if closed := b.Add(tr); len(closed) != 0 {
+			t.Fatalf("Unexpected closed candles %+v", closed)
+		}
+	}
+	c, ok := b.Current(dexpaprika.OHLCVInterval1m)
+	if !ok || c.Open != 10 || c.High != 12 || c.Low != 9 || c.Close != 9 || c.Trades != 3 || c.VolumeUSD != 31 {
+		t.Fatalf("Unexpected in-progress candle %+v", c)
+	}
+
+	closed := b.Add(trade(90*time.Second, 11))
+	if len(closed) != 1 || closed[0].Interval != dexpaprika.OHLCVInterval1m || !closed[0].End.Equal(base.Add(time.Minute)) {
+		t.Fatalf("Expected the first minute candle to close, got %+v", closed)
+	}
+
+	// A late trade is ignored for the minute candle but not the 5m one
+	b.Add(trade(30*time.Second, 100))
+	if c, _ := b.Current(dexpaprika.OHLCVInterval1m); c.High != 11 {
+		t.Errorf("Late trade changed the minute candle: %+v", c)
+	}
+	if c, _ := b.Current(dexpaprika.OHLCVInterval5m); c.High != 100 || c.Trades != 5 {
+		t.Errorf("Expected the late trade in the 5m candle: %+v", c)
+	}
+
+	closed = b.Flush(base.Add(5 * time.Minute))
+	if len(closed) != 2 || closed[0].Interval != dexpaprika.OHLCVInterval1m || closed[1].Interval != dexpaprika.OHLCVInterval5m {
+		t.Errorf("Expected both candles flushed, shortest first, got %+v", closed)
+	}
+	if _, ok := b.Current(dexpaprika.OHLCVInterval5m); ok {
+		t.Error("Expected no in-progress candle after flush")
+	}
+
+	// A trade in a flushed period does not open its candle again
+	if closed := b.Add(trade(100*time.Second, 50)); len(closed) != 0 {
+		t.Errorf("Unexpected closed candles %+v", closed)
+	}
+	for _, interval := range []string{dexpaprika.OHLCVInterval1m, dexpaprika.OHLCVInterval5m} {
+		if c, ok := b.Current(interval); ok {
+			t.Errorf("Late trade reopened the %s candle: %+v", interval, c)
+		}
+	}
+	if closed := b.Flush(base.Add(time.Hour)); len(closed) != 0 {
+		t.Errorf("Expected no candle emitted twice, got %+v", closed)
+	}
+
+	if _, err := NewCandleBuilder("2m"); !errors.Is(err, dexpaprika.ErrInvalidInterval) {
+		t.Errorf("Expected ErrInvalidInterval, got %v", err)
+	}
+}
+
+func TestCandleWatcher(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Minute)
+	older := now.Add(-2 * time.Minute).Format(time.RFC3339)
+	old := now.Add(-90 * time.Second).Format(time.RFC3339)
+	recent := time.Now().UTC().Format(time.RFC3339)
+
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/networks/ethereum/pools/0xpool" {
+			w.Write([]byte(`{"id": "0xpool", "last_price_usd": 2000, "tokens": [{"id": "0xweth"}, {"id": "0xusdc"}]}`))
+			return
+		}
+		txs := []string{fmt.Sprintf(`{"id": "0x1", "token_0": "0xweth", "token_1": "0xusdc", "amount_0": -1, "amount_1": 2000, "created_at": %q}`, older)}
+		if polls.Add(1) > 1 {
+			txs = append([]string{
+				fmt.Sprintf(`{"id": "0x3", "token_0": "0xweth", "token_1": "0xusdc", "amount_0": -1, "amount_1": 2100, "created_at": %q}`, recent),
+				fmt.Sprintf(`{"id": "0x2", "token_0": "0xweth", "token_1": "0xusdc", "amount_0": 1, "amount_1": -1950, "created_at": %q}`, old),
+			}, txs...)
+		}
+		fmt.Fprintf(w, `{"transactions": [%s], "page_info": {"total_pages": 1}}`, strings.Join(txs, ","))
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	w, err := NewCandleWatcher(client, "ethereum", "0xpool", dexpaprika.OHLCVInterval1m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Transactions = NewTransactionWatcher(client, "ethereum", "0xpool", 5*time.Millisecond)
+	candles := w.Watch(ctx)
+
+	c := <-candles
+	if c.Open != 1950 || c.Close != 1950 || c.Trades != 1 || !c.End.Equal(now.Add(-time.Minute)) {
+		t.Errorf("Unexpected first candle %+v", c)
+	}
+	if cur, ok := w.Current(dexpaprika.OHLCVInterval1m); !ok || cur.Close != 2100 {
+		t.Errorf("Unexpected in-progress candle %+v", cur)
+	}
+
+	cancel()
+	for range candles {
+	}
+}
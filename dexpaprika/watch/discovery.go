@@ -0,0 +1,117 @@
+package watch
+
+import (
+	"context"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+// PoolDiscoveryWatcher follows the pools created on a network with
+// Pools.RecentlyCreated, emitting each new pool once, oldest first.
+type PoolDiscoveryWatcher struct {
+	client    *dexpaprika.Client
+	networkID string
+	interval  time.Duration
+
+	// Lookback is how long before the newest pool seen a poll looks for
+	// pools, so that pools listed late, or created in the same second, are
+	// still emitted. It defaults to 5 minutes.
+	Lookback time.Duration
+
+	// Bus, if set, receives a NewPool event for every pool emitted.
+	Bus *events.Bus
+
+	// Metrics, if set, receives the watcher's poll, event and error metrics.
+	Metrics dexpaprika.Metrics
+
+	// Policy decides how polling continues after consecutive errors.
+	// It defaults to Backoff(DefaultMaxBackoff).
+	Policy FailurePolicy
+
+	// OnError, if set, is called with every failed poll.
+	OnError func(error)
+
+	lastErr
+}
+
+// NewPoolDiscoveryWatcher creates a watcher that polls a network's newest
+// pools every interval.
+func NewPoolDiscoveryWatcher(client *dexpaprika.Client, networkID string, interval time.Duration) *PoolDiscoveryWatcher {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &PoolDiscoveryWatcher{
+		client:    client,
+		networkID: networkID,
+		interval:  interval,
+		Lookback:  5 * time.Minute,
+	}
+}
+
+// NewPools follows the pools created on a network with a
+// PoolDiscoveryWatcher polling every minute.
+func NewPools(ctx context.Context, client *dexpaprika.Client, networkID string) <-chan dexpaprika.Pool {
+	return NewPoolDiscoveryWatcher(client, networkID, 0).Watch(ctx)
+}
+
+// Watch starts polling and returns a channel of the pools created after
+// Watch was called. The channel is closed when ctx is done.
+func (w *PoolDiscoveryWatcher) Watch(ctx context.Context) <-chan dexpaprika.Pool {
+	pools := make(chan dexpaprika.Pool)
+	start := time.Now()
+	newest := start
+	seen := make(map[string]time.Time) // Creation times of the pools emitted since the cutoff
+
+	in := newInstruments(w.Metrics, "pool_discovery", w.networkID, "")
+	p := poller{interval: w.interval, policy: w.Policy, onError: w.OnError, metrics: in}
+	go func() {
+		defer close(pools)
+		err := p.run(ctx, func(ctx context.Context) error {
+			cutoff := newest.Add(-w.Lookback)
+			if cutoff.Before(start) {
+				cutoff = start
+			}
+			created, err := w.client.Pools.RecentlyCreated(ctx, w.networkID, cutoff)
+			w.set(err)
+			if err != nil {
+				return err
+			}
+			for id, t := range seen {
+				if !t.After(cutoff) {
+					delete(seen, id)
+				}
+			}
+
+			// Pools are newest first; emit oldest first.
+			for i := len(created) - 1; i >= 0; i-- {
+				pool := created[i]
+				if _, ok := seen[pool.ID]; ok {
+					continue
+				}
+				t, _ := time.Parse(time.RFC3339, pool.CreatedAt)
+				seen[pool.ID] = t
+				if t.After(newest) {
+					newest = t
+				}
+				w.Bus.Publish(events.NewPool{
+					NetworkID: w.networkID,
+					Pool:      pool,
+					Time:      time.Now(),
+				})
+				select {
+				case pools <- pool:
+					in.emitted(1)
+				case <-ctx.Done():
+					return nil
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			w.set(err)
+		}
+	}()
+	return pools
+}
@@ -0,0 +1,77 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+func TestPoolDiscoveryWatcher(t *testing.T) {
+	// Each poll lists the network's pools newest first, by creation time
+	// relative to now; 0xold predates the watcher
+	now := time.Now().UTC().Truncate(time.Second)
+	polls := [][]string{
+		{"0xb:20", "0xa:10", "0xold:-60"},
+		{"0xb:20", "0xa:10", "0xold:-60"},
+		{"0xd:30", "0xc:30", "0xb:20", "0xa:10", "0xold:-60"},
+	}
+	var n atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/networks/ethereum/pools" || r.URL.Query().Get("order_by") != "created_at" {
+			t.Errorf("Unexpected request to %s", r.URL)
+		}
+		i := min(int(n.Add(1))-1, len(polls)-1)
+		var pools []string
+		for _, pool := range polls[i] {
+			id, offset, _ := strings.Cut(pool, ":")
+			var seconds int
+			fmt.Sscan(offset, &seconds)
+			created := now.Add(time.Duration(seconds) * time.Second).Format(time.RFC3339)
+			pools = append(pools, fmt.Sprintf(`{"id": %q, "created_at": %q}`, id, created))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"pools": [%s], "page_info": {"limit": 100, "page": 0, "total_items": %d, "total_pages": 1}}`, strings.Join(pools, ","), len(pools))
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	bus := events.NewBus()
+	published, stop := events.Chan[events.NewPool](bus, 4)
+	defer stop()
+
+	w := NewPoolDiscoveryWatcher(client, "ethereum", 5*time.Millisecond)
+	w.Bus = bus
+	pools := w.Watch(ctx)
+
+	var got []string
+	for len(got) < 4 {
+		got = append(got, (<-pools).ID)
+	}
+	if want := "0xa 0xb 0xc 0xd"; strings.Join(got, " ") != want {
+		t.Errorf("Emitted %v, want %s", got, want)
+	}
+	for _, id := range got {
+		if ev := <-published; ev.Pool.ID != id || ev.NetworkID != "ethereum" {
+			t.Errorf("Published %+v, want pool %s", ev, id)
+		}
+	}
+
+	// Later polls return the same pools, which are not emitted again
+	select {
+	case pool := <-pools:
+		t.Errorf("Emitted %s twice", pool.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
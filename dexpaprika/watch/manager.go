@@ -0,0 +1,330 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+// subscriptionBuffer is the channel capacity of a Manager subscription.
+const subscriptionBuffer = 16
+
+// Manager multiplexes many watch subscriptions over a shared polling budget.
+//
+// Instead of each watcher polling on its own timer, a Manager keeps one
+// target per distinct subscription and polls, on every tick, the targets
+// that are due, oldest first, up to its budget. Subscriptions to the same
+// pool or token share a target and its polls. When more targets are due
+// than the budget allows, the rest wait for later ticks, so the effective
+// interval stretches instead of exceeding the rate limit.
+type Manager struct {
+	client *dexpaprika.Client
+	rate   float64
+
+	// Tick is how often due targets are polled. It defaults to one second.
+	Tick time.Duration
+
+	// Policy decides how a target is polled after consecutive errors. A
+	// target whose policy gives up is removed and its subscriptions closed.
+	// It defaults to Backoff(DefaultMaxBackoff).
+	Policy FailurePolicy
+
+	// Bus, if set, receives the events of the underlying watchers.
+	Bus *events.Bus
+
+	// OnError, if set, is called with every failed poll, and with the
+	// terminal error of targets that give up.
+	OnError func(error)
+
+	// Metrics, if set, receives the metrics of every target, labelled as
+	// for the corresponding watcher.
+	Metrics dexpaprika.Metrics
+
+	mu      sync.Mutex
+	targets map[string]*target
+	nextID  int
+}
+
+// target is a polled resource and its subscribers.
+type target struct {
+	key      string
+	interval time.Duration
+	due      time.Time
+	failures int
+	polling  bool
+	metrics  instruments
+
+	// poll fetches the resource and returns the update to deliver, or nil
+	// if nothing changed.
+	poll func(ctx context.Context) (interface{}, error)
+	last interface{}
+	subs map[int]*subscriber
+}
+
+// subscriber delivers a target's updates to one subscription channel.
+type subscriber struct {
+	interval time.Duration
+	deliver  func(v interface{}) // Non-blocking send
+	close    func()
+}
+
+// NewManager creates a manager that polls at most requestsPerSecond targets
+// per second on average. Requests still pass through the client's own rate
+// limiter, if any.
+func NewManager(client *dexpaprika.Client, requestsPerSecond float64) *Manager {
+	if requestsPerSecond <= 0 {
+		requestsPerSecond = 1
+	}
+	return &Manager{
+		client:  client,
+		rate:    requestsPerSecond,
+		Tick:    time.Second,
+		targets: make(map[string]*target),
+	}
+}
+
+// WatchPool subscribes to a pool's details, polled about every interval.
+// Updates follow PoolWatcher semantics. The channel is buffered; a consumer
+// that falls behind misses intermediate updates, and the next update it
+// receives is relative to the last one delivered to anyone. The channel is
+// closed when ctx is done or the target gives up.
+func (m *Manager) WatchPool(ctx context.Context, networkID, poolAddress string, interval time.Duration) <-chan PoolUpdate {
+	key := fmt.Sprintf("pool:%s:%s", networkID, dexpaprika.NormalizeAddress(networkID, poolAddress))
+	in := newInstruments(m.Metrics, "pool", networkID, poolAddress)
+	return subscribe[PoolUpdate](ctx, m, key, interval, in, func() func(context.Context) (interface{}, error) {
+		w := NewPoolWatcher(m.client, networkID, poolAddress, interval)
+		w.Bus = m.Bus
+		w.metrics = in
+		var prev *dexpaprika.PoolDetails
+		return func(ctx context.Context) (interface{}, error) {
+			update, err := w.next(ctx, prev)
+			if err != nil || update == nil {
+				return nil, err
+			}
+			prev = update.Details
+			return *update, nil
+		}
+	})
+}
+
+// WatchTokenPrice subscribes to a token's USD price, polled about every
+// interval. Updates follow TokenPriceWatcher semantics and delivery is as
+// for WatchPool. Subscriptions with different thresholds use separate
+// targets.
+func (m *Manager) WatchTokenPrice(ctx context.Context, networkID, tokenAddress string, interval time.Duration, thresholdPercent float64) <-chan TokenPriceUpdate {
+	key := fmt.Sprintf("token:%s:%s:%g", networkID, dexpaprika.NormalizeAddress(networkID, tokenAddress), thresholdPercent)
+	in := newInstruments(m.Metrics, "token", networkID, tokenAddress)
+	return subscribe[TokenPriceUpdate](ctx, m, key, interval, in, func() func(context.Context) (interface{}, error) {
+		w := NewTokenPriceWatcher(m.client, networkID, tokenAddress, interval, thresholdPercent)
+		w.Bus = m.Bus
+		w.metrics = in
+		var last *TokenPriceUpdate
+		return func(ctx context.Context) (interface{}, error) {
+			update, err := w.next(ctx, last)
+			if err != nil || update == nil {
+				return nil, err
+			}
+			last = update
+			return *update, nil
+		}
+	})
+}
+
+// subscribe adds a subscription to the target with the given key, creating
+// the target with newPoll if needed.
+func subscribe[T any](ctx context.Context, m *Manager, key string, interval time.Duration, in instruments, newPoll func() func(context.Context) (interface{}, error)) <-chan T {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ch := make(chan T, subscriptionBuffer)
+	sub := &subscriber{
+		interval: interval,
+		deliver: func(v interface{}) {
+			select {
+			case ch <- v.(T):
+			default:
+			}
+		},
+		close: func() { close(ch) },
+	}
+
+	m.mu.Lock()
+	t, ok := m.targets[key]
+	if !ok {
+		t = &target{key: key, interval: interval, metrics: in, poll: newPoll(), subs: make(map[int]*subscriber)}
+		m.targets[key] = t
+	}
+	m.nextID++
+	id := m.nextID
+	t.subs[id] = sub
+	if interval < t.interval {
+		t.interval = interval
+	}
+	if t.last != nil {
+		sub.deliver(t.last)
+	}
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		m.unsubscribe(t, id)
+	}()
+	return ch
+}
+
+// unsubscribe removes a subscription, and its target once it has none left.
+func (m *Manager) unsubscribe(t *target, id int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub, ok := t.subs[id]
+	if !ok {
+		return
+	}
+	delete(t.subs, id)
+	sub.close()
+	if len(t.subs) == 0 {
+		if m.targets[t.key] == t {
+			delete(m.targets, t.key)
+		}
+		return
+	}
+	t.interval = sub.interval
+	for _, s := range t.subs {
+		t.interval = min(t.interval, s.interval)
+	}
+}
+
+// Targets returns the number of distinct targets being polled.
+func (m *Manager) Targets() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.targets)
+}
+
+// Run polls due targets every tick until ctx is done. Each tick polls at
+// most requestsPerSecond × Tick targets, in parallel, and waits for them to
+// finish before the next tick.
+func (m *Manager) Run(ctx context.Context) error {
+	tick := m.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+	budget := max(1, int(m.rate*tick.Seconds()))
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		m.pollDue(ctx, time.Now(), budget)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// pollDue polls up to budget targets that are due at now.
+func (m *Manager) pollDue(ctx context.Context, now time.Time, budget int) {
+	m.mu.Lock()
+	var due []*target
+	for _, t := range m.targets {
+		if !t.polling && !t.due.After(now) {
+			due = append(due, t)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].due.Equal(due[j].due) {
+			return due[i].due.Before(due[j].due)
+		}
+		return due[i].key < due[j].key
+	})
+	if len(due) > budget {
+		due = due[:budget]
+	}
+	for _, t := range due {
+		t.polling = true
+	}
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, t := range due {
+		wg.Add(1)
+		go func(t *target) {
+			defer wg.Done()
+			update, err := t.poll(ctx)
+			m.finish(ctx, t, now, update, err)
+		}(t)
+	}
+	wg.Wait()
+}
+
+// finish records the result of polling t and delivers any update. Errors
+// are passed to OnError once the lock is released, so that it may call
+// back into the Manager, and before the subscriptions of a target that gave
+// up are closed.
+func (m *Manager) finish(ctx context.Context, t *target, now time.Time, update interface{}, err error) {
+	m.mu.Lock()
+	errs, dropped := m.record(ctx, t, now, update, err)
+	m.mu.Unlock()
+
+	if m.OnError != nil {
+		for _, err := range errs {
+			m.OnError(err)
+		}
+	}
+	for _, sub := range dropped {
+		sub.close()
+	}
+}
+
+// record updates t with the result of a poll. It returns the errors to
+// report and, if t gave up, its subscriptions, which the caller closes. It
+// must be called with m.mu held.
+func (m *Manager) record(ctx context.Context, t *target, now time.Time, update interface{}, err error) ([]error, []*subscriber) {
+	policy := m.Policy
+	if policy == nil {
+		policy = Backoff(DefaultMaxBackoff)
+	}
+	t.polling = false
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+		t.failures++
+		t.metrics.poll(err, t.failures)
+		wait, ok := policy.Next(t.failures, t.interval)
+		if !ok {
+			var dropped []*subscriber
+			for id, sub := range t.subs {
+				delete(t.subs, id)
+				dropped = append(dropped, sub)
+			}
+			if m.targets[t.key] == t {
+				delete(m.targets, t.key)
+			}
+			return []error{err, fmt.Errorf("%w: %s after %d consecutive errors: %w", ErrGaveUp, t.key, t.failures, err)}, dropped
+		}
+		t.due = now.Add(wait)
+		return []error{err}, nil
+	}
+
+	t.failures = 0
+	t.metrics.poll(nil, 0)
+	t.due = now.Add(t.interval)
+	if update != nil {
+		t.metrics.emitted(1)
+		t.last = update
+		for _, sub := range t.subs {
+			sub.deliver(update)
+		}
+	}
+	return nil, nil
+}
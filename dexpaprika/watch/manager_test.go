@@ -0,0 +1,170 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// countingServer serves pool details and counts requests per path.
+func countingServer(t *testing.T) (*httptest.Server, func(path string) int) {
+	var mu sync.Mutex
+	counts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		counts[r.URL.Path]++
+		n := counts[r.URL.Path]
+		mu.Unlock()
+		if strings.Contains(r.URL.Path, "broken") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "pool", "last_price_usd": %d}`, 100+n)
+	}))
+	t.Cleanup(server.Close)
+	return server, func(path string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return counts[path]
+	}
+}
+
+func TestManager_SharesTargets(t *testing.T) {
+	server, count := countingServer(t)
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	m := NewManager(client, 100)
+	m.Tick = 5 * time.Millisecond
+	a := m.WatchPool(ctx, "ethereum", "0xAbC", time.Hour)
+	b := m.WatchPool(ctx, "ethereum", "0xabc", time.Hour)
+	if m.Targets() != 1 {
+		t.Fatalf("Expected subscriptions to share a target, got %d", m.Targets())
+	}
+	go m.Run(ctx)
+
+	ua, ub := <-a, <-b
+	if ua.Details.LastPriceUSD != 101 || ub.Details.LastPriceUSD != 101 {
+		t.Errorf("Expected both subscribers to receive the first poll, got %v and %v", ua.Details, ub.Details)
+	}
+
+	// A late subscriber receives the last update straight away
+	c := m.WatchPool(ctx, "ethereum", "0xabc", time.Hour)
+	if uc := <-c; uc.Details.LastPriceUSD != 101 {
+		t.Errorf("Expected the last update for a late subscriber, got %v", uc.Details)
+	}
+	if n := count("/networks/ethereum/pools/0xabc"); n != 1 {
+		t.Errorf("Expected a single poll within the interval, got %d", n)
+	}
+
+	cancel()
+	for range a {
+	}
+	for m.Targets() != 0 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestManager_Budget(t *testing.T) {
+	server, count := countingServer(t)
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := NewManager(client, 1)
+	for i := 0; i < 5; i++ {
+		m.WatchPool(ctx, "ethereum", fmt.Sprintf("0x%d", i), time.Minute)
+	}
+
+	total := func() int {
+		n := 0
+		for i := 0; i < 5; i++ {
+			n += count(fmt.Sprintf("/networks/ethereum/pools/0x%d", i))
+		}
+		return n
+	}
+
+	now := time.Now()
+	m.pollDue(ctx, now, 2)
+	if total() != 2 {
+		t.Fatalf("Expected 2 polls within the budget, got %d", total())
+	}
+	m.pollDue(ctx, now.Add(time.Second), 2)
+	m.pollDue(ctx, now.Add(2*time.Second), 2)
+	if total() != 5 {
+		t.Fatalf("Expected every target polled once, got %d", total())
+	}
+	for i := 0; i < 5; i++ {
+		if n := count(fmt.Sprintf("/networks/ethereum/pools/0x%d", i)); n != 1 {
+			t.Errorf("Pool 0x%d polled %d times before its interval elapsed", i, n)
+		}
+	}
+}
+
+func TestManager_GiveUp(t *testing.T) {
+	server, _ := countingServer(t)
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var terminal error
+	m := NewManager(client, 100)
+	m.Tick = time.Millisecond
+	m.Policy = GiveUpAfter(2, Backoff(time.Millisecond))
+	m.OnError = func(err error) {
+		if errors.Is(err, ErrGaveUp) {
+			terminal = err
+		}
+	}
+	updates := m.WatchPool(ctx, "ethereum", "0xbroken", time.Millisecond)
+	go m.Run(ctx)
+
+	for range updates {
+		t.Error("Expected no updates")
+	}
+	if ctx.Err() != nil || terminal == nil || m.Targets() != 0 {
+		t.Errorf("Expected the target to give up and be removed, got %v with %d targets", terminal, m.Targets())
+	}
+}
+
+func TestManager_OnErrorCallsBack(t *testing.T) {
+	server, _ := countingServer(t)
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// OnError calls back into the Manager, which must not hold its lock
+	reported := make(chan int, 10)
+	m := NewManager(client, 100)
+	m.Tick = time.Millisecond
+	m.Policy = GiveUpAfter(2, Backoff(time.Millisecond))
+	m.OnError = func(err error) {
+		reported <- m.Targets()
+	}
+	updates := m.WatchPool(ctx, "ethereum", "0xbroken", time.Millisecond)
+	go m.Run(ctx)
+
+	for range updates {
+		t.Error("Expected no updates")
+	}
+	if ctx.Err() != nil {
+		t.Fatal("Expected OnError to return while the Manager kept polling")
+	}
+	if n := len(reported); n != 3 {
+		t.Errorf("Expected OnError to be called 3 times, got %d", n)
+	}
+}
@@ -0,0 +1,74 @@
+package watch
+
+import (
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// Metric names recorded by watchers and managers with Metrics set. Every
+// series is labelled with the watcher kind ("pool", "token", "transactions")
+// and its target, e.g. "ethereum:0x88e6...".
+const (
+	MetricPolls             = "watch_polls_total"                    // Counter of polls, labelled with result "ok" or "error"
+	MetricEvents            = "watch_events_total"                   // Counter of updates emitted
+	MetricConsecutiveErrors = "watch_consecutive_errors"             // Gauge of failed polls since the last success
+	MetricLastSuccess       = "watch_last_success_timestamp_seconds" // Gauge of the Unix time of the last successful poll
+	MetricLag               = "watch_lag_seconds"                    // Gauge of the age of the polled data, where the API reports it
+)
+
+// instruments records the metrics of one watched target. The zero value
+// records nothing.
+type instruments struct {
+	metrics dexpaprika.Metrics
+	labels  map[string]string
+}
+
+func newInstruments(m dexpaprika.Metrics, kind, networkID, address string) instruments {
+	if m == nil {
+		return instruments{}
+	}
+	return instruments{
+		metrics: m,
+		labels:  map[string]string{"watcher": kind, "target": networkID + ":" + dexpaprika.NormalizeAddress(networkID, address)},
+	}
+}
+
+// poll records a poll and the number of consecutive failures after it.
+func (in instruments) poll(err error, failures int) {
+	if in.metrics == nil {
+		return
+	}
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	labels := make(map[string]string, len(in.labels)+1)
+	for k, v := range in.labels {
+		labels[k] = v
+	}
+	labels["result"] = result
+	in.metrics.Count(MetricPolls, 1, labels)
+	in.metrics.Gauge(MetricConsecutiveErrors, float64(failures), in.labels)
+	if err == nil {
+		in.metrics.Gauge(MetricLastSuccess, float64(time.Now().Unix()), in.labels)
+	}
+}
+
+// emitted records n emitted updates.
+func (in instruments) emitted(n int) {
+	if in.metrics != nil && n > 0 {
+		in.metrics.Count(MetricEvents, float64(n), in.labels)
+	}
+}
+
+// lag records the age of data last updated by the API at t, given in
+// RFC3339. Unparseable times are ignored.
+func (in instruments) lag(t string) {
+	if in.metrics == nil {
+		return
+	}
+	if updated, err := time.Parse(time.RFC3339, t); err == nil {
+		in.metrics.Gauge(MetricLag, time.Since(updated).Seconds(), in.labels)
+	}
+}
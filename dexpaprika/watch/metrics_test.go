@@ -0,0 +1,92 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestPoolWatcher_Metrics(t *testing.T) {
+	// The first two polls fail, then the price moves on every poll
+	var polls atomic.Int32
+	priceTime := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := polls.Add(1)
+		if n <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "0xpool", "last_price_usd": %d, "price_time": %q}`, n, priceTime)
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+	m := dexpaprika.NewInMemoryMetrics()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	w := NewPoolWatcher(client, "ethereum", "0xPool", time.Millisecond)
+	w.Policy = Backoff(time.Millisecond)
+	w.Metrics = m
+	updates := w.Watch(ctx)
+
+	labels := map[string]string{"watcher": "pool", "target": "ethereum:0xpool"}
+	<-updates
+	if v := m.Value(MetricPolls, map[string]string{"watcher": "pool", "target": "ethereum:0xpool", "result": "error"}); v != 2 {
+		t.Errorf("Expected two failed polls, got %v", v)
+	}
+	if v := m.Value(MetricConsecutiveErrors, labels); v != 0 {
+		t.Errorf("Expected consecutive errors to reset, got %v", v)
+	}
+	if v := m.Value(MetricLastSuccess, labels); v < float64(time.Now().Add(-time.Minute).Unix()) {
+		t.Errorf("Expected a recent last success, got %v", v)
+	}
+	if v := m.Value(MetricLag, labels); v < 60 || v > 120 {
+		t.Errorf("Expected a lag of about a minute, got %v", v)
+	}
+
+	<-updates
+	cancel()
+	for range updates {
+	}
+	if v := m.Value(MetricEvents, labels); v < 2 {
+		t.Errorf("Expected at least two events, got %v", v)
+	}
+}
+
+func TestManager_Metrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "0xpool", "last_price_usd": 100}`)
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+	m := dexpaprika.NewInMemoryMetrics()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	mgr := NewManager(client, 100)
+	mgr.Tick = time.Millisecond
+	mgr.Metrics = m
+	updates := mgr.WatchPool(ctx, "ethereum", "0xpool", time.Millisecond)
+	go mgr.Run(ctx)
+	<-updates
+
+	labels := map[string]string{"watcher": "pool", "target": "ethereum:0xpool", "result": "ok"}
+	for m.Value(MetricPolls, labels) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	if v := m.Value(MetricEvents, map[string]string{"watcher": "pool", "target": "ethereum:0xpool"}); v != 1 {
+		t.Errorf("Expected one event for unchanged polls, got %v", v)
+	}
+}
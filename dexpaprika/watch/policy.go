@@ -0,0 +1,71 @@
+package watch
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultMaxBackoff caps the wait between polls after consecutive errors
+// under the default failure policy.
+const DefaultMaxBackoff = 5 * time.Minute
+
+// ErrGaveUp is wrapped by the terminal error of a watcher whose failure
+// policy stopped polling.
+var ErrGaveUp = errors.New("watch: gave up")
+
+// FailurePolicy decides how a watcher continues after consecutive failed
+// polls.
+type FailurePolicy interface {
+	// Next returns the wait before the next poll after the given number of
+	// consecutive failures, or false to stop polling.
+	Next(failures int, interval time.Duration) (time.Duration, bool)
+}
+
+// FailurePolicyFunc adapts a function to the FailurePolicy interface.
+type FailurePolicyFunc func(failures int, interval time.Duration) (time.Duration, bool)
+
+// Next calls f(failures, interval).
+func (f FailurePolicyFunc) Next(failures int, interval time.Duration) (time.Duration, bool) {
+	return f(failures, interval)
+}
+
+// Backoff doubles the wait after each consecutive failure, starting from the
+// watcher's interval, up to maxWait. It never gives up. Backoff with
+// DefaultMaxBackoff is the default policy.
+func Backoff(maxWait time.Duration) FailurePolicy {
+	return FailurePolicyFunc(func(failures int, interval time.Duration) (time.Duration, bool) {
+		wait := interval
+		for i := 0; i < failures && wait < maxWait; i++ {
+			wait *= 2
+		}
+		return max(min(wait, maxWait), interval), true
+	})
+}
+
+// GiveUpAfter stops polling after n consecutive failures, waiting between
+// them as policy does, or with the default backoff if policy is nil. The
+// watcher's channel is then closed and its Err method returns an error
+// wrapping ErrGaveUp.
+func GiveUpAfter(n int, policy FailurePolicy) FailurePolicy {
+	if policy == nil {
+		policy = Backoff(DefaultMaxBackoff)
+	}
+	return FailurePolicyFunc(func(failures int, interval time.Duration) (time.Duration, bool) {
+		if failures >= n {
+			return 0, false
+		}
+		return policy.Next(failures, interval)
+	})
+}
+
+// Degrade keeps polling at the watcher's interval for the first n
+// consecutive failures, then falls back to the longer degraded interval
+// until a poll succeeds.
+func Degrade(n int, degraded time.Duration) FailurePolicy {
+	return FailurePolicyFunc(func(failures int, interval time.Duration) (time.Duration, bool) {
+		if failures >= n {
+			return max(degraded, interval), true
+		}
+		return interval, true
+	})
+}
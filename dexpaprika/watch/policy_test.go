@@ -0,0 +1,73 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestFailurePolicies(t *testing.T) {
+	interval := time.Second
+	tests := []struct {
+		name   string
+		policy FailurePolicy
+		want   []time.Duration // Wait after 1, 2, 3, 4 failures; -1 means give up
+	}{
+		{"backoff", Backoff(5 * time.Second), []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}},
+		{"give up after 3", GiveUpAfter(3, nil), []time.Duration{2 * time.Second, 4 * time.Second, -1, -1}},
+		{"give up with degrade", GiveUpAfter(4, Degrade(2, time.Minute)), []time.Duration{time.Second, time.Minute, time.Minute, -1}},
+		{"degrade after 2", Degrade(2, time.Minute), []time.Duration{time.Second, time.Minute, time.Minute, time.Minute}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, want := range tt.want {
+				wait, ok := tt.policy.Next(i+1, interval)
+				if want < 0 {
+					if ok {
+						t.Errorf("failure %d: expected to give up, got wait %v", i+1, wait)
+					}
+					continue
+				}
+				if !ok || wait != want {
+					t.Errorf("failure %d: got (%v, %v), want %v", i+1, wait, ok, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWatcher_GiveUp(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	w := NewPoolWatcher(client, "ethereum", "0xpool", time.Millisecond)
+	w.Policy = GiveUpAfter(3, nil)
+	for range w.Watch(ctx) {
+		t.Error("Expected no updates")
+	}
+
+	if ctx.Err() != nil {
+		t.Fatal("Expected the watcher to give up before the context expired")
+	}
+	if polls.Load() != 3 {
+		t.Errorf("Expected 3 polls, got %d", polls.Load())
+	}
+	if err := w.Err(); !errors.Is(err, ErrGaveUp) || !errors.Is(err, dexpaprika.ErrInternalServerError) {
+		t.Errorf("Expected terminal error wrapping ErrGaveUp and the API error, got %v", err)
+	}
+}
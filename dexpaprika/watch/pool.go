@@ -0,0 +1,244 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+// ErrStaleData is returned by polls of a PoolWatcher with FailOnStale set
+// when the pool's price_time is older than MaxAge.
+var ErrStaleData = errors.New("watch: stale data")
+
+// PoolUpdate is emitted by a PoolWatcher when a pool's details change.
+type PoolUpdate struct {
+	NetworkID   string
+	PoolAddress string
+	Details     *dexpaprika.PoolDetails
+	Previous    *dexpaprika.PoolDetails // Nil for the first update
+	Time        time.Time               // When the details were fetched
+
+	PriceUSDDelta      float64 // Change in last_price_usd since Previous
+	PriceChangePercent float64 // PriceUSDDelta relative to the previous price
+	VolumeUSD24hDelta  float64 // Change in 24h volume since Previous
+	Txns24hDelta       int     // Change in 24h transactions since Previous
+
+	// Changed lists the flattened fields (see dexpaprika.Flatten) that
+	// differ from Previous, such as "last_price_usd" or "24h_volume_usd".
+	// It is nil for the first update.
+	Changed []string
+
+	// Stale is set when the pool's price_time is older than the watcher's
+	// MaxAge.
+	Stale bool
+}
+
+// HasChanged reports whether any of the named fields changed. It is true
+// for the first update.
+func (u PoolUpdate) HasChanged(fields ...string) bool {
+	if u.Previous == nil {
+		return true
+	}
+	for _, changed := range u.Changed {
+		for _, f := range fields {
+			if changed == f {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PoolWatcher polls the details of a single pool.
+type PoolWatcher struct {
+	client      *dexpaprika.Client
+	networkID   string
+	poolAddress string
+	interval    time.Duration
+
+	// Options passed to Pools.GetDetails, e.g. to pick a quote token.
+	Options *dexpaprika.PoolDetailsOptions
+
+	// Fields, if set, restricts updates to polls that change at least one
+	// of these flattened fields, e.g. "last_price_usd". Changes of other
+	// fields accumulate into the next update.
+	Fields []string
+
+	// MaxAge, if set, is how old the pool's price_time may be before the
+	// data counts as stale, e.g. while the API lags behind the chain. Stale
+	// updates are flagged and a StaleData event is published on every
+	// stale poll.
+	MaxAge time.Duration
+
+	// FailOnStale makes stale polls fail with ErrStaleData instead of
+	// producing flagged updates, so they count towards Policy.
+	FailOnStale bool
+
+	// Bus, if set, receives a PoolPriceChanged event for every update that
+	// changes the price, and StaleData events when MaxAge is exceeded.
+	Bus *events.Bus
+
+	// Metrics, if set, receives the watcher's poll, event, error and lag
+	// metrics.
+	Metrics dexpaprika.Metrics
+
+	// Policy decides how polling continues after consecutive errors.
+	// It defaults to Backoff(DefaultMaxBackoff).
+	Policy FailurePolicy
+
+	// OnError, if set, is called with every failed poll.
+	OnError func(error)
+
+	// Clock, if set, times updates and measures the age of price_time,
+	// e.g. a dexpaprikatest.Clock in tests. It defaults to
+	// dexpaprika.SystemClock.
+	Clock dexpaprika.Clock
+
+	metrics instruments
+	lastErr
+}
+
+// NewPoolWatcher creates a watcher that polls a pool's details every interval.
+func NewPoolWatcher(client *dexpaprika.Client, networkID, poolAddress string, interval time.Duration) *PoolWatcher {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &PoolWatcher{
+		client:      client,
+		networkID:   networkID,
+		poolAddress: poolAddress,
+		interval:    interval,
+	}
+}
+
+// Watch starts polling and returns a channel of updates. The first poll
+// always produces an update; later polls produce one only when the details
+// differ from the last update, in one of Fields if set. The channel is
+// closed when ctx is done.
+func (w *PoolWatcher) Watch(ctx context.Context) <-chan PoolUpdate {
+	updates := make(chan PoolUpdate)
+	var prev *dexpaprika.PoolDetails
+
+	w.metrics = newInstruments(w.Metrics, "pool", w.networkID, w.poolAddress)
+	p := poller{interval: w.interval, policy: w.Policy, onError: w.OnError, metrics: w.metrics}
+	go func() {
+		defer close(updates)
+		err := p.run(ctx, func(ctx context.Context) error {
+			update, err := w.next(ctx, prev)
+			if err != nil || update == nil {
+				return err
+			}
+			select {
+			case updates <- *update:
+				prev = update.Details
+				w.metrics.emitted(1)
+			case <-ctx.Done():
+			}
+			return nil
+		})
+		if err != nil {
+			w.set(err)
+		}
+	}()
+	return updates
+}
+
+// next polls the pool and returns an update relative to prev, or nil if the
+// details are unchanged.
+func (w *PoolWatcher) next(ctx context.Context, prev *dexpaprika.PoolDetails) (*PoolUpdate, error) {
+	details, err := w.client.Pools.GetDetails(ctx, w.networkID, w.poolAddress, w.Options)
+	w.set(err)
+	if err != nil {
+		return nil, err
+	}
+	w.metrics.lag(details.PriceTime)
+	stale, err := w.checkAge(details)
+	if err != nil {
+		w.set(err)
+		return nil, err
+	}
+	if prev != nil && reflect.DeepEqual(prev, details) {
+		return nil, nil
+	}
+	update := newPoolUpdate(w.networkID, w.poolAddress, prev, details, w.now())
+	update.Stale = stale
+	if len(w.Fields) > 0 && !update.HasChanged(w.Fields...) {
+		return nil, nil
+	}
+	if prev != nil && update.PriceUSDDelta != 0 {
+		w.Bus.Publish(events.PoolPriceChanged{
+			NetworkID:        w.networkID,
+			PoolAddress:      w.poolAddress,
+			PriceUSD:         details.LastPriceUSD,
+			PreviousPriceUSD: prev.LastPriceUSD,
+			ChangePercent:    update.PriceChangePercent,
+			Time:             update.Time,
+		})
+	}
+	return &update, nil
+}
+
+// checkAge reports whether details are older than MaxAge and publishes a
+// StaleData event if so. Details without a parseable price_time are never
+// stale.
+func (w *PoolWatcher) checkAge(details *dexpaprika.PoolDetails) (bool, error) {
+	if w.MaxAge <= 0 {
+		return false, nil
+	}
+	priceTime, err := time.Parse(time.RFC3339, details.PriceTime)
+	if err != nil {
+		return false, nil
+	}
+	now := w.now()
+	age := now.Sub(priceTime)
+	if age <= w.MaxAge {
+		return false, nil
+	}
+	w.Bus.Publish(events.StaleData{
+		NetworkID:   w.networkID,
+		PoolAddress: w.poolAddress,
+		DataTime:    priceTime,
+		Age:         age,
+		Time:        now,
+	})
+	if w.FailOnStale {
+		return true, fmt.Errorf("%w: price_time %s is %s old", ErrStaleData, details.PriceTime, age.Round(time.Second))
+	}
+	return true, nil
+}
+
+// now returns the time on the watcher's Clock.
+func (w *PoolWatcher) now() time.Time {
+	if w.Clock == nil {
+		return time.Now()
+	}
+	return w.Clock.Now()
+}
+
+func newPoolUpdate(networkID, poolAddress string, prev, cur *dexpaprika.PoolDetails, now time.Time) PoolUpdate {
+	update := PoolUpdate{
+		NetworkID:   networkID,
+		PoolAddress: poolAddress,
+		Details:     cur,
+		Previous:    prev,
+		Time:        now,
+	}
+	if prev == nil {
+		return update
+	}
+	update.PriceUSDDelta = cur.LastPriceUSD - prev.LastPriceUSD
+	if prev.LastPriceUSD != 0 {
+		update.PriceChangePercent = update.PriceUSDDelta / prev.LastPriceUSD * 100
+	}
+	update.VolumeUSD24hDelta = cur.Day.VolumeUSD - prev.Day.VolumeUSD
+	update.Txns24hDelta = cur.Day.Txns - prev.Day.Txns
+	for _, c := range dexpaprika.DiffRecords(prev.Flatten(), cur.Flatten()) {
+		update.Changed = append(update.Changed, c.Field)
+	}
+	return update
+}
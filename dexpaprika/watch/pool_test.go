@@ -0,0 +1,241 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/dexpaprikatest"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+func TestPoolWatcher(t *testing.T) {
+	// The first poll fails, then the price holds at 100 for two polls and moves to 110
+	responses := []string{"", "100", "100", "110"}
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/networks/ethereum/pools/0xpool" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		n := int(polls.Add(1)) - 1
+		if n >= len(responses) {
+			n = len(responses) - 1
+		}
+		if responses[n] == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "0xpool", "last_price_usd": %s, "24h": {"volume_usd": %s, "txns": %s}}`, responses[n], responses[n], responses[n][:2])
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var errs atomic.Int32
+	w := NewPoolWatcher(client, "ethereum", "0xpool", 5*time.Millisecond)
+	w.OnError = func(error) { errs.Add(1) }
+	updates := w.Watch(ctx)
+
+	first := <-updates
+	if first.Previous != nil || first.Details.LastPriceUSD != 100 {
+		t.Fatalf("Unexpected first update: %+v", first)
+	}
+	second := <-updates
+	if second.Details.LastPriceUSD != 110 || second.PriceUSDDelta != 10 || second.PriceChangePercent != 10 {
+		t.Errorf("Unexpected second update: %+v", second)
+	}
+	if second.VolumeUSD24hDelta != 10 || second.Txns24hDelta != 1 {
+		t.Errorf("Expected volume and txn deltas, got %+v", second)
+	}
+	if want := []string{"last_price_usd", "24h_volume_usd", "24h_txns"}; !reflect.DeepEqual(second.Changed, want) {
+		t.Errorf("Changed = %v, want %v", second.Changed, want)
+	}
+	if !second.HasChanged("fee", "24h_txns") || second.HasChanged("fee") {
+		t.Errorf("Unexpected HasChanged results for %v", second.Changed)
+	}
+	if polls.Load() < 4 {
+		t.Errorf("Expected the unchanged poll to be skipped, got %d polls", polls.Load())
+	}
+	if errs.Load() != 1 {
+		t.Errorf("Expected one poll error, got %d", errs.Load())
+	}
+
+	cancel()
+	for range updates {
+	}
+}
+
+func TestPoolWatcher_PublishesEvents(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "0xpool", "last_price_usd": %d}`, 100+polls.Add(1))
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	bus := events.NewBus()
+	changes, stop := events.Chan[events.PoolPriceChanged](bus, 1)
+	defer stop()
+
+	w := NewPoolWatcher(client, "ethereum", "0xpool", time.Millisecond)
+	w.Bus = bus
+	updates := w.Watch(ctx)
+	<-updates
+	<-updates
+
+	e := <-changes
+	if e.PoolAddress != "0xpool" || e.PreviousPriceUSD != 101 || e.PriceUSD != 102 {
+		t.Errorf("Unexpected event %+v", e)
+	}
+	cancel()
+	for range updates {
+	}
+}
+
+func TestPoolWatcher_Fields(t *testing.T) {
+	// Volume changes on every poll; the price only on the third
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := polls.Add(1)
+		price := 100
+		if n >= 3 {
+			price = 101
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "0xpool", "last_price_usd": %d, "24h": {"volume_usd": %d}}`, price, n*1000)
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	w := NewPoolWatcher(client, "ethereum", "0xpool", time.Millisecond)
+	w.Fields = []string{"last_price_usd"}
+	updates := w.Watch(ctx)
+
+	<-updates
+	u := <-updates
+	if u.Details.LastPriceUSD != 101 || u.VolumeUSD24hDelta != 2000 {
+		t.Errorf("Expected the volume-only poll to be skipped and accumulated, got %+v", u)
+	}
+
+	cancel()
+	for range updates {
+	}
+}
+
+func TestPoolWatcher_MaxAge(t *testing.T) {
+	// The first poll is fresh; from then on price_time stays an hour old
+	var polls atomic.Int32
+	fresh := time.Now().UTC().Format(time.RFC3339)
+	old := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := polls.Add(1)
+		priceTime := old
+		if n == 1 {
+			priceTime = fresh
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "0xpool", "last_price_usd": 100, "price_time": %q}`, priceTime)
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	bus := events.NewBus()
+	stale, stop := events.Chan[events.StaleData](bus, 1)
+	defer stop()
+
+	w := NewPoolWatcher(client, "ethereum", "0xpool", time.Millisecond)
+	w.MaxAge = 5 * time.Minute
+	w.Bus = bus
+	updates := w.Watch(ctx)
+
+	if u := <-updates; u.Stale {
+		t.Errorf("Expected the first update to be fresh, got %+v", u)
+	}
+	if u := <-updates; !u.Stale || !u.HasChanged("price_time") {
+		t.Errorf("Expected a stale update, got %+v", u)
+	}
+	e := <-stale
+	if e.PoolAddress != "0xpool" || e.Age < time.Hour || e.DataTime.Format(time.RFC3339) != old {
+		t.Errorf("Unexpected event %+v", e)
+	}
+
+	cancel()
+	for range updates {
+	}
+}
+
+func TestPoolWatcher_Clock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "0xpool", "last_price_usd": 100, "price_time": "2024-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	// Staleness and update times follow the watcher's clock, not the
+	// system's, which is years past price_time
+	clock := dexpaprikatest.NewClock(time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC))
+	w := NewPoolWatcher(client, "ethereum", "0xpool", time.Minute)
+	w.MaxAge = 5 * time.Minute
+	w.Clock = clock
+
+	u, err := w.next(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Stale || !u.Time.Equal(clock.Now()) {
+		t.Errorf("Expected a fresh update timed by the clock, got %+v", u)
+	}
+
+	clock.Advance(time.Hour)
+	w.FailOnStale = true
+	if _, err := w.next(context.Background(), nil); !errors.Is(err, ErrStaleData) {
+		t.Errorf("Expected ErrStaleData once the clock moves past MaxAge, got %v", err)
+	}
+}
+
+func TestPoolWatcher_FailOnStale(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "0xpool", "last_price_usd": 100, "price_time": "2024-01-01T00:00:00Z"}`)
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	w := NewPoolWatcher(client, "ethereum", "0xpool", time.Millisecond)
+	w.MaxAge = time.Minute
+	w.FailOnStale = true
+	w.Policy = GiveUpAfter(2, Backoff(time.Millisecond))
+	for range w.Watch(context.Background()) {
+		t.Error("Expected no updates for stale data")
+	}
+	if err := w.Err(); !errors.Is(err, ErrGaveUp) || !errors.Is(err, ErrStaleData) {
+		t.Errorf("Expected a stale data error, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package watch
+
+import (
+	"context"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+// drainBatch caps the updates written to a sink at once by Drain.
+const drainBatch = 100
+
+// Drain writes every update received from a watcher's channel to sink, such
+// as a file or database sink of the export package, until the channel is
+// closed. Updates arriving together are written as one batch, and the sink
+// is flushed after each batch, so rows reach it as they happen. Drain does
+// not close the sink.
+//
+// The watcher closes its channel when its context is done, so the final
+// flush runs without that context's cancellation.
+func Drain[T any](ctx context.Context, updates <-chan T, sink dexpaprika.Sink[T]) error {
+	ctx = context.WithoutCancel(ctx)
+	batch := make([]T, 0, drainBatch)
+	for update := range updates {
+		batch = append(batch[:0], update)
+	collect:
+		for len(batch) < drainBatch {
+			select {
+			case update, ok := <-updates:
+				if !ok {
+					break collect
+				}
+				batch = append(batch, update)
+			default:
+				break collect
+			}
+		}
+		if err := sink.Write(ctx, batch); err != nil {
+			return err
+		}
+		if err := sink.Flush(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// sliceSink is a dexpaprika.Sink collecting rows in memory.
+type sliceSink[T any] struct {
+	rows    []T
+	flushes int
+	err     error
+}
+
+func (s *sliceSink[T]) Write(ctx context.Context, rows []T) error {
+	s.rows = append(s.rows, rows...)
+	return s.err
+}
+
+func (s *sliceSink[T]) Flush(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	s.flushes++
+	return nil
+}
+
+func (s *sliceSink[T]) Close() error { return nil }
+
+func TestDrain(t *testing.T) {
+	updates := make(chan TokenPriceUpdate, 3)
+	updates <- TokenPriceUpdate{Symbol: "A"}
+	updates <- TokenPriceUpdate{Symbol: "B"}
+	updates <- TokenPriceUpdate{Symbol: "C"}
+	close(updates)
+
+	// Writes continue after the watcher's context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sink := &sliceSink[TokenPriceUpdate]{}
+	if err := Drain(ctx, updates, sink); err != nil {
+		t.Fatalf("Drain returned error: %v", err)
+	}
+	if len(sink.rows) != 3 || sink.rows[2].Symbol != "C" {
+		t.Errorf("Drain wrote %v", sink.rows)
+	}
+	if sink.flushes != 1 {
+		t.Errorf("Got %d flushes, want 1 for the buffered batch", sink.flushes)
+	}
+}
+
+func TestDrain_Error(t *testing.T) {
+	updates := make(chan int, 1)
+	updates <- 1
+	sink := &sliceSink[int]{err: errors.New("disk full")}
+	if err := Drain(context.Background(), updates, sink); err == nil {
+		t.Fatal("Expected the write error")
+	}
+}
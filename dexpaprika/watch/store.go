@@ -0,0 +1,225 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// Store persists watcher cursors, so a restarted watcher resumes where the
+// previous one left off. Implementations must be safe for concurrent use.
+// Any key-value database, such as Redis, can back a Store.
+type Store interface {
+	// Get returns the value saved under key, and false if there is none.
+	Get(ctx context.Context, key string) (string, bool, error)
+
+	// Set saves value under key.
+	Set(ctx context.Context, key, value string) error
+}
+
+// MemoryStore is a Store kept in memory. It resumes watchers restarted
+// within a process.
+type MemoryStore struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{values: make(map[string]string)}
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(_ context.Context, key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	return v, ok, nil
+}
+
+// Set implements Store.
+func (s *MemoryStore) Set(_ context.Context, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+	return nil
+}
+
+// FileStore is a Store kept in a JSON file. Every Set rewrites the file
+// atomically, so it suits a handful of watchers rather than thousands; a
+// LogStore suits many keys.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore creates a store saved at path. The file is created by the
+// first Set.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Get implements Store.
+func (s *FileStore) Get(_ context.Context, key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values, err := s.read()
+	if err != nil {
+		return "", false, err
+	}
+	v, ok := values[key]
+	return v, ok, nil
+}
+
+// Set implements Store.
+func (s *FileStore) Set(_ context.Context, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values, err := s.read()
+	if err != nil {
+		return err
+	}
+	values[key] = value
+
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *FileStore) read() (map[string]string, error) {
+	values := make(map[string]string)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// LogStore is a Store kept in memory and in a file of JSON lines, one
+// appended per Set, so saving a value costs the same however many keys the
+// store holds. It suits stores of many keys, such as the checkpoints of a
+// download of every pool of a network. The file is compacted to one line per
+// key when the store is opened.
+type LogStore struct {
+	mu     sync.Mutex
+	file   *os.File
+	values map[string]string
+}
+
+// logEntry is a line of a LogStore file.
+type logEntry struct {
+	Key   string `json:"k"`
+	Value string `json:"v"`
+}
+
+// OpenLogStore opens the store saved at path, creating the file if it does
+// not exist. A last line cut short by a crash is ignored. The store must be
+// closed.
+func OpenLogStore(path string) (*LogStore, error) {
+	values := make(map[string]string)
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		var e logEntry
+		if err := json.Unmarshal(line, &e); err != nil {
+			if i == len(lines)-1 {
+				break // Cut short by a crash during Set
+			}
+			return nil, err
+		}
+		values[e.Key] = e.Value
+	}
+
+	// Compact the file into a temporary one, then keep appending to it
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	var buf bytes.Buffer
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		line, err := json.Marshal(logEntry{key, values[key]})
+		if err != nil {
+			tmp.Close()
+			return nil, err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	return &LogStore{file: tmp, values: values}, nil
+}
+
+// Get implements Store.
+func (s *LogStore) Get(_ context.Context, key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	return v, ok, nil
+}
+
+// Set implements Store.
+func (s *LogStore) Set(_ context.Context, key, value string) error {
+	line, err := json.Marshal(logEntry{key, value})
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	s.values[key] = value
+	return nil
+}
+
+// Close closes the file of the store.
+func (s *LogStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
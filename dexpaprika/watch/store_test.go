@@ -0,0 +1,115 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cursors.json")
+
+	s := NewFileStore(path)
+	if _, ok, err := s.Get(ctx, "a"); ok || err != nil {
+		t.Fatalf("Expected no value before the first Set, got %v, %v", ok, err)
+	}
+	if err := s.Set(ctx, "a", "1"); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if err := s.Set(ctx, "b", "2"); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+
+	// A new store on the same file sees the saved values
+	s = NewFileStore(path)
+	for key, want := range map[string]string{"a": "1", "b": "2"} {
+		if v, ok, err := s.Get(ctx, key); !ok || err != nil || v != want {
+			t.Errorf("Get(%q) = %q, %v, %v; want %q", key, v, ok, err, want)
+		}
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected temporary files to be cleaned up, got %d entries", len(entries))
+	}
+}
+
+func TestFileStore_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cursors.json")
+	os.WriteFile(path, []byte("not json"), 0o644)
+	if _, _, err := NewFileStore(path).Get(context.Background(), "a"); err == nil {
+		t.Error("Expected an error for a corrupt file")
+	}
+}
+
+func TestLogStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "checkpoints.jsonl")
+
+	s, err := OpenLogStore(path)
+	if err != nil {
+		t.Fatalf("OpenLogStore returned error: %v", err)
+	}
+	if _, ok, err := s.Get(ctx, "a"); ok || err != nil {
+		t.Fatalf("Expected no value before the first Set, got %v, %v", ok, err)
+	}
+	for _, kv := range [][2]string{{"a", "1"}, {"b", "2"}, {"a", "3"}} {
+		if err := s.Set(ctx, kv[0], kv[1]); err != nil {
+			t.Fatalf("Set returned error: %v", err)
+		}
+	}
+	if v, _, _ := s.Get(ctx, "a"); v != "3" {
+		t.Errorf("Get(a) = %q, want the latest value 3", v)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if data, _ := os.ReadFile(path); bytes.Count(data, []byte("\n")) != 3 {
+		t.Errorf("Expected a line appended per Set, got:\n%s", data)
+	}
+
+	// A crash during Set leaves a partial last line
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	f.WriteString(`{"k":"c","v":`)
+	f.Close()
+
+	// A reopened store sees the saved values and compacts the file
+	s, err = OpenLogStore(path)
+	if err != nil {
+		t.Fatalf("OpenLogStore returned error: %v", err)
+	}
+	defer s.Close()
+	for key, want := range map[string]string{"a": "3", "b": "2"} {
+		if v, ok, err := s.Get(ctx, key); !ok || err != nil || v != want {
+			t.Errorf("Get(%q) = %q, %v, %v; want %q", key, v, ok, err, want)
+		}
+	}
+	if _, ok, _ := s.Get(ctx, "c"); ok {
+		t.Error("Expected the partial line to be ignored")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "{\"k\":\"a\",\"v\":\"3\"}\n{\"k\":\"b\",\"v\":\"2\"}\n" {
+		t.Errorf("Unexpected compacted file:\n%s", data)
+	}
+	if err := s.Set(ctx, "c", "4"); err != nil {
+		t.Fatalf("Set after reopening returned error: %v", err)
+	}
+	if data, _ := os.ReadFile(path); !bytes.HasSuffix(data, []byte("{\"k\":\"c\",\"v\":\"4\"}\n")) {
+		t.Errorf("Expected Set to append to the compacted file, got:\n%s", data)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected temporary files to be cleaned up, got %d entries", len(entries))
+	}
+}
+
+func TestLogStore_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoints.jsonl")
+	os.WriteFile(path, []byte("not json\n{\"k\":\"a\",\"v\":\"1\"}\n"), 0o644)
+	if _, err := OpenLogStore(path); err == nil {
+		t.Error("Expected an error for a corrupt line before the last")
+	}
+}
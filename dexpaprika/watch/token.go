@@ -0,0 +1,168 @@
+package watch
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+// TokenPriceUpdate is emitted by a TokenPriceWatcher when a token's price
+// moves past the watcher's threshold.
+type TokenPriceUpdate struct {
+	NetworkID    string
+	TokenAddress string
+	Symbol       string
+	PriceUSD     float64
+	Time         time.Time // When the price was fetched
+
+	// PreviousPriceUSD is the price of the last emitted update, and zero
+	// for the first one. ChangePercent is relative to it, and zero when it
+	// is zero: the first non-zero price after a zero one is emitted as a
+	// new baseline.
+	PreviousPriceUSD float64
+	ChangePercent    float64
+}
+
+// TokenPriceWatcher polls the USD price of a token and emits an update only
+// when it moves by at least a threshold percentage since the last update.
+type TokenPriceWatcher struct {
+	client       *dexpaprika.Client
+	networkID    string
+	tokenAddress string
+	interval     time.Duration
+	threshold    float64
+
+	// Bus, if set, receives a TokenPriceChanged event for every update
+	// after the first.
+	Bus *events.Bus
+
+	// Metrics, if set, receives the watcher's poll, event, error and lag
+	// metrics.
+	Metrics dexpaprika.Metrics
+
+	// Policy decides how polling continues after consecutive errors.
+	// It defaults to Backoff(DefaultMaxBackoff).
+	Policy FailurePolicy
+
+	// OnError, if set, is called with every failed poll.
+	OnError func(error)
+
+	metrics instruments
+	lastErr
+}
+
+// NewTokenPriceWatcher creates a watcher that polls a token's price every
+// interval and emits updates for moves of at least thresholdPercent, e.g. 0.5
+// for half a percent. A threshold of zero emits every change in price.
+func NewTokenPriceWatcher(client *dexpaprika.Client, networkID, tokenAddress string, interval time.Duration, thresholdPercent float64) *TokenPriceWatcher {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &TokenPriceWatcher{
+		client:       client,
+		networkID:    networkID,
+		tokenAddress: tokenAddress,
+		interval:     interval,
+		threshold:    math.Abs(thresholdPercent),
+	}
+}
+
+// Watch starts polling and returns a channel of price updates. The first
+// poll always produces an update. The channel is closed when ctx is done.
+//
+// Prices come from the token's summary, falling back to Prices.Get, which
+// weights the prices of the token's pools by liquidity, when the summary
+// has none.
+func (w *TokenPriceWatcher) Watch(ctx context.Context) <-chan TokenPriceUpdate {
+	updates := make(chan TokenPriceUpdate)
+	var last *TokenPriceUpdate
+
+	w.metrics = newInstruments(w.Metrics, "token", w.networkID, w.tokenAddress)
+	p := poller{interval: w.interval, policy: w.Policy, onError: w.OnError, metrics: w.metrics}
+	go func() {
+		defer close(updates)
+		err := p.run(ctx, func(ctx context.Context) error {
+			update, err := w.next(ctx, last)
+			if err != nil || update == nil {
+				return err
+			}
+			select {
+			case updates <- *update:
+				last = update
+				w.metrics.emitted(1)
+			case <-ctx.Done():
+			}
+			return nil
+		})
+		if err != nil {
+			w.set(err)
+		}
+	}()
+	return updates
+}
+
+// next polls the token's price and returns an update relative to last, or
+// nil if the price has not moved past the threshold.
+func (w *TokenPriceWatcher) next(ctx context.Context, last *TokenPriceUpdate) (*TokenPriceUpdate, error) {
+	update, err := w.fetch(ctx)
+	w.set(err)
+	if err != nil {
+		return nil, err
+	}
+	if last != nil && last.PriceUSD == 0 {
+		// No change can be measured from a zero price; a non-zero one
+		// becomes the new baseline.
+		if update.PriceUSD == 0 {
+			return nil, nil
+		}
+		return update, nil
+	}
+	if last != nil {
+		update.PreviousPriceUSD = last.PriceUSD
+		update.ChangePercent = (update.PriceUSD - last.PriceUSD) / last.PriceUSD * 100
+		if update.PriceUSD == last.PriceUSD || math.Abs(update.ChangePercent) < w.threshold {
+			return nil, nil
+		}
+		w.Bus.Publish(events.TokenPriceChanged{
+			NetworkID:        update.NetworkID,
+			TokenAddress:     update.TokenAddress,
+			Symbol:           update.Symbol,
+			PriceUSD:         update.PriceUSD,
+			PreviousPriceUSD: update.PreviousPriceUSD,
+			ChangePercent:    update.ChangePercent,
+			Time:             update.Time,
+		})
+	}
+	return update, nil
+}
+
+// fetch returns the token's current price.
+func (w *TokenPriceWatcher) fetch(ctx context.Context) (*TokenPriceUpdate, error) {
+	token, err := w.client.Tokens.GetDetails(ctx, w.networkID, w.tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	w.metrics.lag(token.LastUpdated)
+	update := &TokenPriceUpdate{
+		NetworkID:    w.networkID,
+		TokenAddress: w.tokenAddress,
+		Symbol:       token.Symbol,
+		Time:         time.Now(),
+	}
+
+	if summary, ok := token.GetSummary(); ok {
+		if price, ok := summary.GetPriceUSD(); ok && price > 0 {
+			update.PriceUSD = price
+			return update, nil
+		}
+	}
+	price, err := w.client.Prices.Get(ctx, w.networkID, w.tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	update.PriceUSD = price.PriceUSD
+	return update, nil
+}
@@ -0,0 +1,88 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestTokenPriceWatcher(t *testing.T) {
+	// Small ticks below the 1% threshold are suppressed until the price has
+	// moved 1.5% from the last emitted update
+	prices := []float64{100, 100.2, 100.4, 101.5, 101.5}
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/networks/ethereum/tokens/0xtoken" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		n := int(polls.Add(1)) - 1
+		if n >= len(prices) {
+			n = len(prices) - 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "0xtoken", "symbol": "TKN", "summary": {"price_usd": %v}}`, prices[n])
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	updates := NewTokenPriceWatcher(client, "ethereum", "0xtoken", 5*time.Millisecond, 1).Watch(ctx)
+
+	first := <-updates
+	if first.PriceUSD != 100 || first.Symbol != "TKN" || first.PreviousPriceUSD != 0 {
+		t.Fatalf("Unexpected first update: %+v", first)
+	}
+	second := <-updates
+	if second.PriceUSD != 101.5 || second.PreviousPriceUSD != 100 || math.Abs(second.ChangePercent-1.5) > 1e-9 {
+		t.Errorf("Unexpected second update: %+v", second)
+	}
+	if polls.Load() < 4 {
+		t.Errorf("Expected sub-threshold polls to be skipped, got %d polls", polls.Load())
+	}
+
+	cancel()
+	for range updates {
+	}
+}
+
+func TestTokenPriceWatcher_ZeroPrice(t *testing.T) {
+	price := 0.0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "0xtoken", "symbol": "TKN", "summary": {"price_usd": %v}}`, price)
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+	w := NewTokenPriceWatcher(client, "ethereum", "0xtoken", time.Minute, 1)
+
+	// A price measured from a zero one would be an infinite change, so the
+	// first non-zero price becomes a new baseline instead
+	price = 2
+	update, err := w.next(context.Background(), &TokenPriceUpdate{PriceUSD: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update == nil || update.PriceUSD != 2 || update.PreviousPriceUSD != 0 || update.ChangePercent != 0 {
+		t.Fatalf("Expected a new baseline, got %+v", update)
+	}
+
+	price = 3
+	update, err = w.next(context.Background(), update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update == nil || update.PreviousPriceUSD != 2 || math.Abs(update.ChangePercent-50) > 1e-9 {
+		t.Errorf("Unexpected update after the baseline: %+v", update)
+	}
+}
@@ -0,0 +1,244 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika/events"
+)
+
+// transactionsPageSize is the number of transactions requested per page.
+const transactionsPageSize = 100
+
+// cursorSize is the number of most recent transaction keys saved as a
+// TransactionWatcher's cursor. Saving several guards against transactions
+// of the same block changing order between polls.
+const cursorSize = 20
+
+// TransactionWatcher follows the transactions of a pool, emitting each new
+// transaction once, oldest first. It gives a pseudo-streaming trade feed on
+// top of the polling API.
+type TransactionWatcher struct {
+	client      *dexpaprika.Client
+	networkID   string
+	poolAddress string
+	interval    time.Duration
+
+	// MaxPages caps the pages fetched per poll to catch up after a burst of
+	// more than 100 transactions between polls. It defaults to 5; older
+	// transactions beyond it are skipped.
+	MaxPages int
+
+	// Bus, if set, receives a NewTransaction event for every transaction
+	// emitted.
+	Bus *events.Bus
+
+	// Store, if set, persists the watcher's position after every delivered
+	// transaction. A watcher started with a saved position emits the
+	// transactions made since, instead of starting from the current ones.
+	Store Store
+
+	// CursorKey is the key of the watcher's position in Store. It defaults
+	// to "transactions:<network>:<pool>".
+	CursorKey string
+
+	// Metrics, if set, receives the watcher's poll, event, error and lag
+	// metrics.
+	Metrics dexpaprika.Metrics
+
+	// Policy decides how polling continues after consecutive errors.
+	// It defaults to Backoff(DefaultMaxBackoff).
+	Policy FailurePolicy
+
+	// OnError, if set, is called with every failed poll.
+	OnError func(error)
+
+	lastErr
+}
+
+// NewTransactionWatcher creates a watcher that polls a pool's transactions
+// every interval.
+func NewTransactionWatcher(client *dexpaprika.Client, networkID, poolAddress string, interval time.Duration) *TransactionWatcher {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	return &TransactionWatcher{
+		client:      client,
+		networkID:   networkID,
+		poolAddress: poolAddress,
+		interval:    interval,
+		MaxPages:    5,
+	}
+}
+
+// Transactions follows a pool's transactions with a TransactionWatcher
+// polling every 10 seconds.
+func Transactions(ctx context.Context, client *dexpaprika.Client, networkID, poolAddress string) <-chan dexpaprika.Transaction {
+	return NewTransactionWatcher(client, networkID, poolAddress, 0).Watch(ctx)
+}
+
+// Watch starts polling and returns a channel of new transactions. Unless a
+// position is saved in Store, the first poll only records the pool's current
+// transactions, so the channel carries transactions made after Watch was
+// called. The channel is closed when ctx is done.
+func (w *TransactionWatcher) Watch(ctx context.Context) <-chan dexpaprika.Transaction {
+	txs := make(chan dexpaprika.Transaction)
+	var seen map[string]bool
+	var cursor []string // Most recent keys first
+	loaded := false
+
+	in := newInstruments(w.Metrics, "transactions", w.networkID, w.poolAddress)
+	p := poller{interval: w.interval, policy: w.Policy, onError: w.OnError, metrics: in}
+	go func() {
+		defer close(txs)
+		err := p.run(ctx, func(ctx context.Context) error {
+			if !loaded {
+				var err error
+				if cursor, err = w.loadCursor(ctx); err != nil {
+					w.set(err)
+					return err
+				}
+				if len(cursor) > 0 {
+					seen = make(map[string]bool, len(cursor))
+					for _, key := range cursor {
+						seen[key] = true
+					}
+				}
+				loaded = true
+			}
+
+			fresh, keys, err := w.poll(ctx, seen)
+			w.set(err)
+			if err != nil {
+				return err
+			}
+			first := seen == nil
+			seen = keys
+			if first {
+				for i := len(fresh) - 1; i >= 0; i-- {
+					cursor = pushCursor(cursor, transactionKey(fresh[i]))
+				}
+				return w.saveCursor(ctx, cursor)
+			}
+
+			// Pages are newest first; emit oldest first. A failed save is
+			// reported after the remaining transactions are delivered, as
+			// they are already marked seen.
+			var saveErr error
+			for i := len(fresh) - 1; i >= 0; i-- {
+				w.Bus.Publish(events.NewTransaction{
+					NetworkID:   w.networkID,
+					PoolAddress: w.poolAddress,
+					Transaction: fresh[i],
+					Time:        time.Now(),
+				})
+				select {
+				case txs <- fresh[i]:
+					in.emitted(1)
+				case <-ctx.Done():
+					return nil
+				}
+				cursor = pushCursor(cursor, transactionKey(fresh[i]))
+				if err := w.saveCursor(ctx, cursor); err != nil {
+					saveErr = err
+				}
+			}
+			return saveErr
+		})
+		if err != nil {
+			w.set(err)
+		}
+	}()
+	return txs
+}
+
+// poll returns the transactions not in seen, newest first, and the keys of
+// every transaction fetched. Pages are fetched until one contains a seen
+// transaction, or MaxPages is reached.
+func (w *TransactionWatcher) poll(ctx context.Context, seen map[string]bool) ([]dexpaprika.Transaction, map[string]bool, error) {
+	maxPages := w.MaxPages
+	if maxPages <= 0 || seen == nil {
+		maxPages = 1
+	}
+
+	var fresh []dexpaprika.Transaction
+	keys := make(map[string]bool)
+	for page := 0; page < maxPages; page++ {
+		resp, err := w.client.Pools.GetTransactions(ctx, w.networkID, w.poolAddress, page, transactionsPageSize, "")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		caughtUp := false
+		for _, tx := range resp.Transactions {
+			key := transactionKey(tx)
+			keys[key] = true
+			if seen[key] {
+				caughtUp = true
+				continue
+			}
+			if !caughtUp {
+				fresh = append(fresh, tx)
+			}
+		}
+		if caughtUp || len(resp.Transactions) < transactionsPageSize || page+1 >= resp.PageInfo.TotalPages {
+			break
+		}
+	}
+	return fresh, keys, nil
+}
+
+func (w *TransactionWatcher) cursorKey() string {
+	if w.CursorKey != "" {
+		return w.CursorKey
+	}
+	return fmt.Sprintf("transactions:%s:%s", w.networkID, dexpaprika.NormalizeAddress(w.networkID, w.poolAddress))
+}
+
+// loadCursor returns the saved keys of the most recently emitted
+// transactions, or nil if there is no Store or no saved position.
+func (w *TransactionWatcher) loadCursor(ctx context.Context) ([]string, error) {
+	if w.Store == nil {
+		return nil, nil
+	}
+	value, ok, err := w.Store.Get(ctx, w.cursorKey())
+	if err != nil || !ok {
+		return nil, err
+	}
+	var cursor []string
+	if err := json.Unmarshal([]byte(value), &cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor %s: %w", w.cursorKey(), err)
+	}
+	return cursor, nil
+}
+
+// saveCursor saves cursor to Store, if set.
+func (w *TransactionWatcher) saveCursor(ctx context.Context, cursor []string) error {
+	if w.Store == nil || len(cursor) == 0 {
+		return nil
+	}
+	value, err := json.Marshal(cursor)
+	if err != nil {
+		return err
+	}
+	return w.Store.Set(ctx, w.cursorKey(), string(value))
+}
+
+// pushCursor prepends key to cursor, keeping at most cursorSize keys.
+func pushCursor(cursor []string, key string) []string {
+	cursor = append([]string{key}, cursor...)
+	if len(cursor) > cursorSize {
+		cursor = cursor[:cursorSize]
+	}
+	return cursor
+}
+
+// transactionKey identifies a swap. Transactions in the same on-chain
+// transaction share an ID and differ by log index.
+func transactionKey(tx dexpaprika.Transaction) string {
+	return tx.ID + ":" + strconv.Itoa(tx.LogIndex)
+}
@@ -0,0 +1,182 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinpaprika/dexpaprika-sdk-go/dexpaprika"
+)
+
+func TestTransactionWatcher(t *testing.T) {
+	// Each poll returns the pool's transactions newest first; two swaps
+	// share the on-chain transaction 0x3 and differ by log index
+	polls := [][]string{
+		{"0x2:0", "0x1:0"},
+		{"0x3:1", "0x3:0", "0x2:0", "0x1:0"},
+		{"0x3:1", "0x3:0", "0x2:0", "0x1:0"},
+		{"0x4:0", "0x3:1", "0x3:0", "0x2:0"},
+	}
+	var n atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/networks/ethereum/pools/0xpool/transactions" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		i := int(n.Add(1)) - 1
+		if i >= len(polls) {
+			i = len(polls) - 1
+		}
+		var txs []string
+		for _, key := range polls[i] {
+			id, logIndex, _ := strings.Cut(key, ":")
+			txs = append(txs, fmt.Sprintf(`{"id": %q, "log_index": %s}`, id, logIndex))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"transactions": [%s], "page_info": {"limit": 100, "page": 0, "total_items": %d, "total_pages": 1}}`, strings.Join(txs, ","), len(txs))
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	txs := NewTransactionWatcher(client, "ethereum", "0xpool", 5*time.Millisecond).Watch(ctx)
+
+	var got []string
+	for len(got) < 3 {
+		tx := <-txs
+		got = append(got, transactionKey(tx))
+	}
+	if want := "0x3:0 0x3:1 0x4:0"; strings.Join(got, " ") != want {
+		t.Errorf("Emitted %v, want %s", got, want)
+	}
+
+	cancel()
+	for range txs {
+	}
+}
+
+func TestTransactionWatcher_CatchUp(t *testing.T) {
+	// After the baseline poll 150 transactions arrive, spanning two pages
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" || page == "0" {
+			polls.Add(1)
+		}
+		total := 1
+		if polls.Load() > 1 {
+			total = 151
+		}
+		start, end := 0, total
+		if page == "1" {
+			start = 100
+		}
+		if end-start > 100 {
+			end = start + 100
+		}
+		var txs []string
+		for i := start; i < end; i++ {
+			txs = append(txs, fmt.Sprintf(`{"id": "0x%d"}`, total-i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"transactions": [%s], "page_info": {"limit": 100, "total_items": %d, "total_pages": 2}}`, strings.Join(txs, ","), total)
+	}))
+	defer server.Close()
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	txs := NewTransactionWatcher(client, "ethereum", "0xpool", 5*time.Millisecond).Watch(ctx)
+	for i := 2; i <= 151; i++ {
+		tx := <-txs
+		if want := fmt.Sprintf("0x%d", i); tx.ID != want {
+			t.Fatalf("Transaction %d = %s, want %s", i, tx.ID, want)
+		}
+	}
+
+	cancel()
+	for range txs {
+	}
+}
+
+func TestTransactionWatcher_Store(t *testing.T) {
+	var mu sync.Mutex
+	keys := []string{"0x2", "0x1"} // Newest first
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		var txs []string
+		for _, id := range keys {
+			txs = append(txs, fmt.Sprintf(`{"id": %q}`, id))
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"transactions": [%s], "page_info": {"limit": 100, "page": 0, "total_items": %d, "total_pages": 1}}`, strings.Join(txs, ","), len(txs))
+	}))
+	defer server.Close()
+	add := func(ids ...string) {
+		mu.Lock()
+		keys = append(ids, keys...)
+		mu.Unlock()
+	}
+
+	client := dexpaprika.NewClient(dexpaprika.WithBaseURL(server.URL), dexpaprika.WithRetryConfig(0, 0, 0))
+	store := NewMemoryStore()
+
+	// The first watcher records a baseline, emits 0x3 and stops
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	w := NewTransactionWatcher(client, "ethereum", "0xpool", time.Millisecond)
+	w.Store = store
+	txs := w.Watch(ctx)
+	for {
+		if _, ok, _ := store.Get(ctx, "transactions:ethereum:0xpool"); ok {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	add("0x3")
+	if tx := <-txs; tx.ID != "0x3" {
+		t.Errorf("Expected 0x3, got %s", tx.ID)
+	}
+	cancel()
+	for range txs {
+	}
+
+	// Two transactions arrive while no watcher runs; a restarted watcher
+	// resumes after 0x3
+	add("0x5", "0x4")
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	w = NewTransactionWatcher(client, "ethereum", "0xpool", time.Millisecond)
+	w.Store = store
+	txs = w.Watch(ctx)
+	var got []string
+	for len(got) < 2 {
+		got = append(got, (<-txs).ID)
+	}
+	if strings.Join(got, " ") != "0x4 0x5" {
+		t.Errorf("Expected the restarted watcher to emit 0x4 0x5, got %v", got)
+	}
+	// The cursor is saved once the transaction has been delivered
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		cursor, _, _ := store.Get(ctx, "transactions:ethereum:0xpool")
+		if strings.HasPrefix(cursor, `["0x5:0","0x4:0","0x3:0"`) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Unexpected cursor %s", cursor)
+		}
+	}
+	cancel()
+	for range txs {
+	}
+}
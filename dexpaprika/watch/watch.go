@@ -0,0 +1,84 @@
+// Package watch polls the DexPaprika API and emits typed updates on channels,
+// giving applications a feed of changes without hand-written polling loops.
+//
+// Watchers poll at a fixed interval, skip polls where nothing changed, and
+// close their channel when the context is cancelled. While the API returns
+// errors they follow a FailurePolicy: by default they back off exponentially,
+// but they can also give up, closing their channel with a terminal error
+// reported by Err, or fall back to a longer interval.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// poller calls fetch immediately and then every interval until ctx is done.
+// After failed fetches the wait before the next one is set by the failure
+// policy, and resets once a fetch succeeds.
+type poller struct {
+	interval time.Duration
+	policy   FailurePolicy
+	onError  func(error)
+	metrics  instruments
+}
+
+// run polls until ctx is done, returning nil, or until the failure policy
+// gives up, returning an error wrapping ErrGaveUp and the last fetch error.
+func (p poller) run(ctx context.Context, fetch func(context.Context) error) error {
+	policy := p.policy
+	if policy == nil {
+		policy = Backoff(DefaultMaxBackoff)
+	}
+
+	failures := 0
+	for {
+		wait := p.interval
+		if err := fetch(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if p.onError != nil {
+				p.onError(err)
+			}
+			failures++
+			p.metrics.poll(err, failures)
+			var ok bool
+			if wait, ok = policy.Next(failures, p.interval); !ok {
+				return fmt.Errorf("%w after %d consecutive errors: %w", ErrGaveUp, failures, err)
+			}
+		} else {
+			failures = 0
+			p.metrics.poll(nil, 0)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		}
+	}
+}
+
+// lastErr records the error of a watcher's most recent poll.
+type lastErr struct {
+	mu  sync.Mutex
+	err error
+}
+
+// Err returns the error of the last poll, or nil if it succeeded.
+func (e *lastErr) Err() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err
+}
+
+func (e *lastErr) set(err error) {
+	e.mu.Lock()
+	e.err = err
+	e.mu.Unlock()
+}
@@ -105,10 +105,7 @@ func main() {
 		// Display first pool
 		if len(pools.Pools) > 0 {
 			pool := pools.Pools[0]
-			fmt.Printf("   Top pool: %s on %s (Volume: $%.2f)\n",
-				pool.DexName,
-				pool.Chain,
-				pool.VolumeUSD)
+			fmt.Printf("   Top pool: %s\n", pool)
 		}
 	}
 
@@ -162,13 +159,12 @@ func main() {
 
 		fmt.Printf("   Getting details for pool %s on %s\n", poolID, poolChain)
 
-		poolDetails, err := client.Pools.GetDetails(ctx, poolChain, poolID, false)
+		poolDetails, err := client.Pools.GetDetails(ctx, poolChain, poolID, nil)
 		if err != nil {
 			handleError("Failed to get pool details", err)
 		} else {
-			fmt.Printf("   Pool: %s on %s\n", poolDetails.DexName, poolDetails.Chain)
+			fmt.Printf("   Pool: %s\n", poolDetails)
 			fmt.Printf("   Fee: %.2f%%\n", poolDetails.Fee*100)
-			fmt.Printf("   Last price: $%.4f\n", poolDetails.LastPriceUSD)
 
 			// Try to get OHLCV data
 			fmt.Println("   Getting OHLCV data...")
@@ -192,11 +188,7 @@ func main() {
 					if i >= 3 {
 						break
 					}
-					fmt.Printf("   - %s: Open=$%.4f, Close=$%.4f, Volume=%d\n",
-						record.TimeOpen,
-						record.Open,
-						record.Close,
-						record.Volume)
+					fmt.Printf("   - %s\n", record)
 				}
 			}
 		}
@@ -208,7 +200,7 @@ func main() {
 func handleError(message string, err error) {
 	var apiErr *dexpaprika.APIError
 	if errors.As(err, &apiErr) {
-		log.Printf("%s: API Error (%d): %s", message, apiErr.StatusCode, apiErr.Message)
+		log.Printf("%s: %s", message, apiErr)
 
 		// Check for specific error types
 		switch {
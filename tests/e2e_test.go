@@ -122,7 +122,7 @@ func TestPoolsService(t *testing.T) {
 				}
 
 				pool := pools.Pools[0]
-				details, err := client.Pools.GetDetails(ctx, pool.Chain, pool.ID, false)
+				details, err := client.Pools.GetDetails(ctx, pool.Chain, pool.ID, nil)
 				if err != nil {
 					t.Errorf("GetDetails() error = %v", err)
 					return